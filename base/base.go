@@ -22,6 +22,7 @@ var (
 	COINLIST  = "COINLIST"
 	BTSE      = "BTSE"
 	LBANK     = "LBANK"
+	SIM       = "SIM" // 内存模拟交易所，用于离线测试
 )
 
 // 枚举类型
@@ -61,6 +62,34 @@ var (
 	UnifiedSell = "sell"
 
 	ICEBERG = "iceBerg"
+
+	// 策略委托（条件单）类型，冰山单使用 ICEBERG
+	TRIGGER      = "trigger"       //计划委托，触发后下单
+	CONDITIONAL  = "conditional"   //止盈止损
+	OCO          = "oco"           //止盈止损二选一
+	TRAILINGSTOP = "trailing_stop" //移动止损
+	TWAP         = "twap"          //时间加权
+
+	// 划转的账户类型
+	FUNDING = "funding" //资金账户
+	SPOT    = "spot"    //现货账户，OKX 为交易账户
+	FUTURES = "futures" //U本位合约账户，OKX 与现货共用交易账户
+
+	// 充值、提币状态，已取消使用 CANCELED
+	PROCESSING = "processing" //处理中
+	SUCCEEDED  = "succeeded"  //已到账
+	FAILED     = "failed"     //失败
+
+	// 子账户 API key 权限
+	PermRead     = "read"     //只读
+	PermTrade    = "trade"    //交易
+	PermWithdraw = "withdraw" //提币
+
+	// 账户模式
+	AccountSimple         = "simple"          //简单交易模式，只能现货（OKX）
+	AccountSingleCurrency = "single_currency" //单币种保证金，Binance 单资产模式
+	AccountMultiCurrency  = "multi_currency"  //跨币种保证金，Binance 联合保证金
+	AccountPortfolio      = "portfolio"       //组合保证金
 )
 
 // 订单状态
@@ -0,0 +1,10 @@
+package base
+
+// 可选功能，交易所不支持时对应方法返回 ErrNotSupported
+const (
+	CapWithdraw      = "withdraw"      // Withdraw 提币
+	CapIceberg       = "iceberg"       // IceBergOrder 冰山单
+	CapHiddenOrder   = "hiddenOrder"   // LimitHiddenOrder(s) 隐藏限价单
+	CapFeeFromFilled = "feeFromFilled" // GetFeeFromFilled 按成交查询手续费
+	CapMarginType    = "marginType"    // ChangeMarginType 切换全仓/逐仓
+)
@@ -0,0 +1,62 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 统一的错误分类，交易所返回的错误码会被映射为这些错误，调用方使用 errors.Is 判断
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrInvalidSymbol     = errors.New("invalid symbol")
+	ErrAuth              = errors.New("authentication failed")
+	ErrTimestamp         = errors.New("timestamp out of recv window")
+	ErrDuplicateOrder    = errors.New("duplicate client order id")
+	ErrWithdrawNotFound  = errors.New("withdrawal not found")
+	// ErrUnknownStatus 交易所超时，请求是否执行未知，需要按 clientOrderID 查询确认
+	ErrUnknownStatus = errors.New("execution status unknown")
+	// ErrNotSupported 交易所没有对应接口，可先用 Exchange.Has 查询
+	ErrNotSupported = errors.New("not supported by exchange")
+)
+
+// ExchangeError 交易所返回的错误，保留原始错误码和响应体。
+// errors.Is(err, ErrResponse) 恒为 true，Kind 不为空时 errors.Is(err, Kind) 也为 true。
+type ExchangeError struct {
+	Exchange   string
+	HTTPStatus int
+	Code       string // 交易所错误码，OKX 的 code/sCode 或 Binance 的 code
+	Message    string
+	Raw        []byte // 原始响应体
+	Kind       error  // 映射后的错误分类，未识别的错误码为 nil
+}
+
+func (e *ExchangeError) Error() string {
+	msg := fmt.Sprintf("%s: code=%s msg=%s", e.Exchange, e.Code, e.Message)
+	if e.HTTPStatus != 0 && e.HTTPStatus != 200 {
+		msg += fmt.Sprintf(" http=%d", e.HTTPStatus)
+	}
+	if e.Kind != nil {
+		msg += " (" + e.Kind.Error() + ")"
+	}
+	return msg
+}
+
+func (e *ExchangeError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{ErrResponse}
+	}
+	return []error{e.Kind, ErrResponse}
+}
+
+// KindOfHTTPStatus 按 HTTP 状态码推断错误分类，交易所错误码无法识别时使用
+func KindOfHTTPStatus(status int) error {
+	switch status {
+	case 401, 403:
+		return ErrAuth
+	case 418, 429:
+		return ErrRateLimited
+	}
+	return nil
+}
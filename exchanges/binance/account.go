@@ -0,0 +1,119 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// GetAccount 由现货账户 /api/v3/account、U本位合约账户 /fapi/v2/account 和持仓模式组成的账户快照。
+// 只调用了 New 或 NewFuture 时只包含对应的账户。USDValue 按 <asset>USDT 的最新价估值（没有现货客户端时取合约价格），
+// 没有该交易对的币种为 0；保证金和 MarginRatio 只来自合约账户
+func (c *Client) GetAccount(ctx context.Context) (models.Account, error) {
+	if c.Client == nil && c.FutureClient == nil {
+		return models.Account{}, fmt.Errorf("%w: client not initialized", base.ErrNotSupported)
+	}
+	px, err := c.lastPrices(ctx)
+	if err != nil {
+		return models.Account{}, err
+	}
+	usd := func(asset string, amount models.Decimal) models.Decimal {
+		if models.IsUSDStable(asset) {
+			return amount
+		}
+		return amount.Mul(px[asset+"USDT"])
+	}
+
+	acct := models.Account{Mode: base.AccountSingleCurrency, Time: time.Now().UnixMilli()}
+	if c.Client != nil {
+		spot, err := c.Client.NewGetAccountService().Do(ctx)
+		if err != nil {
+			return models.Account{}, apiError(err)
+		}
+		for _, b := range spot.Balances {
+			free, locked := models.ParseDecimalOrZero(b.Free), models.ParseDecimalOrZero(b.Locked)
+			if free.IsZero() && locked.IsZero() {
+				continue
+			}
+			equity := free.Add(locked)
+			acct.Balances = append(acct.Balances, models.AssetBalance{
+				Account:  base.SPOT,
+				Asset:    b.Asset,
+				Free:     free,
+				Locked:   locked,
+				Equity:   equity,
+				USDValue: usd(b.Asset, equity),
+			})
+		}
+	}
+
+	if c.FutureClient != nil {
+		fut, err := c.FutureClient.NewGetAccountService().Do(ctx)
+		if err != nil {
+			return models.Account{}, apiError(err)
+		}
+		dual, err := c.FutureClient.NewGetPositionModeService().Do(ctx)
+		if err != nil {
+			return models.Account{}, apiError(err)
+		}
+		addFutures(&acct, fut, usd)
+		acct.DualSide = dual.DualSidePosition
+	}
+	for _, b := range acct.Balances {
+		acct.TotalEquity = acct.TotalEquity.Add(b.USDValue)
+	}
+	return acct, nil
+}
+
+// lastPrices 全部交易对的最新价，优先取现货，只有合约客户端时取 U本位合约
+func (c *Client) lastPrices(ctx context.Context) (map[string]models.Decimal, error) {
+	px := make(map[string]models.Decimal)
+	if c.Client != nil {
+		prices, err := c.Client.NewListPricesService().Do(ctx)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		for _, p := range prices {
+			px[p.Symbol] = models.ParseDecimalOrZero(p.Price)
+		}
+		return px, nil
+	}
+	prices, err := c.FutureClient.NewListPricesService().Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	for _, p := range prices {
+		px[p.Symbol] = models.ParseDecimalOrZero(p.Price)
+	}
+	return px, nil
+}
+
+// addFutures 合约账户的币种余额：Free 为可用余额，Locked 为占用的初始保证金，Equity 为保证金余额（含未实现盈亏）
+func addFutures(acct *models.Account, fut *futures.Account, usd func(string, models.Decimal) models.Decimal) {
+	if fut.MultiAssetsMargin {
+		acct.Mode = base.AccountMultiCurrency
+	}
+	for _, a := range fut.Assets {
+		wallet, equity := models.ParseDecimalOrZero(a.WalletBalance), models.ParseDecimalOrZero(a.MarginBalance)
+		if wallet.IsZero() && equity.IsZero() {
+			continue
+		}
+		acct.Balances = append(acct.Balances, models.AssetBalance{
+			Account:       base.FUTURES,
+			Asset:         a.Asset,
+			Free:          models.ParseDecimalOrZero(a.AvailableBalance),
+			Locked:        models.ParseDecimalOrZero(a.InitialMargin),
+			Equity:        equity,
+			USDValue:      usd(a.Asset, equity),
+			InitialMargin: models.ParseDecimalOrZero(a.InitialMargin),
+			MaintMargin:   models.ParseDecimalOrZero(a.MaintMargin),
+		})
+	}
+	acct.InitialMargin = models.ParseDecimalOrZero(fut.TotalInitialMargin)
+	acct.MaintMargin = models.ParseDecimalOrZero(fut.TotalMaintMargin)
+	acct.MarginRatio = models.MarginRatio(acct.MaintMargin, models.ParseDecimalOrZero(fut.TotalMarginBalance))
+}
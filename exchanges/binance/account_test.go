@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/exchangetest"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetAccount(t *testing.T) {
+	c, srv := newFakeClient(t)
+	srv.Handle(http.MethodGet, "/api/v3/ticker/price", http.StatusOK, `[{"symbol":"BTCUSDT","price":"37000"},{"symbol":"ETHUSDT","price":"2000"}]`)
+	acct, err := c.GetAccount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct.Mode != base.AccountSingleCurrency || !acct.DualSide || len(acct.Balances) != 3 {
+		t.Fatalf("account = %+v", acct)
+	}
+	btc := acct.Balance(base.SPOT, "BTC")
+	if btc.Free.String() != "0.5" || btc.Locked.String() != "0.1" || btc.Equity.String() != "0.6" || btc.USDValue.String() != "22200" {
+		t.Fatalf("btc = %+v", btc)
+	}
+	fut := acct.Balance(base.FUTURES, "USDT")
+	if fut.Free.String() != "1810" || fut.Locked.String() != "150" || fut.Equity.String() != "1960" || fut.MaintMargin.String() != "20" {
+		t.Fatalf("futures usdt = %+v", fut)
+	}
+	if acct.TotalEquity.String() != "25170.5" || acct.InitialMargin.String() != "150" || acct.MaintMargin.String() != "20" || acct.MarginRatio.String() != "0.01020408" {
+		t.Fatalf("totals = %s %s %s %s", acct.TotalEquity, acct.InitialMargin, acct.MaintMargin, acct.MarginRatio)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v3/ticker/price")[0].Query; q.Get("symbol") != "" {
+		t.Fatalf("price query = %v", q)
+	}
+	if bnb := acct.Balance(base.FUTURES, "BNB"); !bnb.Equity.IsZero() {
+		t.Fatalf("bnb = %+v", bnb)
+	}
+
+	// 余额为零的币种返回 0 而不是 nil
+	if bal, err := c.GetAccountBalance("DOGE"); err != nil || len(bal) != 3 || bal[0] != "0" {
+		t.Fatalf("missing asset = %v err = %v", bal, err)
+	}
+}
+
+func TestGetAccountFuturesOnly(t *testing.T) {
+	srv := exchangetest.NewBinance(fakeCreds)
+	t.Cleanup(srv.Close)
+	c := &Client{}
+	if err := c.NewFuture(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	acct, err := c.GetAccount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acct.Balances) != 1 || acct.Balances[0].Account != base.FUTURES || acct.TotalEquity.String() != "1960" || acct.MaintMargin.String() != "20" {
+		t.Fatalf("account = %+v", acct)
+	}
+	if len(srv.Requests(http.MethodGet, "/fapi/v2/ticker/price")) != 1 || len(srv.Requests(http.MethodGet, "/api/v3/account")) != 0 {
+		t.Fatalf("requests = %+v", srv.Requests("", ""))
+	}
+}
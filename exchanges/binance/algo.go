@@ -0,0 +1,273 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// 策略委托（store/exchange.AlgoExchange）的实现，市场按 symbol 选择（见 symbolMarket）。
+// U本位合约用 STOP/TAKE_PROFIT(_MARKET) 和 TRAILING_STOP_MARKET 条件单，algo id 即订单号；
+// 现货只支持 OCO，algo id 为 orderListId。交易所没有的类型返回 base.ErrNotSupported
+
+// PlaceAlgoOrder 下策略委托，返回 algo id
+func (c *Client) PlaceAlgoOrder(ctx context.Context, req models.AlgoOrderRequest) (string, error) {
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+	if symbolMarket(req.Symbol) == futuresMarket {
+		return c.placeFutureAlgo(ctx, req)
+	}
+	if req.Type != base.OCO {
+		return "", fmt.Errorf("%w: spot %s order", base.ErrNotSupported, req.Type)
+	}
+	return c.placeOCO(ctx, req)
+}
+
+// placeOCO 现货 OCO：止盈腿为限价单，价格取 TakeProfitPrice，未填时取 TakeProfitTrigger；
+// 止损腿 StopLossPrice 为 0 时为市价止损
+func (c *Client) placeOCO(ctx context.Context, req models.AlgoOrderRequest) (string, error) {
+	limit := req.TakeProfitPrice
+	if limit.IsZero() {
+		limit = req.TakeProfitTrigger
+	}
+	price, size, err := c.quantizeOrder(instrument.Spot, c.spotID(req.Symbol), base.LIMIT, limit.String(), req.Size.String())
+	if err != nil {
+		return "", err
+	}
+	svc := c.Client.NewCreateOCOService().
+		Symbol(c.spotID(req.Symbol)).
+		Side(spotSide(req.Side)).
+		Quantity(size).
+		Price(price).
+		StopPrice(req.StopLossTrigger.String())
+	if req.StopLossPrice.Sign() > 0 {
+		svc.StopLimitPrice(req.StopLossPrice.String()).StopLimitTimeInForce(binance.TimeInForceTypeGTC)
+	}
+	resp, err := svc.Do(ctx)
+	if err != nil {
+		return "", apiError(err)
+	}
+	return strconv.FormatInt(resp.OrderListID, 10), nil
+}
+
+func spotSide(side string) binance.SideType {
+	if side == base.BID {
+		return binance.SideTypeBuy
+	}
+	return binance.SideTypeSell
+}
+
+// placeFutureAlgo 合约条件单。TRIGGER 按当前价格判断方向：买单触发价高于现价（卖单低于现价）为 STOP，
+// 反之为 TAKE_PROFIT。CONDITIONAL 每次只能带止盈或止损一侧，单向持仓时为只减仓
+func (c *Client) placeFutureAlgo(ctx context.Context, req models.AlgoOrderRequest) (string, error) {
+	var (
+		typ         futures.OrderType
+		stop, price models.Decimal
+		reduce      = req.ReduceOnly
+	)
+	switch req.Type {
+	case base.TRIGGER:
+		last, err := c.GetFutureMarketPrice(req.Symbol)
+		if err != nil {
+			return "", err
+		}
+		stop, price = req.TriggerPrice, req.Price
+		if (req.Side == base.BID) == req.TriggerPrice.GreaterThan(models.ParseDecimalOrZero(last)) {
+			typ = stopOrderType(futures.OrderTypeStop, futures.OrderTypeStopMarket, price)
+		} else {
+			typ = stopOrderType(futures.OrderTypeTakeProfit, futures.OrderTypeTakeProfitMarket, price)
+		}
+	case base.CONDITIONAL:
+		tp := req.TakeProfitTrigger.Sign() > 0
+		if tp && req.StopLossTrigger.Sign() > 0 {
+			return "", fmt.Errorf("%w: futures conditional order with both take-profit and stop-loss", base.ErrNotSupported)
+		}
+		reduce = true
+		if tp {
+			stop, price = req.TakeProfitTrigger, req.TakeProfitPrice
+			typ = stopOrderType(futures.OrderTypeTakeProfit, futures.OrderTypeTakeProfitMarket, price)
+		} else {
+			stop, price = req.StopLossTrigger, req.StopLossPrice
+			typ = stopOrderType(futures.OrderTypeStop, futures.OrderTypeStopMarket, price)
+		}
+	case base.TRAILINGSTOP:
+		typ = futures.OrderTypeTrailingStopMarket
+	default:
+		return "", fmt.Errorf("%w: futures %s order", base.ErrNotSupported, req.Type)
+	}
+
+	id := c.futureID(req.Symbol)
+	px, size, err := c.quantizeOrder(futureKind(id), id, base.LIMIT, optionalPrice(price), req.Size.String())
+	if err != nil {
+		return "", err
+	}
+	side := futures.SideTypeSell
+	if req.Side == base.BID {
+		side = futures.SideTypeBuy
+	}
+	svc := c.FutureClient.NewCreateOrderService().
+		Symbol(id).
+		Side(side).
+		Type(typ).
+		Quantity(size)
+	switch req.PositionSide {
+	case base.LONG:
+		svc.PositionSide(futures.PositionSideTypeLong)
+	case base.SHORT:
+		svc.PositionSide(futures.PositionSideTypeShort)
+	default:
+		// 双向持仓不接受 reduceOnly，由 positionSide 和方向决定是否平仓
+		if reduce {
+			svc.ReduceOnly(true)
+		}
+	}
+	if !stop.IsZero() {
+		svc.StopPrice(stop.String())
+	}
+	if !price.IsZero() {
+		svc.Price(px).TimeInForce(futures.TimeInForceTypeGTC)
+	}
+	if typ == futures.OrderTypeTrailingStopMarket {
+		// callbackRate 以百分数表示，1 为 1%
+		svc.CallbackRate(req.CallbackRate.Mul(models.NewDecimalFromInt(100)).String())
+		if req.ActivationPrice.Sign() > 0 {
+			svc.ActivationPrice(req.ActivationPrice.String())
+		}
+	}
+	resp, err := c.createFutureOrder(req.Symbol, "", svc)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(resp.OrderID, 10), nil
+}
+
+// stopOrderType 有委托价时为限价条件单，否则为市价条件单
+func stopOrderType(limit, market futures.OrderType, price models.Decimal) futures.OrderType {
+	if price.IsZero() {
+		return market
+	}
+	return limit
+}
+
+// optionalPrice 0 表示不填
+func optionalPrice(d models.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+// CancelAlgoOrder 撤销策略委托，合约按订单号，现货按 orderListId
+func (c *Client) CancelAlgoOrder(ctx context.Context, symbol, algoID string) error {
+	id, err := strconv.ParseInt(algoID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: algo id %q", models.ErrInvalidRequest, algoID)
+	}
+	if symbolMarket(symbol) == futuresMarket {
+		_, err = c.FutureClient.NewCancelOrderService().Symbol(c.futureID(symbol)).OrderID(id).Do(ctx)
+	} else {
+		_, err = c.Client.NewCancelOCOService().Symbol(c.spotID(symbol)).OrderListID(id).Do(ctx)
+	}
+	if err != nil {
+		return apiError(err)
+	}
+	return nil
+}
+
+// GetOpenAlgoOrders 未触发的策略委托。合约的只减仓（或平仓）止盈止损单视为 CONDITIONAL，其余为 TRIGGER；
+// 现货返回 OCO，按 orderListId 合并两条腿
+func (c *Client) GetOpenAlgoOrders(ctx context.Context, symbol string) ([]models.AlgoOrder, error) {
+	if symbolMarket(symbol) == futuresMarket {
+		return c.futureAlgoOrders(ctx, symbol)
+	}
+	orders, err := c.Client.NewListOpenOrdersService().Symbol(c.spotID(symbol)).Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	lists := make(map[int64]*models.AlgoOrder)
+	for _, o := range orders {
+		if o.OrderListId < 0 {
+			continue
+		}
+		a, ok := lists[o.OrderListId]
+		if !ok {
+			a = &models.AlgoOrder{
+				AlgoID: strconv.FormatInt(o.OrderListId, 10),
+				Symbol: echoSymbol(symbol, o.Symbol),
+				Side:   orderSide(string(o.Side)),
+				Type:   base.OCO,
+				Size:   models.ParseDecimalOrZero(o.OrigQuantity),
+				Status: base.OPEN,
+				Time:   o.Time,
+			}
+			lists[o.OrderListId] = a
+		}
+		switch o.Type {
+		case binance.OrderTypeLimitMaker:
+			a.TakeProfitTrigger = models.ParseDecimalOrZero(o.Price)
+			a.TakeProfitPrice = a.TakeProfitTrigger
+		case binance.OrderTypeStopLoss:
+			a.StopLossTrigger = models.ParseDecimalOrZero(o.StopPrice)
+		case binance.OrderTypeStopLossLimit:
+			a.StopLossTrigger = models.ParseDecimalOrZero(o.StopPrice)
+			a.StopLossPrice = models.ParseDecimalOrZero(o.Price)
+		}
+	}
+	algos := make([]models.AlgoOrder, 0, len(lists))
+	for _, a := range lists {
+		algos = append(algos, *a)
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i].Time < algos[j].Time })
+	return algos, nil
+}
+
+func (c *Client) futureAlgoOrders(ctx context.Context, symbol string) ([]models.AlgoOrder, error) {
+	orders, err := c.FutureClient.NewListOpenOrdersService().Symbol(c.futureID(symbol)).Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var algos []models.AlgoOrder
+	for _, o := range orders {
+		a := models.AlgoOrder{
+			AlgoID:       strconv.FormatInt(o.OrderID, 10),
+			Symbol:       echoSymbol(symbol, o.Symbol),
+			Side:         orderSide(string(o.Side)),
+			PositionSide: positionSide(string(o.PositionSide)),
+			Size:         models.ParseDecimalOrZero(o.OrigQuantity),
+			Status:       orderStatus(string(o.Status)),
+			Time:         o.Time,
+		}
+		stop, price := models.ParseDecimalOrZero(o.StopPrice), models.ParseDecimalOrZero(o.Price)
+		conditional := o.ReduceOnly || o.ClosePosition
+		switch o.Type {
+		case futures.OrderTypeTrailingStopMarket:
+			a.Type = base.TRAILINGSTOP
+			a.ActivationPrice = models.ParseDecimalOrZero(o.ActivatePrice)
+			a.CallbackRate, _ = models.ParseDecimalOrZero(o.PriceRate).Div(models.NewDecimalFromInt(100), 8)
+		case futures.OrderTypeStop, futures.OrderTypeStopMarket:
+			if conditional {
+				a.Type, a.StopLossTrigger, a.StopLossPrice = base.CONDITIONAL, stop, price
+			} else {
+				a.Type, a.TriggerPrice, a.Price = base.TRIGGER, stop, price
+			}
+		case futures.OrderTypeTakeProfit, futures.OrderTypeTakeProfitMarket:
+			if conditional {
+				a.Type, a.TakeProfitTrigger, a.TakeProfitPrice = base.CONDITIONAL, stop, price
+			} else {
+				a.Type, a.TriggerPrice, a.Price = base.TRIGGER, stop, price
+			}
+		default:
+			continue
+		}
+		algos = append(algos, a)
+	}
+	return algos, nil
+}
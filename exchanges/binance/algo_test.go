@@ -0,0 +1,123 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPlaceSpotOCO(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+
+	id, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: "BTC/USDT", Side: base.ASK, Type: base.OCO, Size: models.ParseDecimalOrZero("0.01"),
+		TakeProfitTrigger: models.ParseDecimalOrZero("40000"),
+		StopLossTrigger:   models.ParseDecimalOrZero("35000"), StopLossPrice: models.ParseDecimalOrZero("34900"),
+	})
+	if err != nil || id != "12" {
+		t.Fatalf("id = %s err = %v", id, err)
+	}
+	params := form(t, srv.Requests(http.MethodPost, "/api/v3/order/oco")[0])
+	if params.Get("symbol") != "BTCUSDT" || params.Get("side") != "SELL" || params.Get("quantity") != "0.01" || params.Get("price") != "40000" ||
+		params.Get("stopPrice") != "35000" || params.Get("stopLimitPrice") != "34900" || params.Get("stopLimitTimeInForce") != "GTC" {
+		t.Fatalf("params = %v", params)
+	}
+
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: "BTC/USDT", Side: base.BID, Type: base.TRAILINGSTOP, Size: models.ParseDecimalOrZero("0.01"), CallbackRate: models.ParseDecimalOrZero("0.01"),
+	}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("spot trailing err = %v", err)
+	}
+
+	srv.Handle(http.MethodGet, "/api/v3/openOrders", http.StatusOK, `[`+
+		`{"symbol":"BTCUSDT","orderId":40,"orderListId":12,"price":"34900","origQty":"0.01","status":"NEW","type":"STOP_LOSS_LIMIT","side":"SELL","stopPrice":"35000","time":1700000000000},`+
+		`{"symbol":"BTCUSDT","orderId":41,"orderListId":12,"price":"40000","origQty":"0.01","status":"NEW","type":"LIMIT_MAKER","side":"SELL","stopPrice":"0","time":1700000000000},`+
+		`{"symbol":"BTCUSDT","orderId":30,"orderListId":-1,"price":"35000","origQty":"0.01","status":"NEW","type":"LIMIT","side":"BUY","time":1700000000000}]`)
+	algos, err := c.GetOpenAlgoOrders(ctx, "BTC/USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(algos) != 1 {
+		t.Fatalf("algos = %+v", algos)
+	}
+	a := algos[0]
+	if a.AlgoID != "12" || a.Type != base.OCO || a.Side != base.ASK || a.Symbol != "BTC/USDT" ||
+		a.TakeProfitPrice.String() != "40000" || a.StopLossTrigger.String() != "35000" || a.StopLossPrice.String() != "34900" {
+		t.Fatalf("algo = %+v", a)
+	}
+
+	if err := c.CancelAlgoOrder(ctx, "BTC/USDT", "12"); err != nil {
+		t.Fatal(err)
+	}
+	if q := form(t, srv.Requests(http.MethodDelete, "/api/v3/orderList")[0]); q.Get("orderListId") != "12" || q.Get("symbol") != "BTCUSDT" {
+		t.Fatalf("cancel query = %v", q)
+	}
+}
+
+func TestPlaceFutureAlgo(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	sym := "ETH/USDT:USDT"
+
+	// 现价 2100，买单触发价 2200 高于现价为 STOP_MARKET
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: sym, Side: base.BID, Type: base.TRIGGER, Size: models.ParseDecimalOrZero("1"), TriggerPrice: models.ParseDecimalOrZero("2200"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// 止盈限价单，单向持仓时只减仓
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: sym, Side: base.ASK, Type: base.CONDITIONAL, Size: models.ParseDecimalOrZero("1"),
+		TakeProfitTrigger: models.ParseDecimalOrZero("2300"), TakeProfitPrice: models.ParseDecimalOrZero("2290"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: sym, Side: base.ASK, PositionSide: base.LONG, Type: base.TRAILINGSTOP, Size: models.ParseDecimalOrZero("1"),
+		CallbackRate: models.ParseDecimalOrZero("0.015"), ActivationPrice: models.ParseDecimalOrZero("2250"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests(http.MethodPost, "/fapi/v1/order")
+	if len(reqs) != 3 {
+		t.Fatalf("requests = %d", len(reqs))
+	}
+	trigger, tp, trailing := form(t, reqs[0]), form(t, reqs[1]), form(t, reqs[2])
+	if trigger.Get("type") != "STOP_MARKET" || trigger.Get("stopPrice") != "2200" || trigger.Get("side") != "BUY" || trigger.Has("price") {
+		t.Fatalf("trigger = %v", trigger)
+	}
+	if tp.Get("type") != "TAKE_PROFIT" || tp.Get("stopPrice") != "2300" || tp.Get("price") != "2290" || tp.Get("reduceOnly") != "true" || tp.Get("timeInForce") != "GTC" {
+		t.Fatalf("take profit = %v", tp)
+	}
+	if trailing.Get("type") != "TRAILING_STOP_MARKET" || trailing.Get("callbackRate") != "1.5" || trailing.Get("activationPrice") != "2250" ||
+		trailing.Get("positionSide") != "LONG" || trailing.Has("reduceOnly") {
+		t.Fatalf("trailing = %v", trailing)
+	}
+
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: sym, Side: base.ASK, Type: base.OCO, Size: models.ParseDecimalOrZero("1"),
+		TakeProfitTrigger: models.ParseDecimalOrZero("2300"), StopLossTrigger: models.ParseDecimalOrZero("1900"),
+	}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("futures oco err = %v", err)
+	}
+
+	// fixture 中的挂单是平多的市价止损
+	algos, err := c.GetOpenAlgoOrders(ctx, sym)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(algos) != 1 || algos[0].Type != base.CONDITIONAL || algos[0].StopLossTrigger.String() != "1900" || algos[0].PositionSide != base.LONG {
+		t.Fatalf("algos = %+v", algos)
+	}
+	if err := c.CancelAlgoOrder(ctx, sym, algos[0].AlgoID); err != nil {
+		t.Fatal(err)
+	}
+	if q := form(t, srv.Requests(http.MethodDelete, "/fapi/v1/order")[0]); q.Get("orderId") != "8389765521" {
+		t.Fatalf("cancel query = %v", q)
+	}
+}
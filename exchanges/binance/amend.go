@@ -0,0 +1,146 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// AmendOrder 修改限价单的价格和数量，newPrice、newSize 为空表示不变，newSize 为含已成交部分的总数量。
+// 市场按 symbol 选择（见 symbolMarket）。现货没有改单接口，用 cancelReplace 撤单后按剩余数量重新下单，
+// 返回的是新订单，OrderID 与原订单不同；U本位合约用 modify-order 原地修改，OrderID 不变
+func (c *Client) AmendOrder(ctx context.Context, symbol, id, newPrice, newSize string) (models.OrderInfo, error) {
+	if newPrice == "" && newSize == "" {
+		return models.OrderInfo{}, fmt.Errorf("%w: nothing to amend", models.ErrInvalidRequest)
+	}
+	oid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return models.OrderInfo{}, fmt.Errorf("%w: order id %q", models.ErrInvalidRequest, id)
+	}
+	if symbolMarket(symbol) == futuresMarket {
+		return c.amendFutureOrder(ctx, symbol, oid, newPrice, newSize)
+	}
+	return c.amendSpotOrder(ctx, symbol, oid, newPrice, newSize)
+}
+
+// cancelReplaceResponse POST /api/v3/order/cancelReplace 的响应
+type cancelReplaceResponse struct {
+	CancelResult     string                       `json:"cancelResult"`
+	NewOrderResult   string                       `json:"newOrderResult"`
+	NewOrderResponse *binance.CreateOrderResponse `json:"newOrderResponse"`
+}
+
+func (c *Client) amendSpotOrder(ctx context.Context, symbol string, oid int64, newPrice, newSize string) (models.OrderInfo, error) {
+	order, err := c.Client.NewGetOrderService().Symbol(c.spotID(symbol)).OrderID(oid).Do(ctx)
+	if err != nil {
+		return models.OrderInfo{}, apiError(err)
+	}
+	if order.Type != binance.OrderTypeLimit && order.Type != binance.OrderTypeLimitMaker {
+		return models.OrderInfo{}, fmt.Errorf("%w: cannot amend %s order", models.ErrInvalidRequest, order.Type)
+	}
+	if newPrice == "" {
+		newPrice = order.Price
+	}
+	if newSize == "" {
+		newSize = order.OrigQuantity
+	}
+	size, err := models.RequireDecimal("size", newSize)
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+	remaining := size.Sub(models.ParseDecimalOrZero(order.ExecutedQuantity))
+	if remaining.Sign() <= 0 {
+		return models.OrderInfo{}, fmt.Errorf("%w: size %s not above filled %s", models.ErrInvalidRequest, newSize, order.ExecutedQuantity)
+	}
+	price, quantity, err := c.quantizeOrder(instrument.Spot, c.spotID(symbol), base.LIMIT, newPrice, remaining.String())
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+
+	params := url.Values{}
+	params.Set("symbol", order.Symbol)
+	params.Set("side", string(order.Side))
+	params.Set("type", string(order.Type))
+	params.Set("cancelReplaceMode", "STOP_ON_FAILURE")
+	params.Set("cancelOrderId", strconv.FormatInt(oid, 10))
+	params.Set("quantity", quantity)
+	params.Set("price", price)
+	if order.Type == binance.OrderTypeLimit {
+		params.Set("timeInForce", string(order.TimeInForce))
+	}
+	if models.ParseDecimalOrZero(order.IcebergQuantity).Sign() > 0 {
+		params.Set("icebergQty", order.IcebergQuantity)
+	}
+	var resp cancelReplaceResponse
+	if err := c.signedRequest(ctx, spotMarket, http.MethodPost, "/api/v3/order/cancelReplace", params, &resp); err != nil {
+		return models.OrderInfo{}, err
+	}
+	r := resp.NewOrderResponse
+	if r == nil {
+		return models.OrderInfo{}, fmt.Errorf("%w: cancelReplace %s/%s", base.ErrUnknownStatus, resp.CancelResult, resp.NewOrderResult)
+	}
+	return models.OrderInfo{
+		OrderID:  strconv.FormatInt(r.OrderID, 10),
+		Symbol:   echoSymbol(symbol, r.Symbol),
+		Side:     orderSide(string(r.Side)),
+		Price:    models.ParseDecimalOrZero(r.Price),
+		Quantity: models.ParseDecimalOrZero(r.OrigQuantity),
+		Type:     spotOrderType(string(r.Type), string(r.TimeInForce)),
+		Filled:   models.ParseDecimalOrZero(r.ExecutedQuantity),
+		USDT:     models.ParseDecimalOrZero(r.CummulativeQuoteQuantity),
+		Status:   orderStatus(string(r.Status)),
+		Time:     r.TransactTime,
+	}, nil
+}
+
+func (c *Client) amendFutureOrder(ctx context.Context, symbol string, oid int64, newPrice, newSize string) (models.OrderInfo, error) {
+	order, err := c.FutureClient.NewGetOrderService().Symbol(c.futureID(symbol)).OrderID(oid).Do(ctx)
+	if err != nil {
+		return models.OrderInfo{}, apiError(err)
+	}
+	if order.Type != futures.OrderTypeLimit {
+		return models.OrderInfo{}, fmt.Errorf("%w: cannot amend %s order", models.ErrInvalidRequest, order.Type)
+	}
+	// modify-order 的价格和数量都必填
+	if newPrice == "" {
+		newPrice = order.Price
+	}
+	if newSize == "" {
+		newSize = order.OrigQuantity
+	}
+	price, quantity, err := c.quantizeOrder(futureKind(order.Symbol), order.Symbol, base.LIMIT, newPrice, newSize)
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+
+	params := url.Values{}
+	params.Set("symbol", order.Symbol)
+	params.Set("orderId", strconv.FormatInt(oid, 10))
+	params.Set("side", string(order.Side))
+	params.Set("quantity", quantity)
+	params.Set("price", price)
+	var r futures.Order
+	if err := c.signedRequest(ctx, futuresMarket, http.MethodPut, "/fapi/v1/order", params, &r); err != nil {
+		return models.OrderInfo{}, err
+	}
+	return models.OrderInfo{
+		OrderID:  strconv.FormatInt(r.OrderID, 10),
+		Symbol:   echoSymbol(symbol, r.Symbol),
+		Side:     orderSide(string(r.Side)),
+		Price:    models.ParseDecimalOrZero(r.Price),
+		Quantity: models.ParseDecimalOrZero(r.OrigQuantity),
+		Type:     futureOrderType(string(r.Type)),
+		Filled:   models.ParseDecimalOrZero(r.ExecutedQuantity),
+		USDT:     models.ParseDecimalOrZero(r.CumQuote),
+		Status:   orderStatus(string(r.Status)),
+		Time:     r.UpdateTime,
+	}, nil
+}
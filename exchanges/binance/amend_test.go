@@ -0,0 +1,69 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAmendSpotOrder(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	// 原订单数量 0.02 已成交 0.01，改为总数量 0.03 后重新下剩余的 0.02
+	o, err := c.AmendOrder(context.Background(), "BTC/USDT", "28", "35900", "0.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OrderID != "29" || o.Symbol != "BTC/USDT" || o.Status != base.OPEN || o.Type != base.LIMIT || o.Price.String() != "35900" {
+		t.Fatalf("order = %+v", o)
+	}
+	reqs := srv.Requests(http.MethodPost, "/api/v3/order/cancelReplace")
+	if len(reqs) != 1 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	q := reqs[0].Query
+	if q.Get("symbol") != "BTCUSDT" || q.Get("cancelOrderId") != "28" || q.Get("side") != "BUY" || q.Get("type") != "LIMIT" ||
+		q.Get("timeInForce") != "GTC" || q.Get("price") != "35900" || q.Get("quantity") != "0.02" ||
+		q.Get("cancelReplaceMode") != "STOP_ON_FAILURE" || q.Has("icebergQty") {
+		t.Fatalf("query = %v", q)
+	}
+
+	if _, err := c.AmendOrder(context.Background(), "BTC/USDT", "28", "", "0.01"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("amend to filled size err = %v", err)
+	}
+	if _, err := c.AmendOrder(context.Background(), "BTC/USDT", "28", "", ""); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("empty amend err = %v", err)
+	}
+}
+
+func TestAmendFutureOrder(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	o, err := c.AmendOrder(context.Background(), "ETH/USDT:USDT", "8389765519", "1990", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OrderID != "8389765519" || o.Symbol != "ETH/USDT:USDT" || o.Status != base.OPEN || o.Quantity.String() != "2" || o.Price.String() != "1990" {
+		t.Fatalf("order = %+v", o)
+	}
+	reqs := srv.Requests(http.MethodPut, "/fapi/v1/order")
+	if len(reqs) != 1 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	q := reqs[0].Query
+	if q.Get("symbol") != "ETHUSDT" || q.Get("orderId") != "8389765519" || q.Get("side") != "BUY" || q.Get("price") != "1990" || q.Get("quantity") != "2" {
+		t.Fatalf("query = %v", q)
+	}
+
+	srv.HandleFunc(http.MethodPut, "/fapi/v1/order", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-2013,"msg":"Order does not exist."}`))
+	})
+	var exErr *base.ExchangeError
+	if _, err := c.AmendOrder(context.Background(), "ETH/USDT:USDT", "8389765519", "1980", ""); !errors.As(err, &exErr) || exErr.Code != "-2013" {
+		t.Fatalf("err = %v", err)
+	}
+}
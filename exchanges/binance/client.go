@@ -2,7 +2,10 @@ package binance
 
 import (
 	"AxonTrading/base"
+	"AxonTrading/instrument"
 	"AxonTrading/models"
+	"AxonTrading/ratelimit"
+	"AxonTrading/retry"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,39 +16,56 @@ import (
 	"github.com/bitly/go-simplejson"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type Client struct {
 	Client       *binance.Client
 	FutureClient *futures.Client
+	// Instruments 合约注册表，nil 时按命名规则转换统一符号，可通过 LoadInstruments 加载
+	Instruments *instrument.Registry
+	// Limiter 请求限速，现货和合约共用，New/NewFuture 按 params 中的 rateLimit 创建，nil 时不限速
+	Limiter *ratelimit.Limiter
+	// Retry 下单重试策略，零值使用 retry.DefaultPolicy
+	Retry retry.Policy
+	// WsURL / FutureWsURL 现货和合约推送地址，New/NewFuture 读取 params 中的 wsUrl，为空时使用生产地址
+	WsURL       string
+	FutureWsURL string
+	// StreamRetry 推送断线重连的退避，零值为 500ms 起、最长 30s
+	StreamRetry retry.Policy
+
+	loader    instrument.Loader // 第一次下单前加载 Instruments，New/NewFuture 后重新加载
+	streamMu  sync.Mutex
+	userFeeds map[string]*userFeed // 账户推送连接，key 为 spotMarket / futuresMarket
 }
 
 func (c *Client) GetFutureTradingFee(symbol string) (models.TradingFee, error) {
-	result, err := c.FutureClient.NewCommissionRateService().Symbol(symbol).Do(context.Background())
+	result, err := c.FutureClient.NewCommissionRateService().Symbol(c.futureID(symbol)).Do(context.Background())
 	if err != nil {
-		return models.TradingFee{}, err
+		return models.TradingFee{}, apiError(err)
 	}
 	tradingFee := models.TradingFee{
-		Symbol:                result.Symbol,
-		TakerFeeFromApi:       result.TakerCommissionRate,
-		MakerFeeFromApi:       result.MakerCommissionRate,
-		TakerFeeFromRealOrder: "",
-		MakerFeeFromRealOrder: "",
+		Symbol:                echoSymbol(symbol, result.Symbol),
+		TakerFeeFromApi:       models.ParseDecimalOrZero(result.TakerCommissionRate),
+		MakerFeeFromApi:       models.ParseDecimalOrZero(result.MakerCommissionRate),
+		TakerFeeFromRealOrder: models.Decimal{},
+		MakerFeeFromRealOrder: models.Decimal{},
 		IfDiscount:            false,
 	}
-	return tradingFee, err
+	c.realFees(&tradingFee, futuresMarket, symbol)
+	return tradingFee, nil
 }
 
 func (c *Client) GetPositionRisk(symbol string) ([]models.PositionInfo, error) {
-	result, err := c.FutureClient.NewGetPositionRiskService().Symbol(symbol).Do(context.Background())
+	result, err := c.FutureClient.NewGetPositionRiskService().Symbol(c.futureID(symbol)).Do(context.Background())
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 	var positionInfo []models.PositionInfo
 	var marginType, positionSide string
 	for _, r := range result {
-		if r.MarginType == "ISOLATED" {
+		if strings.EqualFold(r.MarginType, "isolated") { // positionRisk 返回小写 isolated/cross
 			marginType = base.ISOLATED
 		} else {
 			marginType = base.CROSSED
@@ -59,20 +79,20 @@ func (c *Client) GetPositionRisk(symbol string) ([]models.PositionInfo, error) {
 		}
 
 		p := models.PositionInfo{
-			Symbol:           r.Symbol,
-			PositionAmt:      r.PositionAmt,
-			EntryPrice:       r.EntryPrice,
-			MarkPrice:        r.MarkPrice,
-			UnRealizedProfit: r.UnRealizedProfit,
-			LiquidationPrice: r.LiquidationPrice,
-			Leverage:         r.Leverage,
-			MaxNotionalValue: r.MaxNotionalValue,
+			Symbol:           echoSymbol(symbol, r.Symbol),
+			PositionAmt:      models.ParseDecimalOrZero(r.PositionAmt),
+			EntryPrice:       models.ParseDecimalOrZero(r.EntryPrice),
+			MarkPrice:        models.ParseDecimalOrZero(r.MarkPrice),
+			UnRealizedProfit: models.ParseDecimalOrZero(r.UnRealizedProfit),
+			LiquidationPrice: models.ParseDecimalOrZero(r.LiquidationPrice),
+			Leverage:         models.ParseDecimalOrZero(r.Leverage),
+			MaxNotionalValue: models.ParseDecimalOrZero(r.MaxNotionalValue),
 			MarginType:       marginType,
-			IsolatedMargin:   r.IsolatedMargin,
+			IsolatedMargin:   models.ParseDecimalOrZero(r.IsolatedMargin),
 			IsAutoAddMargin:  r.IsAutoAddMargin,
 			PositionSide:     positionSide,
-			Notional:         r.Notional,
-			IsolatedWallet:   r.IsolatedWallet,
+			Notional:         models.ParseDecimalOrZero(r.Notional),
+			IsolatedWallet:   models.ParseDecimalOrZero(r.IsolatedWallet),
 			UpdateTime:       0,
 		}
 		positionInfo = append(positionInfo, p)
@@ -96,9 +116,9 @@ func (c *Client) ChangePositionMargin(symbol, positionSide, amount string, typ i
 		pSide = "BOTH"
 	}
 
-	err = c.FutureClient.NewUpdatePositionMarginService().Symbol(symbol).PositionSide(futures.PositionSideType(pSide)).Amount(amount).Type(typ).Do(context.Background())
+	err = c.FutureClient.NewUpdatePositionMarginService().Symbol(c.futureID(symbol)).PositionSide(futures.PositionSideType(pSide)).Amount(amount).Type(typ).Do(context.Background())
 	if err != nil {
-		return false, err
+		return false, apiError(err)
 	}
 	return true, err
 }
@@ -110,25 +130,25 @@ func (c *Client) ChangeMarginType(symbol, typ string) error {
 	} else if typ == base.CROSSED {
 		marginType = "CROSSED"
 	}
-	err := c.FutureClient.NewChangeMarginTypeService().Symbol(symbol).MarginType(futures.MarginType(marginType)).Do(context.Background())
+	err := c.FutureClient.NewChangeMarginTypeService().Symbol(c.futureID(symbol)).MarginType(futures.MarginType(marginType)).Do(context.Background())
 	if err != nil {
-		return err
+		return apiError(err)
 	}
 	return err
 }
 
 func (c *Client) ChangeLeverage(symbol string, leverage int) (string, error) {
-	reslut, err := c.FutureClient.NewChangeLeverageService().Symbol(symbol).Leverage(leverage).Do(context.Background())
+	reslut, err := c.FutureClient.NewChangeLeverageService().Symbol(c.futureID(symbol)).Leverage(leverage).Do(context.Background())
 	if err != nil {
-		return strconv.Itoa(reslut.Leverage) + " " + reslut.Symbol, err
+		return "", apiError(err)
 	}
 	return strconv.Itoa(reslut.Leverage) + " " + reslut.Symbol, err
 }
 
 func (c *Client) GetFutureOpenOrders(symbol string) ([]models.FutureOrderInfo, error) {
-	reslut, err := c.FutureClient.NewListOpenOrdersService().Symbol(symbol).Do(context.Background())
+	reslut, err := c.FutureClient.NewListOpenOrdersService().Symbol(c.futureID(symbol)).Do(context.Background())
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 	var opens []models.FutureOrderInfo
 	var orderSide, pSide, orderState, orderType string
@@ -169,20 +189,20 @@ func (c *Client) GetFutureOpenOrders(symbol string) ([]models.FutureOrderInfo, e
 		}
 
 		orderInfo := models.FutureOrderInfo{
-			AvgPrice:      r.AvgPrice,
-			CumQuote:      r.CumQuote,
-			ExecutedQty:   r.ExecutedQuantity,
+			AvgPrice:      models.ParseDecimalOrZero(r.AvgPrice),
+			CumQuote:      models.ParseDecimalOrZero(r.CumQuote),
+			ExecutedQty:   models.ParseDecimalOrZero(r.ExecutedQuantity),
 			OrderId:       int(r.OrderID),
-			OrigQty:       r.OrigQuantity,
+			OrigQty:       models.ParseDecimalOrZero(r.OrigQuantity),
 			OrigType:      string(r.OrigType),
-			Price:         r.Price,
+			Price:         models.ParseDecimalOrZero(r.Price),
 			ReduceOnly:    r.ReduceOnly,
 			Side:          orderSide,
 			PositionSide:  pSide,
 			Status:        orderState,
-			StopPrice:     r.StopPrice,
+			StopPrice:     models.ParseDecimalOrZero(r.StopPrice),
 			ClosePosition: r.ClosePosition,
-			Symbol:        r.Symbol,
+			Symbol:        echoSymbol(symbol, r.Symbol),
 			Time:          r.Time,
 			TimeInForce:   string(r.TimeInForce),
 			Type:          orderType,
@@ -197,26 +217,26 @@ func (c *Client) GetFutureOpenOrders(symbol string) ([]models.FutureOrderInfo, e
 func (c *Client) CancelFutureOrder(symbol, orderID string) (bool, error) {
 	id, _ := strconv.ParseInt(orderID, 10, 64)
 
-	_, err := c.FutureClient.NewCancelOrderService().Symbol(symbol).OrderID(id).Do(context.Background())
+	_, err := c.FutureClient.NewCancelOrderService().Symbol(c.futureID(symbol)).OrderID(id).Do(context.Background())
 	if err != nil {
-		return false, err
+		return false, apiError(err)
 	}
 	return true, err
 }
 
 func (c *Client) CancelFutureOrders(symbol string) error {
-	err := c.FutureClient.NewCancelAllOpenOrdersService().Symbol(symbol).Do(context.Background())
+	err := c.FutureClient.NewCancelAllOpenOrdersService().Symbol(c.futureID(symbol)).Do(context.Background())
 	if err != nil {
-		return err
+		return apiError(err)
 	}
 	return err
 }
 
 func (c *Client) GetFutureOrder(symbol, orderID string) (models.FutureOrderInfo, error) {
 	id, _ := strconv.ParseInt(orderID, 10, 64)
-	result, err := c.FutureClient.NewGetOrderService().Symbol(symbol).OrderID(id).Do(context.Background())
+	result, err := c.FutureClient.NewGetOrderService().Symbol(c.futureID(symbol)).OrderID(id).Do(context.Background())
 	if err != nil {
-		return models.FutureOrderInfo{}, err
+		return models.FutureOrderInfo{}, apiError(err)
 	}
 	var orderSide, pSide, orderState, orderType string
 	if result.Side == "BUY" {
@@ -254,20 +274,20 @@ func (c *Client) GetFutureOrder(symbol, orderID string) (models.FutureOrderInfo,
 		orderType = base.TAKEPROFITMARKET
 	}
 	orderInfo := models.FutureOrderInfo{
-		AvgPrice:      result.AvgPrice,
-		CumQuote:      result.CumQuote,
-		ExecutedQty:   result.ExecutedQuantity,
+		AvgPrice:      models.ParseDecimalOrZero(result.AvgPrice),
+		CumQuote:      models.ParseDecimalOrZero(result.CumQuote),
+		ExecutedQty:   models.ParseDecimalOrZero(result.ExecutedQuantity),
 		OrderId:       int(id),
-		OrigQty:       result.OrigQuantity,
+		OrigQty:       models.ParseDecimalOrZero(result.OrigQuantity),
 		OrigType:      string(result.OrigType),
-		Price:         result.Price,
+		Price:         models.ParseDecimalOrZero(result.Price),
 		ReduceOnly:    result.ReduceOnly,
 		Side:          orderSide,
 		PositionSide:  pSide,
 		Status:        orderState,
-		StopPrice:     result.StopPrice,
+		StopPrice:     models.ParseDecimalOrZero(result.StopPrice),
 		ClosePosition: result.ClosePosition,
-		Symbol:        result.Symbol,
+		Symbol:        echoSymbol(symbol, result.Symbol),
 		Time:          result.Time,
 		TimeInForce:   string(result.TimeInForce),
 		Type:          orderType,
@@ -278,7 +298,22 @@ func (c *Client) GetFutureOrder(symbol, orderID string) (models.FutureOrderInfo,
 }
 
 func (c *Client) NewFutureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool) (string, error) {
-	err := c.ChangeMarginType(symbol, positionType)
+	return c.futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType, closePosition, priceProtect, "")
+}
+
+// NewFutureOrderWithClientID 与 NewFutureOrder 相同，使用调用方指定的 newClientOrderId
+func (c *Client) NewFutureOrderWithClientID(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool, clientID string) (string, error) {
+	return c.futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType, closePosition, priceProtect, clientID)
+}
+
+// futureOrder U本位合约下单，clientID 为空时自动生成
+func (c *Client) futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool, clientID string) (string, error) {
+	id := c.futureID(symbol)
+	price, size, err := c.quantizeOrder(futureKind(id), id, typ, price, size)
+	if err != nil {
+		return "", err
+	}
+	err = c.ChangeMarginType(symbol, positionType)
 	if err != nil {
 		return "", err
 	}
@@ -315,64 +350,60 @@ func (c *Client) NewFutureOrder(symbol, side, positionSide, typ, size, price, st
 		orderType = "TAKE_PROFIT_MARKET"
 	}
 	if typ == base.LIMIT {
-		result, err := c.FutureClient.NewCreateOrderService().
-			Symbol(symbol).
+		result, err := c.createFutureOrder(symbol, clientID, c.FutureClient.NewCreateOrderService().
+			Symbol(c.futureID(symbol)).
 			Side(futures.SideType(orderSide)).
 			PositionSide(futures.PositionSideType(pSide)).
 			Type(futures.OrderType(orderType)).TimeInForce("GTC").
 			Price(price).Quantity(size).
 			ClosePosition(closePosition).
-			PriceProtect(priceProtect).
-			Do(context.Background())
+			PriceProtect(priceProtect))
 		if err != nil {
-			return "", err
+			return "", apiError(err)
 		}
 		return fmt.Sprint(result.OrderID), err
 
 	} else if typ == base.MARKET {
-		result, err := c.FutureClient.NewCreateOrderService().
-			Symbol(symbol).
+		result, err := c.createFutureOrder(symbol, clientID, c.FutureClient.NewCreateOrderService().
+			Symbol(c.futureID(symbol)).
 			Side(futures.SideType(orderSide)).
 			PositionSide(futures.PositionSideType(pSide)).
 			Type(futures.OrderType(orderType)).
 			Quantity(size).
 			ClosePosition(closePosition).
-			PriceProtect(priceProtect).
-			Do(context.Background())
+			PriceProtect(priceProtect))
 		if err != nil {
-			return "", err
+			return "", apiError(err)
 		}
 		return fmt.Sprint(result.OrderID), err
 
 	} else if typ == base.STOP || typ == base.TAKEPROFIT {
-		result, err := c.FutureClient.NewCreateOrderService().
-			Symbol(symbol).
+		result, err := c.createFutureOrder(symbol, clientID, c.FutureClient.NewCreateOrderService().
+			Symbol(c.futureID(symbol)).
 			Side(futures.SideType(orderSide)).
 			PositionSide(futures.PositionSideType(pSide)).
 			Type(futures.OrderType(orderType)).
 			Price(price).Quantity(size).
 			StopPrice(stopPrice).
 			ClosePosition(closePosition).
-			PriceProtect(priceProtect).
-			Do(context.Background())
+			PriceProtect(priceProtect))
 		if err != nil {
-			return "", err
+			return "", apiError(err)
 		}
 		return fmt.Sprint(result.OrderID), err
 
 	} else {
-		result, err := c.FutureClient.NewCreateOrderService().
-			Symbol(symbol).
+		result, err := c.createFutureOrder(symbol, clientID, c.FutureClient.NewCreateOrderService().
+			Symbol(c.futureID(symbol)).
 			Side(futures.SideType(orderSide)).
 			PositionSide(futures.PositionSideType(pSide)).
 			Type(futures.OrderType(orderType)).
 			Quantity(size).
 			StopPrice(stopPrice).
 			ClosePosition(closePosition).
-			PriceProtect(priceProtect).
-			Do(context.Background())
+			PriceProtect(priceProtect))
 		if err != nil {
-			return "", err
+			return "", apiError(err)
 		}
 		return fmt.Sprint(result.OrderID), err
 
@@ -387,7 +418,7 @@ func (c *Client) Dual(dualSize bool) (bool, error) {
 		Do(context.Background())
 
 	if err != nil {
-		return false, err
+		return false, apiError(err)
 	}
 	return true, err
 }
@@ -398,7 +429,7 @@ func (c *Client) CheckDual() (bool, error) {
 		Do(context.Background())
 
 	if err != nil {
-		return false, err
+		return false, apiError(err)
 	}
 	return dual.DualSidePosition, err
 }
@@ -410,7 +441,7 @@ func (c *Client) NewFuture(params []byte) error {
 		if err != nil {
 			return err
 		}
-		_ = sj.Get("url").MustString()
+		baseURL := sj.Get("url").MustString()
 		apiKey := sj.Get("apiKey").MustString()
 		secretKey := sj.Get("secretKey").MustString()
 		_ = sj.Get("password").MustString()
@@ -418,6 +449,16 @@ func (c *Client) NewFuture(params []byte) error {
 		// init client by config
 		binance.UseTestnet = false
 		c.FutureClient = futures.NewClient(apiKey, secretKey)
+		// url 为空时使用 go-binance 默认的生产地址
+		if baseURL != "" {
+			c.FutureClient.BaseURL = baseURL
+		}
+		c.FutureWsURL = sj.Get("wsUrl").MustString()
+		if err := c.initLimiter(params); err != nil {
+			return err
+		}
+		c.FutureClient.HTTPClient = limitedHTTPClient(c.Limiter, futuresMarket)
+		c.loader.Reset()
 
 		return nil
 	}
@@ -431,16 +472,16 @@ func (c *Client) GetFutureBalance() (models.FutureBalance, error) {
 		Do(context.Background())
 
 	if err != nil {
-		return models.FutureBalance{}, err
+		return models.FutureBalance{}, apiError(err)
 	}
 	var balance models.FutureBalance
 	for _, b := range account {
 		if b.Asset == "USDT" {
 			balance = models.FutureBalance{
 				Asset:            b.Asset,
-				TotalBalance:     b.Balance,
-				CrossBalance:     b.CrossWalletBalance,
-				AvailableBalance: b.AvailableBalance,
+				TotalBalance:     models.ParseDecimalOrZero(b.Balance),
+				CrossBalance:     models.ParseDecimalOrZero(b.CrossWalletBalance),
+				AvailableBalance: models.ParseDecimalOrZero(b.AvailableBalance),
 			}
 		}
 	}
@@ -460,9 +501,12 @@ func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
 	}
 
 	res, err := c.FutureClient.NewDepthService().
-		Symbol(symbol).
+		Symbol(c.futureID(symbol)).
 		Limit(parseInt).
 		Do(context.Background())
+	if err != nil {
+		return models.WsData{}, apiError(err)
+	}
 
 	resByre, err := json.Marshal(res)
 	if err != nil {
@@ -479,8 +523,8 @@ func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
 
 	for _, bid := range info.Bids {
 		b := models.PriceLevel{
-			Price:    bid.Price,
-			Quantity: bid.Quantity,
+			Price:    models.ParseDecimalOrZero(bid.Price),
+			Quantity: models.ParseDecimalOrZero(bid.Quantity),
 		}
 
 		bids = append(bids, b)
@@ -489,8 +533,8 @@ func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
 	for _, ask := range info.Asks {
 
 		a := models.PriceLevel{
-			Price:    ask.Price,
-			Quantity: ask.Quantity,
+			Price:    models.ParseDecimalOrZero(ask.Price),
+			Quantity: models.ParseDecimalOrZero(ask.Quantity),
 		}
 
 		asks = append(asks, a)
@@ -505,10 +549,10 @@ func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
 
 func (c *Client) GetFutureMarketPrice(symbol string) (string, error) {
 	prices, err := c.FutureClient.NewListPricesService().
-		Symbol(symbol).
+		Symbol(c.futureID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return "", err
+		return "", apiError(err)
 	}
 
 	return prices[0].Price, nil
@@ -516,19 +560,19 @@ func (c *Client) GetFutureMarketPrice(symbol string) (string, error) {
 
 func (c *Client) GetMarkPriceAndFundingRate(symbol string) (models.FundingRate, error) {
 	FR, err := c.FutureClient.NewPremiumIndexService().
-		Symbol(symbol).
+		Symbol(c.futureID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return models.FundingRate{}, err
+		return models.FundingRate{}, apiError(err)
 	}
 	result := models.FundingRate{
-		Symbol:               FR[0].Symbol,
-		MarkPrice:            FR[0].MarkPrice,
-		IndexPrice:           "",
-		EstimatedSettlePrice: "",
-		LastFundingRate:      FR[0].LastFundingRate,
+		Symbol:               echoSymbol(symbol, FR[0].Symbol),
+		MarkPrice:            models.ParseDecimalOrZero(FR[0].MarkPrice),
+		IndexPrice:           models.Decimal{},
+		EstimatedSettlePrice: models.Decimal{},
+		LastFundingRate:      models.ParseDecimalOrZero(FR[0].LastFundingRate),
 		NextFundingTime:      FR[0].NextFundingTime,
-		InterestRate:         "",
+		InterestRate:         models.Decimal{},
 		Time:                 FR[0].Time,
 	}
 	return result, err
@@ -539,36 +583,82 @@ func (c *Client) GetDepositAddress(token, chain string) (string, error) {
 		Coin(token).Network(chain).
 		Do(context.Background())
 	if err != nil {
-		return "", err
+		return "", apiError(err)
 	}
 	return address.Address, err
 }
 
+// Withdraw 提币，chain 为 Binance 的 network（如 TRX、BSC），返回提币 id
 func (c *Client) Withdraw(token, chain, to, amount string) (string, error) {
-
-	//TODO implement me
-	panic("implement me")
+	svc := c.Client.NewCreateWithdrawService().
+		Coin(token).
+		Address(to).
+		Amount(amount)
+	if chain != "" {
+		svc.Network(chain)
+	}
+	res, err := svc.Do(context.Background())
+	if err != nil {
+		return "", apiError(err)
+	}
+	return res.ID, nil
 }
 
+// GetAllTickers 全部现货币对的 24 小时行情，ChangeRate 为涨跌比例（0.01 表示涨 1%）
 func (c *Client) GetAllTickers() ([]models.SymbolTicker, error) {
-
-	//TODO implement me
-	panic("implement me")
+	stats, err := c.Client.NewListPriceChangeStatsService().Do(context.Background())
+	if err != nil {
+		return nil, apiError(err)
+	}
+	hundred := models.NewDecimalFromInt(100)
+	tickers := make([]models.SymbolTicker, 0, len(stats))
+	for _, s := range stats {
+		rate, err := models.ParseDecimalOrZero(s.PriceChangePercent).Div(hundred, 8)
+		if err != nil {
+			return nil, err
+		}
+		tickers = append(tickers, models.SymbolTicker{
+			Symbol:     s.Symbol,
+			ChangeRate: rate.String(),
+			Volume:     s.Volume,
+			LastPrice:  s.LastPrice,
+		})
+	}
+	return tickers, nil
 }
 
+// LimitHiddenOrder Binance 现货没有隐藏单
 func (c *Client) LimitHiddenOrder(symbol, side, price, size string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	return "", base.ErrNotSupported
 }
 
 func (c *Client) LimitHiddenOrders(symbol string, ol []models.OrderList) ([]string, error) {
-	//TODO implement me
-	panic("implement me")
+	return nil, base.ErrNotSupported
 }
 
+// GetFeeFromFilled 汇总订单全部成交的手续费，返回 手续费, 手续费币种
 func (c *Client) GetFeeFromFilled(symbol, id string) (string, string, error) {
-	//TODO implement me
-	panic("implement me")
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: order id %q", models.ErrInvalidRequest, id)
+	}
+	trades, err := c.Client.NewListTradesService().
+		Symbol(c.spotID(symbol)).
+		OrderId(orderID).
+		Do(context.Background())
+	if err != nil {
+		return "", "", apiError(err)
+	}
+	// 部分成交用 BNB 抵扣时手续费会分布在多个币种，无法用一个数表示
+	total, asset := models.Decimal{}, ""
+	for _, t := range trades {
+		if asset != "" && t.CommissionAsset != asset {
+			return "", "", fmt.Errorf("fee charged in both %s and %s", asset, t.CommissionAsset)
+		}
+		asset = t.CommissionAsset
+		total = total.Add(models.ParseDecimalOrZero(t.Commission))
+	}
+	return total.String(), asset, nil
 }
 
 func (c *Client) New(params []byte) error {
@@ -578,7 +668,7 @@ func (c *Client) New(params []byte) error {
 		if err != nil {
 			return err
 		}
-		_ = sj.Get("url").MustString()
+		baseURL := sj.Get("url").MustString()
 		apiKey := sj.Get("apiKey").MustString()
 		secretKey := sj.Get("secretKey").MustString()
 		_ = sj.Get("password").MustString()
@@ -586,6 +676,16 @@ func (c *Client) New(params []byte) error {
 		// init client by config
 		binance.UseTestnet = false
 		c.Client = binance.NewClient(apiKey, secretKey)
+		// url 为空时使用 go-binance 默认的生产地址
+		if baseURL != "" {
+			c.Client.BaseURL = baseURL
+		}
+		c.WsURL = sj.Get("wsUrl").MustString()
+		if err := c.initLimiter(params); err != nil {
+			return err
+		}
+		c.Client.HTTPClient = limitedHTTPClient(c.Limiter, spotMarket)
+		c.loader.Reset()
 
 		return nil
 	}
@@ -598,15 +698,55 @@ func (c *Client) NewWithParams(apiKey, secretKey string) error {
 		// init client by config
 		binance.UseTestnet = false
 		c.Client = binance.NewClient(apiKey, secretKey)
+		if err := c.initLimiter(nil); err != nil {
+			return err
+		}
+		c.Client.HTTPClient = limitedHTTPClient(c.Limiter, spotMarket)
+		c.loader.Reset()
 		return nil
 	}
 
 	return errors.New("binance client has not been initialized")
 }
 
+// IceBergOrder 冰山单，ice 为每次展示的数量（icebergQty），typ 为 base.MAKER 时只挂单
 func (c *Client) IceBergOrder(symbol, side, typ, price, size, ice string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	price, size, err := c.quantizeOrder(instrument.Spot, c.spotID(symbol), base.LIMIT, price, size)
+	if err != nil {
+		return "", err
+	}
+	var s binance.SideType
+	if side == base.BID {
+		s = binance.SideTypeBuy
+	} else if side == base.ASK {
+		s = binance.SideTypeSell
+	}
+
+	svc := c.Client.NewCreateOrderService().
+		Symbol(c.spotID(symbol)).
+		Side(s).
+		Quantity(size).
+		Price(price).
+		IcebergQuantity(ice)
+	if typ == base.MAKER {
+		svc.Type(binance.OrderTypeLimitMaker)
+	} else {
+		svc.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC)
+	}
+	order, err := c.createOrder(symbol, "", svc)
+	if err != nil {
+		return "", apiError(err)
+	}
+	return strconv.FormatInt(order.OrderID, 10), nil
+}
+
+// Has Binance 没有隐藏单，其余可选功能都支持
+func (c *Client) Has(capability string) bool {
+	switch capability {
+	case base.CapWithdraw, base.CapIceberg, base.CapFeeFromFilled, base.CapMarginType:
+		return true
+	}
+	return false
 }
 
 func (c *Client) GetAccountBalance(currency string) ([]string, error) {
@@ -615,86 +755,71 @@ func (c *Client) GetAccountBalance(currency string) ([]string, error) {
 		NewGetAccountService().
 		Do(context.Background())
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 	var res []string
 
 	for _, balance := range account.Balances {
 		if balance.Asset == currency {
-			a, _ := strconv.ParseFloat(balance.Free, 64)
-			b, _ := strconv.ParseFloat(balance.Locked, 64)
-			d := strconv.FormatFloat(a+b, 'f', 5, 64)
-			res = append(res, balance.Free, balance.Locked, d)
+			total := models.ParseDecimalOrZero(balance.Free).Add(models.ParseDecimalOrZero(balance.Locked))
+			res = append(res, balance.Free, balance.Locked, total.String())
 			return res, nil
 		}
 	}
-	return nil, nil
+	// 余额为零的币种不在 balances 中
+	return []string{"0", "0", "0"}, nil
 }
 
-func (c *Client) MarketOrder(symbol, side, size string) (string, error) {
-	var s binance.SideType
-	if side == base.BID {
-		s = binance.SideTypeBuy
-	} else if side == base.ASK {
-		s = binance.SideTypeSell
-	}
+// PlaceOrderWithClientID 现货下单并使用调用方指定的 newClientOrderId，typ 为 base.MARKET / LIMIT / MAKER / TAKER
+func (c *Client) PlaceOrderWithClientID(symbol, side, typ, price, size, clientID string) (string, error) {
+	return c.spotOrder(symbol, side, typ, price, size, clientID)
+}
 
-	order, err := c.Client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(s).
-		Type(binance.OrderTypeMarket).
-		//	TimeInForce(binance.TimeInForceTypeGTC).
-		Quantity(size).
-		//	Price(price).
-		Do(context.Background())
+// spotOrder 现货下单，clientID 为空时自动生成
+func (c *Client) spotOrder(symbol, side, typ, price, size, clientID string) (string, error) {
+	price, size, err := c.quantizeOrder(instrument.Spot, c.spotID(symbol), typ, price, size)
 	if err != nil {
 		return "", err
 	}
-	return strconv.FormatInt(order.OrderID, 10), nil
-}
-
-func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
 	var s binance.SideType
 	if side == base.BID {
 		s = binance.SideTypeBuy
 	} else if side == base.ASK {
 		s = binance.SideTypeSell
 	}
-
-	order, err := c.Client.NewCreateOrderService().
-		Symbol(symbol).
+	svc := c.Client.NewCreateOrderService().
+		Symbol(c.spotID(symbol)).
 		Side(s).
-		Type(binance.OrderTypeLimit).
-		TimeInForce(binance.TimeInForceTypeGTC).
-		Quantity(size).
-		Price(price).
-		Do(context.Background())
+		Quantity(size)
+	switch typ {
+	case base.MARKET:
+		svc.Type(binance.OrderTypeMarket)
+	case base.LIMIT:
+		svc.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).Price(price)
+	case base.MAKER:
+		svc.Type(binance.OrderTypeLimitMaker).Price(price)
+	case base.TAKER:
+		svc.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeIOC).Price(price)
+	default:
+		return "", fmt.Errorf("%w: binance spot order type %q", base.ErrNotSupported, typ)
+	}
+	order, err := c.createOrder(symbol, clientID, svc)
 	if err != nil {
-		return "", err
+		return "", apiError(err)
 	}
 	return strconv.FormatInt(order.OrderID, 10), nil
 }
 
-func (c *Client) TakerOrder(symbol, side, price, size string) (string, error) {
-	var s binance.SideType
-	if side == base.BID {
-		s = binance.SideTypeBuy
-	} else if side == base.ASK {
-		s = binance.SideTypeSell
-	}
+func (c *Client) MarketOrder(symbol, side, size string) (string, error) {
+	return c.spotOrder(symbol, side, base.MARKET, "", size, "")
+}
 
-	order, err := c.Client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(s).
-		Type(binance.OrderTypeLimit).
-		TimeInForce(binance.TimeInForceTypeIOC).
-		Quantity(size).
-		Price(price).
-		Do(context.Background())
-	if err != nil {
-		return "", err
-	}
-	return strconv.FormatInt(order.OrderID, 10), nil
+func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
+	return c.spotOrder(symbol, side, base.LIMIT, price, size, "")
+}
+
+func (c *Client) TakerOrder(symbol, side, price, size string) (string, error) {
+	return c.spotOrder(symbol, side, base.TAKER, price, size, "")
 }
 
 func (c *Client) CancelOrder(symbol, id string) (bool, error) {
@@ -704,11 +829,11 @@ func (c *Client) CancelOrder(symbol, id string) (bool, error) {
 	}
 
 	resp, err := c.Client.NewCancelOrderService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		OrderID(parseInt).
 		Do(context.Background())
 	if err != nil {
-		return false, err
+		return false, apiError(err)
 	}
 
 	if resp.Status != "CANCELED" {
@@ -719,10 +844,10 @@ func (c *Client) CancelOrder(symbol, id string) (bool, error) {
 
 func (c *Client) CancelOrders(symbol string) error {
 	_, err := c.Client.NewCancelOpenOrdersService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return err
+		return apiError(err)
 	}
 
 	return err
@@ -736,11 +861,11 @@ func (c *Client) GetOrder(symbol, id string) (models.OrderInfo, error) {
 	}
 
 	order, err := c.Client.NewGetOrderService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		OrderID(oid).
 		Do(context.Background())
 	if err != nil {
-		return models.OrderInfo{}, err
+		return models.OrderInfo{}, apiError(err)
 	}
 
 	var side string
@@ -773,14 +898,14 @@ func (c *Client) GetOrder(symbol, id string) (models.OrderInfo, error) {
 
 	o := models.OrderInfo{
 		OrderID:  strconv.FormatInt(order.OrderID, 10),
-		Symbol:   order.Symbol,
+		Symbol:   echoSymbol(symbol, order.Symbol),
 		Side:     side,
-		Price:    order.Price,
-		Quantity: order.OrigQuantity,
+		Price:    models.ParseDecimalOrZero(order.Price),
+		Quantity: models.ParseDecimalOrZero(order.OrigQuantity),
 		Status:   status,
 		Type:     typ,
-		USDT:     order.CummulativeQuoteQuantity,
-		Filled:   order.ExecutedQuantity,
+		USDT:     models.ParseDecimalOrZero(order.CummulativeQuoteQuantity),
+		Filled:   models.ParseDecimalOrZero(order.ExecutedQuantity),
 		Time:     order.Time,
 	}
 
@@ -789,10 +914,10 @@ func (c *Client) GetOrder(symbol, id string) (models.OrderInfo, error) {
 
 func (c *Client) GetOpenOrders(symbol string) ([]models.OrderInfo, error) {
 	orders, err := c.Client.NewListOpenOrdersService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	if len(orders) == 0 {
@@ -823,11 +948,11 @@ func (c *Client) GetOpenOrders(symbol string) ([]models.OrderInfo, error) {
 
 		o := models.OrderInfo{
 			OrderID:  strconv.FormatInt(order.OrderID, 10),
-			Symbol:   order.Symbol,
+			Symbol:   echoSymbol(symbol, order.Symbol),
 			Side:     side,
-			Price:    order.Price,
-			Quantity: order.OrigQuantity,
-			Filled:   order.ExecutedQuantity,
+			Price:    models.ParseDecimalOrZero(order.Price),
+			Quantity: models.ParseDecimalOrZero(order.OrigQuantity),
+			Filled:   models.ParseDecimalOrZero(order.ExecutedQuantity),
 			Type:     typ,
 			Time:     order.Time,
 		}
@@ -855,10 +980,10 @@ func (c *Client) GetOpenOrdersWithSide(symbol, side string) ([]models.OrderInfo,
 
 func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models.OrderInfo, error) {
 	orders, err := c.Client.NewListOpenOrdersService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, apiError(err)
 	}
 
 	if len(orders) == 0 {
@@ -887,11 +1012,11 @@ func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models
 
 			o := models.OrderInfo{
 				OrderID:  strconv.FormatInt(order.OrderID, 10),
-				Symbol:   order.Symbol,
+				Symbol:   echoSymbol(symbol, order.Symbol),
 				Side:     side,
-				Price:    order.Price,
-				Quantity: order.OrigQuantity,
-				Filled:   order.ExecutedQuantity,
+				Price:    models.ParseDecimalOrZero(order.Price),
+				Quantity: models.ParseDecimalOrZero(order.OrigQuantity),
+				Filled:   models.ParseDecimalOrZero(order.ExecutedQuantity),
 				Type:     typ,
 				Time:     order.Time,
 			}
@@ -903,10 +1028,10 @@ func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models
 
 			o := models.OrderInfo{
 				OrderID:  strconv.FormatInt(order.OrderID, 10),
-				Symbol:   order.Symbol,
+				Symbol:   echoSymbol(symbol, order.Symbol),
 				Side:     side,
-				Price:    order.Price,
-				Quantity: order.OrigQuantity,
+				Price:    models.ParseDecimalOrZero(order.Price),
+				Quantity: models.ParseDecimalOrZero(order.OrigQuantity),
 				Type:     typ,
 				Time:     order.Time,
 			}
@@ -921,10 +1046,10 @@ func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models
 
 func (c *Client) GetMarketPrice(symbol string) (string, error) {
 	prices, err := c.Client.NewListPricesService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return "", err
+		return "", apiError(err)
 	}
 
 	return prices[0].Price, nil
@@ -942,9 +1067,12 @@ func (c *Client) Depth(symbol, limit string) (models.WsData, error) {
 	}
 
 	res, err := c.Client.NewDepthService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Limit(parseInt).
 		Do(context.Background())
+	if err != nil {
+		return models.WsData{}, apiError(err)
+	}
 
 	resByre, err := json.Marshal(res)
 	if err != nil {
@@ -961,8 +1089,8 @@ func (c *Client) Depth(symbol, limit string) (models.WsData, error) {
 
 	for _, bid := range info.Bids {
 		b := models.PriceLevel{
-			Price:    bid.Price,
-			Quantity: bid.Quantity,
+			Price:    models.ParseDecimalOrZero(bid.Price),
+			Quantity: models.ParseDecimalOrZero(bid.Quantity),
 		}
 
 		bids = append(bids, b)
@@ -971,8 +1099,8 @@ func (c *Client) Depth(symbol, limit string) (models.WsData, error) {
 	for _, ask := range info.Asks {
 
 		a := models.PriceLevel{
-			Price:    ask.Price,
-			Quantity: ask.Quantity,
+			Price:    models.ParseDecimalOrZero(ask.Price),
+			Quantity: models.ParseDecimalOrZero(ask.Quantity),
 		}
 
 		asks = append(asks, a)
@@ -1019,7 +1147,7 @@ func (c *Client) TakerOrders(symbol string, ol []models.OrderList) ([]string, er
 		side := ol[i].Side
 		price := ol[i].Price
 		size := ol[i].Size
-		id, err = c.TakerOrder(side, symbol, price, size)
+		id, err = c.TakerOrder(symbol, side, price, size)
 		if err != nil {
 			return nil, err
 		}
@@ -1030,25 +1158,7 @@ func (c *Client) TakerOrders(symbol string, ol []models.OrderList) ([]string, er
 }
 
 func (c *Client) MakerOrder(symbol, side, price, size string) (string, error) {
-	var s binance.SideType
-	if side == base.BID {
-		s = binance.SideTypeBuy
-	} else if side == base.ASK {
-		s = binance.SideTypeSell
-	}
-
-	order, err := c.Client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(s).
-		Type(binance.OrderTypeLimitMaker).
-		Quantity(size).
-		Price(price).
-		Do(context.Background())
-	if err != nil {
-		return "", err
-	}
-
-	return strconv.FormatInt(order.OrderID, 10), err
+	return c.spotOrder(symbol, side, base.MAKER, price, size, "")
 }
 
 func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, error) {
@@ -1062,7 +1172,7 @@ func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, er
 		side := ol[i].Side
 		price := ol[i].Price
 		size := ol[i].Size
-		id, err = c.MakerOrder(side, symbol, price, size)
+		id, err = c.MakerOrder(symbol, side, price, size)
 		if err != nil {
 			return nil, err
 		}
@@ -1075,59 +1185,43 @@ func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, er
 func (c *Client) GetPairInfo(symbol string) (models.PairInfo, error) {
 	pair, err := c.Client.
 		NewExchangeInfoService().
-		Symbol(symbol).
+		Symbol(c.spotID(symbol)).
 		Do(context.Background())
 	if err != nil {
-		return models.PairInfo{}, err
+		return models.PairInfo{}, apiError(err)
 	}
 
 	depth, err := c.Depth(symbol, "5")
 	if err != nil {
 		return models.PairInfo{}, err
 	}
-	q := depth.Bids[0].Quantity
-	l := len(q)
-	for i := l; i >= 0; i-- {
-		if q[len(q)-1] == '0' {
-			q = q[0 : len(q)-1]
-		} else {
-			break
-		}
-	}
-
-	p := depth.Bids[0].Price
-	l = len(p)
-	for i := l; i >= 0; i-- {
-		if p[len(p)-1] == '0' {
-			p = p[0 : len(p)-1]
-		} else {
-			break
-		}
-	}
-
 	info := models.PairInfo{
-		MinBaseAmount:   strconv.FormatFloat(float64(1/10^pair.Symbols[0].BaseAssetPrecision), 'f', 7, 64),
-		MinQuoteAmount:  strconv.FormatFloat(float64(1/10^pair.Symbols[0].QuoteAssetPrecision), 'f', 7, 64),
-		AmountPrecision: len(strings.Split(q, ".")[1]),
-		Precision:       len(strings.Split(p, ".")[1]),
-	}
+		MinBaseAmount:   models.NewDecimal(1, int32(pair.Symbols[0].BaseAssetPrecision)),
+		MinQuoteAmount:  models.NewDecimal(1, int32(pair.Symbols[0].QuoteAssetPrecision)),
+		AmountPrecision: depth.Bids[0].Quantity.Places(),
+		Precision:       depth.Bids[0].Price.Places(),
+	}
+	inst := spotInstruments(pair.Symbols[:1])[0]
+	info.TickSize, info.LotSize, info.MinSize = inst.TickSize, inst.LotSize, inst.MinSize
+	info.MaxLimitSize, info.MaxMarketSize, info.MinNotional = inst.MaxLimitSize, inst.MaxMarketSize, inst.MinNotional
 	return info, err
 
 }
 
 func (c *Client) GetTradingFee(symbol string) (models.TradingFee, error) {
-	account, err := c.Client.NewTradeFeeService().Symbol(symbol).Do(context.Background())
+	account, err := c.Client.NewTradeFeeService().Symbol(c.spotID(symbol)).Do(context.Background())
 	if err != nil {
-		return models.TradingFee{}, err
+		return models.TradingFee{}, apiError(err)
 	}
 
 	info := models.TradingFee{
 		Symbol:                symbol,
-		TakerFeeFromApi:       account[0].TakerCommission,
-		MakerFeeFromApi:       account[0].MakerCommission,
-		TakerFeeFromRealOrder: "",
-		MakerFeeFromRealOrder: "",
+		TakerFeeFromApi:       models.ParseDecimalOrZero(account[0].TakerCommission),
+		MakerFeeFromApi:       models.ParseDecimalOrZero(account[0].MakerCommission),
+		TakerFeeFromRealOrder: models.Decimal{},
+		MakerFeeFromRealOrder: models.Decimal{},
 		IfDiscount:            false,
 	}
-	return info, err
+	c.realFees(&info, spotMarket, symbol)
+	return info, nil
 }
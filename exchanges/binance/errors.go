@@ -0,0 +1,69 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// errorCodes Binance 错误码到 base 错误分类的映射
+// https://binance-docs.github.io/apidocs/spot/en/#error-codes
+var errorCodes = map[int64]error{
+	-1003: base.ErrRateLimited, // TOO_MANY_REQUESTS
+	-1015: base.ErrRateLimited, // TOO_MANY_ORDERS
+
+	-1021: base.ErrTimestamp, // INVALID_TIMESTAMP
+
+	-1002: base.ErrAuth, // UNAUTHORIZED
+	-1022: base.ErrAuth, // INVALID_SIGNATURE
+	-2008: base.ErrAuth, // BAD_API_ID
+	-2014: base.ErrAuth, // BAD_API_KEY_FMT
+	-2015: base.ErrAuth, // REJECTED_MBX_KEY
+
+	-2018: base.ErrInsufficientFunds, // BALANCE_NOT_SUFFICIENT（合约）
+	-2019: base.ErrInsufficientFunds, // MARGIN_NOT_SUFFICIEN（合约）
+
+	-2013: base.ErrOrderNotFound, // NO_SUCH_ORDER
+	-2011: base.ErrOrderNotFound, // CANCEL_REJECTED: Unknown order sent
+
+	-1121: base.ErrInvalidSymbol, // BAD_SYMBOL
+
+	-1000: base.ErrUnknownStatus, // UNKNOWN: An unknown error occurred while processing the request
+	-1006: base.ErrUnknownStatus, // UNEXPECTED_RESP: execution status unknown
+	-1007: base.ErrUnknownStatus, // TIMEOUT: send status unknown; execution status unknown
+}
+
+// apiError 把 go-binance 返回的 *common.APIError 包装为 *base.ExchangeError，其它错误原样返回
+func apiError(err error) error {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	kind := errorCodes[apiErr.Code]
+	// go-binance 在 4xx/5xx 响应体不是 JSON（网关 502 的 HTML 页面等）时返回 code 0，
+	// 拿不到 HTTP 状态，无法确定请求是否已执行
+	if apiErr.Code == 0 {
+		kind = base.ErrUnknownStatus
+	}
+	// -2010 NEW_ORDER_REJECTED 是通用拒单，按消息识别余额不足和重复的 clientOrderID
+	if kind == nil && apiErr.Code == -2010 {
+		msg := strings.ToLower(apiErr.Message)
+		if strings.Contains(msg, "insufficient balance") {
+			kind = base.ErrInsufficientFunds
+		} else if strings.Contains(msg, "duplicate order") {
+			kind = base.ErrDuplicateOrder
+		}
+	}
+	raw, _ := json.Marshal(apiErr)
+	return &base.ExchangeError{
+		Exchange: base.BINANCE,
+		Code:     strconv.FormatInt(apiErr.Code, 10),
+		Message:  apiErr.Message,
+		Raw:      raw,
+		Kind:     kind,
+	}
+}
@@ -0,0 +1,65 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/retry"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+func TestAPIError(t *testing.T) {
+	cases := []struct {
+		code int64
+		msg  string
+		want error
+	}{
+		{-1003, "Too many requests", base.ErrRateLimited},
+		{-1021, "Timestamp for this request is outside of the recvWindow.", base.ErrTimestamp},
+		{-2015, "Invalid API-key, IP, or permissions for action.", base.ErrAuth},
+		{-2010, "Account has insufficient balance for requested action.", base.ErrInsufficientFunds},
+		{-2019, "Margin is insufficient.", base.ErrInsufficientFunds},
+		{-2013, "Order does not exist.", base.ErrOrderNotFound},
+		{-1121, "Invalid symbol.", base.ErrInvalidSymbol},
+	}
+	for _, c := range cases {
+		err := apiError(fmt.Errorf("wrapped: %w", &common.APIError{Code: c.code, Message: c.msg}))
+		if !errors.Is(err, c.want) || !errors.Is(err, base.ErrResponse) {
+			t.Errorf("apiError(%d) = %v, want %v", c.code, err, c.want)
+		}
+	}
+
+	// 其它 -2010 拒单不归类为余额不足
+	err := apiError(&common.APIError{Code: -2010, Message: "Order would immediately match and take."})
+	if errors.Is(err, base.ErrInsufficientFunds) || !errors.Is(err, base.ErrResponse) {
+		t.Errorf("apiError(-2010) = %v", err)
+	}
+	// 非 API 错误原样返回
+	plain := errors.New("dial tcp: timeout")
+	if apiError(plain) != plain {
+		t.Error("non API errors should be returned unchanged")
+	}
+}
+
+func TestAPIErrorGatewayHTML(t *testing.T) {
+	c, srv := newFakeClient(t)
+	srv.Handle(http.MethodGet, "/api/v3/account", http.StatusBadGateway, "<html><body><h1>502 Bad Gateway</h1></body></html>")
+	srv.Handle(http.MethodPost, "/api/v3/order/cancelReplace", http.StatusBadGateway, `{"code":-1000,"msg":"An unknown error occurred while processing the request."}`)
+
+	// go-binance 解析不了响应体，没有错误码也没有 HTTP 状态
+	_, err := c.GetAccount(context.Background())
+	if !errors.Is(err, base.ErrUnknownStatus) || !retry.IsAmbiguous(err) {
+		t.Fatalf("html err = %v", err)
+	}
+	// 自己发送的签名请求记录 HTTP 状态
+	err = c.signedRequest(context.Background(), spotMarket, http.MethodPost, "/api/v3/order/cancelReplace", url.Values{}, nil)
+	var exErr *base.ExchangeError
+	if !errors.As(err, &exErr) || exErr.HTTPStatus != http.StatusBadGateway || !errors.Is(err, base.ErrUnknownStatus) {
+		t.Fatalf("-1000 err = %v", err)
+	}
+}
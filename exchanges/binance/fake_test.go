@@ -0,0 +1,156 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/exchangetest"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var fakeCreds = exchangetest.Credentials{APIKey: "key", SecretKey: "secret"}
+
+func newFakeClient(t *testing.T) (*Client, *exchangetest.Server) {
+	t.Helper()
+	srv := exchangetest.NewBinance(fakeCreds)
+	t.Cleanup(srv.Close)
+	c := &Client{}
+	if err := c.New(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.NewFuture(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	return c, srv
+}
+
+func TestFakeSpot(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	bal, err := c.GetAccountBalance("USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(bal, ",") != "1000.00000000,10.50000000,1010.5" {
+		t.Fatalf("balance = %v", bal)
+	}
+	reqs := srv.Requests(http.MethodGet, "/api/v3/account")
+	if len(reqs) != 1 || reqs[0].Header.Get("X-MBX-APIKEY") != "key" || reqs[0].Query.Get("signature") == "" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	o, err := c.GetOrder("BTC/USDT", "28")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Symbol != "BTC/USDT" || o.Status != base.PARTIALLY || o.Side != base.BID || o.Filled.String() != "0.01" {
+		t.Fatalf("order = %+v", o)
+	}
+	q := srv.Requests(http.MethodGet, "/api/v3/order")[0].Query
+	if q.Get("symbol") != "BTCUSDT" || q.Get("orderId") != "28" {
+		t.Fatalf("query = %v", q)
+	}
+
+	depth, err := c.Depth("BTCUSDT", "5")
+	if err != nil || len(depth.Bids) != 2 || depth.Asks[0].Price.String() != "37000.2" {
+		t.Fatalf("depth = %+v err = %v", depth, err)
+	}
+}
+
+func TestFakeFutures(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	bal, err := c.GetFutureBalance()
+	if err != nil || bal.TotalBalance.String() != "10000.5" || bal.AvailableBalance.String() != "9500.25" {
+		t.Fatalf("balance = %+v err = %v", bal, err)
+	}
+	dual, err := c.CheckDual()
+	if err != nil || !dual {
+		t.Fatalf("dual = %v err = %v", dual, err)
+	}
+	pos, err := c.GetPositionRisk("ETHUSDT")
+	if err != nil || len(pos) != 2 {
+		t.Fatalf("positions = %+v err = %v", pos, err)
+	}
+	if pos[0].PositionSide != base.LONG || pos[0].PositionAmt.String() != "1" || pos[0].MarginType != base.ISOLATED {
+		t.Fatalf("long = %+v", pos[0])
+	}
+	fr, err := c.GetMarkPriceAndFundingRate("ETHUSDT")
+	if err != nil || fr.MarkPrice.String() != "2100.12" || fr.LastFundingRate.String() != "0.0001" {
+		t.Fatalf("funding = %+v err = %v", fr, err)
+	}
+}
+
+func TestFakeRejectsBadKey(t *testing.T) {
+	srv := exchangetest.NewBinance(fakeCreds)
+	defer srv.Close()
+	c := &Client{}
+	if err := c.New([]byte(`{"url":"` + srv.URL + `","apiKey":"other","secretKey":"secret"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAccountBalance("USDT"); !errors.Is(err, base.ErrAuth) {
+		t.Fatalf("err = %v", err)
+	}
+	c.Client.APIKey = "key"
+	c.Client.SecretKey = "wrong"
+	if _, err := c.GetAccountBalance("USDT"); !errors.Is(err, base.ErrAuth) {
+		t.Fatalf("bad signature err = %v", err)
+	}
+}
+
+func TestFakeWithdrawIcebergAndFees(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	id, err := c.Withdraw("USDT", "TRX", "TXYZ", "100")
+	if err != nil || id != "7213fea8e94b4a5593d507237e5a555b" {
+		t.Fatalf("withdraw = %s err = %v", id, err)
+	}
+	wd := srv.Requests(http.MethodPost, "/sapi/v1/capital/withdraw/apply")
+	if len(wd) != 1 {
+		t.Fatalf("requests = %+v", wd)
+	}
+	params := form(t, wd[0])
+	if params.Get("coin") != "USDT" || params.Get("network") != "TRX" || params.Get("address") != "TXYZ" || params.Get("amount") != "100" {
+		t.Fatalf("withdraw params = %v", params)
+	}
+
+	if _, err := c.IceBergOrder("BTCUSDT", base.ASK, base.MAKER, "36000", "0.02", "0.005"); err != nil {
+		t.Fatal(err)
+	}
+	params = form(t, srv.Requests(http.MethodPost, "/api/v3/order")[0])
+	if params.Get("icebergQty") != "0.005" || params.Get("type") != "LIMIT_MAKER" || params.Get("side") != "SELL" {
+		t.Fatalf("order params = %v", params)
+	}
+
+	fee, asset, err := c.GetFeeFromFilled("BTCUSDT", "29")
+	if err != nil || fee != "0.00002" || asset != "BTC" {
+		t.Fatalf("fee = %s %s err = %v", fee, asset, err)
+	}
+
+	tickers, err := c.GetAllTickers()
+	if err != nil || len(tickers) != 2 {
+		t.Fatalf("tickers = %v err = %v", tickers, err)
+	}
+	if tickers[1].Symbol != "ETHUSDT" || tickers[1].ChangeRate != "-0.00525" || tickers[1].LastPrice != "1990.00000000" {
+		t.Fatalf("ticker = %+v", tickers[1])
+	}
+
+	if _, err := c.LimitHiddenOrders("BTCUSDT", nil); !errors.Is(err, base.ErrNotSupported) || c.Has(base.CapHiddenOrder) {
+		t.Fatalf("hidden orders err = %v", err)
+	}
+}
+
+// form 合并请求的 query 和表单 body
+func form(t *testing.T, r exchangetest.Request) url.Values {
+	t.Helper()
+	v, err := url.ParseQuery(string(r.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, vs := range r.Query {
+		v[k] = append(v[k], vs...)
+	}
+	return v
+}
@@ -0,0 +1,160 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// 成交接口单次查询的时间跨度上限（现货 myTrades 24 小时，U本位合约 userTrades 7 天）和每页条数
+const (
+	spotFillWindow   = 24 * time.Hour
+	futureFillWindow = 7 * 24 * time.Hour
+	fillsPage        = 1000
+)
+
+// GetFills 自己在 symbol 上 [since, until] 内的成交，按时间升序；since 为零时取 until 前 24 小时，until 为零时取当前时间。
+// 市场按 symbol 选择（见 symbolMarket），只调用了 NewFuture 时旧格式 symbol 查询 U本位合约。
+// 按接口允许的最大跨度分段查询，某段超过一页时按 fromId 继续翻页
+func (c *Client) GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error) {
+	since, until, err := models.FillRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	market := symbolMarket(symbol)
+	if market == spotMarket && c.Client == nil && !instrument.IsUnified(symbol) {
+		market = futuresMarket
+	}
+	return c.fills(ctx, market, symbol, since, until)
+}
+
+func (c *Client) fills(ctx context.Context, market, symbol string, since, until time.Time) ([]models.Fill, error) {
+	if (market == spotMarket && c.Client == nil) || (market == futuresMarket && c.FutureClient == nil) {
+		return nil, fmt.Errorf("%w: %s client not initialized", base.ErrNotSupported, market)
+	}
+	fetch, window := c.spotFills, spotFillWindow.Milliseconds()
+	if market == futuresMarket {
+		fetch, window = c.futureFills, futureFillWindow.Milliseconds()
+	}
+	var fills []models.Fill
+	last := until.UnixMilli()
+	for start := since.UnixMilli(); start <= last; start += window {
+		end := start + window - 1
+		if end > last {
+			end = last
+		}
+		batch, err := fetch(ctx, symbol, start, end, 0)
+		if err != nil {
+			return nil, err
+		}
+		for len(batch) == fillsPage {
+			fills = append(fills, batch...)
+			id, _ := strconv.ParseInt(batch[len(batch)-1].TradeID, 10, 64)
+			if batch, err = fetch(ctx, symbol, 0, 0, id+1); err != nil {
+				return nil, err
+			}
+			// fromId 不能和时间一起使用，超出本段的成交留给下一段
+			n := 0
+			for n < len(batch) && batch[n].Time <= end {
+				n++
+			}
+			batch = batch[:n]
+		}
+		fills = append(fills, batch...)
+	}
+	return fills, nil
+}
+
+// spotFills 查询一页现货成交，fromID 不为 0 时按成交 id 查询，否则按时间
+func (c *Client) spotFills(ctx context.Context, symbol string, start, end, fromID int64) ([]models.Fill, error) {
+	svc := c.Client.NewListTradesService().Symbol(c.spotID(symbol)).Limit(fillsPage)
+	if fromID > 0 {
+		svc.FromID(fromID)
+	} else {
+		svc.StartTime(start).EndTime(end)
+	}
+	trades, err := svc.Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	fills := make([]models.Fill, 0, len(trades))
+	for _, t := range trades {
+		side := base.ASK
+		if t.IsBuyer {
+			side = base.BID
+		}
+		fills = append(fills, models.Fill{
+			Symbol:   echoSymbol(symbol, t.Symbol),
+			OrderID:  strconv.FormatInt(t.OrderID, 10),
+			TradeID:  strconv.FormatInt(t.ID, 10),
+			Side:     side,
+			Price:    models.ParseDecimalOrZero(t.Price),
+			Quantity: models.ParseDecimalOrZero(t.Quantity),
+			Fee:      models.ParseDecimalOrZero(t.Commission),
+			FeeAsset: t.CommissionAsset,
+			Maker:    t.IsMaker,
+			Time:     t.Time,
+		})
+	}
+	return fills, nil
+}
+
+// futureFills 查询一页 U本位合约成交，参数同 spotFills
+func (c *Client) futureFills(ctx context.Context, symbol string, start, end, fromID int64) ([]models.Fill, error) {
+	svc := c.FutureClient.NewListAccountTradeService().Symbol(c.futureID(symbol)).Limit(fillsPage)
+	if fromID > 0 {
+		svc.FromID(fromID)
+	} else {
+		svc.StartTime(start).EndTime(end)
+	}
+	trades, err := svc.Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	fills := make([]models.Fill, 0, len(trades))
+	for _, t := range trades {
+		side := base.ASK
+		if t.Side == futures.SideTypeBuy {
+			side = base.BID
+		}
+		fills = append(fills, models.Fill{
+			Symbol:   echoSymbol(symbol, t.Symbol),
+			OrderID:  strconv.FormatInt(t.OrderID, 10),
+			TradeID:  strconv.FormatInt(t.ID, 10),
+			Side:     side,
+			Price:    models.ParseDecimalOrZero(t.Price),
+			Quantity: models.ParseDecimalOrZero(t.Quantity),
+			Fee:      models.ParseDecimalOrZero(t.Commission),
+			FeeAsset: t.CommissionAsset,
+			Maker:    t.Maker,
+			Time:     t.Time,
+		})
+	}
+	return fills, nil
+}
+
+// realFees 尽量用最近 24 小时的成交填充 fee 的实际费率。查询失败或无法拆分出基础币和计价币时不填，
+// 不影响已经取到的接口费率
+func (c *Client) realFees(fee *models.TradingFee, market, symbol string) {
+	var assets []string
+	if sym, err := instrument.Parse(symbol); err == nil {
+		assets = []string{sym.Base, sym.Quote}
+	} else {
+		assets = instrument.SplitNative(symbol)
+	}
+	if len(assets) != 2 {
+		return
+	}
+	until := time.Now()
+	fills, err := c.fills(context.Background(), market, symbol, until.Add(-models.DefaultFillWindow), until)
+	if err != nil {
+		return
+	}
+	fee.SetRealFees(fills, assets[0], assets[1], models.NewDecimalFromInt(1))
+}
@@ -0,0 +1,115 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/exchangetest"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetFillsPaginates(t *testing.T) {
+	c, srv := newFakeClient(t)
+	since := time.UnixMilli(1700000000000)
+	until := since.Add(30 * time.Hour)
+	second := since.Add(spotFillWindow).UnixMilli()
+
+	trade := func(id, ts int64) string {
+		return fmt.Sprintf(`{"symbol":"BTCUSDT","id":%d,"orderId":7,"orderListId":-1,"price":"36000","qty":"0.001","quoteQty":"36",`+
+			`"commission":"0.036","commissionAsset":"USDT","time":%d,"isBuyer":false,"isMaker":true,"isBestMatch":true}`, id, ts)
+	}
+	// 第一段满 1000 条，按 fromId 继续时返回一条本段内和一条下一段的成交，下一段再按时间查到后者
+	srv.HandleFunc(http.MethodGet, "/api/v3/myTrades", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var data []string
+		switch {
+		case q.Get("fromId") == "1001":
+			data = []string{trade(1001, since.UnixMilli()+1001), trade(1002, second)}
+		case q.Get("startTime") == strconv.FormatInt(since.UnixMilli(), 10):
+			for id := int64(1); id <= fillsPage; id++ {
+				data = append(data, trade(id, since.UnixMilli()+id))
+			}
+		case q.Get("startTime") == strconv.FormatInt(second, 10):
+			data = []string{trade(1002, second)}
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(data, ",") + "]"))
+	})
+
+	fills, err := c.GetFills(context.Background(), "BTC/USDT", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1002 {
+		t.Fatalf("fills = %d", len(fills))
+	}
+	for i, f := range fills {
+		if f.TradeID != strconv.Itoa(i+1) {
+			t.Fatalf("fill %d = %+v", i, f)
+		}
+	}
+	f := fills[0]
+	if f.Symbol != "BTC/USDT" || f.Side != base.ASK || !f.Maker || f.OrderID != "7" || f.Fee.String() != "0.036" || f.FeeAsset != "USDT" {
+		t.Fatalf("fill = %+v", f)
+	}
+
+	reqs := srv.Requests(http.MethodGet, "/api/v3/myTrades")
+	if len(reqs) != 3 {
+		t.Fatalf("requests = %d", len(reqs))
+	}
+	if q := reqs[0].Query; q.Get("symbol") != "BTCUSDT" || q.Get("limit") != "1000" || q.Get("endTime") != strconv.FormatInt(second-1, 10) {
+		t.Fatalf("first query = %v", q)
+	}
+	if q := reqs[1].Query; q.Has("startTime") || q.Get("fromId") != "1001" {
+		t.Fatalf("fromId query = %v", q)
+	}
+	if q := reqs[2].Query; q.Get("endTime") != strconv.FormatInt(until.UnixMilli(), 10) {
+		t.Fatalf("last query = %v", q)
+	}
+}
+
+func TestFutureTradingFeeFromFills(t *testing.T) {
+	c, srv := newFakeClient(t)
+	fee, err := c.GetFutureTradingFee("ETH/USDT:USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// taker 0.84 / 2100，maker 0.2 / 1000
+	if fee.TakerFeeFromRealOrder.String() != "0.0004" || fee.MakerFeeFromRealOrder.String() != "0.0002" || fee.IfDiscount {
+		t.Fatalf("fee = %+v", fee)
+	}
+	if q := srv.Requests(http.MethodGet, "/fapi/v1/userTrades")[0].Query; q.Get("symbol") != "ETHUSDT" || q.Get("startTime") == "" {
+		t.Fatalf("userTrades query = %v", q)
+	}
+	if len(srv.Requests(http.MethodGet, "/api/v3/myTrades")) != 0 {
+		t.Fatal("futures fee queried spot trades")
+	}
+}
+
+func TestFillsFuturesOnlyClient(t *testing.T) {
+	srv := exchangetest.NewBinance(fakeCreds)
+	t.Cleanup(srv.Close)
+	c := &Client{}
+	if err := c.NewFuture(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	// 没有现货客户端时旧格式 symbol 查询 U本位合约
+	fills, err := c.GetFills(context.Background(), "ETHUSDT", time.Time{}, time.Time{})
+	if err != nil || len(fills) == 0 {
+		t.Fatalf("fills = %+v err = %v", fills, err)
+	}
+	if _, err := c.GetFills(context.Background(), "BTC/USDT", time.Time{}, time.Time{}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("spot fills err = %v", err)
+	}
+
+	// 成交查询失败时仍返回接口费率
+	srv.Handle(http.MethodGet, "/fapi/v1/userTrades", http.StatusInternalServerError, `{"code":-1000,"msg":"unknown error"}`)
+	fee, err := c.GetFutureTradingFee("ETH/USDT:USDT")
+	if err != nil || fee.TakerFeeFromApi.IsZero() || !fee.TakerFeeFromRealOrder.IsZero() {
+		t.Fatalf("fee = %+v err = %v", fee, err)
+	}
+}
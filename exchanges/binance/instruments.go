@@ -0,0 +1,169 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// spotID 现货 symbol。统一符号（BTC/USDT）经 Instruments 解析，旧格式 BTCUSDT 原样使用
+func (c *Client) spotID(symbol string) string {
+	return c.nativeID(symbol)
+}
+
+// futureID U本位合约 symbol。统一符号（BTC/USDT:USDT、BTC/USDT:USDT-240927）经 Instruments 解析
+func (c *Client) futureID(symbol string) string {
+	return c.nativeID(symbol)
+}
+
+// futureKind U本位合约 symbol 的类型，与 futureInstruments 一致：带到期日后缀的（BTCUSDT_240927）是交割合约
+func futureKind(id string) instrument.Kind {
+	if strings.Contains(id, "_") {
+		return instrument.Futures
+	}
+	return instrument.Swap
+}
+
+func (c *Client) nativeID(symbol string) string {
+	if !instrument.IsUnified(symbol) {
+		return symbol
+	}
+	id, err := c.Instruments.Native(base.BINANCE, symbol)
+	if err != nil {
+		// 无法解析时原样发送，由交易所返回错误
+		return symbol
+	}
+	return id
+}
+
+// echoSymbol 调用方使用统一符号时，返回结果里的 Symbol 也使用统一符号
+func echoSymbol(symbol, native string) string {
+	if instrument.IsUnified(symbol) {
+		return symbol
+	}
+	return native
+}
+
+// quantizeOrder 按合约规格校验并取整下单价格和数量。第一次下单前加载 Instruments，
+// 加载失败时原样返回，交给交易所校验
+func (c *Client) quantizeOrder(kind instrument.Kind, id, typ, price, size string) (string, string, error) {
+	_ = c.loader.Ensure(c.LoadInstruments)
+	return c.Instruments.QuantizeOrder(base.BINANCE, kind, id, typ, price, size)
+}
+
+// LoadInstruments 从现货和 U本位合约的 exchangeInfo 加载合约到 c.Instruments。
+// 币本位合约（dapi）和期权（eapi）不加载：Client 没有这两个市场的客户端，也不在上面下单，
+// 它们的统一符号只按命名规则（instrument.FormatNative / ParseNative）转换，没有 tick/lot 规格可供 QuantizeOrder 校验
+func (c *Client) LoadInstruments() error {
+	if c.Instruments == nil {
+		c.Instruments = instrument.NewRegistry()
+	}
+	if c.Client != nil {
+		info, err := c.Client.NewExchangeInfoService().Do(context.Background())
+		if err != nil {
+			return err
+		}
+		if err := c.Instruments.Add(spotInstruments(info.Symbols)...); err != nil {
+			return err
+		}
+	}
+	if c.FutureClient != nil {
+		info, err := c.FutureClient.NewExchangeInfoService().Do(context.Background())
+		if err != nil {
+			return err
+		}
+		if err := c.Instruments.Add(futureInstruments(info.Symbols)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func spotInstruments(symbols []binance.Symbol) []instrument.Instrument {
+	insts := make([]instrument.Instrument, 0, len(symbols))
+	for _, s := range symbols {
+		sym := instrument.Symbol{Base: s.BaseAsset, Quote: s.QuoteAsset, Kind: instrument.Spot}
+		inst := instrument.Instrument{
+			Symbol:   sym.String(),
+			Exchange: base.BINANCE,
+			Kind:     instrument.Spot,
+			NativeID: s.Symbol,
+			Live:     s.Status == "TRADING",
+		}
+		if f := s.PriceFilter(); f != nil {
+			inst.TickSize = models.ParseDecimalOrZero(f.TickSize)
+		}
+		if f := s.LotSizeFilter(); f != nil {
+			inst.LotSize = models.ParseDecimalOrZero(f.StepSize)
+			inst.MinSize = models.ParseDecimalOrZero(f.MinQuantity)
+			inst.MaxLimitSize = models.ParseDecimalOrZero(f.MaxQuantity)
+		}
+		if f := s.MarketLotSizeFilter(); f != nil {
+			inst.MaxMarketSize = models.ParseDecimalOrZero(f.MaxQuantity)
+		}
+		inst.MinNotional = spotMinNotional(s)
+		insts = append(insts, inst)
+	}
+	return insts
+}
+
+// spotMinNotional 新版 exchangeInfo 使用 NOTIONAL，旧版使用 MIN_NOTIONAL
+func spotMinNotional(s binance.Symbol) models.Decimal {
+	if f := s.NotionalFilter(); f != nil && f.MinNotional != "" {
+		return models.ParseDecimalOrZero(f.MinNotional)
+	}
+	for _, filter := range s.Filters {
+		if filter["filterType"] == "MIN_NOTIONAL" {
+			if v, ok := filter["minNotional"].(string); ok {
+				return models.ParseDecimalOrZero(v)
+			}
+		}
+	}
+	return models.Decimal{}
+}
+
+func futureInstruments(symbols []futures.Symbol) []instrument.Instrument {
+	insts := make([]instrument.Instrument, 0, len(symbols))
+	for _, s := range symbols {
+		sym := instrument.Symbol{Base: s.BaseAsset, Quote: s.QuoteAsset, Settle: s.MarginAsset}
+		switch {
+		case s.ContractType == futures.ContractTypePerpetual:
+			sym.Kind = instrument.Swap
+		case strings.Contains(s.Symbol, "_"):
+			// 交割合约 BTCUSDT_240927
+			sym.Kind = instrument.Futures
+			sym.Expiry = s.Symbol[strings.LastIndex(s.Symbol, "_")+1:]
+		default:
+			continue
+		}
+		inst := instrument.Instrument{
+			Symbol:        sym.String(),
+			Exchange:      base.BINANCE,
+			Kind:          sym.Kind,
+			NativeID:      s.Symbol,
+			ContractValue: models.NewDecimalFromInt(1),
+			Live:          s.Status == "TRADING",
+		}
+		if f := s.PriceFilter(); f != nil {
+			inst.TickSize = models.ParseDecimalOrZero(f.TickSize)
+		}
+		if f := s.LotSizeFilter(); f != nil {
+			inst.LotSize = models.ParseDecimalOrZero(f.StepSize)
+			inst.MinSize = models.ParseDecimalOrZero(f.MinQuantity)
+			inst.MaxLimitSize = models.ParseDecimalOrZero(f.MaxQuantity)
+		}
+		if f := s.MarketLotSizeFilter(); f != nil {
+			inst.MaxMarketSize = models.ParseDecimalOrZero(f.MaxQuantity)
+		}
+		if f := s.MinNotionalFilter(); f != nil {
+			inst.MinNotional = models.ParseDecimalOrZero(f.Notional)
+		}
+		insts = append(insts, inst)
+	}
+	return insts
+}
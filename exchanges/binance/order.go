@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/retry"
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// createOrder 现货下单，clientID 为空时生成 newClientOrderId，按 c.Retry 重试。
+// 结果不确定时按 origClientOrderId 轮询对账，不会重新提交，期限内查不到返回 base.ErrUnknownStatus
+func (c *Client) createOrder(symbol, clientID string, svc *binance.CreateOrderService) (*binance.CreateOrderResponse, error) {
+	if clientID == "" {
+		clientID = retry.ClientOrderID()
+	}
+	svc.NewClientOrderID(clientID)
+	var resp *binance.CreateOrderResponse
+	place := func() (string, error) {
+		r, err := svc.Do(context.Background())
+		if err != nil {
+			return "", apiError(err)
+		}
+		resp = r
+		return strconv.FormatInt(r.OrderID, 10), nil
+	}
+	lookup := func() (string, bool, error) {
+		order, err := c.Client.NewGetOrderService().Symbol(c.spotID(symbol)).OrigClientOrderID(clientID).Do(context.Background())
+		if err != nil {
+			return lookupResult(apiError(err))
+		}
+		resp = &binance.CreateOrderResponse{Symbol: order.Symbol, OrderID: order.OrderID, ClientOrderID: order.ClientOrderID}
+		return strconv.FormatInt(order.OrderID, 10), true, nil
+	}
+	if _, err := retry.Order(context.Background(), c.Retry, place, lookup); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// createFutureOrder U本位合约下单，重试和对账规则与 createOrder 相同
+func (c *Client) createFutureOrder(symbol, clientID string, svc *futures.CreateOrderService) (*futures.CreateOrderResponse, error) {
+	if clientID == "" {
+		clientID = retry.ClientOrderID()
+	}
+	svc.NewClientOrderID(clientID)
+	var resp *futures.CreateOrderResponse
+	place := func() (string, error) {
+		r, err := svc.Do(context.Background())
+		if err != nil {
+			return "", apiError(err)
+		}
+		resp = r
+		return strconv.FormatInt(r.OrderID, 10), nil
+	}
+	lookup := func() (string, bool, error) {
+		order, err := c.FutureClient.NewGetOrderService().Symbol(c.futureID(symbol)).OrigClientOrderID(clientID).Do(context.Background())
+		if err != nil {
+			return lookupResult(apiError(err))
+		}
+		resp = &futures.CreateOrderResponse{Symbol: order.Symbol, OrderID: order.OrderID, ClientOrderID: order.ClientOrderID}
+		return strconv.FormatInt(order.OrderID, 10), true, nil
+	}
+	if _, err := retry.Order(context.Background(), c.Retry, place, lookup); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// lookupResult 查询失败时区分订单不存在和查询出错
+func lookupResult(err error) (string, bool, error) {
+	if errors.Is(err, base.ErrOrderNotFound) {
+		return "", false, nil
+	}
+	return "", false, err
+}
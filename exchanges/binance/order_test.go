@@ -0,0 +1,94 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/retry"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCreateOrderReconciles(t *testing.T) {
+	c, srv := newFakeClient(t)
+	c.Retry = retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, Reconcile: 50 * time.Millisecond}
+	srv.Handle(http.MethodPost, "/api/v3/order", http.StatusServiceUnavailable,
+		`{"code":-1007,"msg":"Timeout waiting for response from backend server. Send status unknown; execution status unknown."}`)
+	order, err := os.ReadFile("../exchangetest/fixtures/binance/get_api_v3_order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 已经接受的订单第 3 次查询才查得到
+	lookups := 0
+	srv.HandleFunc(http.MethodGet, "/api/v3/order", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		if lookups < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"code":-2013,"msg":"Order does not exist."}`)
+			return
+		}
+		w.Write(order)
+	})
+	id, err := c.LimitOrder("BTCUSDT", base.BID, "36000", "0.01")
+	if err != nil || id != "28" {
+		t.Fatalf("id = %s err = %v", id, err)
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v3/order")
+	if len(posts) != 1 || srv.Requests(http.MethodGet, "/api/v3/order")[0].Query.Get("origClientOrderId") != form(t, posts[0]).Get("newClientOrderId") {
+		t.Fatalf("posts = %+v", posts)
+	}
+
+	// 一直查不到时返回状态未知，不重新提交
+	lookups = -100
+	if _, err := c.LimitOrder("BTCUSDT", base.BID, "36000", "0.01"); !errors.Is(err, base.ErrUnknownStatus) {
+		t.Fatalf("err = %v", err)
+	}
+	if posts := srv.Requests(http.MethodPost, "/api/v3/order"); len(posts) != 2 {
+		t.Fatalf("posts = %d", len(posts))
+	}
+}
+
+func TestPlaceOrderWithClientID(t *testing.T) {
+	c, srv := newFakeClient(t)
+	if _, err := c.PlaceOrderWithClientID("BTCUSDT", base.BID, base.MAKER, "100", "1", "my-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewFutureOrderWithClientID("BTCUSDT", base.ASK, "", base.MARKET, "1", "", "", base.CROSSED, false, false, "my-2"); err != nil {
+		t.Fatal(err)
+	}
+	spot := form(t, srv.Requests(http.MethodPost, "/api/v3/order")[0])
+	if spot.Get("newClientOrderId") != "my-1" || spot.Get("type") != "LIMIT_MAKER" || spot.Get("price") != "100" {
+		t.Fatalf("spot order = %v", spot)
+	}
+	future := form(t, srv.Requests(http.MethodPost, "/fapi/v1/order")[0])
+	if future.Get("newClientOrderId") != "my-2" || future.Get("type") != "MARKET" {
+		t.Fatalf("future order = %v", future)
+	}
+}
+
+func TestFutureOrderOnDeliveryContract(t *testing.T) {
+	c, srv := newFakeClient(t)
+	srv.Handle(http.MethodGet, "/api/v3/exchangeInfo", http.StatusOK, `{"symbols":[]}`)
+	srv.Handle(http.MethodGet, "/fapi/v1/exchangeInfo", http.StatusOK, `{"symbols":[{"symbol":"BTCUSDT","contractType":"PERPETUAL","status":"TRADING","baseAsset":"BTC","quoteAsset":"USDT","marginAsset":"USDT"},
+		{"symbol":"BTCUSDT_240927","contractType":"CURRENT_QUARTER","status":"TRADING",
+		"baseAsset":"BTC","quoteAsset":"USDT","marginAsset":"USDT","filters":[{"filterType":"PRICE_FILTER","tickSize":"0.1"},
+		{"filterType":"LOT_SIZE","stepSize":"0.001","minQty":"0.001","maxQty":"1000"}]}]}`)
+
+	// 交割合约按 Futures 查找规格，统一符号和原生 id 都能下单
+	for _, symbol := range []string{"BTC/USDT:USDT-240927", "BTCUSDT_240927"} {
+		if _, err := c.NewFutureOrder(symbol, base.BID, base.LONG, base.LIMIT, "0.0015", "30000.07", "", base.CROSSED, false, false); err != nil {
+			t.Fatalf("%s: %v", symbol, err)
+		}
+	}
+	posts := srv.Requests(http.MethodPost, "/fapi/v1/order")
+	if len(posts) != 2 {
+		t.Fatalf("posts = %d", len(posts))
+	}
+	for _, p := range posts {
+		if f := form(t, p); f.Get("symbol") != "BTCUSDT_240927" || f.Get("price") != "30000.1" || f.Get("quantity") != "0.001" {
+			t.Fatalf("order = %v", f)
+		}
+	}
+}
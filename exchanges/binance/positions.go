@@ -0,0 +1,115 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// GetPositions U本位合约（永续和交割）的全部非零持仓，需要先调用 NewFuture。
+// 由 /fapi/v2/positionRisk、/fapi/v2/account 的保证金和 /fapi/v1/adlQuantile 组成；
+// Binance 不提供单个持仓的已实现盈亏和开仓时间，RealizedPnl 和 OpenTime 为 0。
+// 期权和杠杆持仓不支持，只过滤这两种类型时返回 base.ErrNotSupported
+func (c *Client) GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error) {
+	if !filter.HasKind(models.PositionSwap) && !filter.HasKind(models.PositionFutures) {
+		return nil, fmt.Errorf("%w: binance positions of %v", base.ErrNotSupported, filter.Kinds)
+	}
+	if c.FutureClient == nil {
+		return nil, fmt.Errorf("%w: futures client not initialized", base.ErrNotSupported)
+	}
+	risks, err := c.FutureClient.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	acct, err := c.FutureClient.NewGetAccountService().Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var adl []struct {
+		Symbol      string         `json:"symbol"`
+		AdlQuantile map[string]int `json:"adlQuantile"`
+	}
+	if err := c.signedRequest(ctx, futuresMarket, http.MethodGet, "/fapi/v1/adlQuantile", url.Values{}, &adl); err != nil {
+		return nil, err
+	}
+	ranks := make(map[string]map[string]int, len(adl))
+	for _, a := range adl {
+		ranks[a.Symbol] = a.AdlQuantile
+	}
+	type key struct{ symbol, side string }
+	margins := make(map[key]*futures.AccountPosition, len(acct.Positions))
+	for _, p := range acct.Positions {
+		margins[key{p.Symbol, string(p.PositionSide)}] = p
+	}
+
+	var positions []models.Position
+	for _, r := range risks {
+		amt := models.ParseDecimalOrZero(r.PositionAmt)
+		if amt.IsZero() {
+			continue
+		}
+		kind := models.PositionSwap
+		if strings.Contains(r.Symbol, "_") {
+			kind = models.PositionFutures
+		}
+		symbol, err := c.Instruments.Unified(base.BINANCE, instrument.Kind(kind), r.Symbol)
+		if err != nil {
+			symbol = r.Symbol
+		}
+		m, ok := margins[key{r.Symbol, r.PositionSide}]
+		if !ok {
+			m = &futures.AccountPosition{}
+		}
+		pos := models.Position{
+			Symbol:           symbol,
+			InstrumentID:     r.Symbol,
+			Kind:             kind,
+			Side:             base.LONG,
+			MarginType:       base.CROSSED,
+			Quantity:         amt.Abs(),
+			Available:        amt.Abs(),
+			EntryPrice:       models.ParseDecimalOrZero(r.EntryPrice),
+			MarkPrice:        models.ParseDecimalOrZero(r.MarkPrice),
+			LiquidationPrice: models.ParseDecimalOrZero(r.LiquidationPrice),
+			Leverage:         models.ParseDecimalOrZero(r.Leverage),
+			Notional:         models.ParseDecimalOrZero(r.Notional).Abs(),
+			Margin:           models.ParseDecimalOrZero(m.InitialMargin),
+			InitialMargin:    models.ParseDecimalOrZero(m.InitialMargin),
+			MaintMargin:      models.ParseDecimalOrZero(m.MaintMargin),
+			UnrealizedPnl:    models.ParseDecimalOrZero(r.UnRealizedProfit),
+			UpdateTime:       m.UpdateTime,
+		}
+		if sym, err := instrument.Parse(symbol); err == nil {
+			pos.MarginAsset = sym.Settle
+		}
+		if amt.Sign() < 0 {
+			pos.Side = base.SHORT
+		}
+		switch r.PositionSide {
+		case "LONG":
+			pos.PositionSide = base.LONG
+		case "SHORT":
+			pos.PositionSide = base.SHORT
+		}
+		if strings.EqualFold(r.MarginType, "isolated") {
+			pos.MarginType = base.ISOLATED
+			pos.Margin = models.ParseDecimalOrZero(r.IsolatedWallet)
+		}
+		// adlQuantile 为 0-4，双向持仓时按 LONG / SHORT 区分
+		if q, ok := ranks[r.Symbol][r.PositionSide]; ok {
+			pos.ADLRank = q + 1
+		}
+		pos.MarginRatio = pos.IsolatedMarginRatio()
+		if filter.Match(pos) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions, nil
+}
@@ -0,0 +1,36 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetPositions(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ctx := context.Background()
+	pos, err := c.GetPositions(ctx, models.PositionFilter{})
+	if err != nil || len(pos) != 1 {
+		t.Fatalf("positions = %+v err = %v", pos, err)
+	}
+	p := pos[0]
+	if p.Symbol != "ETH/USDT:USDT" || p.InstrumentID != "ETHUSDT" || p.Kind != models.PositionSwap || p.MarginAsset != "USDT" {
+		t.Fatalf("instrument = %+v", p)
+	}
+	if p.Side != base.LONG || p.PositionSide != base.LONG || p.MarginType != base.ISOLATED || p.Quantity.String() != "1" {
+		t.Fatalf("side = %+v", p)
+	}
+	// 逐仓保证金 200 + 未实现盈亏 100.12，维持保证金 10.5006
+	if p.Margin.String() != "200" || p.MaintMargin.String() != "10.5006" || p.MarginRatio.String() != "0.034988" || p.ADLRank != 2 || p.UpdateTime != 1700000000000 {
+		t.Fatalf("margin = %+v", p)
+	}
+
+	if pos, err := c.GetPositions(ctx, models.PositionFilter{Symbols: []string{"BTCUSDT"}}); err != nil || len(pos) != 0 {
+		t.Fatalf("filtered = %+v err = %v", pos, err)
+	}
+	if _, err := c.GetPositions(ctx, models.PositionFilter{Kinds: []string{models.PositionOption}}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("option err = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package binance
+
+import (
+	"AxonTrading/ratelimit"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Binance 限速规则名：<市场>:weight:<周期> 为请求权重，<市场>:orders:<周期> 为下单数量。
+// 周期与响应头 X-MBX-USED-WEIGHT-1M、X-MBX-ORDER-COUNT-10S 等的后缀一致。
+const (
+	spotMarket    = "spot"
+	futuresMarket = "futures"
+)
+
+// DefaultRateLimits Binance 现货和 U本位合约的默认限速
+// https://binance-docs.github.io/apidocs/spot/en/#limits
+var DefaultRateLimits = map[string]ratelimit.Rule{
+	"spot:weight:1m":  {Limit: 6000, Interval: time.Minute},
+	"spot:orders:10s": {Limit: 100, Interval: 10 * time.Second},
+	"spot:orders:1d":  {Limit: 200000, Interval: 24 * time.Hour},
+
+	"futures:weight:1m":  {Limit: 2400, Interval: time.Minute},
+	"futures:orders:10s": {Limit: 300, Interval: 10 * time.Second},
+	"futures:orders:1m":  {Limit: 1200, Interval: time.Minute},
+}
+
+// endpointWeights 接口权重，未列出的接口权重为 1。key 为 "METHOD path"
+var endpointWeights = map[string]int{
+	"GET /api/v3/order":                           4,
+	"GET /api/v3/openOrders":                      6,
+	"GET /api/v3/allOrders":                       20,
+	"GET /api/v3/myTrades":                        20,
+	"GET /api/v3/account":                         20,
+	"GET /api/v3/exchangeInfo":                    20,
+	"GET /api/v3/ticker/price":                    2,
+	"GET /api/v3/ticker/24hr":                     2,
+	"GET /fapi/v2/balance":                        5,
+	"GET /fapi/v2/account":                        5,
+	"GET /fapi/v2/positionRisk":                   5,
+	"GET /fapi/v1/adlQuantile":                    5,
+	"GET /fapi/v1/allOrders":                      5,
+	"GET /fapi/v1/userTrades":                     5,
+	"GET /fapi/v1/commissionRate":                 20,
+	"GET /fapi/v1/positionSide/dual":              30,
+	"POST /fapi/v1/order":                         0, // 合约下单只计订单数
+	"POST /fapi/v1/batchOrders":                   5,
+	"GET /sapi/v1/capital/config/getall":          10,
+	"GET /sapi/v1/capital/withdraw/history":       10,
+	"GET /sapi/v3/sub-account/assets":             60,
+	"POST /sapi/v1/sub-account/universalTransfer": 360,
+}
+
+// noSymbolWeights 不带 symbol 参数时的权重
+var noSymbolWeights = map[string]int{
+	"GET /api/v3/openOrders":    80,
+	"GET /api/v3/ticker/price":  4,
+	"GET /api/v3/ticker/24hr":   80,
+	"GET /fapi/v1/openOrders":   40,
+	"GET /fapi/v1/ticker/price": 2,
+	"GET /fapi/v1/premiumIndex": 10,
+}
+
+// orderEndpoints 计入下单数量的接口
+var orderEndpoints = map[string]bool{
+	"POST /api/v3/order":               true,
+	"POST /api/v3/order/oco":           true,
+	"POST /api/v3/order/cancelReplace": true,
+	"POST /fapi/v1/order":              true,
+	"POST /fapi/v1/batchOrders":        true,
+	"PUT /fapi/v1/order":               true,
+}
+
+// requestWeight 计算请求权重，深度接口的权重取决于 limit
+func requestWeight(r *http.Request) int {
+	key := r.Method + " " + r.URL.Path
+	q := r.URL.Query()
+	switch r.URL.Path {
+	case "/api/v3/depth":
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		switch {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		}
+		return 250
+	case "/fapi/v1/depth":
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		switch {
+		case limit <= 50:
+			return 2
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 10
+		}
+		return 20
+	}
+	if q.Get("symbol") == "" {
+		if w, ok := noSymbolWeights[key]; ok {
+			return w
+		}
+	}
+	if w, ok := endpointWeights[key]; ok {
+		return w
+	}
+	return 1
+}
+
+// limitTransport 请求前按权重和下单数量取令牌，响应后用 X-MBX-* 头校准本地计数。
+// 收到 429/418 时按 Retry-After 暂停该市场的全部请求。
+type limitTransport struct {
+	limiter *ratelimit.Limiter
+	market  string
+	next    http.RoundTripper
+}
+
+func (t *limitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	costs := []ratelimit.Cost{}
+	if w := requestWeight(r); w > 0 {
+		costs = append(costs, ratelimit.Cost{Rule: t.market + ":weight:1m", N: w})
+	}
+	if orderEndpoints[r.Method+" "+r.URL.Path] {
+		for name := range DefaultRateLimits {
+			if strings.HasPrefix(name, t.market+":orders:") {
+				costs = append(costs, ratelimit.Cost{Rule: name, N: 1})
+			}
+		}
+	}
+	if err := t.limiter.Wait(r.Context(), costs...); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+	now := t.limiter.Now()
+	for key, values := range resp.Header {
+		if len(values) == 0 {
+			continue
+		}
+		key = strings.ToLower(key)
+		var rule string
+		if suffix, ok := strings.CutPrefix(key, "x-mbx-used-weight-"); ok {
+			rule = t.market + ":weight:" + suffix
+		} else if suffix, ok := strings.CutPrefix(key, "x-mbx-order-count-"); ok {
+			rule = t.market + ":orders:" + suffix
+		} else {
+			continue
+		}
+		used, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		if b := t.limiter.Bucket(rule, ""); b != nil {
+			b.Sync(used, now)
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+		retry, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if retry <= 0 {
+			retry = 1
+		}
+		if b := t.limiter.Bucket(t.market+":weight:1m", ""); b != nil {
+			b.Pause(now.Add(time.Duration(retry) * time.Second))
+		}
+	}
+	return resp, nil
+}
+
+// limitedHTTPClient 返回带限速的 http.Client，limiter 为 nil 时不限速
+func limitedHTTPClient(limiter *ratelimit.Limiter, market string) *http.Client {
+	if limiter == nil {
+		return &http.Client{}
+	}
+	return &http.Client{Transport: &limitTransport{limiter: limiter, market: market, next: http.DefaultTransport}}
+}
+
+// initLimiter 按 params 中的 rateLimit 创建限速器，已创建时复用（现货和合约共用一个）
+func (c *Client) initLimiter(params []byte) error {
+	if c.Limiter != nil {
+		return nil
+	}
+	cfg, err := ratelimit.ParseConfig(params)
+	if err != nil {
+		return err
+	}
+	c.Limiter, err = cfg.Build(DefaultRateLimits)
+	return err
+}
@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/ratelimit"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestWeight(t *testing.T) {
+	cases := map[string]int{
+		"GET /api/v3/depth?symbol=BTCUSDT&limit=5":      5,
+		"GET /api/v3/depth?symbol=BTCUSDT&limit=1000":   50,
+		"GET /fapi/v1/depth?symbol=BTCUSDT&limit=500":   10,
+		"GET /api/v3/openOrders?symbol=BTCUSDT":         6,
+		"GET /api/v3/openOrders":                        80,
+		"POST /fapi/v1/order?symbol=BTCUSDT":            0,
+		"DELETE /api/v3/order?symbol=BTCUSDT&orderId=1": 1,
+	}
+	for in, want := range cases {
+		method, target, _ := strings.Cut(in, " ")
+		r := httptest.NewRequest(method, target, nil)
+		if got := requestWeight(r); got != want {
+			t.Errorf("requestWeight(%s) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestLimitTransport(t *testing.T) {
+	used := "5990"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", used)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	limiter := ratelimit.New(ratelimit.Fail, DefaultRateLimits)
+	client := limitedHTTPClient(limiter, spotMarket)
+
+	get := func(path string) error {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+path, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	if err := get("/api/v3/ticker/price?symbol=BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	// 服务端报告已用 5990，剩余 10，权重 20 的请求应在本地被拒绝
+	if err := get("/api/v3/account"); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if err := get("/api/v3/ticker/price?symbol=BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,60 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// signedRequest 发送 go-binance 没有封装的签名接口，参数和签名都放在 query 中。
+// 使用对应市场客户端的地址、密钥和限速；错误响应按 apiError 转换，成功时把响应体解码到 v
+func (c *Client) signedRequest(ctx context.Context, market, method, path string, params url.Values, v interface{}) error {
+	baseURL, apiKey, secret, offset, hc := c.Client.BaseURL, c.Client.APIKey, c.Client.SecretKey, c.Client.TimeOffset, c.Client.HTTPClient
+	if market == futuresMarket {
+		fc := c.FutureClient
+		baseURL, apiKey, secret, offset, hc = fc.BaseURL, fc.APIKey, fc.SecretKey, fc.TimeOffset, fc.HTTPClient
+	}
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli()-offset, 10))
+	query := params.Encode()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(query))
+	query += "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path+"?"+query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-MBX-APIKEY", apiKey)
+	res, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := &common.APIError{}
+		if json.Unmarshal(body, apiErr) == nil && apiErr.Code != 0 {
+			exErr := apiError(apiErr).(*base.ExchangeError)
+			exErr.HTTPStatus = res.StatusCode
+			return exErr
+		}
+		return &base.ExchangeError{Exchange: base.BINANCE, HTTPStatus: res.StatusCode, Message: string(body), Raw: body, Kind: base.KindOfHTTPStatus(res.StatusCode)}
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,440 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"AxonTrading/retry"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/gorilla/websocket"
+)
+
+// 推送地址，Client.WsURL / FutureWsURL 为空时使用
+const (
+	spotWsURL   = "wss://stream.binance.com:9443/ws"
+	futureWsURL = "wss://fstream.binance.com/ws"
+)
+
+const (
+	// wsReadTimeout 超过这个时间没有消息也没有 ping 视为断线，Binance 每 3 分钟 ping 一次
+	wsReadTimeout = 5 * time.Minute
+	// depthSnapshotLimit 同步本地深度时 REST 快照的档数
+	depthSnapshotLimit = 1000
+)
+
+// defaultStreamRetry 断线重连的退避，StreamRetry 为零值时使用
+var defaultStreamRetry = retry.Policy{BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// errDepthGap 增量推送的 updateId 不连续，需要重新拿快照
+var errDepthGap = errors.New("binance: depth update id gap")
+
+func (c *Client) wsURL(market string) string {
+	if market == futuresMarket {
+		if c.FutureWsURL != "" {
+			return c.FutureWsURL
+		}
+		return futureWsURL
+	}
+	if c.WsURL != "" {
+		return c.WsURL
+	}
+	return spotWsURL
+}
+
+func (c *Client) streamRetry() retry.Policy {
+	if c.StreamRetry.BaseDelay <= 0 {
+		return defaultStreamRetry
+	}
+	return c.StreamRetry
+}
+
+// stream 连接推送并逐条交给 handle，直到 ctx 取消。
+// 每次连接前调用 endpoint 取得地址（可在其中重置状态）；第一次连接失败时直接返回错误，
+// 之后断线或 handle 返回错误都按 StreamRetry 退避重连
+func (c *Client) stream(ctx context.Context, endpoint func() (string, error), handle func(data []byte) error) error {
+	conn, err := dialStream(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for attempt := 0; ; {
+			if conn != nil {
+				attempt = 0
+				_ = readStream(ctx, conn, handle)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			attempt++
+			select {
+			case <-time.After(c.streamRetry().Delay(attempt)):
+			case <-ctx.Done():
+				return
+			}
+			conn, _ = dialStream(ctx, endpoint)
+		}
+	}()
+	return nil
+}
+
+func dialStream(ctx context.Context, endpoint func() (string, error)) (*websocket.Conn, error) {
+	url, err := endpoint()
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	return conn, err
+}
+
+func readStream(ctx context.Context, conn *websocket.Conn, handle func(data []byte) error) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		conn.Close()
+	}()
+	_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		if err := handle(data); err != nil {
+			return err
+		}
+	}
+}
+
+// send 推送到调用方的 channel，ch 为 nil 时丢弃；ctx 取消时返回 false
+func send[T any](ctx context.Context, ch chan<- T, v T) bool {
+	if ch == nil {
+		return true
+	}
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func streamName(native, stream string) string {
+	return strings.ToLower(native) + "@" + stream
+}
+
+func (c *Client) marketStream(ctx context.Context, market, name string, handle func(data []byte) error) error {
+	url := c.wsURL(market) + "/" + name
+	return c.stream(ctx, func() (string, error) { return url, nil }, handle)
+}
+
+// StreamDepth 现货深度推送。按 Binance 文档用 REST 快照加 diff depth 增量维护本地深度，
+// 每次更新后把买卖各前 limit 档（<= 0 为全部）推送到 ch，Time 为事件时间。
+// updateId 不连续或重连时重新拿快照。ctx 取消后停止，ch 不会被关闭
+func (c *Client) StreamDepth(ctx context.Context, symbol string, limit int, ch chan<- models.WsData) error {
+	return c.streamDepth(ctx, spotMarket, c.spotID(symbol), limit, ch)
+}
+
+// StreamFutureDepth U本位合约深度推送，规则同 StreamDepth
+func (c *Client) StreamFutureDepth(ctx context.Context, symbol string, limit int, ch chan<- models.WsData) error {
+	return c.streamDepth(ctx, futuresMarket, c.futureID(symbol), limit, ch)
+}
+
+// depthEvent diff depth 推送，合约多一个 pu（上一条推送的 u）
+type depthEvent struct {
+	Event  string     `json:"e"` // encoding/json 键名不区分大小写，需要声明 e 以免匹配到 E
+	Time   int64      `json:"E"`
+	First  int64      `json:"U"`
+	Last   int64      `json:"u"`
+	Prev   *int64     `json:"pu"`
+	Bids   [][]string `json:"b"`
+	Asks   [][]string `json:"a"`
+	Symbol string     `json:"s"`
+}
+
+// localDepth 本地深度，bids 价格从高到低，asks 从低到高
+type localDepth struct {
+	lastID     int64
+	bids, asks []models.PriceLevel
+	synced     bool
+	first      bool // 快照后还没有应用过增量
+}
+
+func (c *Client) streamDepth(ctx context.Context, market, native string, limit int, ch chan<- models.WsData) error {
+	book := &localDepth{}
+	url := c.wsURL(market) + "/" + streamName(native, "depth@100ms")
+	endpoint := func() (string, error) {
+		// 重连后之前的增量已经丢了，等新快照
+		book.synced = false
+		return url, nil
+	}
+	return c.stream(ctx, endpoint, func(data []byte) error {
+		var e depthEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		if !book.synced {
+			if err := c.depthSnapshot(ctx, market, native, book); err != nil {
+				return err
+			}
+		}
+		applied, err := book.apply(&e)
+		if err != nil {
+			// 断档：重新拿快照后再试这一条，仍然接不上就等下一条推送
+			if err := c.depthSnapshot(ctx, market, native, book); err != nil {
+				book.synced = false
+				return err
+			}
+			if applied, err = book.apply(&e); err != nil {
+				book.synced = false
+				return nil
+			}
+		}
+		if !applied {
+			return nil
+		}
+		d := models.WsData{Time: e.Time, Bids: topLevels(book.bids, limit), Asks: topLevels(book.asks, limit)}
+		if !send(ctx, ch, d) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+func (c *Client) depthSnapshot(ctx context.Context, market, native string, book *localDepth) error {
+	var lastID int64
+	var bids, asks []binance.Bid // 与 futures.Bid 同为 common.PriceLevel
+	if market == futuresMarket {
+		res, err := c.FutureClient.NewDepthService().Symbol(native).Limit(depthSnapshotLimit).Do(ctx)
+		if err != nil {
+			return apiError(err)
+		}
+		lastID, bids, asks = res.LastUpdateID, res.Bids, res.Asks
+	} else {
+		res, err := c.Client.NewDepthService().Symbol(native).Limit(depthSnapshotLimit).Do(ctx)
+		if err != nil {
+			return apiError(err)
+		}
+		lastID, bids, asks = res.LastUpdateID, res.Bids, res.Asks
+	}
+	book.bids, book.asks = book.bids[:0], book.asks[:0]
+	for _, b := range bids {
+		book.bids = upsertLevel(book.bids, b.Price, b.Quantity, true)
+	}
+	for _, a := range asks {
+		book.asks = upsertLevel(book.asks, a.Price, a.Quantity, false)
+	}
+	book.lastID = lastID
+	book.synced, book.first = true, true
+	return nil
+}
+
+// apply 应用一条增量，返回是否改变了深度。
+// 快照之前的推送（u <= lastUpdateId）丢弃；快照后的第一条需要满足 U <= lastUpdateId+1，
+// 之后现货要求 U == 上一条 u + 1，合约要求 pu == 上一条 u
+func (b *localDepth) apply(e *depthEvent) (bool, error) {
+	if e.Last <= b.lastID {
+		return false, nil
+	}
+	var ok bool
+	switch {
+	case b.first:
+		ok = e.First <= b.lastID+1
+	case e.Prev != nil:
+		ok = *e.Prev == b.lastID
+	default:
+		ok = e.First == b.lastID+1
+	}
+	if !ok {
+		return false, fmt.Errorf("%w: U %d, last %d", errDepthGap, e.First, b.lastID)
+	}
+	for _, l := range e.Bids {
+		if len(l) >= 2 {
+			b.bids = upsertLevel(b.bids, l[0], l[1], true)
+		}
+	}
+	for _, l := range e.Asks {
+		if len(l) >= 2 {
+			b.asks = upsertLevel(b.asks, l[0], l[1], false)
+		}
+	}
+	b.lastID = e.Last
+	b.first = false
+	return true, nil
+}
+
+// upsertLevel 更新一档，数量为 0 时删除；desc 为 true 时按价格从高到低排列
+func upsertLevel(ls []models.PriceLevel, px, qty string, desc bool) []models.PriceLevel {
+	price := models.ParseDecimalOrZero(px)
+	q := models.ParseDecimalOrZero(qty)
+	i := sort.Search(len(ls), func(i int) bool {
+		if desc {
+			return ls[i].Price.Cmp(price) <= 0
+		}
+		return ls[i].Price.Cmp(price) >= 0
+	})
+	found := i < len(ls) && ls[i].Price.Equal(price)
+	switch {
+	case q.IsZero():
+		if found {
+			ls = append(ls[:i], ls[i+1:]...)
+		}
+	case found:
+		ls[i].Quantity = q
+	default:
+		ls = append(ls, models.PriceLevel{})
+		copy(ls[i+1:], ls[i:])
+		ls[i] = models.PriceLevel{Price: price, Quantity: q}
+	}
+	return ls
+}
+
+func topLevels(ls []models.PriceLevel, limit int) []models.PriceLevel {
+	if limit <= 0 || limit > len(ls) {
+		limit = len(ls)
+	}
+	return append([]models.PriceLevel(nil), ls[:limit]...)
+}
+
+// StreamTrades 现货归集成交（aggTrade）推送
+func (c *Client) StreamTrades(ctx context.Context, symbol string, ch chan<- models.Trade) error {
+	return c.streamTrades(ctx, spotMarket, symbol, c.spotID(symbol), ch)
+}
+
+// StreamFutureTrades U本位合约归集成交推送
+func (c *Client) StreamFutureTrades(ctx context.Context, symbol string, ch chan<- models.Trade) error {
+	return c.streamTrades(ctx, futuresMarket, symbol, c.futureID(symbol), ch)
+}
+
+func (c *Client) streamTrades(ctx context.Context, market, symbol, native string, ch chan<- models.Trade) error {
+	return c.marketStream(ctx, market, streamName(native, "aggTrade"), func(data []byte) error {
+		var e binance.WsAggTradeEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		// 买方是 maker 说明主动成交的是卖方
+		side := base.BID
+		if e.IsBuyerMaker {
+			side = base.ASK
+		}
+		t := models.Trade{
+			Symbol:   echoSymbol(symbol, e.Symbol),
+			TradeID:  strconv.FormatInt(e.AggTradeID, 10),
+			Price:    models.ParseDecimalOrZero(e.Price),
+			Quantity: models.ParseDecimalOrZero(e.Quantity),
+			Side:     side,
+			Time:     e.TradeTime,
+		}
+		if !send(ctx, ch, t) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// StreamBookTicker 现货最优买卖价推送，现货推送不带时间
+func (c *Client) StreamBookTicker(ctx context.Context, symbol string, ch chan<- models.Ticker) error {
+	return c.streamBookTicker(ctx, spotMarket, symbol, c.spotID(symbol), ch)
+}
+
+// StreamFutureBookTicker U本位合约最优买卖价推送
+func (c *Client) StreamFutureBookTicker(ctx context.Context, symbol string, ch chan<- models.Ticker) error {
+	return c.streamBookTicker(ctx, futuresMarket, symbol, c.futureID(symbol), ch)
+}
+
+func (c *Client) streamBookTicker(ctx context.Context, market, symbol, native string, ch chan<- models.Ticker) error {
+	return c.marketStream(ctx, market, streamName(native, "bookTicker"), func(data []byte) error {
+		// 合约的 bookTicker 是现货的超集
+		var e futures.WsBookTickerEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		t := models.Ticker{
+			Symbol:   echoSymbol(symbol, e.Symbol),
+			BidPrice: models.ParseDecimalOrZero(e.BestBidPrice),
+			BidQty:   models.ParseDecimalOrZero(e.BestBidQty),
+			AskPrice: models.ParseDecimalOrZero(e.BestAskPrice),
+			AskQty:   models.ParseDecimalOrZero(e.BestAskQty),
+			Time:     e.Time,
+		}
+		if !send(ctx, ch, t) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// StreamMarkPrice U本位合约标记价格和资金费率推送，每秒一次
+func (c *Client) StreamMarkPrice(ctx context.Context, symbol string, ch chan<- models.FundingRate) error {
+	return c.marketStream(ctx, futuresMarket, streamName(c.futureID(symbol), "markPrice@1s"), func(data []byte) error {
+		var e futures.WsMarkPriceEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		fr := models.FundingRate{
+			Symbol:               echoSymbol(symbol, e.Symbol),
+			MarkPrice:            models.ParseDecimalOrZero(e.MarkPrice),
+			IndexPrice:           models.ParseDecimalOrZero(e.IndexPrice),
+			EstimatedSettlePrice: models.ParseDecimalOrZero(e.EstimatedSettlePrice),
+			LastFundingRate:      models.ParseDecimalOrZero(e.FundingRate),
+			NextFundingTime:      e.NextFundingTime,
+			Time:                 e.Time,
+		}
+		if !send(ctx, ch, fr) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// StreamKlines 现货 K 线推送，interval 如 1m、1h
+func (c *Client) StreamKlines(ctx context.Context, symbol, interval string, ch chan<- models.Kline) error {
+	return c.streamKlines(ctx, spotMarket, symbol, c.spotID(symbol), interval, ch)
+}
+
+// StreamFutureKlines U本位合约 K 线推送
+func (c *Client) StreamFutureKlines(ctx context.Context, symbol, interval string, ch chan<- models.Kline) error {
+	return c.streamKlines(ctx, futuresMarket, symbol, c.futureID(symbol), interval, ch)
+}
+
+func (c *Client) streamKlines(ctx context.Context, market, symbol, native, interval string, ch chan<- models.Kline) error {
+	return c.marketStream(ctx, market, streamName(native, "kline_"+interval), func(data []byte) error {
+		var e binance.WsKlineEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		k := models.Kline{
+			Symbol:      echoSymbol(symbol, e.Symbol),
+			Interval:    e.Kline.Interval,
+			OpenTime:    e.Kline.StartTime,
+			CloseTime:   e.Kline.EndTime,
+			Open:        models.ParseDecimalOrZero(e.Kline.Open),
+			High:        models.ParseDecimalOrZero(e.Kline.High),
+			Low:         models.ParseDecimalOrZero(e.Kline.Low),
+			Close:       models.ParseDecimalOrZero(e.Kline.Close),
+			Volume:      models.ParseDecimalOrZero(e.Kline.Volume),
+			QuoteVolume: models.ParseDecimalOrZero(e.Kline.QuoteVolume),
+			Closed:      e.Kline.IsFinal,
+		}
+		if !send(ctx, ch, k) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
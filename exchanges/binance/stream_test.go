@@ -0,0 +1,282 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsServer 推送服务器，每个连接依次发送 pushes 中对应下标的消息后保持连接；paths 记录连接的路径
+type wsServer struct {
+	*httptest.Server
+	paths chan string
+}
+
+func newWsServer(t *testing.T, pushes ...[]string) *wsServer {
+	t.Helper()
+	s := &wsServer{paths: make(chan string, 10)}
+	upgrader := websocket.Upgrader{}
+	var mu sync.Mutex
+	conns := 0
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		n := conns
+		conns++
+		mu.Unlock()
+		s.paths <- r.URL.Path
+		if n < len(pushes) {
+			for _, m := range pushes[n] {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(m))
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *wsServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func recv[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		var zero T
+		t.Fatal("timed out waiting for push")
+		return zero
+	}
+}
+
+func levels(ls []models.PriceLevel) string {
+	var out []string
+	for _, l := range ls {
+		out = append(out, l.Price.String()+":"+l.Quantity.String())
+	}
+	return strings.Join(out, ",")
+}
+
+func TestStreamDepthSync(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// 快照 lastUpdateId 为 1027024：第一条在快照之前被丢弃，第二条跨过快照，第三条断档触发重新拿快照
+	ws := newWsServer(t, []string{
+		`{"e":"depthUpdate","E":1,"s":"BTCUSDT","U":1027000,"u":1027020,"b":[["1","1"]],"a":[]}`,
+		`{"e":"depthUpdate","E":2,"s":"BTCUSDT","U":1027020,"u":1027030,"b":[["37000.10000000","0"],["37000.00","3"]],"a":[["37000.30","1"]]}`,
+		`{"e":"depthUpdate","E":3,"s":"BTCUSDT","U":1027040,"u":1027050,"b":[],"a":[["37000.20000000","0"]]}`,
+		`{"e":"depthUpdate","E":4,"s":"BTCUSDT","U":1027020,"u":1027060,"b":[],"a":[["37000.50000000","0"]]}`,
+	})
+	c.WsURL = ws.url()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan models.WsData, 10)
+	if err := c.StreamDepth(ctx, "BTCUSDT", 2, ch); err != nil {
+		t.Fatal(err)
+	}
+	if p := recv(t, ws.paths); p != "/btcusdt@depth@100ms" {
+		t.Fatalf("path = %s", p)
+	}
+
+	d := recv(t, ch)
+	if d.Time != 2 || levels(d.Bids) != "37000:3,36999.9:2" || levels(d.Asks) != "37000.2:1.2,37000.3:1" {
+		t.Fatalf("depth = %+v", d)
+	}
+	// 断档的那条接不上新快照，没有推送；第四条再拿一次快照后应用
+	d = recv(t, ch)
+	if d.Time != 4 || levels(d.Bids) != "37000.1:0.8,36999.9:2" || levels(d.Asks) != "37000.2:1.2" {
+		t.Fatalf("depth after resync = %+v", d)
+	}
+	if n := len(srv.Requests(http.MethodGet, "/api/v3/depth")); n != 3 {
+		t.Fatalf("snapshots = %d", n)
+	}
+}
+
+func TestStreamFutureDepthChecksPrevID(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ws := newWsServer(t, []string{
+		`{"e":"depthUpdate","E":1,"s":"ETHUSDT","U":1027020,"u":1027030,"pu":1027019,"b":[["2099.90","1"]],"a":[]}`,
+		`{"e":"depthUpdate","E":2,"s":"ETHUSDT","U":1027031,"u":1027040,"pu":1027030,"b":[],"a":[["2100.00","0"]]}`,
+		`{"e":"depthUpdate","E":3,"s":"ETHUSDT","U":1027041,"u":1027050,"pu":1027039,"b":[],"a":[]}`,
+	})
+	c.FutureWsURL = ws.url()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan models.WsData, 10)
+	if err := c.StreamFutureDepth(ctx, "ETHUSDT", 0, ch); err != nil {
+		t.Fatal(err)
+	}
+	if d := recv(t, ch); levels(d.Bids) != "2099.9:1,2099.8:5" {
+		t.Fatalf("depth = %+v", d)
+	}
+	if d := recv(t, ch); levels(d.Asks) != "2100.1:3" {
+		t.Fatalf("depth = %+v", d)
+	}
+	// pu 不连续，第三条被丢弃并重新拿快照
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.Requests(http.MethodGet, "/fapi/v1/depth")) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("no resync snapshot")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case d := <-ch:
+		t.Fatalf("gap pushed %+v", d)
+	default:
+	}
+}
+
+func TestStreamMarketData(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ws := newWsServer(t,
+		[]string{`{"e":"aggTrade","E":1,"s":"BTCUSDT","a":12345,"p":"37000.1","q":"0.5","f":1,"l":2,"T":1700000000000,"m":true}`},
+		[]string{`{"u":400900217,"s":"BTCUSDT","b":"37000.1","B":"1.5","a":"37000.2","A":"2"}`},
+		[]string{`{"e":"markPriceUpdate","E":1700000000000,"s":"ETHUSDT","p":"2100.5","i":"2100.4","P":"2100.6","r":"0.0001","T":1700028800000}`},
+		[]string{`{"e":"kline","E":1,"s":"BTCUSDT","k":{"t":1700000000000,"T":1700000059999,"s":"BTCUSDT","i":"1m","o":"1","c":"2","h":"3","l":"0.5","v":"10","q":"15","x":true}}`},
+	)
+	c.WsURL, c.FutureWsURL = ws.url(), ws.url()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trades := make(chan models.Trade, 1)
+	if err := c.StreamTrades(ctx, "BTCUSDT", trades); err != nil {
+		t.Fatal(err)
+	}
+	if tr := recv(t, trades); tr.TradeID != "12345" || tr.Side != base.ASK || tr.Price.String() != "37000.1" || tr.Time != 1700000000000 {
+		t.Fatalf("trade = %+v", tr)
+	}
+	tickers := make(chan models.Ticker, 1)
+	if err := c.StreamBookTicker(ctx, "BTCUSDT", tickers); err != nil {
+		t.Fatal(err)
+	}
+	if tk := recv(t, tickers); tk.BidQty.String() != "1.5" || tk.AskPrice.String() != "37000.2" {
+		t.Fatalf("ticker = %+v", tk)
+	}
+	marks := make(chan models.FundingRate, 1)
+	if err := c.StreamMarkPrice(ctx, "ETHUSDT", marks); err != nil {
+		t.Fatal(err)
+	}
+	if m := recv(t, marks); m.MarkPrice.String() != "2100.5" || m.LastFundingRate.String() != "0.0001" || m.NextFundingTime != 1700028800000 {
+		t.Fatalf("mark price = %+v", m)
+	}
+	klines := make(chan models.Kline, 1)
+	if err := c.StreamKlines(ctx, "BTCUSDT", "1m", klines); err != nil {
+		t.Fatal(err)
+	}
+	if k := recv(t, klines); !k.Closed || k.High.String() != "3" || k.QuoteVolume.String() != "15" {
+		t.Fatalf("kline = %+v", k)
+	}
+
+	var paths []string
+	for i := 0; i < 4; i++ {
+		paths = append(paths, recv(t, ws.paths))
+	}
+	if strings.Join(paths, " ") != "/btcusdt@aggTrade /btcusdt@bookTicker /ethusdt@markPrice@1s /btcusdt@kline_1m" {
+		t.Fatalf("paths = %v", paths)
+	}
+}
+
+func TestStreamUserData(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// 第一个连接推送 listenKey 过期，重连后用新的 listenKey 推送订单和余额
+	ws := newWsServer(t,
+		[]string{`{"e":"listenKeyExpired","E":1}`},
+		[]string{
+			`{"e":"executionReport","E":2,"s":"BTCUSDT","c":"my-1","S":"BUY","o":"LIMIT","f":"GTC","q":"1","p":"100","x":"TRADE","X":"PARTIALLY_FILLED","i":42,"l":"0.4","z":"0.4","L":"100","n":"0.001","N":"BNB","T":3,"t":7,"m":true,"Z":"40"}`,
+			`{"e":"outboundAccountPosition","E":4,"u":4,"B":[{"a":"USDT","f":"960","l":"40"}]}`,
+		},
+	)
+	c.WsURL = ws.url()
+	c.StreamRetry.BaseDelay = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	orders := make(chan models.OrderUpdate, 1)
+	fills := make(chan models.Fill, 1)
+	balances := make(chan models.BalanceUpdate, 1)
+	if err := c.StreamUserData(ctx, UserDataChans{Orders: orders, Fills: fills, Balances: balances}); err != nil {
+		t.Fatal(err)
+	}
+
+	o := recv(t, orders)
+	if o.OrderID != "42" || o.ClientOrderID != "my-1" || o.Status != base.PARTIALLY || o.Type != base.LIMIT || o.AvgPrice.String() != "100" {
+		t.Fatalf("order = %+v", o)
+	}
+	f := recv(t, fills)
+	if f.TradeID != "7" || f.Quantity.String() != "0.4" || f.Fee.String() != "0.001" || f.FeeAsset != "BNB" || !f.Maker {
+		t.Fatalf("fill = %+v", f)
+	}
+	b := recv(t, balances)
+	if b.Asset != "USDT" || b.Total.String() != "1000" || b.Locked.String() != "40" {
+		t.Fatalf("balance = %+v", b)
+	}
+	if p1, p2 := recv(t, ws.paths), recv(t, ws.paths); p1 != "/spot-listen-key" || p2 != p1 {
+		t.Fatalf("paths = %s %s", p1, p2)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v3/userDataStream")); n != 2 {
+		t.Fatalf("listen keys created = %d", n)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.Requests(http.MethodDelete, "/api/v3/userDataStream")) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("listen key not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStreamFutureUserData(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ws := newWsServer(t, []string{
+		`{"e":"ORDER_TRADE_UPDATE","E":1,"T":1,"o":{"s":"ETHUSDT","c":"my-2","S":"SELL","o":"MARKET","q":"2","p":"0","ap":"2100","x":"TRADE","X":"FILLED","i":9,"l":"2","z":"2","L":"2100","N":"USDT","n":"1.68","T":5,"t":11,"m":false,"R":true,"ps":"SHORT"}}`,
+		`{"e":"ACCOUNT_UPDATE","E":6,"T":6,"a":{"m":"ORDER","B":[{"a":"USDT","wb":"998.32","cw":"998.32","bc":"0"}],"P":[{"s":"ETHUSDT","ps":"SHORT","pa":"-2","mt":"isolated","iw":"420","ep":"2100","up":"0"}]}}`,
+	})
+	c.FutureWsURL = ws.url()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	orders := make(chan models.OrderUpdate, 1)
+	fills := make(chan models.Fill, 1)
+	balances := make(chan models.BalanceUpdate, 1)
+	positions := make(chan models.PositionInfo, 1)
+	chans := UserDataChans{Orders: orders, Fills: fills, Balances: balances, Positions: positions}
+	if err := c.StreamFutureUserData(ctx, chans); err != nil {
+		t.Fatal(err)
+	}
+	if p := recv(t, ws.paths); p != "/futures-listen-key" {
+		t.Fatalf("path = %s", p)
+	}
+
+	o := recv(t, orders)
+	if o.Side != base.ASK || o.PositionSide != base.SHORT || o.Type != base.MARKET || o.Status != base.FILLED || !o.ReduceOnly {
+		t.Fatalf("order = %+v", o)
+	}
+	if f := recv(t, fills); f.TradeID != "11" || f.Fee.String() != "1.68" || f.Maker {
+		t.Fatalf("fill = %+v", f)
+	}
+	if b := recv(t, balances); b.Total.String() != "998.32" || b.Time != 6 {
+		t.Fatalf("balance = %+v", b)
+	}
+	p := recv(t, positions)
+	if p.PositionAmt.String() != "-2" || p.PositionSide != base.SHORT || p.MarginType != base.ISOLATED || p.IsolatedWallet.String() != "420" {
+		t.Fatalf("position = %+v", p)
+	}
+}
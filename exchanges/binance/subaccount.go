@@ -0,0 +1,117 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// 子账户管理（store/exchange.SubAccountManager）的实现，只能用母账户的 API key 调用，子账户以邮箱标识。
+// go-binance 的子账户列表固定带 isFreeze 参数、余额用 float64，这里直接用 signedRequest。
+// 子账户 API key 只有经纪商账户能通过接口管理，普通母账户返回 base.ErrNotSupported
+
+// subAccountPage 子账户列表每页的最大条数
+const subAccountPage = 200
+
+// universalAccounts 统一账户类型对应的子账户万向划转账户，资金账户不支持
+var universalAccounts = map[string]string{
+	base.SPOT:    "SPOT",
+	base.FUTURES: "USDT_FUTURE",
+}
+
+// GetSubAccounts 全部子账户，Name 为子账户邮箱，Enabled 为未冻结
+func (c *Client) GetSubAccounts(ctx context.Context) ([]models.SubAccount, error) {
+	var subs []models.SubAccount
+	for page := 1; ; page++ {
+		params := url.Values{"page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(subAccountPage)}}
+		var res struct {
+			SubAccounts []struct {
+				Email      string `json:"email"`
+				IsFreeze   bool   `json:"isFreeze"`
+				CreateTime int64  `json:"createTime"`
+			} `json:"subAccounts"`
+		}
+		if err := c.signedRequest(ctx, spotMarket, http.MethodGet, "/sapi/v1/sub-account/list", params, &res); err != nil {
+			return nil, err
+		}
+		for _, s := range res.SubAccounts {
+			subs = append(subs, models.SubAccount{Name: s.Email, Enabled: !s.IsFreeze, Time: s.CreateTime})
+		}
+		if len(res.SubAccounts) < subAccountPage {
+			return subs, nil
+		}
+	}
+}
+
+// GetSubAccountBalances 子账户现货账户的余额
+func (c *Client) GetSubAccountBalances(ctx context.Context, sub string) ([]models.Balance, error) {
+	if sub == "" {
+		return nil, fmt.Errorf("%w: empty sub account", models.ErrInvalidRequest)
+	}
+	var res struct {
+		Balances []models.Balance `json:"balances"`
+	}
+	if err := c.signedRequest(ctx, spotMarket, http.MethodGet, "/sapi/v3/sub-account/assets", url.Values{"email": {sub}}, &res); err != nil {
+		return nil, err
+	}
+	balances := make([]models.Balance, 0, len(res.Balances))
+	for _, b := range res.Balances {
+		if b.Free.IsZero() && b.Locked.IsZero() {
+			continue
+		}
+		balances = append(balances, b)
+	}
+	return balances, nil
+}
+
+// SubAccountTransfer 母子账户或子账户之间的万向划转，支持现货和 U本位合约账户，返回 tranId
+func (c *Client) SubAccountTransfer(ctx context.Context, req models.SubAccountTransferRequest) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
+	}
+	fromAccount, toAccount := req.Accounts()
+	from, ok := universalAccounts[fromAccount]
+	to, ok2 := universalAccounts[toAccount]
+	if !ok || !ok2 {
+		return models.Transfer{}, fmt.Errorf("%w: sub-account transfer from %s to %s", base.ErrNotSupported, fromAccount, toAccount)
+	}
+	params := url.Values{
+		"fromAccountType": {from},
+		"toAccountType":   {to},
+		"asset":           {req.Currency},
+		"amount":          {req.Amount.String()},
+	}
+	if req.FromSub != "" {
+		params.Set("fromEmail", req.FromSub)
+	}
+	if req.ToSub != "" {
+		params.Set("toEmail", req.ToSub)
+	}
+	var res struct {
+		TranID int64 `json:"tranId"`
+	}
+	if err := c.signedRequest(ctx, spotMarket, http.MethodPost, "/sapi/v1/sub-account/universalTransfer", params, &res); err != nil {
+		return models.Transfer{}, err
+	}
+	return models.Transfer{
+		TransferID: strconv.FormatInt(res.TranID, 10),
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		From:       fromAccount,
+		To:         toAccount,
+	}, nil
+}
+
+// CreateSubAccountAPIKey 普通母账户不能为子账户创建 API key
+func (c *Client) CreateSubAccountAPIKey(ctx context.Context, req models.SubAccountAPIKeyRequest) (models.SubAccountAPIKey, error) {
+	return models.SubAccountAPIKey{}, fmt.Errorf("%w: binance sub-account api keys are managed on the website", base.ErrNotSupported)
+}
+
+// DeleteSubAccountAPIKey 普通母账户不能删除子账户的 API key
+func (c *Client) DeleteSubAccountAPIKey(ctx context.Context, sub, apiKey string) error {
+	return fmt.Errorf("%w: binance sub-account api keys are managed on the website", base.ErrNotSupported)
+}
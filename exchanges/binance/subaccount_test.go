@@ -0,0 +1,59 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetSubAccounts(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	subs, err := c.GetSubAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 || subs[0].Name != "grid01_virtual@abc.com" || !subs[0].Enabled || subs[1].Enabled || subs[1].Time != 1700000200000 {
+		t.Fatalf("subs = %+v", subs)
+	}
+	// 不带 isFreeze，冻结的子账户也要返回
+	if q := srv.Requests(http.MethodGet, "/sapi/v1/sub-account/list")[0].Query; q.Get("page") != "1" || q.Get("limit") != "200" || q.Has("isFreeze") {
+		t.Fatalf("query = %v", q)
+	}
+
+	balances, err := c.GetSubAccountBalances(ctx, "grid01_virtual@abc.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Asset != "USDT" || balances[0].Free.String() != "1500.5" || balances[0].Locked.String() != "20" {
+		t.Fatalf("balances = %+v", balances)
+	}
+	if q := srv.Requests(http.MethodGet, "/sapi/v3/sub-account/assets")[0].Query; q.Get("email") != "grid01_virtual@abc.com" {
+		t.Fatalf("query = %v", q)
+	}
+}
+
+func TestSubAccountTransfer(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	amount := models.ParseDecimalOrZero("25.5")
+	tr, err := c.SubAccountTransfer(ctx, models.SubAccountTransferRequest{Currency: "USDT", Amount: amount, ToSub: "grid01_virtual@abc.com", ToAccount: base.FUTURES})
+	if err != nil || tr.TransferID != "11945860693" || tr.From != base.SPOT || tr.To != base.FUTURES {
+		t.Fatalf("transfer = %+v err = %v", tr, err)
+	}
+	params := form(t, srv.Requests(http.MethodPost, "/sapi/v1/sub-account/universalTransfer")[0])
+	if params.Has("fromEmail") || params.Get("toEmail") != "grid01_virtual@abc.com" || params.Get("fromAccountType") != "SPOT" ||
+		params.Get("toAccountType") != "USDT_FUTURE" || params.Get("asset") != "USDT" || params.Get("amount") != "25.5" {
+		t.Fatalf("params = %v", params)
+	}
+
+	if _, err := c.SubAccountTransfer(ctx, models.SubAccountTransferRequest{Currency: "USDT", Amount: amount, FromSub: "a@abc.com", FromAccount: base.FUNDING}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("funding err = %v", err)
+	}
+	if _, err := c.CreateSubAccountAPIKey(ctx, models.SubAccountAPIKeyRequest{SubAccount: "a@abc.com", Label: "bot"}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("create api key err = %v", err)
+	}
+}
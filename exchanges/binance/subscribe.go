@@ -0,0 +1,198 @@
+package binance
+
+import (
+	"AxonTrading/feed"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+)
+
+// 统一推送接口（store/exchange.StreamingExchange）的实现。
+// 统一符号按类型选择现货或 U本位合约，原生 symbol 视为现货；推送中的 Symbol 与传入的一致。
+// 账户推送在同一市场的订阅之间共用一个 listenKey 连接，最后一个订阅取消时断开
+
+// symbolMarket symbol 对应的市场：统一符号按类型区分现货和 U本位合约，原生 symbol 视为现货
+func symbolMarket(symbol string) string {
+	if instrument.IsUnified(symbol) {
+		if sym, err := instrument.Parse(symbol); err == nil && sym.Kind != instrument.Spot {
+			return futuresMarket
+		}
+	}
+	return spotMarket
+}
+
+// subscribe 用 start 启动一路推送，取消订阅时停止推送并关闭返回的 channel
+func subscribe[T any](ctx context.Context, start func(ctx context.Context, ch chan<- T) error) (<-chan T, *feed.Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	raw := make(chan T)
+	if err := start(ctx, raw); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	sub := feed.NewSubscription(ctx, func() error {
+		cancel()
+		return nil
+	})
+	return feed.Pipe(sub.Done(), raw, feed.Same[T]), sub, nil
+}
+
+// SubscribeOrderBook 本地维护的深度，每次更新推送买卖各前 depth 档
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, depth int) (<-chan models.WsData, *feed.Subscription, error) {
+	return subscribe(ctx, func(ctx context.Context, ch chan<- models.WsData) error {
+		if symbolMarket(symbol) == futuresMarket {
+			return c.StreamFutureDepth(ctx, symbol, depth, ch)
+		}
+		return c.StreamDepth(ctx, symbol, depth, ch)
+	})
+}
+
+// SubscribeTrades 公开成交
+func (c *Client) SubscribeTrades(ctx context.Context, symbol string) (<-chan models.Trade, *feed.Subscription, error) {
+	return subscribe(ctx, func(ctx context.Context, ch chan<- models.Trade) error {
+		if symbolMarket(symbol) == futuresMarket {
+			return c.StreamFutureTrades(ctx, symbol, ch)
+		}
+		return c.StreamTrades(ctx, symbol, ch)
+	})
+}
+
+// SubscribeTicker 最优买卖价，Binance 的 bookTicker 不带最新成交价
+func (c *Client) SubscribeTicker(ctx context.Context, symbol string) (<-chan models.Ticker, *feed.Subscription, error) {
+	return subscribe(ctx, func(ctx context.Context, ch chan<- models.Ticker) error {
+		if symbolMarket(symbol) == futuresMarket {
+			return c.StreamFutureBookTicker(ctx, symbol, ch)
+		}
+		return c.StreamBookTicker(ctx, symbol, ch)
+	})
+}
+
+// SubscribeOrders symbol 的订单状态推送
+func (c *Client) SubscribeOrders(ctx context.Context, symbol string) (<-chan models.OrderUpdate, *feed.Subscription, error) {
+	native := c.nativeID(symbol)
+	return subscribeUser(c, ctx, symbolMarket(symbol), func(f *userFeed) *feed.Hub[models.OrderUpdate] { return &f.orders },
+		func(o models.OrderUpdate) (models.OrderUpdate, bool) {
+			ok := o.Symbol == native
+			o.Symbol = echoSymbol(symbol, o.Symbol)
+			return o, ok
+		})
+}
+
+// SubscribeFills symbol 的成交推送
+func (c *Client) SubscribeFills(ctx context.Context, symbol string) (<-chan models.Fill, *feed.Subscription, error) {
+	native := c.nativeID(symbol)
+	return subscribeUser(c, ctx, symbolMarket(symbol), func(f *userFeed) *feed.Hub[models.Fill] { return &f.fills },
+		func(fill models.Fill) (models.Fill, bool) {
+			ok := fill.Symbol == native
+			fill.Symbol = echoSymbol(symbol, fill.Symbol)
+			return fill, ok
+		})
+}
+
+// SubscribePositions U本位合约持仓推送，symbol 为空时推送全部持仓（原生 symbol）
+func (c *Client) SubscribePositions(ctx context.Context, symbol string) (<-chan models.PositionInfo, *feed.Subscription, error) {
+	native := c.nativeID(symbol)
+	return subscribeUser(c, ctx, futuresMarket, func(f *userFeed) *feed.Hub[models.PositionInfo] { return &f.positions },
+		func(p models.PositionInfo) (models.PositionInfo, bool) {
+			if symbol == "" {
+				return p, true
+			}
+			ok := p.Symbol == native
+			p.Symbol = echoSymbol(symbol, p.Symbol)
+			return p, ok
+		})
+}
+
+// SubscribeBalances 余额推送，future 为 true 时为 U本位合约账户
+func (c *Client) SubscribeBalances(ctx context.Context, future bool) (<-chan models.BalanceUpdate, *feed.Subscription, error) {
+	market := spotMarket
+	if future {
+		market = futuresMarket
+	}
+	return subscribeUser(c, ctx, market, func(f *userFeed) *feed.Hub[models.BalanceUpdate] { return &f.balances },
+		feed.Same[models.BalanceUpdate])
+}
+
+// userFeed 一个市场的账户推送连接，由该市场的账户订阅共用
+type userFeed struct {
+	refs      int
+	cancel    context.CancelFunc
+	orders    feed.Hub[models.OrderUpdate]
+	fills     feed.Hub[models.Fill]
+	balances  feed.Hub[models.BalanceUpdate]
+	positions feed.Hub[models.PositionInfo]
+}
+
+func subscribeUser[T any](c *Client, ctx context.Context, market string, hub func(*userFeed) *feed.Hub[T], convert func(T) (T, bool)) (<-chan T, *feed.Subscription, error) {
+	f, err := c.acquireUserFeed(market)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, remove := hub(f).Subscribe()
+	sub := feed.NewSubscription(ctx, func() error {
+		remove()
+		c.releaseUserFeed(market)
+		return nil
+	})
+	return feed.Pipe(sub.Done(), ch, convert), sub, nil
+}
+
+func (c *Client) acquireUserFeed(market string) (*userFeed, error) {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	if f := c.userFeeds[market]; f != nil {
+		f.refs++
+		return f, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &userFeed{refs: 1, cancel: cancel}
+	orders := make(chan models.OrderUpdate)
+	fills := make(chan models.Fill)
+	balances := make(chan models.BalanceUpdate)
+	positions := make(chan models.PositionInfo)
+	chans := UserDataChans{Orders: orders, Fills: fills, Balances: balances}
+	var err error
+	if market == futuresMarket {
+		chans.Positions = positions
+		err = c.StreamFutureUserData(ctx, chans)
+	} else {
+		err = c.StreamUserData(ctx, chans)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case o := <-orders:
+				f.orders.Publish(o)
+			case fill := <-fills:
+				f.fills.Publish(fill)
+			case b := <-balances:
+				f.balances.Publish(b)
+			case p := <-positions:
+				f.positions.Publish(p)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if c.userFeeds == nil {
+		c.userFeeds = make(map[string]*userFeed)
+	}
+	c.userFeeds[market] = f
+	return f, nil
+}
+
+func (c *Client) releaseUserFeed(market string) {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	f := c.userFeeds[market]
+	if f == nil {
+		return
+	}
+	if f.refs--; f.refs == 0 {
+		f.cancel()
+		delete(c.userFeeds, market)
+	}
+}
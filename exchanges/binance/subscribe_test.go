@@ -0,0 +1,80 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSubscribeSharesUserDataStream(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ws := newWsServer(t, []string{
+		`{"e":"executionReport","E":1,"s":"ETHUSDT","c":"eth-1","S":"SELL","o":"MARKET","f":"GTC","q":"1","p":"0","x":"NEW","X":"NEW","i":1,"l":"0","z":"0","L":"0","n":"0","T":1,"t":-1,"Z":"0"}`,
+		`{"e":"executionReport","E":2,"s":"BTCUSDT","c":"btc-1","S":"BUY","o":"LIMIT","f":"GTC","q":"1","p":"100","x":"TRADE","X":"FILLED","i":2,"l":"1","z":"1","L":"100","n":"0.1","N":"USDT","T":2,"t":9,"Z":"100"}`,
+	})
+	c.WsURL = ws.url()
+
+	orders, orderSub, err := c.SubscribeOrders(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fills, fillSub, err := c.SubscribeFills(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ETHUSDT 的订单被过滤掉
+	if o := recv(t, orders); o.OrderID != "2" || o.Symbol != "BTCUSDT" {
+		t.Fatalf("order = %+v", o)
+	}
+	if f := recv(t, fills); f.TradeID != "9" || f.Price.String() != "100" {
+		t.Fatalf("fill = %+v", f)
+	}
+	recv(t, ws.paths)
+	if n := len(srv.Requests(http.MethodPost, "/api/v3/userDataStream")); n != 1 {
+		t.Fatalf("listen keys created = %d", n)
+	}
+
+	if err := orderSub.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-orders; ok {
+		t.Fatal("orders channel not closed")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := len(srv.Requests(http.MethodDelete, "/api/v3/userDataStream")); n != 0 {
+		t.Fatalf("listen key closed while still subscribed")
+	}
+
+	_ = fillSub.Unsubscribe()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.Requests(http.MethodDelete, "/api/v3/userDataStream")) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("listen key not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSubscribeTradesUnified(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ws := newWsServer(t, []string{
+		`{"e":"aggTrade","E":1,"s":"BTCUSDT","a":5,"p":"100","q":"2","f":1,"l":1,"T":1,"m":false}`,
+	})
+	c.FutureWsURL = ws.url()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, _, err := c.SubscribeTrades(ctx, "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr := recv(t, ch); tr.Symbol != "BTC/USDT:USDT" || tr.Price.String() != "100" {
+		t.Fatalf("trade = %+v", tr)
+	}
+	if p := recv(t, ws.paths); p != "/btcusdt@aggTrade" {
+		t.Fatalf("path = %s", p)
+	}
+	cancel()
+	for range ch {
+	}
+}
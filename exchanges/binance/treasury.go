@@ -0,0 +1,289 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// 资金（store/exchange.TreasuryExchange）的实现，提币从现货账户发出。划转使用 /sapi/v1/asset/transfer（万向划转），
+// 提币和充值记录按 id 查询需要 go-binance 没有的参数，直接用 signedRequest
+
+// 充提记录接口单次查询的时间跨度上限和每页条数
+const (
+	historyWindow = 90 * 24 * time.Hour
+	historyPage   = 1000
+)
+
+// transferTypes 万向划转的 type，key 为 from + "_" + to
+var transferTypes = map[string]string{
+	base.SPOT + "_" + base.FUTURES:    "MAIN_UMFUTURE",
+	base.FUTURES + "_" + base.SPOT:    "UMFUTURE_MAIN",
+	base.SPOT + "_" + base.FUNDING:    "MAIN_FUNDING",
+	base.FUNDING + "_" + base.SPOT:    "FUNDING_MAIN",
+	base.FUNDING + "_" + base.FUTURES: "FUNDING_UMFUTURE",
+	base.FUTURES + "_" + base.FUNDING: "UMFUTURE_FUNDING",
+}
+
+// GetChains 币种在各个 network 上的充提信息，currency 为空时返回全部币种
+func (c *Client) GetChains(ctx context.Context, currency string) ([]models.ChainInfo, error) {
+	coins, err := c.Client.NewGetAllCoinsInfoService().Do(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var chains []models.ChainInfo
+	for _, coin := range coins {
+		if currency != "" && coin.Coin != currency {
+			continue
+		}
+		for _, n := range coin.NetworkList {
+			fee := models.ParseDecimalOrZero(n.WithdrawFee)
+			chains = append(chains, models.ChainInfo{
+				Currency:       coin.Coin,
+				Chain:          n.Network,
+				CanDeposit:     n.DepositEnable,
+				CanWithdraw:    n.WithdrawEnable,
+				WithdrawFee:    fee,
+				MaxWithdrawFee: fee,
+				MinWithdraw:    models.ParseDecimalOrZero(n.WithdrawMin),
+				MaxWithdraw:    models.ParseDecimalOrZero(n.WithdrawMax),
+				WithdrawStep:   models.ParseDecimalOrZero(n.WithdrawIntegerMultiple),
+				NeedTag:        n.SameAddress,
+			})
+		}
+	}
+	return chains, nil
+}
+
+// SubmitWithdrawal 从现货账户链上提币，chain 为 Binance 的 network（如 TRX、BSC），手续费由交易所另外扣除
+func (c *Client) SubmitWithdrawal(ctx context.Context, req models.WithdrawRequest) (models.Withdrawal, error) {
+	if err := req.Validate(); err != nil {
+		return models.Withdrawal{}, err
+	}
+	chains, err := c.GetChains(ctx, req.Currency)
+	if err != nil {
+		return models.Withdrawal{}, err
+	}
+	var info *models.ChainInfo
+	for i := range chains {
+		if chains[i].Chain == req.Chain {
+			info = &chains[i]
+		}
+	}
+	switch {
+	case info == nil:
+		return models.Withdrawal{}, fmt.Errorf("%w: unknown network %s for %s", models.ErrInvalidRequest, req.Chain, req.Currency)
+	case !info.CanWithdraw:
+		return models.Withdrawal{}, fmt.Errorf("%w: %s withdrawal on %s is suspended", models.ErrInvalidRequest, req.Currency, req.Chain)
+	case req.Amount.LessThan(info.MinWithdraw):
+		return models.Withdrawal{}, fmt.Errorf("%w: amount %s below minimum %s", models.ErrInvalidRequest, req.Amount, info.MinWithdraw)
+	case info.NeedTag && req.Tag == "":
+		return models.Withdrawal{}, fmt.Errorf("%w: %s on %s needs a tag", models.ErrInvalidRequest, req.Currency, req.Chain)
+	}
+	svc := c.Client.NewCreateWithdrawService().
+		Coin(req.Currency).
+		Network(req.Chain).
+		Address(req.Address).
+		Amount(req.Amount.String())
+	if req.Tag != "" {
+		svc.AddressTag(req.Tag)
+	}
+	res, err := svc.Do(ctx)
+	if err != nil {
+		return models.Withdrawal{}, apiError(err)
+	}
+	return models.Withdrawal{
+		WithdrawID: res.ID,
+		Currency:   req.Currency,
+		Chain:      req.Chain,
+		Amount:     req.Amount,
+		Fee:        info.WithdrawFee,
+		Address:    req.Address,
+		Tag:        req.Tag,
+		Status:     base.PROCESSING,
+		Time:       time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetWithdrawal 按提币 id 查询，查不到时返回 base.ErrWithdrawNotFound
+func (c *Client) GetWithdrawal(ctx context.Context, currency, id string) (models.Withdrawal, error) {
+	params := url.Values{"idList": {id}}
+	if currency != "" {
+		params.Set("coin", currency)
+	}
+	var ws []binance.Withdraw
+	if err := c.signedRequest(ctx, spotMarket, http.MethodGet, "/sapi/v1/capital/withdraw/history", params, &ws); err != nil {
+		return models.Withdrawal{}, err
+	}
+	for _, w := range ws {
+		if w.ID == id {
+			return convertWithdrawal(w), nil
+		}
+	}
+	return models.Withdrawal{}, fmt.Errorf("%w: %s", base.ErrWithdrawNotFound, id)
+}
+
+// GetWithdrawals [since, until] 内的提币记录，按时间升序；currency 为空时返回全部币种
+func (c *Client) GetWithdrawals(ctx context.Context, currency string, since, until time.Time) ([]models.Withdrawal, error) {
+	since, until, err := models.HistoryRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var ws []models.Withdrawal
+	err = history(since, until, func(start, end int64, offset int) (int, error) {
+		params := url.Values{}
+		setHistoryParams(params, currency, start, end, offset)
+		var page []binance.Withdraw
+		if err := c.signedRequest(ctx, spotMarket, http.MethodGet, "/sapi/v1/capital/withdraw/history", params, &page); err != nil {
+			return 0, err
+		}
+		for _, w := range page {
+			ws = append(ws, convertWithdrawal(w))
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(ws, func(i, j int) bool { return ws[i].Time < ws[j].Time })
+	return ws, nil
+}
+
+func convertWithdrawal(w binance.Withdraw) models.Withdrawal {
+	status := base.PROCESSING
+	switch w.Status {
+	case 1:
+		status = base.CANCELED
+	case 3, 5: // 拒绝、失败
+		status = base.FAILED
+	case 6:
+		status = base.SUCCEEDED
+	}
+	// applyTime 为 UTC 的 "2006-01-02 15:04:05"
+	applied, _ := time.ParseInLocation(time.DateTime, w.ApplyTime, time.UTC)
+	return models.Withdrawal{
+		WithdrawID: w.ID,
+		Currency:   w.Coin,
+		Chain:      w.Network,
+		Amount:     models.ParseDecimalOrZero(w.Amount),
+		Fee:        models.ParseDecimalOrZero(w.TransactionFee),
+		Address:    w.Address,
+		TxID:       w.TxID,
+		Status:     status,
+		Time:       applied.UnixMilli(),
+	}
+}
+
+// deposit 充值记录，go-binance 的 Deposit 没有 id
+type deposit struct {
+	binance.Deposit
+	ID string `json:"id"`
+}
+
+// GetDeposits [since, until] 内的充值记录，按时间升序；currency 为空时返回全部币种
+func (c *Client) GetDeposits(ctx context.Context, currency string, since, until time.Time) ([]models.Deposit, error) {
+	since, until, err := models.HistoryRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var deposits []models.Deposit
+	err = history(since, until, func(start, end int64, offset int) (int, error) {
+		params := url.Values{}
+		setHistoryParams(params, currency, start, end, offset)
+		var page []deposit
+		if err := c.signedRequest(ctx, spotMarket, http.MethodGet, "/sapi/v1/capital/deposit/hisrec", params, &page); err != nil {
+			return 0, err
+		}
+		for _, d := range page {
+			status := base.PROCESSING
+			switch d.Status {
+			case 1, 6: // 6 为已入账但暂不可提
+				status = base.SUCCEEDED
+			case 2, 7: // 拒绝、错误充值
+				status = base.FAILED
+			}
+			deposits = append(deposits, models.Deposit{
+				DepositID: d.ID,
+				Currency:  d.Coin,
+				Chain:     d.Network,
+				Amount:    models.ParseDecimalOrZero(d.Amount),
+				Address:   d.Address,
+				Tag:       d.AddressTag,
+				TxID:      d.TxID,
+				Status:    status,
+				Time:      d.InsertTime,
+			})
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(deposits, func(i, j int) bool { return deposits[i].Time < deposits[j].Time })
+	return deposits, nil
+}
+
+func setHistoryParams(params url.Values, currency string, start, end int64, offset int) {
+	if currency != "" {
+		params.Set("coin", currency)
+	}
+	params.Set("startTime", strconv.FormatInt(start, 10))
+	params.Set("endTime", strconv.FormatInt(end, 10))
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("limit", strconv.Itoa(historyPage))
+}
+
+// history 按 90 天分段查询充提记录，某段超过一页时按 offset 翻页。page 返回本页条数
+func history(since, until time.Time, page func(start, end int64, offset int) (int, error)) error {
+	window := historyWindow.Milliseconds()
+	last := until.UnixMilli()
+	for start := since.UnixMilli(); start <= last; start += window {
+		end := start + window - 1
+		if end > last {
+			end = last
+		}
+		for offset := 0; ; offset += historyPage {
+			n, err := page(start, end, offset)
+			if err != nil {
+				return err
+			}
+			if n < historyPage {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Transfer 现货、U本位合约和资金账户之间的万向划转，返回 tranId
+func (c *Client) Transfer(ctx context.Context, req models.TransferRequest) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
+	}
+	params := url.Values{
+		"type":   {transferTypes[req.From+"_"+req.To]},
+		"asset":  {req.Currency},
+		"amount": {req.Amount.String()},
+	}
+	var res struct {
+		TranID int64 `json:"tranId"`
+	}
+	if err := c.signedRequest(ctx, spotMarket, http.MethodPost, "/sapi/v1/asset/transfer", params, &res); err != nil {
+		return models.Transfer{}, err
+	}
+	return models.Transfer{
+		TransferID: strconv.FormatInt(res.TranID, 10),
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		From:       req.From,
+		To:         req.To,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetChains(t *testing.T) {
+	c, _ := newFakeClient(t)
+	chains, err := c.GetChains(context.Background(), "USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chains) != 2 {
+		t.Fatalf("chains = %+v", chains)
+	}
+	trx, eos := chains[0], chains[1]
+	if trx.Chain != "TRX" || !trx.CanWithdraw || trx.WithdrawFee.String() != "1" || trx.MinWithdraw.String() != "10" ||
+		trx.WithdrawStep.String() != "0.000001" || trx.NeedTag {
+		t.Fatalf("trx = %+v", trx)
+	}
+	if eos.Chain != "EOS" || !eos.NeedTag {
+		t.Fatalf("eos = %+v", eos)
+	}
+	all, err := c.GetChains(context.Background(), "")
+	if err != nil || len(all) != 3 {
+		t.Fatalf("all chains = %+v err = %v", all, err)
+	}
+}
+
+func TestSubmitWithdrawal(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	req := models.WithdrawRequest{Currency: "USDT", Chain: "EOS", Address: "binancecleos", Tag: "101", Amount: models.ParseDecimalOrZero("20")}
+	w, err := c.SubmitWithdrawal(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.WithdrawID != "7213fea8e94b4a5593d507237e5a555b" || w.Status != base.PROCESSING || w.Fee.String() != "1" {
+		t.Fatalf("withdrawal = %+v", w)
+	}
+	params := form(t, srv.Requests(http.MethodPost, "/sapi/v1/capital/withdraw/apply")[0])
+	if params.Get("network") != "EOS" || params.Get("addressTag") != "101" || params.Get("amount") != "20" {
+		t.Fatalf("params = %v", params)
+	}
+
+	// 缺少 tag、低于最小数量、暂停提币、未知 network
+	for _, r := range []models.WithdrawRequest{
+		{Currency: "USDT", Chain: "EOS", Address: "binancecleos", Amount: models.ParseDecimalOrZero("20")},
+		{Currency: "USDT", Chain: "TRX", Address: "TXYZ", Amount: models.ParseDecimalOrZero("5")},
+		{Currency: "BTC", Chain: "BTC", Address: "bc1q", Amount: models.ParseDecimalOrZero("1")},
+		{Currency: "USDT", Chain: "SOL", Address: "sol", Amount: models.ParseDecimalOrZero("20")},
+	} {
+		if _, err := c.SubmitWithdrawal(ctx, r); !errors.Is(err, models.ErrInvalidRequest) {
+			t.Fatalf("%s on %s err = %v", r.Currency, r.Chain, err)
+		}
+	}
+	if n := len(srv.Requests(http.MethodPost, "/sapi/v1/capital/withdraw/apply")); n != 1 {
+		t.Fatalf("withdraw requests = %d", n)
+	}
+}
+
+func TestWithdrawalHistory(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	w, err := c.GetWithdrawal(ctx, "USDT", "b6ae22b3aa844210a7041aee7589627c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Status != base.SUCCEEDED || w.Chain != "TRX" || w.Amount.String() != "50" || w.Fee.String() != "1" || w.Time != 1700000100000 || !w.Done() {
+		t.Fatalf("withdrawal = %+v", w)
+	}
+	if q := srv.Requests(http.MethodGet, "/sapi/v1/capital/withdraw/history")[0].Query; q.Get("idList") != "b6ae22b3aa844210a7041aee7589627c" || q.Get("coin") != "USDT" {
+		t.Fatalf("query = %v", q)
+	}
+	if _, err := c.GetWithdrawal(ctx, "USDT", "missing"); !errors.Is(err, base.ErrWithdrawNotFound) {
+		t.Fatalf("not found err = %v", err)
+	}
+
+	// 100 天分成两段查询
+	until := time.UnixMilli(1700010000000)
+	since := until.Add(-100 * 24 * time.Hour)
+	ws, err := c.GetWithdrawals(ctx, "USDT", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ws) != 4 || ws[0].WithdrawID != "b6ae22b3aa844210a7041aee7589627c" || ws[3].Status != base.PROCESSING {
+		t.Fatalf("withdrawals = %+v", ws)
+	}
+	reqs := srv.Requests(http.MethodGet, "/sapi/v1/capital/withdraw/history")[2:]
+	if len(reqs) != 2 {
+		t.Fatalf("requests = %d", len(reqs))
+	}
+	first, second := reqs[0].Query, reqs[1].Query
+	if first.Get("startTime") != fmt.Sprint(since.UnixMilli()) || first.Get("endTime") != fmt.Sprint(since.Add(historyWindow).UnixMilli()-1) ||
+		second.Get("endTime") != fmt.Sprint(until.UnixMilli()) || first.Get("limit") != "1000" || first.Get("offset") != "0" {
+		t.Fatalf("queries = %v %v", first, second)
+	}
+
+	deposits, err := c.GetDeposits(ctx, "USDT", time.Time{}, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 2 || deposits[0].Status != base.FAILED || deposits[0].Tag != "101" || deposits[1].DepositID != "769800519366885376" || deposits[1].Status != base.SUCCEEDED {
+		t.Fatalf("deposits = %+v", deposits)
+	}
+}
+
+func TestDepositHistoryPaginates(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// 第一页满 1000 条，第二页 1 条
+	srv.HandleFunc(http.MethodGet, "/sapi/v1/capital/deposit/hisrec", func(w http.ResponseWriter, r *http.Request) {
+		n := historyPage
+		if r.URL.Query().Get("offset") != "0" {
+			n = 1
+		}
+		rows := make([]string, n)
+		for i := range rows {
+			rows[i] = fmt.Sprintf(`{"id":"%s-%d","amount":"1","coin":"USDT","network":"TRX","status":1,"insertTime":%d}`, r.URL.Query().Get("offset"), i, 1700000000000+int64(i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(rows, ",") + "]"))
+	})
+	deposits, err := c.GetDeposits(context.Background(), "", time.UnixMilli(1699000000000), time.UnixMilli(1700010000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != historyPage+1 {
+		t.Fatalf("deposits = %d", len(deposits))
+	}
+	reqs := srv.Requests(http.MethodGet, "/sapi/v1/capital/deposit/hisrec")
+	if len(reqs) != 2 || reqs[1].Query.Get("offset") != "1000" || reqs[0].Query.Get("coin") != "" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	tr, err := c.Transfer(ctx, models.TransferRequest{Currency: "USDT", Amount: models.ParseDecimalOrZero("100.5"), From: base.FUTURES, To: base.SPOT})
+	if err != nil || tr.TransferID != "13526853623" {
+		t.Fatalf("transfer = %+v err = %v", tr, err)
+	}
+	params := form(t, srv.Requests(http.MethodPost, "/sapi/v1/asset/transfer")[0])
+	if params.Get("type") != "UMFUTURE_MAIN" || params.Get("asset") != "USDT" || params.Get("amount") != "100.5" {
+		t.Fatalf("params = %v", params)
+	}
+	if _, err := c.Transfer(ctx, models.TransferRequest{Currency: "USDT", Amount: models.ParseDecimalOrZero("1"), From: base.SPOT, To: base.SPOT}); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("same account err = %v", err)
+	}
+}
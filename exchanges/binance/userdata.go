@@ -0,0 +1,347 @@
+package binance
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// listenKeyKeepalive listenKey 60 分钟不续期就失效，按文档建议每 30 分钟续一次
+const listenKeyKeepalive = 30 * time.Minute
+
+// errListenKeyExpired 收到 listenKeyExpired，重连时重新创建 listenKey
+var errListenKeyExpired = errors.New("binance: listen key expired")
+
+// UserDataChans 账户推送的接收 channel，为 nil 的类型不推送。symbol 为 Binance 原生 id
+type UserDataChans struct {
+	Orders    chan<- models.OrderUpdate
+	Fills     chan<- models.Fill
+	Balances  chan<- models.BalanceUpdate
+	Positions chan<- models.PositionInfo // 仅合约
+}
+
+// StreamUserData 现货账户推送：订单状态、成交和余额。
+// 每次连接前创建 listenKey 并定时续期，ctx 取消后停止并删除 listenKey，channel 不会被关闭
+func (c *Client) StreamUserData(ctx context.Context, chans UserDataChans) error {
+	return c.streamUserData(ctx, spotMarket, chans, c.handleSpotUserData)
+}
+
+// StreamFutureUserData U本位合约账户推送：订单状态、成交、余额和持仓，规则同 StreamUserData
+func (c *Client) StreamFutureUserData(ctx context.Context, chans UserDataChans) error {
+	return c.streamUserData(ctx, futuresMarket, chans, c.handleFutureUserData)
+}
+
+type userDataHandler func(ctx context.Context, data []byte, chans UserDataChans) error
+
+func (c *Client) streamUserData(ctx context.Context, market string, chans UserDataChans, handle userDataHandler) error {
+	var mu sync.Mutex
+	var key string
+	endpoint := func() (string, error) {
+		k, err := c.startListenKey(ctx, market)
+		if err != nil {
+			return "", err
+		}
+		mu.Lock()
+		key = k
+		mu.Unlock()
+		return c.wsURL(market) + "/" + k, nil
+	}
+	err := c.stream(ctx, endpoint, func(data []byte) error {
+		return handle(ctx, data, chans)
+	})
+	if err != nil {
+		return err
+	}
+	go func() {
+		t := time.NewTicker(listenKeyKeepalive)
+		defer t.Stop()
+		current := func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			return key
+		}
+		for {
+			select {
+			case <-t.C:
+				// 续期失败时等 listenKeyExpired 推送或断线后重建
+				_ = c.keepaliveListenKey(ctx, market, current())
+			case <-ctx.Done():
+				_ = c.closeListenKey(context.Background(), market, current())
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *Client) startListenKey(ctx context.Context, market string) (string, error) {
+	var key string
+	var err error
+	if market == futuresMarket {
+		key, err = c.FutureClient.NewStartUserStreamService().Do(ctx)
+	} else {
+		key, err = c.Client.NewStartUserStreamService().Do(ctx)
+	}
+	return key, apiError(err)
+}
+
+func (c *Client) keepaliveListenKey(ctx context.Context, market, key string) error {
+	if market == futuresMarket {
+		return apiError(c.FutureClient.NewKeepaliveUserStreamService().ListenKey(key).Do(ctx))
+	}
+	return apiError(c.Client.NewKeepaliveUserStreamService().ListenKey(key).Do(ctx))
+}
+
+func (c *Client) closeListenKey(ctx context.Context, market, key string) error {
+	if market == futuresMarket {
+		return apiError(c.FutureClient.NewCloseUserStreamService().ListenKey(key).Do(ctx))
+	}
+	return apiError(c.Client.NewCloseUserStreamService().ListenKey(key).Do(ctx))
+}
+
+type userDataEvent struct {
+	Event string `json:"e"`
+	Time  int64  `json:"E"`
+}
+
+func (c *Client) handleSpotUserData(ctx context.Context, data []byte, chans UserDataChans) error {
+	var head userDataEvent
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil
+	}
+	switch head.Event {
+	case "listenKeyExpired":
+		return errListenKeyExpired
+	case "executionReport":
+		var e binance.WsOrderUpdate
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		// 现货推送没有均价，用累计成交额 / 累计成交量，未成交时为 0
+		filled := models.ParseDecimalOrZero(e.FilledVolume)
+		avg, _ := models.ParseDecimalOrZero(e.FilledQuoteVolume).Div(filled, 16)
+		o := models.OrderUpdate{
+			Symbol:        e.Symbol,
+			OrderID:       strconv.FormatInt(e.Id, 10),
+			ClientOrderID: spotClientOrderID(&e),
+			Side:          orderSide(e.Side),
+			Type:          spotOrderType(e.Type, string(e.TimeInForce)),
+			Price:         models.ParseDecimalOrZero(e.Price),
+			Quantity:      models.ParseDecimalOrZero(e.Volume),
+			Filled:        filled,
+			AvgPrice:      avg,
+			Status:        orderStatus(e.Status),
+			Time:          e.TransactionTime,
+		}
+		if !send(ctx, chans.Orders, o) {
+			return ctx.Err()
+		}
+		if e.ExecutionType != "TRADE" {
+			return nil
+		}
+		f := models.Fill{
+			Symbol:        e.Symbol,
+			OrderID:       o.OrderID,
+			ClientOrderID: o.ClientOrderID,
+			TradeID:       strconv.FormatInt(e.TradeId, 10),
+			Side:          o.Side,
+			Price:         models.ParseDecimalOrZero(e.LatestPrice),
+			Quantity:      models.ParseDecimalOrZero(e.LatestVolume),
+			Fee:           models.ParseDecimalOrZero(e.FeeCost),
+			FeeAsset:      e.FeeAsset,
+			Maker:         e.IsMaker,
+			Time:          e.TransactionTime,
+		}
+		if !send(ctx, chans.Fills, f) {
+			return ctx.Err()
+		}
+	case "outboundAccountPosition":
+		var e binance.WsAccountUpdateList
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		for _, b := range e.WsAccountUpdates {
+			free := models.ParseDecimalOrZero(b.Free)
+			locked := models.ParseDecimalOrZero(b.Locked)
+			u := models.BalanceUpdate{Asset: b.Asset, Total: free.Add(locked), Free: free, Locked: locked, Time: head.Time}
+			if !send(ctx, chans.Balances, u) {
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// spotClientOrderID 撤单推送的 c 是撤单请求的 id，原订单的 id 在 C 中
+func spotClientOrderID(e *binance.WsOrderUpdate) string {
+	if e.Status == "CANCELED" && e.OrigCustomOrderId != "" {
+		return e.OrigCustomOrderId
+	}
+	return e.ClientOrderId
+}
+
+func (c *Client) handleFutureUserData(ctx context.Context, data []byte, chans UserDataChans) error {
+	var head userDataEvent
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil
+	}
+	switch head.Event {
+	case "listenKeyExpired":
+		return errListenKeyExpired
+	case "ORDER_TRADE_UPDATE":
+		var e futures.WsUserDataEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		u := e.OrderTradeUpdate
+		o := models.OrderUpdate{
+			Symbol:        u.Symbol,
+			OrderID:       strconv.FormatInt(u.ID, 10),
+			ClientOrderID: u.ClientOrderID,
+			Side:          orderSide(string(u.Side)),
+			PositionSide:  positionSide(string(u.PositionSide)),
+			Type:          futureOrderType(string(u.Type)),
+			Price:         models.ParseDecimalOrZero(u.OriginalPrice),
+			Quantity:      models.ParseDecimalOrZero(u.OriginalQty),
+			Filled:        models.ParseDecimalOrZero(u.AccumulatedFilledQty),
+			AvgPrice:      models.ParseDecimalOrZero(u.AveragePrice),
+			Status:        orderStatus(string(u.Status)),
+			ReduceOnly:    u.IsReduceOnly,
+			Time:          u.TradeTime,
+		}
+		if !send(ctx, chans.Orders, o) {
+			return ctx.Err()
+		}
+		if u.ExecutionType != futures.OrderExecutionTypeTrade {
+			return nil
+		}
+		f := models.Fill{
+			Symbol:        u.Symbol,
+			OrderID:       o.OrderID,
+			ClientOrderID: o.ClientOrderID,
+			TradeID:       strconv.FormatInt(u.TradeID, 10),
+			Side:          o.Side,
+			Price:         models.ParseDecimalOrZero(u.LastFilledPrice),
+			Quantity:      models.ParseDecimalOrZero(u.LastFilledQty),
+			Fee:           models.ParseDecimalOrZero(u.Commission),
+			FeeAsset:      u.CommissionAsset,
+			Maker:         u.IsMaker,
+			Time:          u.TradeTime,
+		}
+		if !send(ctx, chans.Fills, f) {
+			return ctx.Err()
+		}
+	case "ACCOUNT_UPDATE":
+		var e futures.WsUserDataEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil
+		}
+		for _, b := range e.AccountUpdate.Balances {
+			u := models.BalanceUpdate{
+				Asset: b.Asset,
+				Total: models.ParseDecimalOrZero(b.Balance),
+				Free:  models.ParseDecimalOrZero(b.CrossWalletBalance),
+				Time:  e.TransactionTime,
+			}
+			if !send(ctx, chans.Balances, u) {
+				return ctx.Err()
+			}
+		}
+		for _, p := range e.AccountUpdate.Positions {
+			var marginType string
+			if strings.EqualFold(string(p.MarginType), "isolated") { // 推送中为小写 isolated/cross
+				marginType = base.ISOLATED
+			} else {
+				marginType = base.CROSSED
+			}
+			pos := models.PositionInfo{
+				Symbol:           p.Symbol,
+				PositionAmt:      models.ParseDecimalOrZero(p.Amount),
+				EntryPrice:       models.ParseDecimalOrZero(p.EntryPrice),
+				UnRealizedProfit: models.ParseDecimalOrZero(p.UnrealizedPnL),
+				MarginType:       marginType,
+				IsolatedWallet:   models.ParseDecimalOrZero(p.IsolatedWallet),
+				PositionSide:     positionSide(string(p.Side)),
+				UpdateTime:       e.TransactionTime,
+			}
+			if !send(ctx, chans.Positions, pos) {
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+func orderSide(s string) string {
+	if s == "BUY" {
+		return base.BID
+	}
+	return base.ASK
+}
+
+// positionSide 单向持仓模式下为 BOTH，与 GetFuturePositions 一致原样返回
+func positionSide(s string) string {
+	switch s {
+	case "LONG":
+		return base.LONG
+	case "SHORT":
+		return base.SHORT
+	}
+	return s
+}
+
+// orderStatus 未知状态（如 REJECTED、EXPIRED_IN_MATCH）原样返回
+func orderStatus(s string) string {
+	switch s {
+	case "NEW":
+		return base.OPEN
+	case "PARTIALLY_FILLED":
+		return base.PARTIALLY
+	case "FILLED":
+		return base.FILLED
+	case "CANCELED", "EXPIRED":
+		return base.CANCELED
+	}
+	return s
+}
+
+func spotOrderType(typ, tif string) string {
+	switch {
+	case typ == "LIMIT" && tif == "IOC":
+		return base.TAKER
+	case typ == "LIMIT":
+		return base.LIMIT
+	case typ == "LIMIT_MAKER":
+		return base.MAKER
+	case typ == "MARKET":
+		return base.MARKET
+	}
+	return typ
+}
+
+func futureOrderType(typ string) string {
+	switch typ {
+	case "LIMIT":
+		return base.LIMIT
+	case "MARKET":
+		return base.MARKET
+	case "STOP":
+		return base.STOP
+	case "STOP_MARKET":
+		return base.STOPMARKET
+	case "TAKE_PROFIT":
+		return base.TAKEPROFIT
+	case "TAKE_PROFIT_MARKET":
+		return base.TAKEPROFITMARKET
+	}
+	return typ
+}
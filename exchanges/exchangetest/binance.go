@@ -0,0 +1,87 @@
+package exchangetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binanceRecvWindow 请求未带 recvWindow 时 Binance 使用的默认值
+const binanceRecvWindow = 5000 * time.Millisecond
+
+// binancePublic 不需要 API key 的行情接口
+var binancePublic = []string{"/depth", "/ticker/", "/exchangeInfo", "/premiumIndex", "/time", "/ping", "/trades", "/klines"}
+
+// NewBinance 启动 Binance 现货（/api、/sapi）和 U 本位合约（/fapi）假服务器，非行情接口需要签名
+func NewBinance(creds Credentials) *Server {
+	return newServer(venue{
+		name:    "binance",
+		private: binancePrivate,
+		auth:    binanceAuth,
+		missing: func(method, path string) string {
+			return binanceError(-1000, fmt.Sprintf("no fixture for %s %s", method, path))
+		},
+	}, creds)
+}
+
+// binanceKeyOnly 只需要 API key、不需要签名的接口（listenKey）
+var binanceKeyOnly = []string{"/userDataStream", "/listenKey"}
+
+func binancePrivate(path string) bool {
+	for _, p := range binancePublic {
+		if strings.Contains(path, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func binanceError(code int, msg string) string {
+	return fmt.Sprintf(`{"code":%d,"msg":%q}`, code, msg)
+}
+
+// BinanceSign 按 Binance 规则计算签名：hex(hmac_sha256(queryString + body))
+func BinanceSign(secret, payload string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func binanceAuth(r *http.Request, body []byte, creds Credentials) (int, string) {
+	if r.Header.Get("X-MBX-APIKEY") != creds.APIKey {
+		return http.StatusUnauthorized, binanceError(-2015, "Invalid API-key, IP, or permissions for action.")
+	}
+	for _, p := range binanceKeyOnly {
+		if strings.HasSuffix(r.URL.Path, p) {
+			return 0, ""
+		}
+	}
+	// signature 是最后一个参数，签名内容是它之前的原始 query 加上表单 body
+	raw := r.URL.RawQuery
+	i := strings.LastIndex(raw, "signature=")
+	if i < 0 {
+		return http.StatusBadRequest, binanceError(-1102, "Mandatory parameter 'signature' was not sent, was empty/null, or malformed.")
+	}
+	payload := strings.TrimSuffix(raw[:i], "&")
+	if !hmac.Equal([]byte(raw[i+len("signature="):]), []byte(BinanceSign(creds.SecretKey, payload+string(body)))) {
+		return http.StatusBadRequest, binanceError(-1022, "Signature for this request is not valid.")
+	}
+	q := r.URL.Query()
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, binanceError(-1102, "Mandatory parameter 'timestamp' was not sent, was empty/null, or malformed.")
+	}
+	window := binanceRecvWindow
+	if w, err := strconv.ParseInt(q.Get("recvWindow"), 10, 64); err == nil {
+		window = time.Duration(w) * time.Millisecond
+	}
+	if d := time.Since(time.UnixMilli(ts)); d > window || d < -time.Second {
+		return http.StatusBadRequest, binanceError(-1021, "Timestamp for this request is outside of the recvWindow.")
+	}
+	return 0, ""
+}
@@ -0,0 +1,91 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/account"
+	"AxonTrading/models"
+	"context"
+	"time"
+)
+
+// accountModes /account/config 的 acctLv 对应的账户模式
+var accountModes = map[string]string{
+	"1": base.AccountSimple,
+	"2": base.AccountSingleCurrency,
+	"3": base.AccountMultiCurrency,
+	"4": base.AccountPortfolio,
+}
+
+// GetAccount 由 /account/config 和 /account/balance 组成的账户快照，余额都在交易账户（base.SPOT）。
+// 跨币种和组合保证金模式的保证金取账户级别的 imr / mmr / adjEq；单币种保证金模式按币种计算，
+// 账户保证金为各币种折算成美元之和，MarginRatio 取风险最高的币种
+func (c *Client) GetAccount(ctx context.Context) (models.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Account{}, err
+	}
+	cfg, err := c.rest(ctx).Account.GetConfig()
+	if err != nil {
+		return models.Account{}, restError(err)
+	}
+	if cfg.Code != 0 || len(cfg.Configs) == 0 {
+		return models.Account{}, sdkError(cfg.Basic)
+	}
+	if err := ctx.Err(); err != nil {
+		return models.Account{}, err
+	}
+	resp, err := c.rest(ctx).Account.GetBalance(account.GetBalance{})
+	if err != nil {
+		return models.Account{}, restError(err)
+	}
+	if resp.Code != 0 {
+		return models.Account{}, sdkError(resp.Basic)
+	}
+	acct := models.Account{
+		Mode:     accountModes[cfg.Configs[0].AcctLv],
+		DualSide: cfg.Configs[0].PosMode == sdk.PositionLongShortMode,
+	}
+	if len(resp.Balances) == 0 {
+		return acct, nil
+	}
+	b := resp.Balances[0]
+	acct.TotalEquity = decimalOf(b.TotalEq)
+	acct.Time = time.Time(b.UTime).UnixMilli()
+	for _, d := range b.Details {
+		ab := models.AssetBalance{
+			Account:       base.SPOT,
+			Asset:         d.Ccy,
+			Free:          decimalOf(d.AvailBal),
+			Locked:        decimalOf(d.FrozenBal),
+			Equity:        decimalOf(d.Eq),
+			USDValue:      decimalOf(d.EqUsd),
+			InitialMargin: decimalOf(d.Imr),
+			MaintMargin:   decimalOf(d.Mmr),
+		}
+		if ab.Free.IsZero() && ab.Locked.IsZero() && ab.Equity.IsZero() {
+			continue
+		}
+		acct.Balances = append(acct.Balances, ab)
+	}
+
+	if acct.Mode != base.AccountSingleCurrency {
+		acct.InitialMargin = decimalOf(b.Imr)
+		acct.MaintMargin = decimalOf(b.Mmr)
+		acct.MarginRatio = models.MarginRatio(acct.MaintMargin, decimalOf(b.AdjEq))
+		return acct, nil
+	}
+	for _, ab := range acct.Balances {
+		if ab.MaintMargin.IsZero() && ab.InitialMargin.IsZero() {
+			continue
+		}
+		// 按该币种的美元价格折算
+		if px, err := ab.USDValue.Div(ab.Equity, 16); err == nil {
+			acct.InitialMargin = acct.InitialMargin.Add(ab.InitialMargin.Mul(px).Round(8))
+			acct.MaintMargin = acct.MaintMargin.Add(ab.MaintMargin.Mul(px).Round(8))
+		}
+		if r := models.MarginRatio(ab.MaintMargin, ab.Equity); r.GreaterThan(acct.MarginRatio) {
+			acct.MarginRatio = r
+		}
+	}
+	return acct, nil
+}
@@ -0,0 +1,68 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetAccount(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	acct, err := c.GetAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdt := acct.Balance(base.SPOT, "USDT")
+	if acct.Mode != base.AccountSingleCurrency || !acct.DualSide || len(acct.Balances) != 1 || acct.TotalEquity.String() != "1010.5" || acct.Time != 1700000000000 {
+		t.Fatalf("account = %+v", acct)
+	}
+	if usdt.Free.String() != "1000.5" || usdt.Locked.String() != "10" || usdt.Equity.String() != "1010.5" || usdt.USDValue.String() != "1010.5" {
+		t.Fatalf("usdt = %+v", usdt)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/account/balance")[0].Query; q.Get("ccy") != "" {
+		t.Fatalf("balance query = %v", q)
+	}
+
+	// 单币种保证金：按币种的美元价格折算，MarginRatio 取风险最高的币种
+	srv.Handle(http.MethodGet, "/api/v5/account/balance", http.StatusOK, `{"code":"0","msg":"","data":[{"totalEq":"3000","uTime":"1700000001000","details":[
+		{"ccy":"BTC","availBal":"0.01","frozenBal":"0","eq":"0.05","eqUsd":"2000","imr":"0.02","mmr":"0.01"},
+		{"ccy":"USDT","availBal":"900","frozenBal":"0","eq":"1000","eqUsd":"1000","imr":"100","mmr":"10"}]}]}`)
+	acct, err = c.GetAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct.InitialMargin.String() != "900" || acct.MaintMargin.String() != "410" || acct.MarginRatio.String() != "0.2" {
+		t.Fatalf("single currency = %s %s %s", acct.InitialMargin, acct.MaintMargin, acct.MarginRatio)
+	}
+
+	// 跨币种保证金取账户级别的字段
+	srv.Handle(http.MethodGet, "/api/v5/account/config", http.StatusOK, `{"code":"0","msg":"","data":[{"acctLv":"3","posMode":"net_mode"}]}`)
+	srv.Handle(http.MethodGet, "/api/v5/account/balance", http.StatusOK, `{"code":"0","msg":"","data":[{"totalEq":"3000","adjEq":"2800","imr":"500","mmr":"140","uTime":"1700000001000","details":[
+		{"ccy":"USDT","availBal":"900","frozenBal":"0","eq":"1000","eqUsd":"1000"}]}]}`)
+	acct, err = c.GetAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct.Mode != base.AccountMultiCurrency || acct.DualSide || acct.InitialMargin.String() != "500" || acct.MaintMargin.String() != "140" || acct.MarginRatio.String() != "0.05" {
+		t.Fatalf("multi currency = %+v", acct)
+	}
+
+	// 超出 float64 精度的数值原样保留
+	srv.Handle(http.MethodGet, "/api/v5/account/balance", http.StatusOK, `{"code":"0","msg":"","data":[{"totalEq":"123456789012.123456789","details":[
+		{"ccy":"USDT","availBal":"123456789012.123456789","frozenBal":"0","eq":"123456789012.123456789"}]}]}`)
+	acct, err = c.GetAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct.TotalEquity.String() != "123456789012.123456789" || acct.Balance(base.SPOT, "USDT").Free.String() != "123456789012.123456789" {
+		t.Fatalf("precision = %+v", acct)
+	}
+
+	// 余额为零的币种返回 0
+	srv.Handle(http.MethodGet, "/api/v5/account/balance", http.StatusOK, `{"code":"0","msg":"","data":[{"details":[]}]}`)
+	if bal, err := c.GetAccountBalance("DOGE"); err != nil || len(bal) != 3 || bal[0] != "0" {
+		t.Fatalf("missing asset = %v err = %v", bal, err)
+	}
+}
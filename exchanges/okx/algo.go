@@ -0,0 +1,267 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/trade"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// 策略委托（store/exchange.AlgoExchange）的实现，使用 /api/v5/trade/order-algo，支持现货和永续。
+// 永续的数量以币计，与 NewFutureOrder 相同换算成张数
+
+// algoOrdTypes 统一类型对应的 ordType，GetOpenAlgoOrders 按此逐个类型查询
+var algoOrdTypes = map[string]sdk.AlgoOrderType{
+	base.TRIGGER:      "trigger",
+	base.CONDITIONAL:  "conditional",
+	base.OCO:          "oco",
+	base.TRAILINGSTOP: "move_order_stop",
+	base.ICEBERG:      "iceberg",
+	base.TWAP:         "twap",
+}
+
+// algoPx 委托价，0 表示市价（-1）
+func algoPx(d models.Decimal) string {
+	if d.IsZero() {
+		return "-1"
+	}
+	return d.String()
+}
+
+// futureStopTypes NewFutureOrder 的止损、止盈类型，OKX 普通订单没有触发价，改为止盈止损策略委托
+var futureStopTypes = map[string]bool{
+	base.STOP:             true,
+	base.STOPMARKET:       true,
+	base.TAKEPROFIT:       true,
+	base.TAKEPROFITMARKET: true,
+}
+
+// futureStopOrder 永续的止损、止盈单，stopPrice 为触发价，市价类型忽略 price，closePosition 时只减仓且必须带数量。返回 algoId，
+// 需要通过 GetOpenAlgoOrders / CancelAlgoOrder 查询和撤销
+func (c *Client) futureStopOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, reduceOnly bool) (string, error) {
+	sz, err := models.ParseDecimal(size)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", models.ErrInvalidRequest, err)
+	}
+	if sz.IsZero() && reduceOnly {
+		return "", fmt.Errorf("%w: okx stop order closing the whole position without size", base.ErrNotSupported)
+	}
+	trigger, err := models.ParseDecimal(stopPrice)
+	if err != nil {
+		return "", fmt.Errorf("%w: stop price: %v", models.ErrInvalidRequest, err)
+	}
+	var px models.Decimal
+	if typ == base.STOP || typ == base.TAKEPROFIT {
+		if px, err = models.ParseDecimal(price); err != nil {
+			return "", fmt.Errorf("%w: price: %v", models.ErrInvalidRequest, err)
+		}
+	}
+	dual, err := c.CheckDual()
+	if err != nil {
+		return "", err
+	}
+	if !dual {
+		positionSide = ""
+	}
+	req := models.AlgoOrderRequest{
+		Symbol:       c.swapID(symbol),
+		Side:         side,
+		PositionSide: positionSide,
+		MarginType:   positionType,
+		Type:         base.CONDITIONAL,
+		Size:         sz,
+		ReduceOnly:   reduceOnly,
+	}
+	if typ == base.STOP || typ == base.STOPMARKET {
+		req.StopLossTrigger, req.StopLossPrice = trigger, px
+	} else {
+		req.TakeProfitTrigger, req.TakeProfitPrice = trigger, px
+	}
+	return c.PlaceAlgoOrder(context.Background(), req)
+}
+
+// PlaceAlgoOrder 下策略委托，返回 algoId
+func (c *Client) PlaceAlgoOrder(ctx context.Context, req models.AlgoOrderRequest) (string, error) {
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+	instID := c.spotID(req.Symbol)
+	kind, tdMode := instrument.Spot, sdk.TradeCashMode
+	switch instType(instID) {
+	case sdk.SpotInstrument:
+	case sdk.SwapInstrument:
+		kind, tdMode = instrument.Swap, sdk.TradeCrossMode
+		if req.MarginType == base.ISOLATED {
+			tdMode = sdk.TradeIsolatedMode
+		}
+	default:
+		return "", fmt.Errorf("%w: algo order on %s", base.ErrNotSupported, instID)
+	}
+	size := func(d models.Decimal) (string, error) {
+		_, sz, err := c.quantizeOrder(kind, instID, base.LIMIT, "", d.String())
+		if err != nil || kind == instrument.Spot {
+			return sz, err
+		}
+		return c.contractSize(instID, sz)
+	}
+	sz, err := size(req.Size)
+	if err != nil {
+		return "", err
+	}
+
+	o := requests.PlaceAlgoOrder{
+		InstID:     instID,
+		TdMode:     tdMode,
+		Side:       sdk.OrderSide(c.setSide(req.Side)),
+		OrdType:    algoOrdTypes[req.Type],
+		Sz:         sz,
+		ReduceOnly: req.ReduceOnly,
+	}
+	if kind == instrument.Swap {
+		switch req.PositionSide {
+		case base.LONG:
+			o.PosSide = sdk.PositionLongSide
+		case base.SHORT:
+			o.PosSide = sdk.PositionShortSide
+		default:
+			o.PosSide = sdk.PositionNetSide
+		}
+	}
+	switch req.Type {
+	case base.TRIGGER:
+		o.TriggerPx = req.TriggerPrice.String()
+		o.OrderPx = algoPx(req.Price)
+	case base.CONDITIONAL, base.OCO:
+		if req.TakeProfitTrigger.Sign() > 0 {
+			o.TpTriggerPx = req.TakeProfitTrigger.String()
+			o.TpOrdPx = algoPx(req.TakeProfitPrice)
+		}
+		if req.StopLossTrigger.Sign() > 0 {
+			o.SlTriggerPx = req.StopLossTrigger.String()
+			o.SlOrdPx = algoPx(req.StopLossPrice)
+		}
+	case base.TRAILINGSTOP:
+		o.CallbackRatio = req.CallbackRate.String()
+		if req.ActivationPrice.Sign() > 0 {
+			o.ActivePx = req.ActivationPrice.String()
+		}
+	case base.ICEBERG, base.TWAP:
+		if o.IcebergOrder.SzLimit, err = size(req.SliceSize); err != nil {
+			return "", err
+		}
+		o.IcebergOrder.PxLimit = req.PriceLimit.String()
+		o.IcebergOrder.PxSpread = req.PriceSpread.String()
+		if req.Type == base.TWAP {
+			o.TimeInterval = strconv.Itoa(int(req.Interval.Seconds()))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	resp, err := c.rest(ctx).Trade.PlaceAlgoOrder(o)
+	if err != nil {
+		return "", restError(err)
+	}
+	if len(resp.PlaceAlgoOrders) == 0 {
+		return "", sdkError(resp.Basic)
+	}
+	if d := resp.PlaceAlgoOrders[0]; resp.Code != 0 || d.SCode != 0 {
+		return "", resultError(resp.Basic, d.SCode, d.SMsg)
+	}
+	return resp.PlaceAlgoOrders[0].AlgoID, nil
+}
+
+// CancelAlgoOrder 按 algoId 撤销策略委托
+func (c *Client) CancelAlgoOrder(ctx context.Context, symbol, algoID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	resp, err := c.rest(ctx).Trade.CancelAlgoOrder([]requests.CancelAlgoOrder{{InstID: c.spotID(symbol), AlgoID: algoID}})
+	if err != nil {
+		return restError(err)
+	}
+	if len(resp.CancelAlgoOrders) == 0 {
+		return sdkError(resp.Basic)
+	}
+	if d := resp.CancelAlgoOrders[0]; resp.Code != 0 || d.SCode != 0 {
+		return resultError(resp.Basic, d.SCode, d.SMsg)
+	}
+	return nil
+}
+
+// GetOpenAlgoOrders 未触发或执行中的策略委托。接口每次只能查一种 ordType，逐个类型查询
+func (c *Client) GetOpenAlgoOrders(ctx context.Context, symbol string) ([]models.AlgoOrder, error) {
+	var orders []models.AlgoOrder
+	for _, typ := range []string{base.TRIGGER, base.CONDITIONAL, base.OCO, base.TRAILINGSTOP, base.ICEBERG, base.TWAP} {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		req := requests.AlgoOrderList{OrdType: algoOrdTypes[typ]}
+		if symbol != "" {
+			req.InstID = c.spotID(symbol)
+		}
+		resp, err := c.rest(ctx).Trade.GetAlgoOrderList(req, false)
+		if err != nil {
+			return nil, restError(err)
+		}
+		if resp.Code != 0 {
+			return nil, sdkError(resp.Basic)
+		}
+		for _, o := range resp.AlgoOrders {
+			orders = append(orders, algoOrder(symbol, typ, o))
+		}
+	}
+	return orders, nil
+}
+
+// algoOrder orders-algo-pending 返回的策略委托，委托价 -1（市价）转为 0
+func algoOrder(symbol, typ string, o *trade.AlgoOrder) models.AlgoOrder {
+	px := func(f sdk.JSONDecimal) models.Decimal {
+		if f == "-1" {
+			return models.Decimal{}
+		}
+		return decimalOf(f)
+	}
+	status := string(o.State)
+	switch o.State {
+	case "live":
+		status = base.OPEN
+	case "partially_effective":
+		status = base.PARTIALLY
+	case "effective":
+		status = base.FILLED
+	case "canceled":
+		status = base.CANCELED
+	}
+	var side string
+	switch o.Side {
+	case sdk.OrderBuy:
+		side = base.BID
+	case sdk.OrderSell:
+		side = base.ASK
+	}
+	return models.AlgoOrder{
+		AlgoID:            o.AlgoID,
+		Symbol:            echoSymbol(symbol, o.InstID),
+		Side:              side,
+		PositionSide:      positionSide(string(o.PosSide)),
+		Type:              typ,
+		Size:              decimalOf(o.Sz),
+		Status:            status,
+		TriggerPrice:      px(o.TriggerPx),
+		Price:             px(o.OrdPx),
+		TakeProfitTrigger: px(o.TpTriggerPx),
+		TakeProfitPrice:   px(o.TpOrdPx),
+		StopLossTrigger:   px(o.SlTriggerPx),
+		StopLossPrice:     px(o.SlOrdPx),
+		CallbackRate:      px(o.CallbackRatio),
+		ActivationPrice:   px(o.ActivePx),
+		Time:              millis(o.CTime),
+	}
+}
@@ -0,0 +1,127 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPlaceAlgoOrder(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+
+	id, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: "BTC-USDT", Side: base.ASK, Type: base.OCO, Size: models.ParseDecimalOrZero("0.01"),
+		TakeProfitTrigger: models.ParseDecimalOrZero("40000"),
+		StopLossTrigger:   models.ParseDecimalOrZero("35000"), StopLossPrice: models.ParseDecimalOrZero("34900"),
+	})
+	if err != nil || id != "681096944655273984" {
+		t.Fatalf("id = %s err = %v", id, err)
+	}
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{
+		Symbol: "BTC-USDT", Side: base.BID, Type: base.TWAP, Size: models.ParseDecimalOrZero("1"),
+		SliceSize: models.ParseDecimalOrZero("0.1"), PriceLimit: models.ParseDecimalOrZero("36000"), Interval: time.Minute,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests(http.MethodPost, "/api/v5/trade/order-algo")
+	if len(reqs) != 2 {
+		t.Fatalf("requests = %d", len(reqs))
+	}
+	var oco, twap map[string]string
+	_ = json.Unmarshal(reqs[0].Body, &oco)
+	_ = json.Unmarshal(reqs[1].Body, &twap)
+	// 止盈没有委托价时按市价（-1）
+	if oco["ordType"] != "oco" || oco["tdMode"] != "cash" || oco["side"] != "sell" || oco["sz"] != "0.01" ||
+		oco["tpTriggerPx"] != "40000" || oco["tpOrdPx"] != "-1" || oco["slTriggerPx"] != "35000" || oco["slOrdPx"] != "34900" {
+		t.Fatalf("oco = %v", oco)
+	}
+	if _, ok := oco["posSide"]; ok {
+		t.Fatalf("spot oco with posSide: %v", oco)
+	}
+	if twap["ordType"] != "twap" || twap["szLimit"] != "0.1" || twap["pxLimit"] != "36000" || twap["pxSpread"] != "0" || twap["timeInterval"] != "60" {
+		t.Fatalf("twap = %v", twap)
+	}
+
+	if _, err := c.PlaceAlgoOrder(ctx, models.AlgoOrderRequest{Symbol: "BTC-USDT", Side: base.BID, Type: base.OCO, Size: models.ParseDecimalOrZero("1"),
+		TakeProfitTrigger: models.ParseDecimalOrZero("1")}); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("oco without stop loss err = %v", err)
+	}
+}
+
+func TestAlgoOrderListAndCancel(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	srv.HandleFunc(http.MethodGet, "/api/v5/trade/orders-algo-pending", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ordType") != "oco" {
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"algoId":"681096944655273984","instId":"BTC-USDT","ordType":"oco","side":"sell",` +
+			`"posSide":"net","sz":"0.01","state":"live","tpTriggerPx":"40000","tpOrdPx":"-1","slTriggerPx":"35000","slOrdPx":"34900","cTime":"1700000000000"}]}`))
+	})
+
+	orders, err := c.GetOpenAlgoOrders(ctx, "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("orders = %+v", orders)
+	}
+	o := orders[0]
+	if o.AlgoID != "681096944655273984" || o.Type != base.OCO || o.Side != base.ASK || o.Status != base.OPEN ||
+		o.TakeProfitTrigger.String() != "40000" || !o.TakeProfitPrice.IsZero() || o.StopLossPrice.String() != "34900" {
+		t.Fatalf("order = %+v", o)
+	}
+	// 每种 ordType 查询一次
+	reqs := srv.Requests(http.MethodGet, "/api/v5/trade/orders-algo-pending")
+	if len(reqs) != 6 || reqs[0].Query.Get("instId") != "BTC-USDT" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	if err := c.CancelAlgoOrder(ctx, "BTC-USDT", o.AlgoID); err != nil {
+		t.Fatal(err)
+	}
+	var body []map[string]string
+	_ = json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/trade/cancel-algos")[0].Body, &body)
+	if len(body) != 1 || body[0]["instId"] != "BTC-USDT" || body[0]["algoId"] != o.AlgoID {
+		t.Fatalf("cancel body = %v", body)
+	}
+}
+
+func TestNewFutureOrderStop(t *testing.T) {
+	c, srv := newFakeClient(t)
+	srv.Handle(http.MethodGet, "/api/v5/public/convert-contract-coin", http.StatusOK,
+		`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"","sz":"0.15","type":"1","unit":"coin"}]}`)
+
+	id, err := c.NewFutureOrder("BTC-USDT", base.ASK, base.LONG, base.STOP, "1.5", "29000", "29500", base.ISOLATED, false, false)
+	if err != nil || id != "681096944655273984" {
+		t.Fatalf("id = %s err = %v", id, err)
+	}
+	if _, err := c.NewFutureOrder("BTC-USDT", base.ASK, base.LONG, base.TAKEPROFITMARKET, "1.5", "", "35000", base.ISOLATED, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/trade/order")); n != 0 {
+		t.Fatalf("stop orders sent as plain orders: %d", n)
+	}
+	reqs := srv.Requests(http.MethodPost, "/api/v5/trade/order-algo")
+	if len(reqs) != 2 {
+		t.Fatalf("requests = %d", len(reqs))
+	}
+	var sl, tp map[string]string
+	_ = json.Unmarshal(reqs[0].Body, &sl)
+	_ = json.Unmarshal(reqs[1].Body, &tp)
+	if sl["instId"] != "BTC-USDT-SWAP" || sl["ordType"] != "conditional" || sl["tdMode"] != "isolated" || sl["posSide"] != "long" ||
+		sl["sz"] != "1.5" || sl["slTriggerPx"] != "29500" || sl["slOrdPx"] != "29000" || sl["tpTriggerPx"] != "" {
+		t.Fatalf("stop = %v", sl)
+	}
+	if tp["ordType"] != "conditional" || tp["tpTriggerPx"] != "35000" || tp["tpOrdPx"] != "-1" || tp["reduceOnly"] != "true" || tp["slTriggerPx"] != "" {
+		t.Fatalf("take profit = %v", tp)
+	}
+}
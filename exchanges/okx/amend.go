@@ -0,0 +1,88 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/trade"
+	traderes "AxonTrading/exchanges/okx/sdk/responses/trade"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// AmendOrder 修改挂单的价格和数量，newPrice、newSize 为空表示不变，newSize 为含已成交部分的总数量（永续以币计，
+// 与 NewFutureOrder 相同）。仅支持现货和永续。WsTrade 为 true 时先经 websocket 发送。
+// symbol 按原样解析：旧格式 BTC-USDT 是现货，永续须使用统一符号（BTC/USDT:USDT）或 instId（BTC-USDT-SWAP）。
+// 改单在交易所异步生效，返回的是请求受理后查询到的订单，可能还是修改前的价格和数量
+func (c *Client) AmendOrder(ctx context.Context, symbol, id, newPrice, newSize string) (models.OrderInfo, error) {
+	if newPrice == "" && newSize == "" {
+		return models.OrderInfo{}, fmt.Errorf("%w: nothing to amend", models.ErrInvalidRequest)
+	}
+	instID := c.spotID(symbol)
+	kind := instrument.Spot
+	switch instType(instID) {
+	case sdk.SpotInstrument:
+	case sdk.SwapInstrument:
+		kind = instrument.Swap
+	default:
+		return models.OrderInfo{}, fmt.Errorf("%w: amend %s", base.ErrNotSupported, instID)
+	}
+	req := requests.AmendOrder{InstID: instID, OrdID: id}
+	if newPrice != "" {
+		if _, err := models.RequireDecimal("price", newPrice); err != nil {
+			return models.OrderInfo{}, err
+		}
+		req.NewPx = newPrice
+	}
+	// 只改价格时没有数量可供校验，价格交给交易所校验
+	if newSize != "" {
+		price, size, err := c.quantizeOrder(kind, instID, base.LIMIT, newPrice, newSize)
+		if err != nil {
+			return models.OrderInfo{}, err
+		}
+		if kind == instrument.Swap {
+			if size, err = c.contractSize(instID, size); err != nil {
+				return models.OrderInfo{}, err
+			}
+		}
+		if newPrice != "" {
+			req.NewPx = price
+		}
+		req.NewSz = size
+	}
+	if err := c.amendOrder(ctx, req); err != nil {
+		return models.OrderInfo{}, err
+	}
+	return c.GetOrder(symbol, id)
+}
+
+// amendOrder 发送 amend-order，code 和 sCode 都为 0 时表示交易所已受理
+func (c *Client) amendOrder(ctx context.Context, req requests.AmendOrder) error {
+	var response traderes.AmendOrder
+	data, err := c.wsTrade(sdk.AmendOrderOperation, []requests.AmendOrder{req})
+	switch {
+	case errors.Is(err, errWsUnavailable):
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if response, err = c.rest(ctx).Trade.AmendOrder([]requests.AmendOrder{req}); err != nil {
+			return restError(err)
+		}
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &response); err != nil {
+			return responseError(0, data)
+		}
+	}
+	if len(response.AmendOrders) == 0 {
+		return sdkError(response.Basic)
+	}
+	if d := response.AmendOrders[0]; response.Code != 0 || d.SCode != 0 {
+		return resultError(response.Basic, d.SCode, d.SMsg)
+	}
+	return nil
+}
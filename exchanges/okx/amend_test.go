@@ -0,0 +1,65 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAmendOrderRest(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	o, err := c.AmendOrder(context.Background(), "BTC-USDT", "680800019749904384", "36100", "0.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OrderID != "680800019749904384" || o.Symbol != "BTC-USDT" {
+		t.Fatalf("order = %+v", o)
+	}
+	reqs := srv.Requests(http.MethodPost, "/api/v5/trade/amend-order")
+	if len(reqs) != 1 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	var body map[string]string
+	_ = json.Unmarshal(reqs[0].Body, &body)
+	if body["instId"] != "BTC-USDT" || body["ordId"] != "680800019749904384" || body["newPx"] != "36100" || body["newSz"] != "0.03" {
+		t.Fatalf("body = %v", body)
+	}
+
+	// 只改价格时不带 newSz
+	if _, err := c.AmendOrder(context.Background(), "BTC-USDT", "680800019749904384", "36200", ""); err != nil {
+		t.Fatal(err)
+	}
+	body = nil
+	_ = json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/trade/amend-order")[1].Body, &body)
+	if _, ok := body["newSz"]; ok || body["newPx"] != "36200" {
+		t.Fatalf("body = %v", body)
+	}
+
+	if _, err := c.AmendOrder(context.Background(), "BTC-USDT", "1", "", ""); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("empty amend err = %v", err)
+	}
+}
+
+func TestAmendOrderWs(t *testing.T) {
+	c, srv := newFakeClient(t)
+	url, ops := newPushServer(t, map[string]string{
+		"amend-order": `{"id":"{id}","op":"amend-order","code":"1","msg":"","data":[{"clOrdId":"","ordId":"9","reqId":"","sCode":"51603","sMsg":"Order does not exist"}]}`,
+	})
+	c.WsPrivateURL, c.WsTrade = url, true
+	t.Cleanup(func() { c.streamer().ws.Cancel() })
+
+	if _, err := c.AmendOrder(context.Background(), "BTC-USDT", "9", "100", ""); !errors.Is(err, base.ErrOrderNotFound) {
+		t.Fatalf("err = %v", err)
+	}
+	if op := nextOp(t, ops); op != "amend-order" {
+		t.Fatalf("op = %s", op)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/trade/amend-order")); n != 0 {
+		t.Fatalf("rest requests = %d", n)
+	}
+}
@@ -0,0 +1,378 @@
+package okx
+
+import (
+	"AxonTrading/exchanges/okx/sdk/api/ws"
+	"AxonTrading/exchanges/okx/sdk/events/public"
+	"AxonTrading/exchanges/okx/sdk/models/market"
+	requests "AxonTrading/exchanges/okx/sdk/requests/ws/public"
+	"AxonTrading/models"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bookChecksumDepth OKX 的 checksum 只覆盖买卖各前 25 档
+const bookChecksumDepth = 25
+
+// incrementalBooks 先推快照再推增量、带 checksum 和 seqId 的频道；books5、bbo-tbt 每次推送全量
+var incrementalBooks = map[string]bool{"books": true, "books-l2-tbt": true, "books50-l2-tbt": true}
+
+var (
+	// ErrBookChecksum 本地订单簿与推送的 checksum 不一致
+	ErrBookChecksum = errors.New("okx: order book checksum mismatch")
+	// ErrBookSequence 增量推送的 prevSeqId 与上一条的 seqId 不连续
+	ErrBookSequence = errors.New("okx: order book sequence gap")
+)
+
+type bookLevel struct {
+	price  models.Decimal
+	px, sz string // 推送中的原始字符串，checksum 按原样计算
+}
+
+// OrderBook 由 OKX 订单簿推送在本地维护的 L2 订单簿，可并发读取
+type OrderBook struct {
+	Channel string
+	InstID  string
+
+	mu      sync.RWMutex
+	bids    []bookLevel // 价格从高到低
+	asks    []bookLevel // 价格从低到高
+	seq     int64
+	ts      int64
+	synced  bool
+	resyncs int
+}
+
+// NewOrderBook channel 为 books、books5、bbo-tbt、books-l2-tbt 或 books50-l2-tbt
+func NewOrderBook(channel, instID string) *OrderBook {
+	return &OrderBook{Channel: channel, InstID: instID}
+}
+
+// Apply 应用一条推送。增量频道在快照到达前忽略更新；seqId 不连续或 checksum 不一致时订单簿失效，
+// 返回 ErrBookSequence / ErrBookChecksum，需要重新订阅拿新快照
+func (b *OrderBook) Apply(e *public.OrderBook) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, d := range e.Books {
+		if err := b.apply(e.Action, d); err != nil {
+			b.invalidate()
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *OrderBook) apply(action string, d *market.OrderBookWs) error {
+	incremental := incrementalBooks[b.Channel]
+	if !incremental || action == "snapshot" {
+		bids, err := parseLevels(d.Bids)
+		if err != nil {
+			return err
+		}
+		asks, err := parseLevels(d.Asks)
+		if err != nil {
+			return err
+		}
+		sort.Slice(bids, func(i, j int) bool { return bids[i].price.GreaterThan(bids[j].price) })
+		sort.Slice(asks, func(i, j int) bool { return asks[i].price.LessThan(asks[j].price) })
+		b.bids, b.asks = bids, asks
+	} else {
+		if !b.synced {
+			return nil
+		}
+		// 没有 seqId 的推送不做连续性检查
+		if d.SeqID != 0 && d.PrevSeqID != b.seq {
+			return fmt.Errorf("%w: %s %s prevSeqId %d, last seqId %d", ErrBookSequence, b.Channel, b.InstID, d.PrevSeqID, b.seq)
+		}
+		for _, ent := range d.Bids {
+			var err error
+			if b.bids, err = upsert(b.bids, ent, true); err != nil {
+				return err
+			}
+		}
+		for _, ent := range d.Asks {
+			var err error
+			if b.asks, err = upsert(b.asks, ent, false); err != nil {
+				return err
+			}
+		}
+	}
+	if incremental {
+		if sum := b.checksum(); sum != int32(d.Checksum) {
+			return fmt.Errorf("%w: %s %s got %d, want %d", ErrBookChecksum, b.Channel, b.InstID, sum, d.Checksum)
+		}
+	}
+	b.seq = d.SeqID
+	b.ts = time.Time(d.TS).UnixMilli()
+	b.synced = true
+	return nil
+}
+
+// invalidate 清空订单簿，等待下一次快照，b.mu 需已加锁
+func (b *OrderBook) invalidate() {
+	b.bids, b.asks = nil, nil
+	b.seq = 0
+	b.synced = false
+	b.resyncs++
+}
+
+func parseLevels(ents []*market.OrderBookEntity) ([]bookLevel, error) {
+	ls := make([]bookLevel, 0, len(ents))
+	for _, ent := range ents {
+		l, err := parseLevel(ent)
+		if err != nil {
+			return nil, err
+		}
+		if !models.ParseDecimalOrZero(l.sz).IsZero() {
+			ls = append(ls, l)
+		}
+	}
+	return ls, nil
+}
+
+func parseLevel(ent *market.OrderBookEntity) (bookLevel, error) {
+	price, err := models.ParseDecimal(ent.Px)
+	if err != nil {
+		return bookLevel{}, fmt.Errorf("okx: order book price %q: %w", ent.Px, err)
+	}
+	return bookLevel{price: price, px: ent.Px, sz: ent.Sz}, nil
+}
+
+// upsert 更新一档，数量为 0 时删除；desc 为 true 时 ls 按价格从高到低排列
+func upsert(ls []bookLevel, ent *market.OrderBookEntity, desc bool) ([]bookLevel, error) {
+	l, err := parseLevel(ent)
+	if err != nil {
+		return ls, err
+	}
+	i := sort.Search(len(ls), func(i int) bool {
+		if desc {
+			return ls[i].price.Cmp(l.price) <= 0
+		}
+		return ls[i].price.Cmp(l.price) >= 0
+	})
+	found := i < len(ls) && ls[i].price.Equal(l.price)
+	switch {
+	case models.ParseDecimalOrZero(l.sz).IsZero():
+		if found {
+			ls = append(ls[:i], ls[i+1:]...)
+		}
+	case found:
+		ls[i] = l
+	default:
+		ls = append(ls, bookLevel{})
+		copy(ls[i+1:], ls[i:])
+		ls[i] = l
+	}
+	return ls, nil
+}
+
+// checksum 买卖前 25 档交替拼成 "bidPx:bidSz:askPx:askSz:..." 后的 CRC32，一侧不足 25 档时只拼另一侧
+func (b *OrderBook) checksum() int32 {
+	var sb strings.Builder
+	for i := 0; i < bookChecksumDepth; i++ {
+		for _, ls := range [][]bookLevel{b.bids, b.asks} {
+			if i < len(ls) {
+				if sb.Len() > 0 {
+					sb.WriteByte(':')
+				}
+				sb.WriteString(ls[i].px)
+				sb.WriteByte(':')
+				sb.WriteString(ls[i].sz)
+			}
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(sb.String())))
+}
+
+// Synced 是否已收到快照且之后的推送都校验通过
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Resyncs 订单簿因校验失败被清空的次数
+func (b *OrderBook) Resyncs() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.resyncs
+}
+
+// Best 买一和卖一，未同步或任一侧为空时 ok 为 false
+func (b *OrderBook) Best() (bid, ask models.PriceLevel, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.bids) == 0 || len(b.asks) == 0 {
+		return bid, ask, false
+	}
+	return priceLevel(b.bids[0]), priceLevel(b.asks[0]), true
+}
+
+// Depth 买卖各前 limit 档，limit <= 0 时返回全部；未同步时返回空
+func (b *OrderBook) Depth(limit int) models.WsData {
+	return b.view(limit, false)
+}
+
+// CumulativeDepth 同 Depth，但每档数量为从最优价累计到该档的总量
+func (b *OrderBook) CumulativeDepth(limit int) models.WsData {
+	return b.view(limit, true)
+}
+
+func (b *OrderBook) view(limit int, cumulative bool) models.WsData {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return models.WsData{}
+	}
+	return models.WsData{
+		Time: b.ts,
+		Bids: priceLevels(b.bids, limit, cumulative),
+		Asks: priceLevels(b.asks, limit, cumulative),
+	}
+}
+
+func priceLevel(l bookLevel) models.PriceLevel {
+	return models.PriceLevel{Price: l.price, Quantity: models.ParseDecimalOrZero(l.sz)}
+}
+
+func priceLevels(ls []bookLevel, limit int, cumulative bool) []models.PriceLevel {
+	if limit <= 0 || limit > len(ls) {
+		limit = len(ls)
+	}
+	out := make([]models.PriceLevel, limit)
+	var sum models.Decimal
+	for i := range out {
+		out[i] = priceLevel(ls[i])
+		if cumulative {
+			sum = sum.Add(out[i].Quantity)
+			out[i].Quantity = sum
+		}
+	}
+	return out
+}
+
+// BookManager 在 OKX 公共 websocket 上订阅订单簿频道并维护本地订单簿，
+// 校验失败时自动重新订阅拿新快照。断线重连后 websocket 客户端重放订阅，快照会覆盖旧数据。
+// 它占用 ws.Public 的订单簿 channel，ws 的 StructuredEventChan 需要有人读取或设为 nil
+type BookManager struct {
+	ws   *ws.ClientWs
+	ch   chan *public.OrderBook
+	done chan struct{}
+
+	mu       sync.Mutex
+	books    map[string]*OrderBook
+	onUpdate func(b *OrderBook)
+}
+
+// NewBookManager 创建并开始处理推送，不再使用时调用 Close
+func NewBookManager(c *ws.ClientWs) *BookManager {
+	m := &BookManager{
+		ws:    c,
+		ch:    make(chan *public.OrderBook),
+		done:  make(chan struct{}),
+		books: make(map[string]*OrderBook),
+	}
+	go m.run()
+	return m
+}
+
+func bookKey(channel, instID string) string {
+	return channel + " " + instID
+}
+
+// Subscribe 订阅并返回订单簿，已订阅时直接返回
+func (m *BookManager) Subscribe(channel, instID string) (*OrderBook, error) {
+	key := bookKey(channel, instID)
+	m.mu.Lock()
+	b, ok := m.books[key]
+	if !ok {
+		b = NewOrderBook(channel, instID)
+		m.books[key] = b
+	}
+	m.mu.Unlock()
+	if ok {
+		return b, nil
+	}
+	if err := m.ws.Public.OrderBook(requests.OrderBook{InstID: instID, Channel: channel}, m.ch); err != nil {
+		m.mu.Lock()
+		delete(m.books, key)
+		m.mu.Unlock()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Unsubscribe 取消订阅，之前返回的 OrderBook 不再更新
+func (m *BookManager) Unsubscribe(channel, instID string) error {
+	m.mu.Lock()
+	delete(m.books, bookKey(channel, instID))
+	m.mu.Unlock()
+	return m.ws.Public.UOrderBook(requests.OrderBook{InstID: instID, Channel: channel})
+}
+
+// Book 已订阅的订单簿，未订阅时返回 nil
+func (m *BookManager) Book(channel, instID string) *OrderBook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.books[bookKey(channel, instID)]
+}
+
+// OnUpdate 设置每次成功应用推送后的回调，在处理推送的 goroutine 中调用，不要阻塞太久
+func (m *BookManager) OnUpdate(fn func(b *OrderBook)) {
+	m.mu.Lock()
+	m.onUpdate = fn
+	m.mu.Unlock()
+}
+
+// Close 停止处理推送，不取消订阅
+func (m *BookManager) Close() {
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
+	}
+}
+
+func (m *BookManager) run() {
+	for {
+		select {
+		case e := <-m.ch:
+			m.handle(e)
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (m *BookManager) handle(e *public.OrderBook) {
+	if e.Arg == nil {
+		return
+	}
+	channel, _ := e.Arg.Get("channel")
+	instID, _ := e.Arg.Get("instId")
+	b := m.Book(fmt.Sprint(channel), fmt.Sprint(instID))
+	if b == nil {
+		return
+	}
+	if err := b.Apply(e); err != nil {
+		m.resync(b)
+		return
+	}
+	m.mu.Lock()
+	fn := m.onUpdate
+	m.mu.Unlock()
+	if fn != nil && b.Synced() {
+		fn(b)
+	}
+}
+
+// resync 重新订阅，交易所会推送新的快照；在此之前的增量会被忽略
+func (m *BookManager) resync(b *OrderBook) {
+	req := requests.OrderBook{InstID: b.InstID, Channel: b.Channel}
+	_ = m.ws.Public.UOrderBook(req)
+	_ = m.ws.Public.OrderBook(req, m.ch)
+}
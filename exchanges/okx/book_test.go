@@ -0,0 +1,191 @@
+package okx
+
+import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api/ws"
+	"AxonTrading/exchanges/okx/sdk/events/public"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func crc(s string) int32 {
+	return int32(crc32.ChecksumIEEE([]byte(s)))
+}
+
+// bookPush OKX 订单簿推送，levels 为 "px:sz" 形式
+func bookPush(channel, action string, seq, prev int64, bids, asks []string, checksum int32) string {
+	side := func(ls []string) string {
+		var out []string
+		for _, l := range ls {
+			p := strings.SplitN(l, ":", 2)
+			out = append(out, fmt.Sprintf(`["%s","%s","0","1"]`, p[0], p[1]))
+		}
+		return "[" + strings.Join(out, ",") + "]"
+	}
+	return fmt.Sprintf(`{"arg":{"channel":%q,"instId":"BTC-USDT"},"action":%q,"data":[{"asks":%s,"bids":%s,"ts":"1700000000000","checksum":%d,"seqId":%d,"prevSeqId":%d}]}`,
+		channel, action, side(asks), side(bids), checksum, seq, prev)
+}
+
+func applyPush(t *testing.T, b *OrderBook, data string) error {
+	t.Helper()
+	e := &public.OrderBook{}
+	if err := json.Unmarshal([]byte(data), e); err != nil {
+		t.Fatal(err)
+	}
+	return b.Apply(e)
+}
+
+func TestOrderBookIncremental(t *testing.T) {
+	b := NewOrderBook("books", "BTC-USDT")
+	// 快照到达前的增量被忽略
+	if err := applyPush(t, b, bookPush("books", "update", 2, 1, []string{"99:1"}, nil, 0)); err != nil || b.Synced() {
+		t.Fatalf("update before snapshot: err = %v synced = %v", err, b.Synced())
+	}
+
+	snap := bookPush("books", "snapshot", 10, -1, []string{"100.5:1.0", "100:2"}, []string{"101:3", "102.50:4"},
+		crc("100.5:1.0:101:3:100:2:102.50:4"))
+	if err := applyPush(t, b, snap); err != nil {
+		t.Fatal(err)
+	}
+	// 删除 100.5，新增 100.8，修改 102.50，新增 101.5
+	update := bookPush("books", "update", 11, 10, []string{"100.5:0", "100.8:0.5"}, []string{"102.50:1", "101.5:2"},
+		crc("100.8:0.5:101:3:100:2:101.5:2:102.50:1"))
+	if err := applyPush(t, b, update); err != nil {
+		t.Fatal(err)
+	}
+
+	bid, ask, ok := b.Best()
+	if !ok || bid.Price.String() != "100.8" || ask.Price.String() != "101" || ask.Quantity.String() != "3" {
+		t.Fatalf("best = %+v %+v %v", bid, ask, ok)
+	}
+	d := b.Depth(2)
+	if len(d.Bids) != 2 || len(d.Asks) != 2 || d.Asks[1].Price.String() != "101.5" || d.Time != 1700000000000 {
+		t.Fatalf("depth = %+v", d)
+	}
+	cum := b.CumulativeDepth(0)
+	if len(cum.Asks) != 3 || cum.Asks[2].Quantity.String() != "6" || cum.Bids[1].Quantity.String() != "2.5" {
+		t.Fatalf("cumulative = %+v", cum)
+	}
+}
+
+func TestOrderBookInvalidates(t *testing.T) {
+	b := NewOrderBook("books", "BTC-USDT")
+	snap := bookPush("books", "snapshot", 10, -1, []string{"100:1"}, []string{"101:1"}, crc("100:1:101:1"))
+	if err := applyPush(t, b, snap); err != nil {
+		t.Fatal(err)
+	}
+	err := applyPush(t, b, bookPush("books", "update", 11, 10, []string{"100:2"}, nil, crc("100:1:101:1")))
+	if !errors.Is(err, ErrBookChecksum) || b.Synced() || b.Resyncs() != 1 {
+		t.Fatalf("checksum err = %v synced = %v", err, b.Synced())
+	}
+	if d := b.Depth(5); len(d.Bids) != 0 {
+		t.Fatalf("depth of invalid book = %+v", d)
+	}
+
+	if err := applyPush(t, b, snap); err != nil {
+		t.Fatal(err)
+	}
+	err = applyPush(t, b, bookPush("books", "update", 13, 12, []string{"100:2"}, nil, crc("100:2:101:1")))
+	if !errors.Is(err, ErrBookSequence) || b.Synced() || b.Resyncs() != 2 {
+		t.Fatalf("sequence err = %v synced = %v", err, b.Synced())
+	}
+}
+
+func TestOrderBookSnapshotChannels(t *testing.T) {
+	b := NewOrderBook("books5", "BTC-USDT")
+	for _, px := range []string{"100", "99"} {
+		if err := applyPush(t, b, bookPush("books5", "", 0, 0, []string{px + ":1"}, []string{"101:1"}, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := b.Depth(0)
+	if len(d.Bids) != 1 || d.Bids[0].Price.String() != "99" {
+		t.Fatalf("books5 depth = %+v", d)
+	}
+}
+
+func TestBookManagerResubscribes(t *testing.T) {
+	snap := bookPush("books", "snapshot", 10, -1, []string{"100:1"}, []string{"101:1"}, crc("100:1:101:1"))
+	subs := make(chan string, 10)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		n := 0
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				Op string `json:"op"`
+			}
+			_ = json.Unmarshal(data, &req)
+			if req.Op == "" {
+				continue
+			}
+			subs <- req.Op
+			if req.Op != "subscribe" {
+				continue
+			}
+			n++
+			// 第一次订阅后推一条错误 checksum 的增量，重新订阅后推新快照
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(snap))
+			if n == 1 {
+				bad := bookPush("books", "update", 11, 10, []string{"100:2"}, nil, 1)
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(bad))
+			}
+		}
+	}))
+	defer srv.Close()
+
+	u := sdk.BaseURL("ws" + strings.TrimPrefix(srv.URL, "http"))
+	c := ws.NewClient(context.Background(), "", "", "", map[bool]sdk.BaseURL{false: u})
+	c.StructuredEventChan = nil
+	defer c.Cancel()
+	m := NewBookManager(c)
+	defer m.Close()
+
+	b, err := m.Subscribe("books", "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ops []string
+	for len(ops) < 3 {
+		select {
+		case op := <-subs:
+			ops = append(ops, op)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ops = %v", ops)
+		}
+	}
+	if fmt.Sprint(ops) != "[subscribe unsubscribe subscribe]" {
+		t.Fatalf("ops = %v", ops)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !b.Synced() {
+		if time.Now().After(deadline) {
+			t.Fatal("book not resynced")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if b.Resyncs() != 1 {
+		t.Fatalf("resyncs = %d", b.Resyncs())
+	}
+	if bid, _, _ := b.Best(); bid.Quantity.String() != "1" {
+		t.Fatalf("best bid = %+v", bid)
+	}
+}
@@ -2,21 +2,31 @@ package okx
 
 import (
 	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api"
+	"AxonTrading/exchanges/okx/sdk/api/rest"
+	"AxonTrading/exchanges/okx/sdk/models/market"
+	"AxonTrading/exchanges/okx/sdk/models/publicdata"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/account"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/funding"
+	marketreq "AxonTrading/exchanges/okx/sdk/requests/rest/market"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/public"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/trade"
+	traderes "AxonTrading/exchanges/okx/sdk/responses/trade"
+	"AxonTrading/instrument"
 	"AxonTrading/models"
+	"AxonTrading/ratelimit"
+	"AxonTrading/retry"
 	"AxonTrading/tools"
-	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/bitly/go-simplejson"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -26,50 +36,42 @@ type Client struct {
 	SecretKey string
 	Password  string
 	Client    *http.Client
+	// Instruments 合约注册表，nil 时按命名规则转换统一符号，可通过 LoadInstruments 加载
+	Instruments *instrument.Registry
+	// Limiter 请求限速，New/NewFuture 按 params 中的 rateLimit 创建，nil 时不限速
+	Limiter *ratelimit.Limiter
+	// Retry 下单重试策略，零值使用 retry.DefaultPolicy
+	Retry retry.Policy
+	// WsPublicURL / WsPrivateURL 推送订阅使用的地址，New/NewFuture 读取 params 中的 wsPublicUrl、wsPrivateUrl，为空时使用生产地址
+	WsPublicURL  string
+	WsPrivateURL string
+	// WsTrade 下单、撤单先经 websocket 发送，不可用时改走 REST，New/NewFuture 读取 params 中的 wsTrade
+	WsTrade bool
+
+	mu     sync.Mutex
+	loader instrument.Loader // 第一次下单前加载 Instruments
+	api    *api.Client
+	apiCfg apiConfig
+	stream *streamer
 }
 
-type convertCoin struct {
-	InstId string `json:"instId"`
-	Px     string `json:"px"`
-	Sz     string `json:"sz"`
-	Type   string `json:"type"`
-	Unit   string `json:"unit"`
+// apiConfig 创建 SDK 客户端时使用的字段
+type apiConfig struct {
+	baseURL, apiKey, secretKey, passphrase string
+	client                                 *http.Client
+	limiter                                *ratelimit.Limiter
 }
 
-func (c *Client) convertContractCoin(typ, symbol, size string) (convertCoin, error) {
-	param := map[string]string{"type": typ, "instId": symbol, "sz": size, "coin": "coin"}
-	url := "/api/v5/public/convert-contract-coin"
-	resp, err := c.do(http.MethodGet, url, false, param)
+// convertContractCoin 币和张数的换算，typ 为 1 时 size 为币数，返回的 Sz 为张数
+func (c *Client) convertContractCoin(typ, symbol, size string) (*publicdata.ContractCoin, error) {
+	resp, err := c.API().Rest.PublicData.ConvertContractCoin(public.ConvertContractCoin{Type: typ, InstID: symbol, Sz: size, Unit: "coin"})
 	if err != nil {
-		return convertCoin{}, err
+		return nil, restError(err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return convertCoin{}, errors.New("http request error" + string(rune(resp.StatusCode)))
+	if resp.Code != 0 || len(resp.ContractCoins) == 0 {
+		return nil, sdkError(resp.Basic)
 	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return convertCoin{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			InstId string `json:"instId"`
-			Px     string `json:"px"`
-			Sz     string `json:"sz"`
-			Type   string `json:"type"`
-			Unit   string `json:"unit"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return convertCoin{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return convertCoin{}, errors.New(bodyMarshal.Msg)
-	}
-	rstData := bodyMarshal.Data[0]
-	return convertCoin{InstId: rstData.InstId, Px: rstData.Px, Sz: rstData.Sz, Type: rstData.Type, Unit: rstData.Unit}, nil
+	return resp.ContractCoins[0], nil
 }
 
 func (c *Client) NewFuture(params []byte) error {
@@ -92,126 +94,57 @@ func (c *Client) NewFuture(params []byte) error {
 	c.AccessKey = apiKey
 	c.SecretKey = secretKey
 	c.Password = password
+	c.WsPublicURL = sj.Get("wsPublicUrl").MustString()
+	c.WsPrivateURL = sj.Get("wsPrivateUrl").MustString()
+	c.WsTrade = sj.Get("wsTrade").MustBool()
 
-	return nil
+	c.Limiter, err = newLimiter(params)
+	return err
 }
 
+// ChangeMarginType OKX 没有按币对设置的保证金模式，全仓/逐仓由每笔订单的 tdMode（positionType）决定
 func (c *Client) ChangeMarginType(symbol, typ string) error {
-	//TODO implement me
-	panic("implement me")
+	return base.ErrNotSupported
 }
 
 func (c *Client) ChangePositionMargin(symbol, positionSide, amount string, typ int) (bool, error) {
-	url := "/api/v5/account/position/margin-balance"
-	param := map[string]string{"instId": symbol + "-SWAP", "posSide": "", "amt": amount, "type": ""}
+	req := account.IncreaseDecreaseMargin{InstID: c.swapID(symbol), Amt: amount}
 	if typ == base.ADDMARGIN {
-		param["type"] = "add"
+		req.ActionType = sdk.CountIncrease
 	} else if typ == base.REMOVEMARGIN {
-		param["type"] = "reduce"
+		req.ActionType = sdk.CountDecrease
 	}
 	if positionSide == base.LONG {
-		param["posSide"] = "long"
+		req.PosSide = sdk.PositionLongSide
 	} else if positionSide == base.SHORT {
-		param["posSide"] = "short"
-	}
-	paramByte, _ := json.Marshal(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return false, err
+		req.PosSide = sdk.PositionShortSide
 	}
-	if resp.StatusCode != http.StatusOK {
-		return false, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Amt      string `json:"amt"`
-			Ccy      string `json:"ccy"`
-			InstId   string `json:"instId"`
-			Leverage string `json:"leverage"`
-			PosSide  string `json:"posSide"`
-			Type     string `json:"type"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
+	resp, err := c.API().Rest.Account.IncreaseDecreaseMargin(req)
 	if err != nil {
-		return false, err
+		return false, restError(err)
 	}
-	if bodyMarshal.Code != "0" {
-		return false, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 {
+		return false, sdkError(resp.Basic)
 	}
 	return true, nil
 }
 
+// HttpErr 只有状态码时非 200 响应的错误，429 等状态码会映射为对应的错误分类；读到响应体时用 httpError，保留 OKX 的 code 和 msg
 func HttpErr(code int) error {
-	return errors.New(fmt.Sprintf("http request err code:%v", code))
+	return httpError(code, nil)
 }
 
 func (c *Client) GetFutureBalance() (models.FutureBalance, error) {
-	url := "/api/v5/account/balance"
-	//asset := "USDT"
-	param := map[string]string{}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Account.GetBalance(account.GetBalance{})
 	if err != nil {
-		return models.FutureBalance{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.FutureBalance{}, HttpErr(resp.StatusCode)
+		return models.FutureBalance{}, restError(err)
 	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.FutureBalance{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			AdjEq   string `json:"adjEq"`
-			Details []struct {
-				AvailBal      string `json:"availBal"`
-				AvailEq       string `json:"availEq"`
-				CashBal       string `json:"cashBal"`
-				Ccy           string `json:"ccy"`
-				CrossLiab     string `json:"crossLiab"`
-				DisEq         string `json:"disEq"`
-				Eq            string `json:"eq"`
-				EqUsd         string `json:"eqUsd"`
-				FrozenBal     string `json:"frozenBal"`
-				Interest      string `json:"interest"`
-				IsoEq         string `json:"isoEq"`
-				IsoLiab       string `json:"isoLiab"`
-				IsoUpl        string `json:"isoUpl"`
-				Liab          string `json:"liab"`
-				MaxLoan       string `json:"maxLoan"`
-				MgnRatio      string `json:"mgnRatio"`
-				NotionalLever string `json:"notionalLever"`
-				OrdFrozen     string `json:"ordFrozen"`
-				Twap          string `json:"twap"`
-				UTime         string `json:"uTime"`
-				Upl           string `json:"upl"`
-				UplLiab       string `json:"uplLiab"`
-				StgyEq        string `json:"stgyEq"`
-				SpotInUseAmt  string `json:"spotInUseAmt"`
-			} `json:"details"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(body, &bodyMarshal)
-	if err != nil {
-		return models.FutureBalance{}, err
+	if resp.Code != 0 || len(resp.Balances) == 0 {
+		return models.FutureBalance{}, sdkError(resp.Basic)
 	}
-	if bodyMarshal.Code != "0" {
-		return models.FutureBalance{}, errors.New(bodyMarshal.Msg)
-	}
-	for _, v := range bodyMarshal.Data[0].Details {
+	for _, v := range resp.Balances[0].Details {
 		if v.Ccy == "USDT" {
-			return models.FutureBalance{Asset: v.Ccy, TotalBalance: v.CashBal, CrossBalance: "", AvailableBalance: v.AvailBal}, nil
+			return models.FutureBalance{Asset: v.Ccy, TotalBalance: decimalOf(v.CashBal), CrossBalance: models.Decimal{}, AvailableBalance: decimalOf(v.AvailBal)}, nil
 		}
 	}
 	return models.FutureBalance{}, errors.New("USDT NOT FOUND")
@@ -220,259 +153,161 @@ func (c *Client) GetFutureBalance() (models.FutureBalance, error) {
 // FutureDepth
 // Example: c.FutureDepth("BTC-USDT", "5")
 func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
-	url := "/api/v5/market/books"
-	param := map[string]string{"instId": symbol + "-SWAP", "sz": limit}
-	resp, err := c.do(http.MethodGet, url, false, param)
-	defer resp.Body.Close()
-	if err != nil {
-		return models.WsData{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.WsData{}, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
+	book, err := c.orderBook(c.swapID(symbol), limit)
 	if err != nil {
 		return models.WsData{}, err
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Asks [][]string `json:"asks"`
-			Bids [][]string `json:"bids"`
-			Ts   string     `json:"ts"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return models.WsData{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return models.WsData{}, errors.New(bodyMarshal.Msg)
-	}
 	var rst models.WsData
-	rst.Time, _ = strconv.ParseInt(bodyMarshal.Data[0].Ts, 10, 64)
-	for _, v := range bodyMarshal.Data[0].Asks {
-		rst.Asks = append(rst.Asks, models.PriceLevel{Price: v[0], Quantity: v[3]})
+	rst.Time = millis(book.TS)
+	for _, v := range book.Asks {
+		rst.Asks = append(rst.Asks, models.PriceLevel{Price: models.ParseDecimalOrZero(v.Px), Quantity: models.ParseDecimalOrZero(v.Sz)})
 	}
-	for _, v := range bodyMarshal.Data[0].Bids {
-		rst.Bids = append(rst.Bids, models.PriceLevel{Price: v[0], Quantity: v[3]})
+	for _, v := range book.Bids {
+		rst.Bids = append(rst.Bids, models.PriceLevel{Price: models.ParseDecimalOrZero(v.Px), Quantity: models.ParseDecimalOrZero(v.Sz)})
 	}
 	return rst, nil
 }
 
-func (c *Client) GetFutureMarketPrice(symbol string) (string, error) {
-	url := "/api/v5/market/ticker"
-	param := map[string]string{"instId": symbol + "-SWAP"}
-	resp, err := c.do(http.MethodGet, url, false, param)
-	defer resp.Body.Close()
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return "", HttpErr(resp.StatusCode)
+// orderBook /market/books 的深度快照，limit 为档数，为空时取交易所默认
+func (c *Client) orderBook(instID, limit string) (*market.OrderBook, error) {
+	req := marketreq.GetOrderBook{InstID: instID}
+	if limit != "" {
+		sz, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: depth limit %q", models.ErrInvalidRequest, limit)
+		}
+		req.Sz = sz
 	}
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := c.API().Rest.Market.GetOrderBook(req)
 	if err != nil {
-		return "", err
+		return nil, restError(err)
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Last string `json:"last"`
-		} `json:"data"`
+	if resp.Code != 0 || len(resp.OrderBooks) == 0 {
+		return nil, sdkError(resp.Basic)
 	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
+	return resp.OrderBooks[0], nil
+}
+
+func (c *Client) GetFutureMarketPrice(symbol string) (string, error) {
+	return c.lastPrice(c.swapID(symbol))
+}
+
+// lastPrice /market/ticker 的最新成交价
+func (c *Client) lastPrice(instID string) (string, error) {
+	resp, err := c.API().Rest.Market.GetTicker(marketreq.GetTicker{InstID: instID})
 	if err != nil {
-		return "", err
+		return "", restError(err)
 	}
-	if bodyMarshal.Code != "0" {
-		return "", errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 || len(resp.Tickers) == 0 {
+		return "", sdkError(resp.Basic)
 	}
-	return bodyMarshal.Data[0].Last, nil
+	return decimalOf(resp.Tickers[0].Last).String(), nil
 }
 
 func (c *Client) GetFundingRate(symbol string) (models.FundingRate, error) {
-	url := "/api/v5/public/funding-rate"
-	param := map[string]string{"instId": symbol + "-SWAP"}
-	resp, err := c.do(http.MethodGet, url, false, param)
-	defer resp.Body.Close()
-	if err != nil {
-		return models.FundingRate{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.FundingRate{}, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.FundingRate{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			FundingRate     string `json:"fundingRate"`
-			FundingTime     string `json:"fundingTime"`
-			InstId          string `json:"instId"`
-			InstType        string `json:"instType"`
-			NextFundingRate string `json:"nextFundingRate"`
-			NextFundingTime string `json:"nextFundingTime"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
+	resp, err := c.API().Rest.PublicData.GetFundingRate(public.GetFundingRate{InstID: c.swapID(symbol)})
 	if err != nil {
-		return models.FundingRate{}, err
+		return models.FundingRate{}, restError(err)
 	}
-	if bodyMarshal.Code != "0" {
-		return models.FundingRate{}, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 || len(resp.FundingRates) == 0 {
+		return models.FundingRate{}, sdkError(resp.Basic)
 	}
-	var rst models.FundingRate
-	NextFundingTime, err := strconv.ParseInt(bodyMarshal.Data[0].NextFundingTime, 10, 64)
-	rst = models.FundingRate{LastFundingRate: bodyMarshal.Data[0].FundingRate, NextFundingTime: NextFundingTime}
-	return rst, nil
+	r := resp.FundingRates[0]
+	return models.FundingRate{LastFundingRate: decimalOf(r.FundingRate), NextFundingTime: millis(r.NextFundingTime)}, nil
 }
 
 func (c *Client) GetMarkPriceAndFundingRate(symbol string) (models.FundingRate, error) {
-	url := "/api/v5/public/mark-price"
-	param := map[string]string{"instId": symbol + "-SWAP", "instType": "SWAP"}
-	resp, err := c.do(http.MethodGet, url, false, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.PublicData.GetMarkPrice(public.GetMarkPrice{InstID: c.swapID(symbol), InstType: sdk.SwapInstrument})
 	if err != nil {
-		return models.FundingRate{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.FundingRate{}, HttpErr(resp.StatusCode)
+		return models.FundingRate{}, restError(err)
 	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.FundingRate{}, err
+	if resp.Code != 0 || len(resp.MarkPrices) == 0 {
+		return models.FundingRate{}, sdkError(resp.Basic)
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			MarkPrice string `json:"markPx"`
-			InstId    string `json:"instId"`
-			InstType  string `json:"instType"`
-			Ts        string `json:"ts"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return models.FundingRate{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return models.FundingRate{}, errors.New(bodyMarshal.Msg)
-	}
-	MarkPrice := bodyMarshal.Data[0].MarkPrice
-	Symbol := bodyMarshal.Data[0].InstId
-	t, err := strconv.ParseInt(bodyMarshal.Data[0].Ts, 10, 64)
-	if err != nil {
-		return models.FundingRate{}, err
-	}
-	var rst models.FundingRate
+	mark := resp.MarkPrices[0]
 	partFundingData, err := c.GetFundingRate(symbol)
 	if err != nil {
 		return models.FundingRate{}, err
 	}
-	rst = models.FundingRate{MarkPrice: MarkPrice, Symbol: Symbol, LastFundingRate: partFundingData.LastFundingRate, NextFundingTime: partFundingData.NextFundingTime, Time: t}
-	return rst, nil
+	return models.FundingRate{
+		MarkPrice: decimalOf(mark.MarkPx), Symbol: echoSymbol(symbol, mark.InstID), LastFundingRate: partFundingData.LastFundingRate,
+		NextFundingTime: partFundingData.NextFundingTime, Time: millis(mark.TS),
+	}, nil
 }
 
 func (c *Client) Dual(dualSize bool) (bool, error) {
-	url := "/api/v5/account/set-position-mode"
-	var posMode string
+	posMode := sdk.PositionNetMode
 	if dualSize {
-		posMode = "long_short_mode"
-	} else {
-		posMode = "net_mode"
+		posMode = sdk.PositionLongShortMode
 	}
-	param := map[string]string{"posMode": posMode}
-	paramByte, _ := json.Marshal(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Account.SetPositionMode(account.SetPositionMode{PosMode: posMode})
 	if err != nil {
-		return false, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return false, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			PosMode string `json:"posMode"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return false, err
+		return false, restError(err)
 	}
-	if bodyMarshal.Code != "0" {
-		return false, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 || len(resp.PositionModes) == 0 {
+		return false, sdkError(resp.Basic)
 	}
-	return bodyMarshal.Data[0].PosMode == posMode, nil
+	return resp.PositionModes[0].PosMode == posMode, nil
 }
 
 func (c *Client) CheckDual() (bool, error) {
-	url := "/api/v5/account/config"
-	param := map[string]string{}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
-	if err != nil {
-		return false, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return false, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := c.API().Rest.Account.GetConfig()
 	if err != nil {
-		return false, err
+		return false, restError(err)
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			PosMode string `json:"posMode"`
-		} `json:"data"`
+	if resp.Code != 0 || len(resp.Configs) == 0 {
+		return false, sdkError(resp.Basic)
 	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
+	return resp.Configs[0].PosMode == sdk.PositionLongShortMode, nil
+}
+
+// contractSize 以币计的数量换算成合约下单的 sz
+func (c *Client) contractSize(instID, size string) (string, error) {
+	coin, err := c.convertContractCoin("1", instID, size)
 	if err != nil {
-		return false, err
-	}
-	if bodyMarshal.Code != "0" {
-		return false, errors.New(bodyMarshal.Msg)
+		return "", err
 	}
-	return bodyMarshal.Data[0].PosMode == "long_short_mode", nil
+	return models.ParseDecimalOrZero(coin.Sz).Mul(models.NewDecimalFromInt(10)).Truncate(8).String(), nil
 }
 
 // NewFutureOrder 下单
 func (c *Client) NewFutureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool) (string, error) {
-	coin, err := c.convertContractCoin("1", symbol+"-SWAP", size)
+	return c.futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType, closePosition, "")
+}
+
+// NewFutureOrderWithClientID 与 NewFutureOrder 相同，使用调用方指定的 clOrdId。止损、止盈单走策略委托，不支持指定
+func (c *Client) NewFutureOrderWithClientID(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool, clientID string) (string, error) {
+	return c.futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType, closePosition, clientID)
+}
+
+// futureOrder 永续和交割合约下单，clientID 为空时自动生成
+func (c *Client) futureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition bool, clientID string) (string, error) {
+	if futureStopTypes[typ] {
+		if clientID != "" {
+			return "", fmt.Errorf("%w: client order id on okx %s order", base.ErrNotSupported, typ)
+		}
+		return c.futureStopOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType, closePosition)
+	}
+	instID := c.swapID(symbol)
+	price, size, err := c.quantizeOrder(positionKinds[instType(instID)], instID, typ, price, size)
+	if err != nil {
+		return "", err
+	}
+	Newsize, err := c.contractSize(instID, size)
 	if err != nil {
 		return "", err
 	}
 
-	Newsize := coin.Sz
-	url := "/api/v5/trade/order"
-	NewsizeFloat, _ := strconv.ParseFloat(Newsize, 64)
-	Newsize = strconv.FormatFloat(NewsizeFloat*10, 'f', 8, 64)
-
-	var orderSide, pSide, orderType, pType string
+	o := PlaceOrder{InstID: instID, ClOrdID: clientID, Sz: Newsize}
 	if positionType == base.ISOLATED {
-		pType = "isolated"
+		o.TdMode = "isolated"
 	} else if positionType == base.CROSSED {
-		pType = "cross"
+		o.TdMode = "cross"
 	}
 	if side == base.BID {
-		orderSide = "buy"
+		o.Side = "buy"
 	} else if side == base.ASK {
-		orderSide = "sell"
+		o.Side = "sell"
 	}
 	dual, err := c.CheckDual()
 	if err != nil {
@@ -480,272 +315,99 @@ func (c *Client) NewFutureOrder(symbol, side, positionSide, typ, size, price, st
 	}
 
 	if dual == true && positionSide == base.LONG {
-		pSide = "long"
+		o.PosSide = "long"
 	} else if dual == true && positionSide == base.SHORT {
-		pSide = "short"
+		o.PosSide = "short"
 	} else if dual == false {
-		pSide = "net"
+		o.PosSide = "net"
 	}
-	param := map[string]string{"instId": symbol + "-SWAP", "side": orderSide, "posSide": pSide, "sz": Newsize, "tdMode": pType}
 
 	if typ == base.LIMIT {
-		orderType = "limit"
-		param["ordType"] = orderType
-		param["px"] = price
-
+		o.OrdType = "limit"
+		o.Px = price
 	} else if typ == base.MARKET {
-		orderType = "market"
-		param["ordType"] = orderType
-	} else if typ == base.STOP {
-		orderType = "limit"
-		param["ordType"] = orderType
-		param["slOrdPx"] = price
-		param["slTriggerPx"] = stopPrice
-		param["px"] = price
-
-	} else if typ == base.STOPMARKET {
-		orderType = "limit"
-		param["ordType"] = orderType
-		param["slOrdPx"] = "-1"
-		param["slTriggerPx"] = stopPrice
-		param["px"] = price
-
-	} else if typ == base.TAKEPROFIT {
-		orderType = "limit"
-		param["ordType"] = orderType
-		param["tpOrdPx"] = price
-		param["tpTriggerPx"] = stopPrice
-		param["px"] = price
-
-	} else if typ == base.TAKEPROFITMARKET {
-		orderType = "limit"
-		param["ordType"] = orderType
-		param["tpOrdPx"] = "-1"
-		param["tpTriggerPx"] = stopPrice
-		param["px"] = price
-
-	}
-
-	paramByte, _ := json.Marshal(param)
-	fmt.Println(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return "", err
+		o.OrdType = "market"
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	fmt.Println(string(respBody))
-	if err != nil {
-		return "", err
+	if o.ClOrdID == "" {
+		o.ClOrdID = retry.ClientOrderID()
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			ClOrdId string `json:"clOrdId"`
-			OrdId   string `json:"ordId"`
-			Tag     string `json:"tag"`
-			SCode   string `json:"sCode"`
-			SMsg    string `json:"sMsg"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return "", err
+	place := func() (string, error) {
+		resp, err := c.placeOrdersRest([]PlaceOrder{o})
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Data) == 0 {
+			return "", responseError(http.StatusOK, resp)
+		}
+		return resp.Data[0].OrdId, nil
 	}
-	if bodyMarshal.Code != "0" {
-		return "", errors.New(bodyMarshal.Msg)
+	lookup := func() (string, bool, error) {
+		return c.orderIDByClient(o.InstID, o.ClOrdID)
 	}
-	return bodyMarshal.Data[0].OrdId, nil
+	return retry.Order(context.Background(), c.Retry, place, lookup)
 }
 
 func (c *Client) GetFutureOrder(symbol, orderID string) (models.FutureOrderInfo, error) {
-	url := "/api/v5/trade/order"
-	// TODO: instId 确认
-	param := map[string]string{"instId": symbol + "-SWAP", "ordId": orderID}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Trade.GetOrderDetail(requests.OrderDetails{InstID: c.swapID(symbol), OrdID: orderID})
 	if err != nil {
-		return models.FutureOrderInfo{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.FutureOrderInfo{}, HttpErr(resp.StatusCode)
+		return models.FutureOrderInfo{}, restError(err)
 	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.FutureOrderInfo{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			InstType           string `json:"instType"`
-			InstId             string `json:"instId"`
-			Ccy                string `json:"ccy"`
-			OrdId              string `json:"ordId"`
-			ClOrdId            string `json:"clOrdId"`
-			Tag                string `json:"tag"`
-			Px                 string `json:"px"`
-			Sz                 string `json:"sz"`
-			Pnl                string `json:"pnl"`
-			OrdType            string `json:"ordType"`
-			Side               string `json:"side"`
-			PosSide            string `json:"posSide"`
-			TdMode             string `json:"tdMode"`
-			AccFillSz          string `json:"accFillSz"`
-			FillPx             string `json:"fillPx"`
-			TradeId            string `json:"tradeId"`
-			FillSz             string `json:"fillSz"`
-			FillTime           string `json:"fillTime"`
-			Source             string `json:"source"`
-			State              string `json:"state"`
-			AvgPx              string `json:"avgPx"`
-			Lever              string `json:"lever"`
-			AttachAlgoClOrdId  string `json:"attachAlgoClOrdId"`
-			TpTriggerPx        string `json:"tpTriggerPx"`
-			TpTriggerPxType    string `json:"tpTriggerPxType"`
-			TpOrdPx            string `json:"tpOrdPx"`
-			SlTriggerPx        string `json:"slTriggerPx"`
-			SlTriggerPxType    string `json:"slTriggerPxType"`
-			SlOrdPx            string `json:"slOrdPx"`
-			StpId              string `json:"stpId"`
-			StpMode            string `json:"stpMode"`
-			FeeCcy             string `json:"feeCcy"`
-			Fee                string `json:"fee"`
-			RebateCcy          string `json:"rebateCcy"`
-			Rebate             string `json:"rebate"`
-			TgtCcy             string `json:"tgtCcy"`
-			Category           string `json:"category"`
-			ReduceOnly         string `json:"reduceOnly"`
-			CancelSource       string `json:"cancelSource"`
-			CancelSourceReason string `json:"cancelSourceReason"`
-			QuickMgnType       string `json:"quickMgnType"`
-			AlgoClOrdId        string `json:"algoClOrdId"`
-			AlgoId             string `json:"algoId"`
-			UTime              string `json:"uTime"`
-			CTime              string `json:"cTime"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return models.FutureOrderInfo{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return models.FutureOrderInfo{}, errors.New(bodyMarshal.Msg)
-	}
-	bodyMarshalData := bodyMarshal.Data[0]
-	var rst models.FutureOrderInfo
-	ordID, err := strconv.Atoi(bodyMarshalData.OrdId)
-	if err != nil {
-		return models.FutureOrderInfo{}, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 || len(resp.Orders) == 0 {
+		return models.FutureOrderInfo{}, sdkError(resp.Basic)
 	}
-	uTime, err := strconv.ParseInt(bodyMarshalData.UTime, 10, 64)
+	o := resp.Orders[0]
+	ordID, err := strconv.Atoi(o.OrdID)
 	if err != nil {
-		return models.FutureOrderInfo{}, errors.New(bodyMarshal.Msg)
-	}
-	fillTime, err := strconv.ParseInt(bodyMarshalData.FillTime, 10, 64)
-
-	var ReduceOnly bool
-	if bodyMarshalData.ReduceOnly == "true" {
-		ReduceOnly = true
-	} else {
-		ReduceOnly = false
-	}
-	var side, posSide, state, orderType string
-	if bodyMarshalData.Side == "buy" {
-		side = base.BID
-	} else if bodyMarshalData.Side == "sell" {
-		side = base.ASK
-	}
-
-	if bodyMarshalData.PosSide == "long" {
-		posSide = base.LONG
-	} else if bodyMarshalData.PosSide == "short" {
-		posSide = base.SHORT
-	}
-
-	if bodyMarshalData.State == "filled" {
-		state = base.FILLED
-	} else if bodyMarshalData.State == "canceled" {
-		state = base.CANCELED
-	} else if bodyMarshalData.State == "partially_filled" {
-		state = base.PARTIALLY
-	} else if bodyMarshalData.State == "live" {
-		state = base.OPEN
-	}
-
-	if bodyMarshalData.OrdType == "market" {
-		orderType = base.MARKET
-	} else if bodyMarshalData.OrdType == "limit" {
-		orderType = base.LIMIT
-	} else if bodyMarshalData.OrdType == "post_only" {
-		orderType = base.MAKER
-	} else if bodyMarshalData.OrdType == "limit" && bodyMarshalData.SlTriggerPx != "" && bodyMarshalData.SlTriggerPx != "-1" {
-		orderType = base.STOP
-
-	} else if bodyMarshalData.OrdType == "limit" && bodyMarshalData.SlTriggerPx == "-1" {
-		orderType = base.STOPMARKET
-
-	} else if bodyMarshalData.OrdType == "limit" && bodyMarshalData.TpTriggerPx != "" && bodyMarshalData.TpTriggerPx != "-1" {
-		orderType = base.TAKEPROFIT
-
-	} else if bodyMarshalData.OrdType == "limit" && bodyMarshalData.TpTriggerPx == "-1" {
-		orderType = base.TAKEPROFITMARKET
-
+		return models.FutureOrderInfo{}, fmt.Errorf("%w: okx order id %q", base.ErrResponse, o.OrdID)
 	}
+	return futureOrderInfo(symbol, ordID, o), nil
+}
 
-	rst = models.FutureOrderInfo{
-		AvgPrice: bodyMarshalData.AvgPx, OrderId: ordID, Status: state,
-		UpdateTime: uTime, Type: orderType, Side: side,
-		Symbol: bodyMarshalData.InstId, Price: bodyMarshalData.Px, Time: fillTime,
-		PositionSide: posSide, ReduceOnly: ReduceOnly, StopPrice: bodyMarshalData.TpTriggerPx,
+// futureOrderInfo 永续订单，Side 为 base.BID / base.ASK
+func futureOrderInfo(symbol string, ordID int, o *trade.Order) models.FutureOrderInfo {
+	return models.FutureOrderInfo{
+		AvgPrice: decimalOf(o.AvgPx), OrderId: ordID, Status: orderState(string(o.State)),
+		UpdateTime: millis(o.UTime), Type: orderType(string(o.OrdType)), Side: orderSide(string(o.Side)),
+		Symbol: echoSymbol(symbol, o.InstID), Price: decimalOf(o.Px), Time: int64(o.FillTime),
+		PositionSide: positionSide(string(o.PosSide)), ReduceOnly: o.ReduceOnly == "true", StopPrice: decimalOf(o.TpTriggerPx),
 		ClosePosition: false, PriceProtect: false,
 	}
-	return rst, nil
 }
 
 func (c *Client) CancelFutureOrder(symbol, orderID string) (bool, error) {
-	url := "/api/v5/trade/cancel-order"
-	param := map[string]string{"instId": symbol + "-SWAP", "ordId": orderID}
-	paramByte, err := json.Marshal(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
+	if _, err := c.cancelOrderWs(c.swapID(symbol), orderID); !errors.Is(err, errWsUnavailable) {
+		// code 为 0 时单个撤单的 sCode 也为 0
+		return err == nil, err
+	}
+	if err := c.cancelOrders([]requests.CancelOrder{{InstID: c.swapID(symbol), OrdID: orderID}}); err != nil {
 		return false, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return false, HttpErr(resp.StatusCode)
+	return true, nil
+}
+
+// cancelOrders 撤销一批订单（不超过 20 个），单个订单走 /trade/cancel-order，多个走 /trade/cancel-batch-orders。
+// 任一订单的 sCode 不为 0 时返回第一个失败订单的错误
+func (c *Client) cancelOrders(req []requests.CancelOrder) error {
+	if len(req) == 0 {
+		return nil
 	}
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := c.API().Rest.Trade.CandleOrder(req)
 	if err != nil {
-		return false, err
+		return restError(err)
 	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			ClOrdId string `json:"clOrdId"`
-			OrdId   string `json:"ordId"`
-			SCode   string `json:"sCode"`
-			SMsg    string `json:"sMsg"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return false, err
+	if len(resp.CancelOrders) == 0 {
+		return sdkError(resp.Basic)
 	}
-	if bodyMarshal.Code != "0" {
-		return false, errors.New(bodyMarshal.Msg)
+	for _, d := range resp.CancelOrders {
+		if d.SCode != 0 {
+			return resultError(resp.Basic, d.SCode, d.SMsg)
+		}
 	}
-	if bodyMarshal.Data[0].SCode == "0" {
-		return true, nil
-	} else {
-		return false, errors.New(bodyMarshal.Data[0].SMsg)
+	if resp.Code != 0 {
+		return sdkError(resp.Basic)
 	}
+	return nil
 }
 
 func (c *Client) CancelFutureOrders(symbol string) error {
@@ -753,610 +415,174 @@ func (c *Client) CancelFutureOrders(symbol string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(cancelOrders)
-	param := make([]map[string]string, 0, len(cancelOrders))
-	for _, v := range cancelOrders {
-		param = append(param, map[string]string{"instId": symbol + "-SWAP", "ordId": strconv.Itoa(v.OrderId)})
-	}
-	url := "/api/v5/trade/cancel-batch-orders"
-	paramByte, err := json.Marshal(param)
-	fmt.Println(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(respBody))
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			ClOrdId string `json:"clOrdId"`
-			OrdId   string `json:"ordId"`
-			SCode   string `json:"sCode"`
-			SMsg    string `json:"sMsg"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return err
-	}
-	if bodyMarshal.Code != "0" {
-		return errors.New(bodyMarshal.Msg)
-	}
-	if bodyMarshal.Data[0].SCode == "0" {
-		return nil
-	} else {
-		return errors.New(bodyMarshal.Data[0].SMsg)
+	for i := 0; i < len(cancelOrders); i += 20 {
+		var req []requests.CancelOrder
+		for _, v := range cancelOrders[i:min(i+20, len(cancelOrders))] {
+			req = append(req, requests.CancelOrder{InstID: c.swapID(symbol), OrdID: strconv.Itoa(v.OrderId)})
+		}
+		if err := c.cancelOrders(req); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func (c *Client) GetFutureOpenOrders(symbol string) ([]models.FutureOrderInfo, error) {
-	url := "/api/v5/trade/orders-pending"
-	param := map[string]string{"instId": symbol + "-SWAP", "instType": "SWAP"}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Trade.GetOrderList(requests.OrderList{InstID: c.swapID(symbol), InstType: sdk.SwapInstrument})
 	if err != nil {
-		return []models.FutureOrderInfo{}, err
+		return []models.FutureOrderInfo{}, restError(err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return []models.FutureOrderInfo{}, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return []models.FutureOrderInfo{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			AccFillSz         string `json:"accFillSz"`
-			AvgPx             string `json:"avgPx"`
-			CTime             string `json:"cTime"`
-			Category          string `json:"category"`
-			Ccy               string `json:"ccy"`
-			ClOrdId           string `json:"clOrdId"`
-			Fee               string `json:"fee"`
-			FeeCcy            string `json:"feeCcy"`
-			FillPx            string `json:"fillPx"`
-			FillSz            string `json:"fillSz"`
-			FillTime          string `json:"fillTime"`
-			InstId            string `json:"instId"`
-			InstType          string `json:"instType"`
-			Lever             string `json:"lever"`
-			OrdId             string `json:"ordId"`
-			OrdType           string `json:"ordType"`
-			Pnl               string `json:"pnl"`
-			PosSide           string `json:"posSide"`
-			Px                string `json:"px"`
-			Rebate            string `json:"rebate"`
-			RebateCcy         string `json:"rebateCcy"`
-			Side              string `json:"side"`
-			AttachAlgoClOrdId string `json:"attachAlgoClOrdId"`
-			SlOrdPx           string `json:"slOrdPx"`
-			SlTriggerPx       string `json:"slTriggerPx"`
-			SlTriggerPxType   string `json:"slTriggerPxType"`
-			Source            string `json:"source"`
-			State             string `json:"state"`
-			StpId             string `json:"stpId"`
-			StpMode           string `json:"stpMode"`
-			Sz                string `json:"sz"`
-			Tag               string `json:"tag"`
-			TdMode            string `json:"tdMode"`
-			TgtCcy            string `json:"tgtCcy"`
-			TpOrdPx           string `json:"tpOrdPx"`
-			TpTriggerPx       string `json:"tpTriggerPx"`
-			TpTriggerPxType   string `json:"tpTriggerPxType"`
-			TradeId           string `json:"tradeId"`
-			ReduceOnly        string `json:"reduceOnly"`
-			QuickMgnType      string `json:"quickMgnType"`
-			AlgoClOrdId       string `json:"algoClOrdId"`
-			AlgoId            string `json:"algoId"`
-			UTime             string `json:"uTime"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return []models.FutureOrderInfo{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return []models.FutureOrderInfo{}, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 {
+		return []models.FutureOrderInfo{}, sdkError(resp.Basic)
 	}
 
 	var rst = make([]models.FutureOrderInfo, 0, 10)
-	var ReduceOnly bool
-	var side, posSide, state, orderType string
-	for _, v := range bodyMarshal.Data {
-		ordID, err := strconv.Atoi(v.OrdId)
+	for _, v := range resp.Orders {
+		ordID, err := strconv.Atoi(v.OrdID)
 		if err != nil {
 			continue
 		}
-		uTime, err := strconv.ParseInt(v.UTime, 10, 64)
-		if err != nil {
-			continue
-		}
-		if v.ReduceOnly == "true" {
-			ReduceOnly = true
+		info := futureOrderInfo(symbol, ordID, v)
+		if v.Side == sdk.OrderBuy {
+			info.Side = base.UnifiedBuy
 		} else {
-			ReduceOnly = false
-		}
-		fillTime, err := strconv.ParseInt(v.FillTime, 10, 64)
-		if v.Side == "buy" {
-			side = base.UnifiedBuy
-		} else if v.Side == "sell" {
-			side = base.UnifiedSell
-		}
-		if v.PosSide == "long" {
-			posSide = base.LONG
-		} else if v.PosSide == "short" {
-			posSide = base.SHORT
-		}
-
-		if v.State == "filled" {
-			state = base.FILLED
-		} else if v.State == "canceled" {
-			state = base.CANCELED
-		} else if v.State == "partially_filled" {
-			state = base.PARTIALLY
-		} else if v.State == "live" {
-			state = base.OPEN
-		}
-
-		if v.OrdType == "market" {
-			orderType = base.MARKET
-		} else if v.OrdType == "limit" {
-			orderType = base.LIMIT
-		} else if v.OrdType == "post_only" {
-			orderType = base.MAKER
-		} else if v.OrdType == "limit" && v.SlTriggerPx != "" && v.SlTriggerPx != "-1" {
-			orderType = base.STOP
-
-		} else if v.OrdType == "limit" && v.SlTriggerPx == "-1" {
-			orderType = base.STOPMARKET
-
-		} else if v.OrdType == "limit" && v.TpTriggerPx != "" && v.TpTriggerPx != "-1" {
-			orderType = base.TAKEPROFIT
-
-		} else if v.OrdType == "limit" && v.TpTriggerPx == "-1" {
-			orderType = base.TAKEPROFITMARKET
-
+			info.Side = base.UnifiedSell
 		}
-
-		// TODO:订单状态常量确认 OrdType,State
-		rst = append(rst, models.FutureOrderInfo{
-			AvgPrice: v.AvgPx, OrderId: ordID, Status: state, UpdateTime: uTime,
-			Type: orderType, Side: side, Symbol: v.InstId, Price: v.Px, Time: fillTime,
-			PositionSide: posSide, ReduceOnly: ReduceOnly, StopPrice: v.TpTriggerPx,
-			ClosePosition: false, PriceProtect: false,
-		})
+		rst = append(rst, info)
 	}
 	return rst, nil
 }
 
+// ChangeLeverage 同时设置全仓和逐仓多空的杠杆
 func (c *Client) ChangeLeverage(symbol string, leverage int) (string, error) {
-	url := "/api/v5/account/set-leverage"
-	param := map[string]string{"instId": symbol + "-SWAP", "lever": strconv.Itoa(leverage), "mgnMode": "cross"}
-	paramByte, _ := json.Marshal(param)
-	resp, err := c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return "", HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Lever   string `json:"lever"`
-			MgnMode string `json:"mgnMode"`
-			InstId  string `json:"instId"`
-			PosSide string `json:"posSide"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return "", err
-	}
-	if bodyMarshal.Code != "0" {
-		return "", errors.New(bodyMarshal.Msg)
-	}
-
-	param = map[string]string{"instId": symbol + "-SWAP", "lever": strconv.Itoa(leverage), "mgnMode": "isolated", "posSide": "long"}
-	paramByte, _ = json.Marshal(param)
-	resp, err = c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return "", HttpErr(resp.StatusCode)
-	}
-	respBody, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return "", err
-	}
-	if bodyMarshal.Code != "0" {
-		return "", errors.New(bodyMarshal.Msg)
-	}
-
-	param = map[string]string{"instId": symbol + "-SWAP", "lever": strconv.Itoa(leverage), "mgnMode": "isolated", "posSide": "short"}
-	paramByte, _ = json.Marshal(param)
-	resp, err = c.doPost(http.MethodPost, url, true, paramByte)
-	defer resp.Body.Close()
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return "", HttpErr(resp.StatusCode)
-	}
-	respBody, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return "", err
-	}
-	if bodyMarshal.Code != "0" {
-		return "", errors.New(bodyMarshal.Msg)
+	reqs := []account.SetLeverage{
+		{InstID: c.swapID(symbol), Lever: int64(leverage), MgnMode: sdk.MarginCrossMode},
+		{InstID: c.swapID(symbol), Lever: int64(leverage), MgnMode: sdk.MarginIsolatedMode, PosSide: sdk.PositionLongSide},
+		{InstID: c.swapID(symbol), Lever: int64(leverage), MgnMode: sdk.MarginIsolatedMode, PosSide: sdk.PositionShortSide},
+	}
+	var lever string
+	for _, req := range reqs {
+		resp, err := c.API().Rest.Account.SetLeverage(req)
+		if err != nil {
+			return "", restError(err)
+		}
+		if resp.Code != 0 || len(resp.Leverages) == 0 {
+			return "", sdkError(resp.Basic)
+		}
+		lever = decimalOf(resp.Leverages[0].Lever).String()
 	}
-	return bodyMarshal.Data[0].Lever, nil
+	return lever, nil
 }
 
 func (c *Client) GetPositionRisk(symbol string) ([]models.PositionInfo, error) {
-	url := "/api/v5/account/positions"
-	param := map[string]string{"instId": symbol + "-SWAP"}
-	//param := map[string]string{}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Account.GetPositions(account.GetPositions{InstID: []string{c.swapID(symbol)}})
 	if err != nil {
-		return []models.PositionInfo{}, err
+		return []models.PositionInfo{}, restError(err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return []models.PositionInfo{}, HttpErr(resp.StatusCode)
-	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return []models.PositionInfo{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Adl            string `json:"adl"`
-			AvailPos       string `json:"availPos"`
-			AvgPx          string `json:"avgPx"`
-			CTime          string `json:"cTime"`
-			Ccy            string `json:"ccy"`
-			DeltaBS        string `json:"deltaBS"`
-			DeltaPA        string `json:"deltaPA"`
-			GammaBS        string `json:"gammaBS"`
-			GammaPA        string `json:"gammaPA"`
-			Imr            string `json:"imr"`
-			InstId         string `json:"instId"`
-			InstType       string `json:"instType"`
-			Interest       string `json:"interest"`
-			IdxPx          string `json:"idxPx"`
-			Last           string `json:"last"`
-			UsdPx          string `json:"usdPx"`
-			Lever          string `json:"lever"`
-			Liab           string `json:"liab"`
-			LiabCcy        string `json:"liabCcy"`
-			LiqPx          string `json:"liqPx"`
-			MarkPx         string `json:"markPx"`
-			Margin         string `json:"margin"`
-			MgnMode        string `json:"mgnMode"`
-			MgnRatio       string `json:"mgnRatio"`
-			Mmr            string `json:"mmr"`
-			NotionalUsd    string `json:"notionalUsd"`
-			OptVal         string `json:"optVal"`
-			PTime          string `json:"pTime"`
-			Pos            string `json:"pos"`
-			PosCcy         string `json:"posCcy"`
-			PosId          string `json:"posId"`
-			PosSide        string `json:"posSide"`
-			SpotInUseAmt   string `json:"spotInUseAmt"`
-			SpotInUseCcy   string `json:"spotInUseCcy"`
-			ThetaBS        string `json:"thetaBS"`
-			ThetaPA        string `json:"thetaPA"`
-			TradeId        string `json:"tradeId"`
-			BizRefId       string `json:"bizRefId"`
-			BizRefType     string `json:"bizRefType"`
-			QuoteBal       string `json:"quoteBal"`
-			BaseBal        string `json:"baseBal"`
-			BaseBorrowed   string `json:"baseBorrowed"`
-			BaseInterest   string `json:"baseInterest"`
-			QuoteBorrowed  string `json:"quoteBorrowed"`
-			QuoteInterest  string `json:"quoteInterest"`
-			UTime          string `json:"uTime"`
-			Upl            string `json:"upl"`
-			UplLastPx      string `json:"uplLastPx"`
-			UplRatio       string `json:"uplRatio"`
-			UplRatioLastPx string `json:"uplRatioLastPx"`
-			VegaBS         string `json:"vegaBS"`
-			VegaPA         string `json:"vegaPA"`
-			CloseOrderAlgo []struct {
-				AlgoId          string `json:"algoId"`
-				SlTriggerPx     string `json:"slTriggerPx"`
-				SlTriggerPxType string `json:"slTriggerPxType"`
-				TpTriggerPx     string `json:"tpTriggerPx"`
-				TpTriggerPxType string `json:"tpTriggerPxType"`
-				CloseFraction   string `json:"closeFraction"`
-			} `json:"closeOrderAlgo"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return []models.PositionInfo{}, err
-	}
-	if bodyMarshal.Code != "0" {
-		return []models.PositionInfo{}, errors.New(bodyMarshal.Msg)
+	if resp.Code != 0 {
+		return []models.PositionInfo{}, sdkError(resp.Basic)
 	}
 	var rst = make([]models.PositionInfo, 0, 20)
-	var mgnMode, posSide string
-	for _, v := range bodyMarshal.Data {
-		uTime, _ := strconv.ParseInt(v.UTime, 10, 64)
-		if v.MgnMode == "cross" {
-			mgnMode = base.CROSSED
-		} else if v.MgnMode == "isolated" {
-			mgnMode = base.ISOLATED
-		}
-
-		if v.PosSide == "long" {
-			posSide = base.LONG
-		} else if v.PosSide == "short" {
-			posSide = base.SHORT
-		}
-		rst = append(rst, models.PositionInfo{
-			Symbol: v.InstId, MarkPrice: v.MarkPx, MarginType: mgnMode,
-			Leverage: v.Lever, UnRealizedProfit: v.Upl, UpdateTime: uTime,
-			LiquidationPrice: v.LiqPx, IsolatedMargin: v.Imr, EntryPrice: v.AvgPx,
-			PositionAmt: v.Pos, PositionSide: posSide, Notional: "", IsAutoAddMargin: "",
-			IsolatedWallet: "", MaxNotionalValue: "",
-		})
+	for _, v := range resp.Positions {
+		rst = append(rst, positionInfo(symbol, v))
 	}
 	return rst, nil
 }
 
 func (c *Client) GetFutureTradingFee(symbol string) (models.TradingFee, error) {
-	url := "/api/v5/account/trade-fee"
-	param := map[string]string{"instType": "SWAP"}
-	resp, err := c.do(http.MethodGet, url, true, param)
-	defer resp.Body.Close()
+	resp, err := c.API().Rest.Account.GetFeeRates(account.GetFeeRates{InstType: sdk.SwapInstrument})
 	if err != nil {
-		return models.TradingFee{}, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return models.TradingFee{}, HttpErr(resp.StatusCode)
+		return models.TradingFee{}, restError(err)
 	}
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.TradingFee{}, err
-	}
-	var bodyMarshal struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Delivery  string `json:"delivery"`
-			Exercise  string `json:"exercise"`
-			InstType  string `json:"instType"`
-			Level     string `json:"level"`
-			Maker     string `json:"maker"`
-			MakerU    string `json:"makerU"`
-			MakerUSDC string `json:"makerUSDC"`
-			Taker     string `json:"taker"`
-			TakerU    string `json:"takerU"`
-			TakerUSDC string `json:"takerUSDC"`
-			Ts        string `json:"ts"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(respBody, &bodyMarshal)
-	if err != nil {
-		return models.TradingFee{}, err
+	if resp.Code != 0 || len(resp.Fees) == 0 {
+		return models.TradingFee{}, sdkError(resp.Basic)
 	}
-	if bodyMarshal.Code != "0" {
-		return models.TradingFee{}, errors.New(bodyMarshal.Msg)
-	}
-	return models.TradingFee{Symbol: symbol, TakerFeeFromApi: bodyMarshal.Data[0].TakerU, MakerFeeFromApi: bodyMarshal.Data[0].MakerU}, nil
+	fee := models.TradingFee{Symbol: symbol, TakerFeeFromApi: decimalOf(resp.Fees[0].TakerU), MakerFeeFromApi: decimalOf(resp.Fees[0].MakerU)}
+	c.realFees(&fee, c.swapID(symbol))
+	return fee, nil
 }
 
 func (c *Client) GetDepositAddress(token, chain string) (string, error) {
-	p := "/api/v5/asset/deposit-address"
-	m := make(map[string]string)
-
-	m["ccy"] = token
-
-	res, err := c.do(http.MethodGet, p, true, m)
+	resp, err := c.API().Rest.Funding.GetDepositAddress(funding.GetDepositAddress{Ccy: token})
 	if err != nil {
-		return "", err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+		return "", restError(err)
 	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Data []struct {
-			Chain    string `json:"chain"`
-			CtAddr   string `json:"ctAddr"`
-			Ccy      string `json:"ccy"`
-			To       string `json:"to"`
-			Addr     string `json:"addr"`
-			Selected bool   `json:"selected"`
-		} `json:"data"`
-		Msg string `json:"msg"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	if resp.Code != 0 {
+		return "", sdkError(resp.Basic)
 	}
-	for _, add := range response.Data {
-		if add.Chain == token+"-"+chain && add.To == "18" {
-			return add.Addr, err
+	// to 为 18 的是交易账户的充值地址
+	for _, add := range resp.DepositAddresses {
+		if add.Chain == token+"-"+chain && add.To == sdk.AccountType(18) {
+			return add.Addr, nil
 		}
 	}
-	return "", err
+	return "", nil
 }
 
+// Withdraw 从资金账户链上提币，chain 不带币种前缀（如 TRC20），返回提币 id。
+// 资金账户可用余额不够数量加手续费时，先从交易账户划入差额
 func (c *Client) Withdraw(token, chain, to, amount string) (string, error) {
-	c.InnerTrans(token, amount, "18", "6")
-	fee, err := c.CurrencyInfo(token, chain)
+	d, err := models.RequireDecimal("amount", amount)
 	if err != nil {
 		return "", err
 	}
-
-	p := "/api/v5/asset/withdrawal"
-	m := make(map[string]string)
-
-	m["ccy"] = token
-	m["amt"] = amount
-	m["dest"] = "4"
-	m["toAddr"] = to
-	m["fee"] = fee
-	m["chain"] = token + "-" + chain
-
-	res, err := c.do(http.MethodPost, p, true, m)
+	info, err := c.chainInfo(context.Background(), token, chain)
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	avail, err := c.fundingAvailable(token)
+	if err != nil {
+		return "", err
 	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Amt      string `json:"amt"`
-			WdId     string `json:"wdId"`
-			Ccy      string `json:"ccy"`
-			ClientId string `json:"clientId"`
-			Chain    string `json:"chain"`
-		} `json:"data"`
+	if need := d.Add(info.WithdrawFee); avail.LessThan(need) {
+		if _, err := c.InnerTrans(token, need.Sub(avail).String(), "18", "6"); err != nil {
+			return "", err
+		}
 	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	w, err := c.SubmitWithdrawal(context.Background(), models.WithdrawRequest{Currency: token, Chain: chain, Address: to, Amount: d})
+	if err != nil {
+		return "", err
 	}
-
-	return response.Data[0].WdId, err
+	return w.WithdrawID, nil
 }
 
-func (c *Client) CurrencyInfo(token, chain string) (string, error) {
-	p := "/api/v5/asset/currencies"
-	m := make(map[string]string)
-
-	m["ccy"] = token
-
-	res, err := c.do(http.MethodGet, p, true, m)
+// fundingAvailable 资金账户中 token 的可用余额
+func (c *Client) fundingAvailable(token string) (models.Decimal, error) {
+	resp, err := c.API().Rest.Funding.GetBalance(funding.GetBalance{Ccy: []string{token}})
 	if err != nil {
-		return "", err
+		return models.Decimal{}, restError(err)
+	}
+	if resp.Code != 0 {
+		return models.Decimal{}, sdkError(resp.Basic)
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			CanDep               bool   `json:"canDep"`
-			CanInternal          bool   `json:"canInternal"`
-			CanWd                bool   `json:"canWd"`
-			Ccy                  string `json:"ccy"`
-			Chain                string `json:"chain"`
-			DepQuotaFixed        string `json:"depQuotaFixed"`
-			DepQuoteDailyLayer2  string `json:"depQuoteDailyLayer2"`
-			LogoLink             string `json:"logoLink"`
-			MainNet              bool   `json:"mainNet"`
-			MaxFee               string `json:"maxFee"`
-			MaxWd                string `json:"maxWd"`
-			MinDep               string `json:"minDep"`
-			MinDepArrivalConfirm string `json:"minDepArrivalConfirm"`
-			MinFee               string `json:"minFee"`
-			MinWd                string `json:"minWd"`
-			MinWdUnlockConfirm   string `json:"minWdUnlockConfirm"`
-			Name                 string `json:"name"`
-			NeedTag              bool   `json:"needTag"`
-			UsedDepQuotaFixed    string `json:"usedDepQuotaFixed"`
-			UsedWdQuota          string `json:"usedWdQuota"`
-			WdQuota              string `json:"wdQuota"`
-			WdTickSz             string `json:"wdTickSz"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-	for _, info := range response.Data {
-		if info.Chain == token+"-"+chain {
-			return info.MinFee, err
+	for _, b := range resp.Balances {
+		if b.Ccy == token {
+			return models.ParseDecimalOrZero(b.AvailBal), nil
 		}
 	}
-
-	return "", err
+	return models.Decimal{}, nil
 }
 
-func (c *Client) InnerTrans(token, amount, from, to string) (string, error) {
-	p := "/api/v5/asset/transfer"
-	m := make(map[string]string)
-
-	m["ccy"] = token
-	m["amt"] = amount
-	m["from"] = from
-	m["to"] = to
-
-	res, err := c.do(http.MethodPost, p, true, m)
+// CurrencyInfo 链上最低提币手续费，完整的充提信息见 GetChains
+func (c *Client) CurrencyInfo(token, chain string) (string, error) {
+	info, err := c.chainInfo(context.Background(), token, chain)
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
+	return info.WithdrawFee.String(), nil
+}
 
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+// InnerTrans 账户间划转，from、to 为 OKX 账户类型（6 资金账户，18 交易账户），返回 transId
+func (c *Client) InnerTrans(token, amount, from, to string) (string, error) {
+	f, err := strconv.ParseUint(from, 10, 8)
+	if err != nil {
+		return "", fmt.Errorf("%w: account %q", models.ErrInvalidRequest, from)
 	}
-
-	return "", err
+	t, err := strconv.ParseUint(to, 10, 8)
+	if err != nil {
+		return "", fmt.Errorf("%w: account %q", models.ErrInvalidRequest, to)
+	}
+	return c.fundsTransfer(funding.FundsTransfer{Ccy: token, Amt: amount, From: sdk.AccountType(f), To: sdk.AccountType(t)})
 }
 
 func (c *Client) New(params []byte) error {
@@ -1379,154 +605,73 @@ func (c *Client) New(params []byte) error {
 	c.AccessKey = apiKey
 	c.SecretKey = secretKey
 	c.Password = password
+	c.WsPublicURL = sj.Get("wsPublicUrl").MustString()
+	c.WsPrivateURL = sj.Get("wsPrivateUrl").MustString()
+	c.WsTrade = sj.Get("wsTrade").MustBool()
 
-	return nil
+	c.Limiter, err = newLimiter(params)
+	return err
 }
 
-// Do the http request to the server
-func (c *Client) do(method, path string, private bool, params ...map[string]string) (*http.Response, error) {
-	u := fmt.Sprintf("%s%s", c.BaseUrl, path)
-	var (
-		r    *http.Request
-		err  error
-		j    []byte
-		body string
-	)
-	if method == http.MethodGet {
-		r, err = http.NewRequest(http.MethodGet, u, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(params) > 0 {
-			q := r.URL.Query()
-			for k, v := range params[0] {
-				q.Add(k, strings.ReplaceAll(v, "\"", ""))
-			}
-			r.URL.RawQuery = q.Encode()
-			if len(params[0]) > 0 {
-				path += "?" + r.URL.RawQuery
-			}
-		}
-	} else {
-		j, err = json.Marshal(params[0])
-		if err != nil {
-			return nil, err
-		}
-		body = string(j)
-		if body == "{}" {
-			body = ""
-		}
-		r, err = http.NewRequest(method, u, bytes.NewBuffer(j))
-		if err != nil {
-			return nil, err
-		}
-		r.Header.Add("Content-Type", "application/json")
-	}
-	if err != nil {
-		return nil, err
-	}
-	if private {
-		timestamp, sign := c.sign(method, path, body)
-		r.Header.Add("OK-ACCESS-KEY", c.AccessKey)
-		r.Header.Add("OK-ACCESS-PASSPHRASE", c.Password)
-		r.Header.Add("OK-ACCESS-SIGN", sign)
-		r.Header.Add("OK-ACCESS-TIMESTAMP", timestamp)
-		//r.Header.Add("x-simulated-trading", "1")
-	}
-	return c.Client.Do(r)
+// API 返回 OKX SDK 客户端，REST 请求都经由 API().Rest 签名发送。
+// 按 BaseUrl、密钥、Client 和 Limiter 创建，这些字段改变后重新创建
+func (c *Client) API() *api.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cfg := apiConfig{c.BaseUrl, c.AccessKey, c.SecretKey, c.Password, c.Client, c.Limiter}
+	if c.api != nil && c.apiCfg == cfg {
+		return c.api
+	}
+	// NewClientWithURL 只分配结构体，不会返回错误
+	c.api, _ = api.NewClientWithURL(context.Background(), c.AccessKey, c.SecretKey, c.Password, sdk.BaseURL(c.BaseUrl), sdk.NormalServer)
+	c.api.Rest.SetHTTPClient(c.httpClient())
+	c.apiCfg = cfg
+	return c.api
 }
 
-func (c *Client) doPost(method, path string, private bool, params []byte) (*http.Response, error) {
-	u := fmt.Sprintf("%s%s", c.BaseUrl, path)
-	var (
-		r    *http.Request
-		err  error
-		body string
-	)
-	body = string(params)
-	fmt.Println(body)
-	if body == "{}" {
-		body = ""
-	}
-	r, err = http.NewRequest(method, u, bytes.NewBuffer(params))
-	if err != nil {
-		return nil, err
-	}
-	r.Header.Add("Content-Type", "application/json")
+// rest 带 ctx 的 REST 客户端，ctx 结束时取消请求和限速等待
+func (c *Client) rest(ctx context.Context) *rest.ClientRest {
+	return c.API().Rest.WithContext(ctx)
+}
 
-	if err != nil {
-		return nil, err
+// httpClient 在 c.Client 的基础上加上限速
+func (c *Client) httpClient() *http.Client {
+	hc := http.Client{}
+	if c.Client != nil {
+		hc = *c.Client
 	}
-	if private {
-		timestamp, sign := c.sign(method, path, body)
-		r.Header.Add("OK-ACCESS-KEY", c.AccessKey)
-		r.Header.Add("OK-ACCESS-PASSPHRASE", c.Password)
-		r.Header.Add("OK-ACCESS-SIGN", sign)
-		r.Header.Add("OK-ACCESS-TIMESTAMP", timestamp)
-		//r.Header.Add("x-simulated-trading", "1")
-
+	if c.Limiter != nil {
+		next := hc.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		hc.Transport = &limitTransport{c: c, next: next}
 	}
-	return c.Client.Do(r)
-}
-
-func (c *Client) sign(method, path, body string) (string, string) {
-	format := "2006-01-02T15:04:05.999Z07:00"
-	t := time.Now().UTC().Format(format)
-	ts := fmt.Sprint(t)
-	s := ts + method + path + body
-	p := []byte(s)
-	h := hmac.New(sha256.New, []byte(c.SecretKey))
-	h.Write(p)
-	return ts, base64.StdEncoding.EncodeToString(h.Sum(nil))
+	return &hc
 }
 
 func (c *Client) GetAccountBalance(currency string) ([]string, error) {
-	p := "/api/v5/account/balance"
-	m := make(map[string]string)
-
+	var req account.GetBalance
 	if len(currency) > 0 {
-		m["ccy"] = currency
+		req.Ccy = []string{currency}
 	}
-	res, err := c.do(http.MethodGet, p, true, m)
+	resp, err := c.API().Rest.Account.GetBalance(req)
 	if err != nil {
-		return []string{"0", "0", "0"}, err
+		return []string{"0", "0", "0"}, restError(err)
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return []string{"0", "0", "0"}, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	if resp.Code != 0 {
+		return []string{"0", "0", "0"}, sdkError(resp.Basic)
 	}
 
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Data []struct {
-			Details []struct {
-				AvailBal  string `json:"availBal"`
-				CashBal   string `json:"cashBal"`
-				Ccy       string `json:"ccy"`
-				FrozenBal string `json:"frozenBal"`
-			} `json:"details"`
-			UTime string `json:"uTime"`
-		} `json:"data"`
-		Msg string `json:"msg"`
-	}
-	err = d.Decode(&response)
-	if response.Code != "0" {
-		data, _ := ioutil.ReadAll(res.Body)
-		return []string{"0", "0", "0"}, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	var bs []string
-	if len(response.Data) > 0 && len(response.Data[0].Details) > 0 {
-		bs = append(bs, response.Data[0].Details[0].AvailBal, response.Data[0].Details[0].FrozenBal, response.Data[0].Details[0].CashBal)
-		return bs, nil
-	} else {
-		return []string{"0", "0", "0"}, fmt.Errorf("not found the currency")
+	// 余额为零的币种不在 details 中
+	for _, data := range resp.Balances {
+		for _, d := range data.Details {
+			if d.Ccy == currency {
+				return []string{decimalOf(d.AvailBal).String(), decimalOf(d.FrozenBal).String(), decimalOf(d.CashBal).String()}, nil
+			}
+		}
 	}
+	return []string{"0", "0", "0"}, nil
 }
 
 type (
@@ -1546,15 +691,16 @@ type (
 		TgtCcy     string `json:"tgtCcy,omitempty"`
 	}
 	PlaceOrderResp struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			ClOrdId string `json:"clOrdId"`
-			OrdId   string `json:"ordId"`
-			Tag     string `json:"tag"`
-			SCode   string `json:"sCode"`
-			SMsg    string `json:"sMsg"`
-		} `json:"data"`
+		Code string           `json:"code"`
+		Msg  string           `json:"msg"`
+		Data []PlaceOrderData `json:"data"`
+	}
+	PlaceOrderData struct {
+		ClOrdId string `json:"clOrdId"`
+		OrdId   string `json:"ordId"`
+		Tag     string `json:"tag"`
+		SCode   string `json:"sCode"`
+		SMsg    string `json:"sMsg"`
 	}
 	CancelR struct {
 		ID     string `json:"-"`
@@ -1563,41 +709,164 @@ type (
 	}
 )
 
+// placeOrder 下单，单个订单走 /trade/order，多个走 /trade/batch-orders。
+// 没有 ClOrdID 的订单会生成一个。被明确拒绝且可以重发的订单按 c.Retry 重新提交；结果不确定的订单不再提交，
+// 按 clOrdId 轮询对账，期限内查不到返回 base.ErrUnknownStatus。部分失败时 response 仍包含已经挂上的订单
 func (c *Client) placeOrder(req []PlaceOrder) (response PlaceOrderResp, err error) {
-	p := "/api/v5/trade/order"
-	var tmp interface{}
-	tmp = req[0]
-	m, err := json.Marshal(tmp)
-	if len(req) > 1 {
-		tmp = req
-		p = "/api/v5/trade/batch-orders"
-	}
-	// m := tool.S2M(tmp)
-
-	res, err := c.doPost(http.MethodPost, p, true, m)
-	// res, err := c.do(http.MethodPost, p, true, m)
-	if err != nil {
-		return
+	for i := range req {
+		if req[i].ClOrdID == "" {
+			req[i].ClOrdID = retry.ClientOrderID()
+		}
+	}
+	var (
+		placed   = make(map[string]string, len(req))
+		pending  = req
+		unknown  []PlaceOrder
+		cause    error // 结果不确定的原因
+		rejected error // 被明确拒绝的订单的错误
+	)
+	err = retry.Do(context.Background(), c.Retry, func() error {
+		resp, err := c.submitOrders(pending)
+		if err == nil {
+			for i, d := range resp.Data {
+				placed[clOrdID(pending, i, d)] = d.OrdId
+			}
+			pending = nil
+			return nil
+		}
+		if len(resp.Data) == 0 {
+			// 整个请求失败
+			if retry.IsAmbiguous(err) || errors.Is(err, base.ErrDuplicateOrder) {
+				unknown, cause, pending = append(unknown, pending...), err, nil
+				return nil
+			}
+			return err
+		}
+		// 部分失败，按每个订单的 sCode 处理
+		codes := make(map[string]PlaceOrderData, len(resp.Data))
+		for i, d := range resp.Data {
+			codes[clOrdID(pending, i, d)] = d
+		}
+		var retryable []PlaceOrder
+		var transient error
+		for _, o := range pending {
+			d, ok := codes[o.ClOrdID]
+			if ok && d.SCode == "0" {
+				placed[o.ClOrdID] = d.OrdId
+				continue
+			}
+			oerr := err
+			if ok {
+				oerr = apiError(http.StatusOK, d.SCode, d.SMsg, nil)
+			}
+			switch {
+			case retry.IsAmbiguous(oerr) || errors.Is(oerr, base.ErrDuplicateOrder):
+				unknown, cause = append(unknown, o), oerr
+			case retry.IsTransient(oerr):
+				retryable, transient = append(retryable, o), oerr
+			default:
+				rejected = oerr
+			}
+		}
+		pending = retryable
+		return transient
+	})
+	if err == nil {
+		err = rejected
+	}
+
+	if len(unknown) > 0 {
+		_, rerr := retry.Reconcile(context.Background(), c.Retry, func() (string, bool, error) {
+			var rest []PlaceOrder
+			for _, o := range unknown {
+				if ordID, found, lerr := c.orderIDByClient(o.InstID, o.ClOrdID); lerr == nil && found {
+					placed[o.ClOrdID] = ordID
+				} else {
+					rest = append(rest, o)
+				}
+			}
+			unknown = rest
+			return "", len(unknown) == 0, nil
+		}, cause)
+		if rerr != nil {
+			err = rerr
+		}
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
 
-	if res.StatusCode != http.StatusOK {
-		// data, _ := ioutil.ReadAll(res.Body)
-		err = fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, res)
-		return
+	response.Code = "0"
+	for _, o := range req {
+		if ordID, ok := placed[o.ClOrdID]; ok {
+			response.Data = append(response.Data, PlaceOrderData{ClOrdId: o.ClOrdID, OrdId: ordID, Tag: o.Tag, SCode: "0"})
+		}
 	}
+	return response, err
+}
 
-	err = d.Decode(&response)
+// clOrdID 返回结果对应订单的 clOrdId。结果按请求的顺序返回，没有回显 clOrdId 时按位置对应
+func clOrdID(req []PlaceOrder, i int, d PlaceOrderData) string {
+	if d.ClOrdId == "" && i < len(req) {
+		return req[i].ClOrdID
+	}
+	return d.ClOrdId
+}
 
-	if response.Code != "0" {
-		err = fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response.Data)
+// submitOrders 提交一次订单，WsTrade 时先经 websocket 提交，没有发出时走 REST
+func (c *Client) submitOrders(req []PlaceOrder) (response PlaceOrderResp, err error) {
+	if response, err = c.submitOrdersWs(req); !errors.Is(err, errWsUnavailable) {
 		return
 	}
+	return c.placeOrdersRest(req)
+}
 
+// placeOrdersRest 经 REST 提交一次订单，code 不为 0 时按第一个失败订单的 sCode 返回错误，response 仍包含每个订单的结果
+func (c *Client) placeOrdersRest(req []PlaceOrder) (response PlaceOrderResp, err error) {
+	orders := make([]requests.PlaceOrder, 0, len(req))
+	for _, o := range req {
+		orders = append(orders, requests.PlaceOrder{
+			InstID: o.InstID, Ccy: o.Ccy, ClOrdID: o.ClOrdID, Tag: o.Tag, ReduceOnly: o.ReduceOnly, Sz: o.Sz, Px: o.Px,
+			TdMode: sdk.TradeMode(o.TdMode), Side: sdk.OrderSide(o.Side), PosSide: sdk.PositionSide(o.PosSide),
+			OrdType: sdk.OrderType(o.OrdType), TgtCcy: sdk.QuantityType(o.TgtCcy),
+		})
+	}
+	resp, err := c.API().Rest.Trade.PlaceOrder(orders)
+	if err != nil {
+		return response, restError(err)
+	}
+	response = PlaceOrderResp{Code: strconv.Itoa(resp.Code), Msg: resp.Msg}
+	for _, d := range resp.PlaceOrders {
+		response.Data = append(response.Data, PlaceOrderData{
+			ClOrdId: d.ClOrdID, OrdId: d.OrdID, Tag: d.Tag, SCode: strconv.FormatInt(int64(d.SCode), 10), SMsg: d.SMsg,
+		})
+	}
+	if resp.Code != 0 {
+		err = responseError(http.StatusOK, response)
+	}
 	return
 }
 
+// orderIDByClient 按 clOrdId 查询订单号，订单不存在时 found 为 false
+func (c *Client) orderIDByClient(instID, clOrdID string) (ordID string, found bool, err error) {
+	resp, err := c.API().Rest.Trade.GetOrderDetail(requests.OrderDetails{InstID: instID, ClOrdID: clOrdID})
+	if err != nil {
+		err = restError(err)
+		if errors.Is(err, base.ErrOrderNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if resp.Code != 0 {
+		err := sdkError(resp.Basic)
+		if errors.Is(err, base.ErrOrderNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if len(resp.Orders) == 0 {
+		return "", false, nil
+	}
+	return resp.Orders[0].OrdID, true, nil
+}
+
 func (c *Client) setSide(side string) string {
 	if side == base.BID {
 		return "buy"
@@ -1611,33 +880,48 @@ func (c *Client) setSide(side string) string {
 }
 
 func (c *Client) MarketOrder(symbol, side, size string) (string, error) {
-	o := PlaceOrder{
-		InstID:  symbol,
-		TdMode:  "cash",
-		Side:    c.setSide(side),
-		OrdType: "market",
-		Sz:      size,
-		TgtCcy:  "base_ccy",
+	return c.spotOrder(symbol, side, base.MARKET, "", size, "")
+}
+
+func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
+	return c.spotOrder(symbol, side, base.LIMIT, price, size, "")
+}
+
+// spotOrdTypes 现货下单类型对应的 ordType
+var spotOrdTypes = map[string]string{
+	base.MARKET: "market",
+	base.LIMIT:  "limit",
+	base.MAKER:  "post_only",
+	base.TAKER:  "ioc",
+}
+
+// PlaceOrderWithClientID 现货下单并使用调用方指定的 clOrdId，typ 为 base.MARKET / LIMIT / MAKER / TAKER
+func (c *Client) PlaceOrderWithClientID(symbol, side, typ, price, size, clientID string) (string, error) {
+	return c.spotOrder(symbol, side, typ, price, size, clientID)
+}
+
+// spotOrder 现货下单，clientID 为空时自动生成
+func (c *Client) spotOrder(symbol, side, typ, price, size, clientID string) (string, error) {
+	ordType, ok := spotOrdTypes[typ]
+	if !ok {
+		return "", fmt.Errorf("%w: okx spot order type %q", base.ErrNotSupported, typ)
 	}
-	placeOrderResp, err := c.placeOrder([]PlaceOrder{o})
+	price, size, err := c.quantizeOrder(instrument.Spot, c.spotID(symbol), typ, price, size)
 	if err != nil {
 		return "", err
 	}
-	if len(placeOrderResp.Data) > 0 {
-		return placeOrderResp.Data[0].OrdId, nil
-	}
-	return "", fmt.Errorf("not get the order")
-}
-
-func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
 	o := PlaceOrder{
-		InstID:  symbol,
+		InstID:  c.spotID(symbol),
+		ClOrdID: clientID,
 		TdMode:  "cash",
 		Side:    c.setSide(side),
-		OrdType: "limit",
+		OrdType: ordType,
 		Sz:      size,
 		Px:      price,
 	}
+	if typ == base.MARKET {
+		o.Px, o.TgtCcy = "", "base_ccy"
+	}
 	placeOrderResp, err := c.placeOrder([]PlaceOrder{o})
 	if err != nil {
 		return "", err
@@ -1648,9 +932,9 @@ func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
 	return "", fmt.Errorf("not get the order")
 }
 
+// LimitHiddenOrder OKX 没有隐藏单
 func (c *Client) LimitHiddenOrder(symbol, side, price, size string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	return "", base.ErrNotSupported
 }
 
 func (c *Client) LimitOrders(symbol string, ol []models.OrderList) ([]string, error) {
@@ -1658,7 +942,7 @@ func (c *Client) LimitOrders(symbol string, ol []models.OrderList) ([]string, er
 	var os []PlaceOrder
 	for _, order := range ol {
 		o := PlaceOrder{
-			InstID:  symbol,
+			InstID:  c.spotID(symbol),
 			TdMode:  "cash",
 			Side:    c.setSide(order.Side),
 			OrdType: "limit",
@@ -1668,44 +952,29 @@ func (c *Client) LimitOrders(symbol string, ol []models.OrderList) ([]string, er
 		os = append(os, o)
 	}
 	placeOrderResp, err := c.placeOrder(os)
+	var orderIds []string
+	for _, datum := range placeOrderResp.Data {
+		orderIds = append(orderIds, datum.OrdId)
+	}
 	if err != nil {
-		fmt.Println(placeOrderResp)
-		return nil, err
+		// 部分失败时同时返回已经挂上的订单，调用方可以跟踪或撤销
+		return orderIds, err
 	}
-	if len(placeOrderResp.Data) > 0 {
-		var orderIds []string
-		for _, datum := range placeOrderResp.Data {
-			orderIds = append(orderIds, datum.OrdId)
-		}
-		return orderIds, nil
+	if len(orderIds) == 0 {
+		return nil, fmt.Errorf("not get the order")
 	}
-	return nil, fmt.Errorf("not get the order")
+	return orderIds, nil
 }
 
 func (c *Client) MakerOrder(symbol, side, price, size string) (string, error) {
-	o := PlaceOrder{
-		InstID:  symbol,
-		TdMode:  "cash",
-		Side:    c.setSide(side),
-		OrdType: "post_only",
-		Sz:      size,
-		Px:      price,
-	}
-	placeOrderResp, err := c.placeOrder([]PlaceOrder{o})
-	if err != nil {
-		return "", err
-	}
-	if len(placeOrderResp.Data) > 0 {
-		return placeOrderResp.Data[0].OrdId, nil
-	}
-	return "", fmt.Errorf("not get the order")
+	return c.spotOrder(symbol, side, base.MAKER, price, size, "")
 }
 
 func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, error) {
 	var os []PlaceOrder
 	for _, order := range ol {
 		o := PlaceOrder{
-			InstID:  symbol,
+			InstID:  c.spotID(symbol),
 			TdMode:  "cash",
 			Side:    c.setSide(order.Side),
 			OrdType: "post_only",
@@ -1715,43 +984,29 @@ func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, er
 		os = append(os, o)
 	}
 	placeOrderResp, err := c.placeOrder(os)
+	var orderIds []string
+	for _, datum := range placeOrderResp.Data {
+		orderIds = append(orderIds, datum.OrdId)
+	}
 	if err != nil {
-		return nil, err
+		// 部分失败时同时返回已经挂上的订单，调用方可以跟踪或撤销
+		return orderIds, err
 	}
-	if len(placeOrderResp.Data) > 0 {
-		var orderIds []string
-		for _, datum := range placeOrderResp.Data {
-			orderIds = append(orderIds, datum.OrdId)
-		}
-		return orderIds, nil
+	if len(orderIds) == 0 {
+		return nil, fmt.Errorf("not get the order")
 	}
-	return nil, fmt.Errorf("not get the order")
+	return orderIds, nil
 }
 
 func (c *Client) TakerOrder(symbol, side, price, size string) (string, error) {
-	o := PlaceOrder{
-		InstID:  symbol,
-		TdMode:  "cash",
-		Side:    c.setSide(side),
-		OrdType: "ioc",
-		Sz:      size,
-		Px:      price,
-	}
-	placeOrderResp, err := c.placeOrder([]PlaceOrder{o})
-	if err != nil {
-		return "", err
-	}
-	if len(placeOrderResp.Data) > 0 {
-		return placeOrderResp.Data[0].OrdId, nil
-	}
-	return "", fmt.Errorf("not get the order")
+	return c.spotOrder(symbol, side, base.TAKER, price, size, "")
 }
 
 func (c *Client) TakerOrders(symbol string, ol []models.OrderList) ([]string, error) {
 	var os []PlaceOrder
 	for _, order := range ol {
 		o := PlaceOrder{
-			InstID:  symbol,
+			InstID:  c.spotID(symbol),
 			TdMode:  "cash",
 			Side:    c.setSide(order.Side),
 			OrdType: "ioc",
@@ -1761,55 +1016,42 @@ func (c *Client) TakerOrders(symbol string, ol []models.OrderList) ([]string, er
 		os = append(os, o)
 	}
 	placeOrderResp, err := c.placeOrder(os)
+	var orderIds []string
+	for _, datum := range placeOrderResp.Data {
+		orderIds = append(orderIds, datum.OrdId)
+	}
 	if err != nil {
-		return nil, err
+		// 部分失败时同时返回已经挂上的订单，调用方可以跟踪或撤销
+		return orderIds, err
 	}
-	if len(placeOrderResp.Data) > 0 {
-		var orderIds []string
-		for _, datum := range placeOrderResp.Data {
-			orderIds = append(orderIds, datum.OrdId)
-		}
-		return orderIds, nil
+	if len(orderIds) == 0 {
+		return nil, fmt.Errorf("not get the order")
 	}
-	return nil, fmt.Errorf("not get the order")
+	return orderIds, nil
 }
 
 func (c *Client) CancelOrder(symbol, id string) (bool, error) {
-
-	p := "/api/v5/trade/cancel-order"
-	m := make(map[string]string)
-
-	m["ordId"] = id
-	m["instId"] = symbol
-
-	res, err := c.do(http.MethodPost, p, true, m)
-	if err != nil {
-		return false, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return false, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	if data, err := c.cancelOrderWs(c.spotID(symbol), id); !errors.Is(err, errWsUnavailable) {
+		if err != nil {
+			return false, err
+		}
+		var response traderes.CancelOrder
+		if err := json.Unmarshal(data, &response); err != nil || len(response.CancelOrders) == 0 {
+			return false, responseError(0, data)
+		}
+		return response.CancelOrders[0].SCode == 0, nil
 	}
 
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			ClOrdId string `json:"clOrdId"`
-			OrdId   string `json:"ordId"`
-			SCode   string `json:"sCode"`
-			SMsg    string `json:"sMsg"`
-		} `json:"data"`
+	resp, err := c.API().Rest.Trade.CandleOrder([]requests.CancelOrder{{InstID: c.spotID(symbol), OrdID: id}})
+	if err != nil {
+		return false, restError(err)
 	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 {
-		return false, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
+	if len(resp.CancelOrders) == 0 {
+		return false, sdkError(resp.Basic)
 	}
-	if response.Data[0].SCode != "0" {
+	if d := resp.CancelOrders[0]; resp.Code != 0 && d.SCode == 0 {
+		return false, sdkError(resp.Basic)
+	} else if d.SCode != 0 {
 		return false, nil
 	}
 	return true, nil
@@ -1821,231 +1063,67 @@ func (c *Client) CancelOrders(symbol string) error {
 	if err != nil {
 		return err
 	}
+	if len(openOrders) == 0 {
+		return nil
+	}
 	newOpenOrders := arrayInGroupsOf(openOrders, 20)
 	for _, orders := range newOpenOrders {
-		var cancelRs []CancelR
+		var cancelRs []requests.CancelOrder
 		for _, o := range orders {
-			cancelRs = append(cancelRs, CancelR{
-				InstID: symbol,
+			cancelRs = append(cancelRs, requests.CancelOrder{
+				InstID: c.spotID(symbol),
 				OrdID:  o.OrderID,
 			})
 		}
-
-		p := "/api/v5/trade/cancel-batch-orders"
-		marshal, err := json.Marshal(cancelRs)
+		resp, err := c.API().Rest.Trade.CandleOrder(cancelRs)
 		if err != nil {
-			return err
-		}
-		res, err := c.doPost(http.MethodPost, p, true, marshal)
-		if err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode != http.StatusOK {
-			data, _ := ioutil.ReadAll(res.Body)
-			return fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-		}
-
-		d := json.NewDecoder(res.Body)
-
-		var response struct {
-			Code string `json:"code"`
-			Msg  string `json:"msg"`
-			Data []struct {
-				ClOrdId string `json:"clOrdId"`
-				OrdId   string `json:"ordId"`
-				SCode   string `json:"sCode"`
-				SMsg    string `json:"sMsg"`
-			} `json:"data"`
+			return restError(err)
 		}
-
-		err = d.Decode(&response)
-		if response.Code != "0" {
-			return fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
+		if resp.Code != 0 {
+			return responseError(http.StatusOK, resp)
 		}
 	}
 	return nil
 }
 
 func (c *Client) GetOrder(symbol, id string) (models.OrderInfo, error) {
-	p := "/api/v5/trade/order"
-	m := make(map[string]string)
-	m["instId"] = symbol
-	m["ordId"] = id
-
-	orderInfo := models.OrderInfo{}
-
-	res, err := c.do(http.MethodGet, p, true, m)
+	resp, err := c.API().Rest.Trade.GetOrderDetail(requests.OrderDetails{InstID: c.spotID(symbol), OrdID: id})
 	if err != nil {
-		return orderInfo, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return orderInfo, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			InstType           string `json:"instType"`
-			InstId             string `json:"instId"`
-			Ccy                string `json:"ccy"`
-			OrdId              string `json:"ordId"`
-			ClOrdId            string `json:"clOrdId"`
-			Tag                string `json:"tag"`
-			Px                 string `json:"px"`
-			Sz                 string `json:"sz"`
-			Pnl                string `json:"pnl"`
-			OrdType            string `json:"ordType"`
-			Side               string `json:"side"`
-			PosSide            string `json:"posSide"`
-			TdMode             string `json:"tdMode"`
-			AccFillSz          string `json:"accFillSz"`
-			FillPx             string `json:"fillPx"`
-			TradeId            string `json:"tradeId"`
-			FillSz             string `json:"fillSz"`
-			FillTime           string `json:"fillTime"`
-			Source             string `json:"source"`
-			State              string `json:"state"`
-			AvgPx              string `json:"avgPx"`
-			Lever              string `json:"lever"`
-			TpTriggerPx        string `json:"tpTriggerPx"`
-			TpTriggerPxType    string `json:"tpTriggerPxType"`
-			TpOrdPx            string `json:"tpOrdPx"`
-			SlTriggerPx        string `json:"slTriggerPx"`
-			SlTriggerPxType    string `json:"slTriggerPxType"`
-			SlOrdPx            string `json:"slOrdPx"`
-			FeeCcy             string `json:"feeCcy"`
-			Fee                string `json:"fee"`
-			RebateCcy          string `json:"rebateCcy"`
-			Rebate             string `json:"rebate"`
-			TgtCcy             string `json:"tgtCcy"`
-			Category           string `json:"category"`
-			ReduceOnly         string `json:"reduceOnly"`
-			CancelSource       string `json:"cancelSource"`
-			CancelSourceReason string `json:"cancelSourceReason"`
-			QuickMgnType       string `json:"quickMgnType"`
-			AlgoClOrdId        string `json:"algoClOrdId"`
-			AlgoId             string `json:"algoId"`
-			UTime              string `json:"uTime"`
-			CTime              string `json:"cTime"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 {
-		return orderInfo, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
-	}
-
-	o := response.Data[0]
-	orderInfo.Symbol = o.InstId
-	orderInfo.Side = o.Side
-	orderInfo.OrderID = o.OrdId
-	orderInfo.Price = o.Px
-	orderInfo.Filled = o.AccFillSz
-	orderInfo.Quantity = o.Sz
-	orderInfo.Status = o.State
-	orderInfo.Type = o.InstType
-
-	ctime, _ := strconv.Atoi(o.CTime)
-	orderInfo.Time = int64(ctime)
-
-	return orderInfo, nil
+		return models.OrderInfo{}, restError(err)
+	}
+	if resp.Code != 0 || len(resp.Orders) == 0 {
+		return models.OrderInfo{}, sdkError(resp.Basic)
+	}
+	return orderInfo(symbol, resp.Orders[0]), nil
 }
 
-func (c *Client) GetOpenOrders(symbol string) ([]models.OrderInfo, error) {
-	p := "/api/v5/trade/orders-pending"
-	m := make(map[string]string)
-	m["instType"] = "SPOT"
-	m["instId"] = symbol
+// orderInfo 现货订单，Side 和 Status 为 OKX 的原始取值
+func orderInfo(symbol string, o *trade.Order) models.OrderInfo {
+	return models.OrderInfo{
+		Symbol:   echoSymbol(symbol, o.InstID),
+		Side:     string(o.Side),
+		OrderID:  o.OrdID,
+		Price:    decimalOf(o.Px),
+		Filled:   decimalOf(o.AccFillSz),
+		Quantity: decimalOf(o.Sz),
+		Status:   string(o.State),
+		Type:     string(o.InstType),
+		Time:     millis(o.CTime),
+	}
+}
 
+func (c *Client) GetOpenOrders(symbol string) ([]models.OrderInfo, error) {
 	var orders []models.OrderInfo
-
-	res, err := c.do(http.MethodGet, p, true, m)
+	resp, err := c.API().Rest.Trade.GetOrderList(requests.OrderList{InstID: c.spotID(symbol), InstType: sdk.SpotInstrument})
 	if err != nil {
-		return orders, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return orders, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			AccFillSz       string `json:"accFillSz"`
-			AvgPx           string `json:"avgPx"`
-			CTime           string `json:"cTime"`
-			Category        string `json:"category"`
-			Ccy             string `json:"ccy"`
-			ClOrdId         string `json:"clOrdId"`
-			Fee             string `json:"fee"`
-			FeeCcy          string `json:"feeCcy"`
-			FillPx          string `json:"fillPx"`
-			FillSz          string `json:"fillSz"`
-			FillTime        string `json:"fillTime"`
-			InstId          string `json:"instId"`
-			InstType        string `json:"instType"`
-			Lever           string `json:"lever"`
-			OrdId           string `json:"ordId"`
-			OrdType         string `json:"ordType"`
-			Pnl             string `json:"pnl"`
-			PosSide         string `json:"posSide"`
-			Px              string `json:"px"`
-			Rebate          string `json:"rebate"`
-			RebateCcy       string `json:"rebateCcy"`
-			Side            string `json:"side"`
-			SlOrdPx         string `json:"slOrdPx"`
-			SlTriggerPx     string `json:"slTriggerPx"`
-			SlTriggerPxType string `json:"slTriggerPxType"`
-			Source          string `json:"source"`
-			State           string `json:"state"`
-			Sz              string `json:"sz"`
-			Tag             string `json:"tag"`
-			TdMode          string `json:"tdMode"`
-			TgtCcy          string `json:"tgtCcy"`
-			TpOrdPx         string `json:"tpOrdPx"`
-			TpTriggerPx     string `json:"tpTriggerPx"`
-			TpTriggerPxType string `json:"tpTriggerPxType"`
-			TradeId         string `json:"tradeId"`
-			ReduceOnly      string `json:"reduceOnly"`
-			QuickMgnType    string `json:"quickMgnType"`
-			AlgoClOrdId     string `json:"algoClOrdId"`
-			AlgoId          string `json:"algoId"`
-			UTime           string `json:"uTime"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" {
-		return orders, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
-	}
-
-	for _, o := range response.Data {
-		var orderInfo models.OrderInfo
-		orderInfo.Symbol = o.InstId
-		orderInfo.Side = o.Side
-		orderInfo.OrderID = o.OrdId
-		orderInfo.Price = o.Px
-		orderInfo.Filled = o.AccFillSz
-		orderInfo.Quantity = o.Sz
-		orderInfo.Status = o.State
-		orderInfo.Type = o.InstType
-
-		ctime, _ := strconv.Atoi(o.CTime)
-		orderInfo.Time = int64(ctime)
-
-		orders = append(orders, orderInfo)
+		return orders, restError(err)
+	}
+	if resp.Code != 0 {
+		return orders, sdkError(resp.Basic)
+	}
+	for _, o := range resp.Orders {
+		orders = append(orders, orderInfo(symbol, o))
 	}
-
 	return orders, nil
 }
 
@@ -2083,237 +1161,132 @@ func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models
 }
 
 func (c *Client) GetMarketPrice(symbol string) (string, error) {
-	p := "/api/v5/market/ticker"
-	m := make(map[string]string)
-	m["instId"] = symbol
-	res, err := c.do(http.MethodGet, p, false, m)
-	if err != nil {
-		return "", err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			InstType  string `json:"instType"`
-			InstId    string `json:"instId"`
-			Last      string `json:"last"`
-			LastSz    string `json:"lastSz"`
-			AskPx     string `json:"askPx"`
-			AskSz     string `json:"askSz"`
-			BidPx     string `json:"bidPx"`
-			BidSz     string `json:"bidSz"`
-			Open24H   string `json:"open24h"`
-			High24H   string `json:"high24h"`
-			Low24H    string `json:"low24h"`
-			VolCcy24H string `json:"volCcy24h"`
-			Vol24H    string `json:"vol24h"`
-			Ts        string `json:"ts"`
-			SodUtc0   string `json:"sodUtc0"`
-			SodUtc8   string `json:"sodUtc8"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 {
-		return "", fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
-	}
-	return response.Data[0].Last, nil
+	return c.lastPrice(c.spotID(symbol))
 }
 
 func (c *Client) Depth(symbol, limit string) (models.WsData, error) {
-
 	var ws models.WsData
-
-	p := "/api/v5/market/books"
-	m := make(map[string]string)
-	m["sz"] = limit
-	m["instId"] = symbol
-	res, err := c.do(http.MethodGet, p, false, m)
+	book, err := c.orderBook(c.spotID(symbol), limit)
 	if err != nil {
 		return ws, err
 	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return ws, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+	if len(book.Asks) == 0 || len(book.Bids) == 0 {
+		return ws, fmt.Errorf("%w: okx empty order book for %s", base.ErrResponse, symbol)
 	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Asks [][]string `json:"asks"`
-			Bids [][]string `json:"bids"`
-			Ts   string     `json:"ts"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 || len(response.Data[0].Asks) == 0 || len(response.Data[0].Bids) == 0 {
-		return ws, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
-	}
-
-	var bids []models.PriceLevel
-	var asks []models.PriceLevel
-
-	for _, ask := range response.Data[0].Asks {
-		asks = append(asks, models.PriceLevel{
-			Price:    ask[0],
-			Quantity: ask[1],
+	for _, ask := range book.Asks {
+		ws.Asks = append(ws.Asks, models.PriceLevel{
+			Price:    models.ParseDecimalOrZero(ask.Px),
+			Quantity: models.ParseDecimalOrZero(ask.Sz),
 		})
 	}
-
-	for _, bid := range response.Data[0].Bids {
-		bids = append(bids, models.PriceLevel{
-			Price:    bid[0],
-			Quantity: bid[1],
+	for _, bid := range book.Bids {
+		ws.Bids = append(ws.Bids, models.PriceLevel{
+			Price:    models.ParseDecimalOrZero(bid.Px),
+			Quantity: models.ParseDecimalOrZero(bid.Sz),
 		})
 	}
-
-	ws.Asks = asks
-	ws.Bids = bids
 	ws.Time = time.Now().Unix()
-
 	return ws, nil
 }
 
 func (c *Client) GetTradingFee(symbol string) (models.TradingFee, error) {
-	p := "/api/v5/account/trade-fee"
 	var fee models.TradingFee
-	m := make(map[string]string)
-	m["instType"] = "SPOT"
-	m["instId"] = symbol
-	res, err := c.do(http.MethodGet, p, true, m)
+	resp, err := c.API().Rest.Account.GetFeeRates(account.GetFeeRates{InstType: sdk.SpotInstrument, InstID: c.spotID(symbol)})
 	if err != nil {
-		return fee, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return fee, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
+		return fee, restError(err)
 	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Category  string `json:"category"`
-			Delivery  string `json:"delivery"`
-			Exercise  string `json:"exercise"`
-			InstType  string `json:"instType"`
-			Level     string `json:"level"`
-			Maker     string `json:"maker"`
-			MakerU    string `json:"makerU"`
-			MakerUSDC string `json:"makerUSDC"`
-			Taker     string `json:"taker"`
-			TakerU    string `json:"takerU"`
-			TakerUSDC string `json:"takerUSDC"`
-			Ts        string `json:"ts"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 {
-		return fee, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
+	if resp.Code != 0 || len(resp.Fees) == 0 {
+		return fee, sdkError(resp.Basic)
 	}
-
 	fee.Symbol = symbol
-	fee.MakerFeeFromApi = response.Data[0].Maker
-	fee.TakerFeeFromApi = response.Data[0].Taker
-
+	fee.MakerFeeFromApi = decimalOf(resp.Fees[0].Maker)
+	fee.TakerFeeFromApi = decimalOf(resp.Fees[0].Taker)
+	c.realFees(&fee, symbol)
 	return fee, nil
 }
 
 func (c *Client) GetPairInfo(symbol string) (models.PairInfo, error) {
-
 	var pairInfo models.PairInfo
-
-	p := "/api/v5/public/instruments"
-	m := make(map[string]string)
-	m["instType"] = "SPOT"
-	m["instId"] = symbol
-	res, err := c.do(http.MethodGet, p, false, m)
+	resp, err := c.API().Rest.PublicData.GetInstruments(public.GetInstruments{InstType: sdk.SpotInstrument, InstID: c.spotID(symbol)})
 	if err != nil {
-		return pairInfo, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(res.Body)
-		return pairInfo, fmt.Errorf("response status code is not OK, response code is %d, body:%s", res.StatusCode, string(data))
-	}
-
-	d := json.NewDecoder(res.Body)
-
-	var response struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Alias        string `json:"alias"`
-			BaseCcy      string `json:"baseCcy"`
-			Category     string `json:"category"`
-			CtMult       string `json:"ctMult"`
-			CtType       string `json:"ctType"`
-			CtVal        string `json:"ctVal"`
-			CtValCcy     string `json:"ctValCcy"`
-			ExpTime      string `json:"expTime"`
-			InstFamily   string `json:"instFamily"`
-			InstId       string `json:"instId"`
-			InstType     string `json:"instType"`
-			Lever        string `json:"lever"`
-			ListTime     string `json:"listTime"`
-			LotSz        string `json:"lotSz"`
-			MaxIcebergSz string `json:"maxIcebergSz"`
-			MaxLmtSz     string `json:"maxLmtSz"`
-			MaxMktSz     string `json:"maxMktSz"`
-			MaxStopSz    string `json:"maxStopSz"`
-			MaxTriggerSz string `json:"maxTriggerSz"`
-			MaxTwapSz    string `json:"maxTwapSz"`
-			MinSz        string `json:"minSz"`
-			OptType      string `json:"optType"`
-			QuoteCcy     string `json:"quoteCcy"`
-			SettleCcy    string `json:"settleCcy"`
-			State        string `json:"state"`
-			Stk          string `json:"stk"`
-			TickSz       string `json:"tickSz"`
-			Uly          string `json:"uly"`
-		} `json:"data"`
-	}
-
-	err = d.Decode(&response)
-	if response.Code != "0" || len(response.Data) == 0 {
-		return pairInfo, fmt.Errorf("response status code is not OK, response code is %d, body:%+v", res.StatusCode, response)
-	}
-
-	pairInfo.Precision = tools.GetDecimalPlacesStr(response.Data[0].TickSz)
-	pairInfo.AmountPrecision = tools.GetDecimalPlacesStr(response.Data[0].LotSz)
+		return pairInfo, restError(err)
+	}
+	if resp.Code != 0 || len(resp.Instruments) == 0 {
+		return pairInfo, sdkError(resp.Basic)
+	}
+	inst := resp.Instruments[0]
+	pairInfo.TickSize = decimalOf(inst.TickSz)
+	pairInfo.LotSize = decimalOf(inst.LotSz)
+	pairInfo.Precision = tools.GetDecimalPlacesStr(pairInfo.TickSize.String())
+	pairInfo.AmountPrecision = tools.GetDecimalPlacesStr(pairInfo.LotSize.String())
+	pairInfo.MinSize = decimalOf(inst.MinSz)
+	pairInfo.MaxLimitSize = decimalOf(inst.MaxLmtSz)
+	pairInfo.MaxMarketSize = decimalOf(inst.MaxMktSz)
 	return pairInfo, nil
 }
 
+// GetFeeFromFilled 汇总订单全部成交的手续费，返回 手续费, 手续费币种。
+// 先查最近 3 天的成交，查不到时再查 fills-history；OKX 的 fee 扣费为负、返佣为正，这里返回扣掉的手续费
 func (c *Client) GetFeeFromFilled(symbol, id string) (string, string, error) {
-	//TODO implement me
-	panic("implement me")
+	instID := c.spotID(symbol)
+	req := requests.TransactionDetails{InstID: instID, InstType: instType(instID), OrdID: id}
+	fills, err := c.fills(context.Background(), symbol, req, false)
+	if err == nil && len(fills) == 0 {
+		fills, err = c.fills(context.Background(), symbol, req, true)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	total, ccy := models.Decimal{}, ""
+	for _, f := range fills {
+		if ccy != "" && f.FeeAsset != ccy {
+			return "", "", fmt.Errorf("fee charged in both %s and %s", ccy, f.FeeAsset)
+		}
+		ccy = f.FeeAsset
+		total = total.Add(f.Fee)
+	}
+	return total.String(), ccy, nil
 }
 
+// IceBergOrder 冰山委托（策略单），按买一/卖一挂出每笔 ice 数量的子单，价格不超过 price。
+// 返回的是策略单 algoId，不是普通订单号；typ 不起作用，子单都是限价挂单
 func (c *Client) IceBergOrder(symbol, side, typ, price, size, ice string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	price, size, err := c.quantizeOrder(instrument.Spot, c.spotID(symbol), base.LIMIT, price, size)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.API().Rest.Trade.PlaceAlgoOrder(requests.PlaceAlgoOrder{
+		InstID:       c.spotID(symbol),
+		TdMode:       sdk.TradeCashMode,
+		Side:         sdk.OrderSide(c.setSide(side)),
+		OrdType:      sdk.AlgoOrderType("iceberg"),
+		Sz:           size,
+		IcebergOrder: requests.IcebergOrder{PxSpread: "0", PxLimit: price, SzLimit: ice},
+	})
+	if err != nil {
+		return "", restError(err)
+	}
+	if len(resp.PlaceAlgoOrders) == 0 {
+		return "", sdkError(resp.Basic)
+	}
+	if d := resp.PlaceAlgoOrders[0]; resp.Code != 0 || d.SCode != 0 {
+		return "", resultError(resp.Basic, d.SCode, d.SMsg)
+	}
+	return resp.PlaceAlgoOrders[0].AlgoID, nil
 }
 
 func (c *Client) LimitHiddenOrders(symbol string, ol []models.OrderList) ([]string, error) {
-	//TODO implement me
-	panic("implement me")
+	return nil, base.ErrNotSupported
+}
+
+// Has OKX 没有隐藏单，保证金模式按订单指定，其余可选功能都支持
+func (c *Client) Has(capability string) bool {
+	switch capability {
+	case base.CapWithdraw, base.CapIceberg, base.CapFeeFromFilled:
+		return true
+	}
+	return false
 }
 
 func arrayInGroupsOf(arr []models.OrderInfo, num int64) [][]models.OrderInfo {
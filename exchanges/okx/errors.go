@@ -0,0 +1,121 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api/rest"
+	"AxonTrading/exchanges/okx/sdk/responses"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// errorCodes OKX 错误码到 base 错误分类的映射
+// https://www.okx.com/docs-v5/en/#error-code
+var errorCodes = map[string]error{
+	"50011": base.ErrRateLimited, // Rate limit reached
+	"50013": base.ErrRateLimited, // Systems are busy
+	"50061": base.ErrRateLimited, // Sub-account rate limit exceeded
+
+	"50102": base.ErrTimestamp, // Timestamp request expired
+	"50112": base.ErrTimestamp, // Invalid OK-ACCESS-TIMESTAMP
+
+	"50100": base.ErrAuth, // API frozen
+	"50101": base.ErrAuth, // APIKey does not match current environment
+	"50103": base.ErrAuth, // OK-ACCESS-KEY header is required
+	"50104": base.ErrAuth, // OK-ACCESS-PASSPHRASE header is required
+	"50105": base.ErrAuth, // Wrong OK-ACCESS-PASSPHRASE
+	"50111": base.ErrAuth, // Invalid OK-ACCESS-KEY
+	"50113": base.ErrAuth, // Invalid Sign
+	"50114": base.ErrAuth, // Invalid authorization
+	"50119": base.ErrAuth, // API key doesn't exist
+	"50120": base.ErrAuth, // API key doesn't have permission
+
+	"51008": base.ErrInsufficientFunds, // Insufficient balance
+	"51127": base.ErrInsufficientFunds, // Available balance is 0
+	"51131": base.ErrInsufficientFunds, // Insufficient balance
+	"58350": base.ErrInsufficientFunds, // Insufficient balance (withdraw)
+
+	"51400": base.ErrOrderNotFound, // Cancellation failed as the order does not exist
+	"51401": base.ErrOrderNotFound, // Cancellation failed as the order is already canceled
+	"51402": base.ErrOrderNotFound, // Cancellation failed as the order is already completed
+	"51603": base.ErrOrderNotFound, // Order does not exist
+
+	"51016": base.ErrDuplicateOrder, // Duplicated clOrdId
+
+	"50001": base.ErrUnknownStatus, // Service temporarily unavailable
+	"50004": base.ErrUnknownStatus, // Endpoint request timeout
+
+	"51001": base.ErrInvalidSymbol, // Instrument ID does not exist
+	"51015": base.ErrInvalidSymbol, // Instrument ID does not match instrument type
+}
+
+// apiError 构造 OKX 错误，批量接口的 code 为 1/2 时使用 data[0].sCode
+func apiError(status int, code, msg string, raw []byte) error {
+	kind := errorCodes[code]
+	if kind == nil {
+		kind = base.KindOfHTTPStatus(status)
+	}
+	return &base.ExchangeError{Exchange: base.OKEX, HTTPStatus: status, Code: code, Message: msg, Raw: raw, Kind: kind}
+}
+
+// sdkError SDK 接口 code 不为 0 的错误，code 为 0 时表示 data 为空
+func sdkError(b responses.Basic) error {
+	msg := b.Msg
+	if b.Code == 0 && msg == "" {
+		msg = "empty response data"
+	}
+	return apiError(0, strconv.Itoa(b.Code), msg, nil)
+}
+
+// resultError 下单、撤单类接口的错误，code 为 1/2 时按第一个结果的 sCode 映射
+func resultError(b responses.Basic, sCode sdk.JSONInt64, sMsg string) error {
+	if sCode != 0 {
+		return apiError(0, strconv.FormatInt(int64(sCode), 10), sMsg, nil)
+	}
+	return sdkError(b)
+}
+
+// restError SDK 请求的错误，非 200 响应按 httpError 映射，保留状态码和响应体，其余原样返回
+func restError(err error) error {
+	var he *rest.HTTPError
+	if errors.As(err, &he) {
+		return httpError(he.StatusCode, he.Body)
+	}
+	return err
+}
+
+// httpError 非 200 响应，响应体是 OKX 的 JSON 时按其中的 code 映射
+func httpError(status int, raw []byte) error {
+	var body struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	_ = json.Unmarshal(raw, &body)
+	return apiError(status, body.Code, body.Msg, raw)
+}
+
+// responseError 从已解码的响应构造错误，v 为响应结构体或原始响应体
+func responseError(status int, v interface{}) error {
+	raw, ok := v.([]byte)
+	if !ok {
+		raw, _ = json.Marshal(v)
+	}
+	var body struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			SCode string `json:"sCode"`
+			SMsg  string `json:"sMsg"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(raw, &body)
+	code, msg := body.Code, body.Msg
+	if len(body.Data) > 0 && body.Data[0].SCode != "" && body.Data[0].SCode != "0" {
+		code, msg = body.Data[0].SCode, body.Data[0].SMsg
+	}
+	if code == "0" && msg == "" {
+		msg = "empty response data"
+	}
+	return apiError(status, code, msg, raw)
+}
@@ -0,0 +1,55 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResponseError(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		code   string
+		want   error
+	}{
+		{200, `{"code":"51008","msg":"Order failed. Insufficient USDT balance","data":[]}`, "51008", base.ErrInsufficientFunds},
+		{200, `{"code":"1","msg":"","data":[{"sCode":"51603","sMsg":"Order does not exist"}]}`, "51603", base.ErrOrderNotFound},
+		{200, `{"code":"51001","msg":"Instrument ID does not exist","data":[]}`, "51001", base.ErrInvalidSymbol},
+		{401, `{"code":"50113","msg":"Invalid Sign"}`, "50113", base.ErrAuth},
+		{200, `{"code":"50102","msg":"Timestamp request expired"}`, "50102", base.ErrTimestamp},
+		{429, `{"code":"50011","msg":"Too Many Requests"}`, "50011", base.ErrRateLimited},
+	}
+	for _, c := range cases {
+		err := responseError(c.status, []byte(c.body))
+		if !errors.Is(err, c.want) || !errors.Is(err, base.ErrResponse) {
+			t.Errorf("responseError(%s) = %v, want %v", c.body, err, c.want)
+		}
+		var exErr *base.ExchangeError
+		if !errors.As(err, &exErr) || exErr.Code != c.code || string(exErr.Raw) != c.body {
+			t.Errorf("responseError(%s) = %+v", c.body, exErr)
+		}
+	}
+
+	// 未识别的错误码只匹配 ErrResponse
+	err := responseError(200, []byte(`{"code":"59999","msg":"unknown"}`))
+	if !errors.Is(err, base.ErrResponse) || errors.Is(err, base.ErrRateLimited) {
+		t.Errorf("unknown code = %v", err)
+	}
+	// 没有响应体的 429 按状态码归类
+	if err := HttpErr(429); !errors.Is(err, base.ErrRateLimited) {
+		t.Errorf("HttpErr(429) = %v", err)
+	}
+}
+
+func TestHttpErrorKeepsBody(t *testing.T) {
+	c, srv := newFakeClient(t)
+	body := `{"code":"51001","msg":"Instrument ID does not exist","data":[]}`
+	srv.Handle(http.MethodGet, "/api/v5/public/mark-price", http.StatusBadRequest, body)
+	_, err := c.GetMarkPriceAndFundingRate("XYZ-USDT")
+	var exErr *base.ExchangeError
+	if !errors.Is(err, base.ErrInvalidSymbol) || !errors.As(err, &exErr) || exErr.Code != "51001" || string(exErr.Raw) != body {
+		t.Fatalf("err = %v", err)
+	}
+}
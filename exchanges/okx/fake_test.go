@@ -0,0 +1,180 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/exchangetest"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var fakeCreds = exchangetest.Credentials{APIKey: "key", SecretKey: "secret", Passphrase: "pass"}
+
+func newFakeClient(t *testing.T) (*Client, *exchangetest.Server) {
+	t.Helper()
+	srv := exchangetest.NewOKX(fakeCreds)
+	t.Cleanup(srv.Close)
+	c := &Client{}
+	if err := c.New(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	return c, srv
+}
+
+func TestFakeSignedRequest(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	bal, err := c.GetAccountBalance("USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(bal, ",") != "1000.5,10,1010.5" {
+		t.Fatalf("balance = %v", bal)
+	}
+	reqs := srv.Requests(http.MethodGet, "/api/v5/account/balance")
+	if len(reqs) != 1 || reqs[0].Query.Get("ccy") != "USDT" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	// 行情接口不带鉴权头
+	price, err := c.GetMarketPrice("BTC-USDT")
+	if err != nil || price != "37000.1" {
+		t.Fatalf("price = %s err = %v", price, err)
+	}
+	reqs = srv.Requests(http.MethodGet, "/api/v5/market/ticker")
+	if len(reqs) != 1 || reqs[0].Header.Get("OK-ACCESS-KEY") != "" || reqs[0].Query.Get("instId") != "BTC-USDT" {
+		t.Fatalf("ticker requests = %+v", reqs)
+	}
+
+	c.SecretKey = "wrong"
+	if _, err := c.GetAccountBalance("USDT"); !errors.Is(err, base.ErrAuth) {
+		t.Fatalf("bad signature err = %v", err)
+	}
+}
+
+func TestFakeGetOrderQuery(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	o, err := c.GetOrder("BTC-USDT", "680800019749904384")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OrderID != "680800019749904384" || o.Status != "partially_filled" || o.Filled.String() != "0.01" || o.Price.String() != "36000" {
+		t.Fatalf("order = %+v", o)
+	}
+	q := srv.Requests(http.MethodGet, "/api/v5/trade/order")[0].Query
+	if q.Get("instId") != "BTC-USDT" || q.Get("ordId") != "680800019749904384" {
+		t.Fatalf("query = %v", q)
+	}
+}
+
+func TestFakeCancelOrdersSplitsBatches(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	var pending []string
+	for i := 0; i < 45; i++ {
+		pending = append(pending, fmt.Sprintf(`{"instId":"BTC-USDT","ordId":"%d","px":"100","sz":"1","side":"buy","state":"live"}`, i))
+	}
+	srv.Handle(http.MethodGet, "/api/v5/trade/orders-pending", http.StatusOK,
+		`{"code":"0","msg":"","data":[`+strings.Join(pending, ",")+`]}`)
+	srv.HandleFunc(http.MethodPost, "/api/v5/trade/cancel-batch-orders", func(w http.ResponseWriter, r *http.Request) {
+		var rs []CancelR
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &rs)
+		var data []string
+		for _, o := range rs {
+			data = append(data, fmt.Sprintf(`{"ordId":%q,"sCode":"0","sMsg":""}`, o.OrdID))
+		}
+		fmt.Fprintf(w, `{"code":"0","msg":"","data":[%s]}`, strings.Join(data, ","))
+	})
+
+	if err := c.CancelOrders("BTC-USDT"); err != nil {
+		t.Fatal(err)
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v5/trade/cancel-batch-orders")
+	var sizes []int
+	for _, p := range posts {
+		var rs []CancelR
+		if err := json.Unmarshal(p.Body, &rs); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range rs {
+			if r.InstID != "BTC-USDT" {
+				t.Fatalf("instId = %s", r.InstID)
+			}
+		}
+		sizes = append(sizes, len(rs))
+	}
+	if fmt.Sprint(sizes) != "[20 20 5]" {
+		t.Fatalf("batch sizes = %v", sizes)
+	}
+}
+
+func TestFakeIcebergAndFees(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	id, err := c.IceBergOrder("BTC-USDT", base.BID, base.LIMIT, "36000", "1", "0.1")
+	if err != nil || id != "681096944655273984" {
+		t.Fatalf("iceberg = %s err = %v", id, err)
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v5/trade/order-algo")
+	if len(posts) != 1 {
+		t.Fatalf("requests = %+v", posts)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(posts[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["ordType"] != "iceberg" || body["side"] != "buy" || body["pxLimit"] != "36000" || body["szLimit"] != "0.1" || body["sz"] != "1" {
+		t.Fatalf("body = %v", body)
+	}
+
+	// 两笔成交的手续费 -0.000006 和 -0.0000032
+	fee, ccy, err := c.GetFeeFromFilled("BTC-USDT", "680800019749904384")
+	if err != nil || fee != "0.0000092" || ccy != "BTC" {
+		t.Fatalf("fee = %s %s err = %v", fee, ccy, err)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/trade/fills")[0].Query; q.Get("ordId") != "680800019749904384" || q.Get("instType") != "SPOT" {
+		t.Fatalf("fills query = %v", q)
+	}
+
+	if _, err := c.LimitHiddenOrder("BTC-USDT", base.BID, "36000", "1"); !errors.Is(err, base.ErrNotSupported) || c.Has(base.CapHiddenOrder) {
+		t.Fatalf("hidden order err = %v", err)
+	}
+	if err := c.ChangeMarginType("BTC-USDT-SWAP", base.ISOLATED); !errors.Is(err, base.ErrNotSupported) || c.Has(base.CapMarginType) {
+		t.Fatalf("margin type err = %v", err)
+	}
+}
+
+func TestFakeSDKEndpoints(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	dual, err := c.CheckDual()
+	if err != nil || !dual {
+		t.Fatalf("dual = %v err = %v", dual, err)
+	}
+	ok, err := c.Dual(true)
+	if err != nil || !ok {
+		t.Fatalf("set dual = %v err = %v", ok, err)
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v5/account/set-position-mode")
+	if len(posts) != 1 || string(posts[0].Body) != `{"posMode":"long_short_mode"}` {
+		t.Fatalf("set-position-mode = %+v", posts)
+	}
+
+	srv.Handle(http.MethodGet, "/api/v5/asset/deposit-address", http.StatusOK,
+		`{"code":"0","msg":"","data":[{"chain":"USDT-TRC20","ccy":"USDT","to":"6","addr":"funding"},{"chain":"USDT-TRC20","ccy":"USDT","to":"18","addr":"trading"}]}`)
+	addr, err := c.GetDepositAddress("USDT", "TRC20")
+	if err != nil || addr != "trading" {
+		t.Fatalf("address = %s err = %v", addr, err)
+	}
+
+	srv.Handle(http.MethodGet, "/api/v5/account/config", http.StatusOK, `{"code":"50011","msg":"Too Many Requests","data":[]}`)
+	if _, err := c.CheckDual(); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("rate limited err = %v", err)
+	}
+}
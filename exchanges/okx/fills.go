@@ -0,0 +1,107 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/trade"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// fillsRecent /api/v5/trade/fills 只保留最近 3 天的成交，更早的在 fills-history（3 个月）
+const fillsRecent = 3 * 24 * time.Hour
+
+// fillsPage 成交接口每页的最大条数
+const fillsPage = 100
+
+// GetFills 自己在 symbol 上 [since, until] 内的成交，按时间升序；since 为零时取 until 前 24 小时，until 为零时取当前时间。
+// since 在 3 天以内时查询 fills，否则查询 fills-history。永续的数量为张数
+func (c *Client) GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error) {
+	since, until, err := models.FillRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	instID := c.spotID(symbol)
+	req := requests.TransactionDetails{
+		InstID:   instID,
+		InstType: instType(instID),
+		Begin:    strconv.FormatInt(since.UnixMilli(), 10),
+		End:      strconv.FormatInt(until.UnixMilli(), 10),
+	}
+	fills, err := c.fills(ctx, symbol, req, time.Since(since) > fillsRecent)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(fills, func(i, j int) bool { return fills[i].Time < fills[j].Time })
+	return fills, nil
+}
+
+// fills 从新到旧按 billId 翻页，直到某页不满
+func (c *Client) fills(ctx context.Context, symbol string, req requests.TransactionDetails, arch bool) ([]models.Fill, error) {
+	req.Limit = fillsPage
+	var fills []models.Fill
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := c.rest(ctx).Trade.GetTransactionDetails(req, arch)
+		if err != nil {
+			return nil, restError(err)
+		}
+		if resp.Code != 0 {
+			return nil, sdkError(resp.Basic)
+		}
+		for _, d := range resp.TransactionDetails {
+			fills = append(fills, convertFill(symbol, d))
+		}
+		if len(resp.TransactionDetails) < fillsPage {
+			return fills, nil
+		}
+		req.After = resp.TransactionDetails[len(resp.TransactionDetails)-1].BillID
+	}
+}
+
+func convertFill(symbol string, d *trade.TransactionDetail) models.Fill {
+	return models.Fill{
+		Symbol:        echoSymbol(symbol, d.InstID),
+		OrderID:       d.OrdID,
+		ClientOrderID: d.ClOrdID,
+		TradeID:       d.TradeID,
+		Side:          orderSide(string(d.Side)),
+		Price:         models.ParseDecimalOrZero(d.FillPx),
+		Quantity:      models.ParseDecimalOrZero(d.FillSz),
+		// OKX 的手续费扣除为负、返佣为正，统一模型相反
+		Fee:      models.ParseDecimalOrZero(d.Fee).Neg(),
+		FeeAsset: d.FeeCcy,
+		Maker:    d.ExecType == sdk.OrderMakerFlow,
+		Time:     time.Time(d.TS).UnixMilli(),
+	}
+}
+
+// realFees 尽量用最近 24 小时的成交填充 fee 的实际费率。永续需要注册表中的合约面值把张数换算成币数，
+// 查不到合约或成交查询失败时不填，不影响已经取到的接口费率
+func (c *Client) realFees(fee *models.TradingFee, symbol string) {
+	instID := c.spotID(symbol)
+	contractValue := models.NewDecimalFromInt(1)
+	if instType(instID) == sdk.SwapInstrument {
+		inst, err := c.Instruments.Lookup(base.OKEX, instrument.Swap, instID)
+		if err != nil || inst.ContractValue.IsZero() {
+			return
+		}
+		contractValue = inst.ContractValue
+	}
+	parts := instrument.SplitNative(instID)
+	if len(parts) != 2 {
+		return
+	}
+	fills, err := c.GetFills(context.Background(), symbol, time.Time{}, time.Time{})
+	if err != nil {
+		return
+	}
+	fee.SetRealFees(fills, parts[0], parts[1], contractValue)
+}
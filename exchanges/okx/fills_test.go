@@ -0,0 +1,72 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetFillsPaginates(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// 第一页满 100 条（billId 1000..901），第二页 1 条
+	srv.HandleFunc(http.MethodGet, "/api/v5/trade/fills-history", func(w http.ResponseWriter, r *http.Request) {
+		first, n := 1000, fillsPage
+		if r.URL.Query().Get("after") != "" {
+			first, n = 900, 1
+		}
+		var data []string
+		for i := 0; i < n; i++ {
+			id := first - i
+			data = append(data, fmt.Sprintf(`{"instType":"SWAP","instId":"BTC-USDT-SWAP","tradeId":"%d","ordId":"1","billId":"%d","fillPx":"36000","fillSz":"2",`+
+				`"side":"sell","posSide":"short","execType":"M","feeCcy":"USDT","fee":"0.01","ts":"%d"}`, id, id, 1700000000000+int64(id)))
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[` + strings.Join(data, ",") + `]}`))
+	})
+
+	since, until := time.Now().Add(-10*24*time.Hour), time.Now()
+	fills, err := c.GetFills(context.Background(), "BTC-USDT-SWAP", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 101 || fills[0].TradeID != "900" || fills[100].TradeID != "1000" {
+		t.Fatalf("fills = %d, first %+v", len(fills), fills[0])
+	}
+	f := fills[0]
+	// 返佣为正，统一模型中为负
+	if f.Side != base.ASK || !f.Maker || f.Fee.String() != "-0.01" || f.FeeAsset != "USDT" || f.Quantity.String() != "2" || f.Time != 1700000000900 {
+		t.Fatalf("fill = %+v", f)
+	}
+
+	reqs := srv.Requests(http.MethodGet, "/api/v5/trade/fills-history")
+	if len(reqs) != 2 || len(srv.Requests(http.MethodGet, "/api/v5/trade/fills")) != 0 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	q := reqs[0].Query
+	if q.Get("instType") != "SWAP" || q.Get("instId") != "BTC-USDT-SWAP" || q.Get("limit") != "100" ||
+		q.Get("begin") != strconv.FormatInt(since.UnixMilli(), 10) || q.Get("end") != strconv.FormatInt(until.UnixMilli(), 10) {
+		t.Fatalf("query = %v", q)
+	}
+	if after := reqs[1].Query.Get("after"); after != "901" {
+		t.Fatalf("after = %s", after)
+	}
+}
+
+func TestTradingFeeFromFills(t *testing.T) {
+	c, srv := newFakeClient(t)
+	fee, err := c.GetTradingFee("BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fixture 中 taker 0.000006 BTC / 0.006 BTC，maker 0.0000032 BTC / 0.004 BTC
+	if fee.TakerFeeFromRealOrder.String() != "0.001" || fee.MakerFeeFromRealOrder.String() != "0.0008" || fee.TakerFeeFromApi.String() != "-0.001" {
+		t.Fatalf("fee = %+v", fee)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/trade/fills")[0].Query; q.Get("instId") != "BTC-USDT" || q.Get("begin") == "" {
+		t.Fatalf("fills query = %v", q)
+	}
+}
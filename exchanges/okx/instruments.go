@@ -0,0 +1,146 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/public"
+	"AxonTrading/instrument"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// spotID 现货 instId。统一符号（BTC/USDT）经 Instruments 解析，旧格式 BTC-USDT 原样使用
+func (c *Client) spotID(symbol string) string {
+	return c.nativeID(symbol, "")
+}
+
+// swapID 合约 instId。统一符号（BTC/USDT:USDT）经 Instruments 解析，旧格式 BTC-USDT 追加 -SWAP
+func (c *Client) swapID(symbol string) string {
+	return c.nativeID(symbol, "-SWAP")
+}
+
+func (c *Client) nativeID(symbol, legacySuffix string) string {
+	if !instrument.IsUnified(symbol) {
+		return symbol + legacySuffix
+	}
+	id, err := c.Instruments.Native(base.OKEX, symbol)
+	if err != nil {
+		// 无法解析时原样发送，由交易所返回错误
+		return symbol
+	}
+	return id
+}
+
+// echoSymbol 调用方使用统一符号时，返回结果里的 Symbol 也使用统一符号
+func echoSymbol(symbol, instID string) string {
+	if instrument.IsUnified(symbol) {
+		return symbol
+	}
+	return instID
+}
+
+// quantizeOrder 按合约规格校验并取整下单价格和数量。第一次下单前加载 Instruments，
+// 加载失败时原样返回，交给交易所校验
+func (c *Client) quantizeOrder(kind instrument.Kind, id, typ, price, size string) (string, string, error) {
+	_ = c.loader.Ensure(c.LoadInstruments)
+	return c.Instruments.QuantizeOrder(base.OKEX, kind, id, typ, price, size)
+}
+
+// LoadInstruments 从 /api/v5/public/instruments 加载现货、永续、交割和期权合约到 c.Instruments
+func (c *Client) LoadInstruments() error {
+	if c.Instruments == nil {
+		c.Instruments = instrument.NewRegistry()
+	}
+	families := make(map[string]bool)
+	for _, instType := range []sdk.InstrumentType{sdk.SpotInstrument, sdk.SwapInstrument, sdk.FuturesInstrument} {
+		insts, err := c.fetchInstruments(public.GetInstruments{InstType: instType})
+		if err != nil {
+			return err
+		}
+		for _, inst := range insts {
+			if inst.Kind == instrument.Futures {
+				families[inst.Base+"-"+inst.Quote] = true
+			}
+		}
+		if err := c.Instruments.Add(insts...); err != nil {
+			return err
+		}
+	}
+	// 期权必须按 instFamily 查询，取交割合约出现过的币对
+	for family := range families {
+		insts, err := c.fetchInstruments(public.GetInstruments{InstType: sdk.OptionsInstrument, InstFamily: family})
+		if err != nil {
+			return err
+		}
+		if err := c.Instruments.Add(insts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) fetchInstruments(req public.GetInstruments) ([]instrument.Instrument, error) {
+	resp, err := c.API().Rest.PublicData.GetInstruments(req)
+	if err != nil {
+		return nil, restError(err)
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("load instruments %s %s: %w", req.InstType, req.InstFamily, sdkError(resp.Basic))
+	}
+
+	insts := make([]instrument.Instrument, 0, len(resp.Instruments))
+	for _, v := range resp.Instruments {
+		sym := instrument.Symbol{Base: v.BaseCcy, Quote: v.QuoteCcy, Settle: v.SettleCcy}
+		if v.InstType != sdk.SpotInstrument {
+			// 衍生品的 baseCcy/quoteCcy 为空，从 uly（BTC-USDT）取
+			parts := strings.SplitN(v.Uly, "-", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			sym.Base, sym.Quote = parts[0], parts[1]
+		}
+		switch v.InstType {
+		case sdk.SpotInstrument:
+			sym.Kind = instrument.Spot
+		case sdk.SwapInstrument:
+			sym.Kind = instrument.Swap
+		case sdk.FuturesInstrument, sdk.OptionsInstrument:
+			sym.Kind = instrument.Futures
+			if v.InstType == sdk.OptionsInstrument {
+				sym.Kind = instrument.Option
+				sym.Strike = decimalOf(v.Stk).String()
+				sym.OptionType = string(v.OptType)
+			}
+			sym.Expiry = okxExpiry(v.InstID, v.ExpTime)
+		default:
+			continue
+		}
+		insts = append(insts, instrument.Instrument{
+			Symbol:        sym.String(),
+			Exchange:      base.OKEX,
+			Kind:          sym.Kind,
+			NativeID:      v.InstID,
+			Base:          sym.Base,
+			Quote:         sym.Quote,
+			Settle:        sym.Settle,
+			TickSize:      decimalOf(v.TickSz),
+			LotSize:       decimalOf(v.LotSz),
+			ContractValue: decimalOf(v.CtVal),
+			MinSize:       decimalOf(v.MinSz),
+			MaxLimitSize:  decimalOf(v.MaxLmtSz),
+			MaxMarketSize: decimalOf(v.MaxMktSz),
+			Live:          v.State == sdk.InstrumentLive,
+		})
+	}
+	return insts, nil
+}
+
+// okxExpiry 从 instId（BTC-USD-240927[-...]）取到期日，取不到时用 expTime
+func okxExpiry(instID string, expTime sdk.JSONTime) string {
+	parts := strings.Split(instID, "-")
+	if len(parts) >= 3 && len(parts[2]) == 6 {
+		return parts[2]
+	}
+	return time.Time(expTime).UTC().Format("060102")
+}
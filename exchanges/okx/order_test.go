@@ -0,0 +1,181 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"AxonTrading/retry"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var fastRetry = retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, Reconcile: 50 * time.Millisecond}
+
+func TestPlaceOrderReconcilesBatch(t *testing.T) {
+	var mu sync.Mutex
+	var posts []string
+	var orders []PlaceOrder
+	lookups := make(map[string]int)
+	visibleAfter := 3 // 第二个订单第 3 次查询才查得到，<= 0 表示一直查不到
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &orders)
+			posts = append(posts, r.URL.Path)
+			// 网关超时，两个订单实际上都已经到达交易所
+			w.WriteHeader(http.StatusGatewayTimeout)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v5/trade/order":
+			id := r.URL.Query().Get("clOrdId")
+			lookups[id]++
+			switch {
+			case id == orders[0].ClOrdID:
+				fmt.Fprint(w, `{"code":"0","msg":"","data":[{"ordId":"1001"}]}`)
+			case visibleAfter > 0 && lookups[id] >= visibleAfter:
+				fmt.Fprint(w, `{"code":"0","msg":"","data":[{"ordId":"1002"}]}`)
+			default:
+				fmt.Fprint(w, `{"code":"51603","msg":"Order does not exist","data":[]}`)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseUrl: srv.URL, Client: srv.Client(), Retry: fastRetry}
+	batch := func() []PlaceOrder {
+		return []PlaceOrder{
+			{InstID: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Sz: "1", Px: "100"},
+			{InstID: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Sz: "1", Px: "99"},
+		}
+	}
+	resp, err := c.placeOrder(batch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].OrdId != "1001" || resp.Data[1].OrdId != "1002" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	// 查不到的订单继续轮询，不会重新提交
+	if len(posts) != 1 {
+		t.Fatalf("posts = %v", posts)
+	}
+
+	// 期限内一直查不到时返回状态未知，已经查到的订单照常返回
+	posts, visibleAfter = nil, 0
+	resp, err = c.placeOrder(batch())
+	if !errors.Is(err, base.ErrUnknownStatus) || len(resp.Data) != 1 || resp.Data[0].OrdId != "1001" || len(posts) != 1 {
+		t.Fatalf("resp = %+v err = %v posts = %v", resp, err, posts)
+	}
+}
+
+func TestPlaceOrderPartialFailure(t *testing.T) {
+	posts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var orders []PlaceOrder
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &orders)
+		posts++
+		fmt.Fprintf(w, `{"code":"2","msg":"","data":[{"clOrdId":%q,"ordId":"1001","sCode":"0"},{"clOrdId":%q,"ordId":"","sCode":"51008","sMsg":"Insufficient balance"}]}`,
+			orders[0].ClOrdID, orders[1].ClOrdID)
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseUrl: srv.URL, Client: srv.Client(), Retry: fastRetry}
+	ids, err := c.LimitOrders("BTC-USDT", []models.OrderList{{Side: base.BID, Price: "100", Size: "1"}, {Side: base.BID, Price: "99", Size: "1"}})
+	// 挂上的订单和被拒绝的原因都要返回
+	if !errors.Is(err, base.ErrInsufficientFunds) || len(ids) != 1 || ids[0] != "1001" || posts != 1 {
+		t.Fatalf("ids = %v err = %v posts = %d", ids, err, posts)
+	}
+}
+
+func TestLimitOrderLoadsInstruments(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	// 第一次下单前加载合约，价格按 tickSz 0.1 取整
+	if _, err := c.LimitOrder("BTC-USDT", base.BID, "30000.07", "0.001"); err != nil {
+		t.Fatal(err)
+	}
+	loads := len(srv.Requests(http.MethodGet, "/api/v5/public/instruments"))
+	if loads == 0 {
+		t.Fatal("instruments not loaded before the first order")
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v5/trade/order")
+	if len(posts) != 1 {
+		t.Fatalf("posts = %d", len(posts))
+	}
+	var o PlaceOrder
+	if err := json.Unmarshal(posts[0].Body, &o); err != nil || o.Px != "30000.1" {
+		t.Fatalf("px = %q, %v", o.Px, err)
+	}
+
+	// 已加载后未知 id 在发请求前返回错误，且不再重复加载
+	if _, err := c.LimitOrder("ETH-USDT", base.BID, "2000", "1"); !errors.Is(err, base.ErrInvalidSymbol) {
+		t.Fatalf("unknown instrument err = %v", err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/trade/order")); n != 1 {
+		t.Fatalf("posts = %d", n)
+	}
+	if n := len(srv.Requests(http.MethodGet, "/api/v5/public/instruments")); n != loads {
+		t.Fatalf("instruments loaded %d times", n)
+	}
+}
+
+func TestPlaceOrderWithClientID(t *testing.T) {
+	c, srv := newFakeClient(t)
+	if _, err := c.PlaceOrderWithClientID("BTC-USDT", base.ASK, base.TAKER, "30000", "0.01", "my1"); err != nil {
+		t.Fatal(err)
+	}
+	var o PlaceOrder
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/trade/order")[0].Body, &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.ClOrdID != "my1" || o.OrdType != "ioc" || o.Px != "30000" {
+		t.Fatalf("order = %+v", o)
+	}
+	if _, err := c.PlaceOrderWithClientID("BTC-USDT", base.ASK, base.LIMITHIDDEN, "30000", "0.01", "my2"); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("hidden err = %v", err)
+	}
+}
+
+func TestFutureOrderOnDeliveryContract(t *testing.T) {
+	c, srv := newFakeClient(t)
+	srv.HandleFunc(http.MethodGet, "/api/v5/public/instruments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("instType") {
+		case "SWAP":
+			fmt.Fprint(w, `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","instType":"SWAP","uly":"BTC-USDT","settleCcy":"USDT","ctVal":"0.01","tickSz":"0.1","lotSz":"1","minSz":"1","state":"live"}]}`)
+			return
+		case "FUTURES":
+		default:
+			fmt.Fprint(w, `{"code":"0","msg":"","data":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-240927","instType":"FUTURES","uly":"BTC-USDT","instFamily":"BTC-USDT",
+			"settleCcy":"USDT","ctVal":"0.01","ctValCcy":"BTC","expTime":"1727424000000","tickSz":"0.1","lotSz":"1","minSz":"1","state":"live"}]}`)
+	})
+	srv.Handle(http.MethodGet, "/api/v5/public/convert-contract-coin", http.StatusOK,
+		`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-240927","px":"","sz":"0.2","type":"1","unit":"coin"}]}`)
+
+	// 交割合约按 Futures 查找规格，不会当作未知的永续合约拒绝
+	if _, err := c.NewFutureOrder("BTC/USDT:USDT-240927", base.BID, base.LONG, base.LIMIT, "0.02", "30000.07", "", base.CROSSED, false, false); err != nil {
+		t.Fatal(err)
+	}
+	var o PlaceOrder
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/trade/order")[0].Body, &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.InstID != "BTC-USDT-240927" || o.Px != "30000.1" {
+		t.Fatalf("order = %+v", o)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/public/convert-contract-coin")[0].Query; q.Get("instId") != "BTC-USDT-240927" || q.Get("sz") != "0.02" {
+		t.Fatalf("convert = %v", q)
+	}
+}
@@ -0,0 +1,103 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	accmodels "AxonTrading/exchanges/okx/sdk/models/account"
+	"AxonTrading/exchanges/okx/sdk/requests/rest/account"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"time"
+)
+
+// positionKinds 持仓 instType 对应的统一符号类型，杠杆持仓的 instId 与现货相同
+var positionKinds = map[sdk.InstrumentType]instrument.Kind{
+	sdk.MarginInstrument:  instrument.Spot,
+	sdk.SwapInstrument:    instrument.Swap,
+	sdk.FuturesInstrument: instrument.Futures,
+	sdk.OptionsInstrument: instrument.Option,
+}
+
+// GetPositions /account/positions 中的杠杆、永续、交割和期权持仓。只过滤一种类型时按 instType 查询，
+// 其它条件在本地过滤。合约数量为张数，希腊值取 BS 模式（美元）
+func (c *Client) GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var req account.GetPositions
+	if len(filter.Kinds) == 1 {
+		req.InstType = sdk.InstrumentType(filter.Kinds[0])
+	}
+	resp, err := c.rest(ctx).Account.GetPositions(req)
+	if err != nil {
+		return nil, restError(err)
+	}
+	if resp.Code != 0 {
+		return nil, sdkError(resp.Basic)
+	}
+	var positions []models.Position
+	for _, p := range resp.Positions {
+		if decimalOf(p.Pos).IsZero() {
+			continue
+		}
+		pos := c.convertPosition(p)
+		if filter.Match(pos) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions, nil
+}
+
+func (c *Client) convertPosition(p *accmodels.Position) models.Position {
+	symbol, err := c.Instruments.Unified(base.OKEX, positionKinds[p.InstType], p.InstID)
+	if err != nil {
+		symbol = p.InstID
+	}
+	pos := models.Position{
+		Symbol:           symbol,
+		InstrumentID:     p.InstID,
+		Kind:             string(p.InstType),
+		PositionID:       p.PosID,
+		Side:             positionSide(string(p.PosSide)),
+		PositionSide:     positionSide(string(p.PosSide)),
+		MarginType:       base.CROSSED,
+		MarginAsset:      p.Ccy,
+		Quantity:         decimalOf(p.Pos).Abs(),
+		Available:        decimalOf(p.AvailPos).Abs(),
+		EntryPrice:       decimalOf(p.AvgPx),
+		MarkPrice:        decimalOf(p.MarkPx),
+		LiquidationPrice: decimalOf(p.LiqPx),
+		Leverage:         decimalOf(p.Lever),
+		Notional:         decimalOf(p.NotionalUsd),
+		Margin:           decimalOf(p.Imr),
+		InitialMargin:    decimalOf(p.Imr),
+		MaintMargin:      decimalOf(p.Mmr),
+		UnrealizedPnl:    decimalOf(p.Upl),
+		RealizedPnl:      decimalOf(p.RealizedPnl),
+		ADLRank:          int(p.ADL),
+		Greeks: models.Greeks{
+			Delta: decimalOf(p.DeltaBS),
+			Gamma: decimalOf(p.GammaBS),
+			Theta: decimalOf(p.ThetaBS),
+			Vega:  decimalOf(p.VegaBS),
+		},
+		OpenTime:   time.Time(p.CTime).UnixMilli(),
+		UpdateTime: time.Time(p.UTime).UnixMilli(),
+	}
+	if pos.Side == "" {
+		// 买卖模式按持仓数量的正负区分方向
+		pos.Side = base.LONG
+		if decimalOf(p.Pos).Sign() < 0 {
+			pos.Side = base.SHORT
+		}
+	}
+	if p.MgnMode == sdk.MarginIsolatedMode {
+		// 逐仓的 imr 为空，初始保证金即仓位保证金
+		pos.MarginType = base.ISOLATED
+		pos.Margin = decimalOf(p.Margin)
+		pos.InitialMargin = pos.Margin
+	}
+	pos.MarginRatio = pos.IsolatedMarginRatio()
+	return pos
+}
@@ -0,0 +1,57 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetPositions(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	pos, err := c.GetPositions(ctx, models.PositionFilter{})
+	if err != nil || len(pos) != 1 {
+		t.Fatalf("positions = %+v err = %v", pos, err)
+	}
+	p := pos[0]
+	if p.Symbol != "ETH/USDT:USDT" || p.InstrumentID != "ETH-USDT-SWAP" || p.Kind != models.PositionSwap || p.PositionID != "1" {
+		t.Fatalf("instrument = %+v", p)
+	}
+	// 逐仓保证金 20 + 未实现盈亏 10，维持保证金 0.84
+	if p.Side != base.LONG || p.MarginType != base.ISOLATED || p.Quantity.String() != "10" || p.Margin.String() != "20" || p.MarginRatio.String() != "0.028" {
+		t.Fatalf("margin = %+v", p)
+	}
+	if p.ADLRank != 1 || p.Notional.String() != "210" || p.OpenTime != 1700000000000 || p.UpdateTime != 1700000000000 {
+		t.Fatalf("position = %+v", p)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/account/positions")[0].Query; q.Get("instType") != "" || q.Get("instId") != "" {
+		t.Fatalf("positions query = %v", q)
+	}
+
+	// 买卖模式的期权空头，全仓
+	srv.Handle(http.MethodGet, "/api/v5/account/positions", http.StatusOK, `{"code":"0","msg":"","data":[
+		{"instType":"OPTION","instId":"BTC-USD-240927-60000-C","posId":"2","posSide":"net","mgnMode":"cross","ccy":"BTC","pos":"-3","availPos":"3",
+		 "avgPx":"0.05","markPx":"0.04","imr":"0.3","mmr":"0.2","upl":"0.03","realizedPnl":"-0.001","adl":"2",
+		 "deltaBS":"-1.2","gammaBS":"0.0001","thetaBS":"15","vegaBS":"-30","cTime":"1700000000000","uTime":"1700000100000"},
+		{"instType":"SWAP","instId":"BTC-USDT-SWAP","posSide":"long","mgnMode":"cross","pos":"1"}]}`)
+	pos, err = c.GetPositions(ctx, models.PositionFilter{Kinds: []string{models.PositionOption}})
+	if err != nil || len(pos) != 1 {
+		t.Fatalf("options = %+v err = %v", pos, err)
+	}
+	p = pos[0]
+	if p.Symbol != "BTC/USD:BTC-240927-60000-C" || p.Side != base.SHORT || p.PositionSide != "" || p.MarginType != base.CROSSED || p.Quantity.String() != "3" {
+		t.Fatalf("option = %+v", p)
+	}
+	if p.Margin.String() != "0.3" || !p.MarginRatio.IsZero() || p.RealizedPnl.String() != "-0.001" || p.Greeks.Delta.String() != "-1.2" || p.Greeks.Vega.String() != "-30" {
+		t.Fatalf("option margin = %+v", p)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/account/positions")[1].Query; q.Get("instType") != "OPTION" {
+		t.Fatalf("option query = %v", q)
+	}
+	pos, err = c.GetPositions(ctx, models.PositionFilter{Symbols: []string{"BTC-USDT-SWAP"}})
+	if err != nil || len(pos) != 1 || pos[0].Symbol != "BTC/USDT:USDT" {
+		t.Fatalf("by symbol = %+v err = %v", pos, err)
+	}
+}
@@ -0,0 +1,159 @@
+package okx
+
+import (
+	"AxonTrading/ratelimit"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ordersRule 子账户维度的下单/改单总数限制，所有 instId 共享
+const ordersRule = "orders"
+
+// DefaultRateLimits OKX 各接口的默认限速，规则名为接口路径。
+// trade 接口按 instId 分别计数，其余接口按账户或 IP 计数。
+// https://www.okx.com/docs-v5/en/#overview-rate-limits
+var DefaultRateLimits = map[string]ratelimit.Rule{
+	ordersRule: {Limit: 1000, Interval: 2 * time.Second},
+
+	"/api/v5/trade/order":               {Limit: 60, Interval: 2 * time.Second},
+	"/api/v5/trade/batch-orders":        {Limit: 300, Interval: 2 * time.Second},
+	"/api/v5/trade/cancel-order":        {Limit: 60, Interval: 2 * time.Second},
+	"/api/v5/trade/cancel-batch-orders": {Limit: 300, Interval: 2 * time.Second},
+	"/api/v5/trade/amend-order":         {Limit: 60, Interval: 2 * time.Second},
+	"/api/v5/trade/amend-batch-orders":  {Limit: 300, Interval: 2 * time.Second},
+	"/api/v5/trade/orders-pending":      {Limit: 60, Interval: 2 * time.Second},
+	"/api/v5/trade/orders-history":      {Limit: 40, Interval: 2 * time.Second},
+	"/api/v5/trade/fills":               {Limit: 60, Interval: 2 * time.Second},
+	"/api/v5/trade/fills-history":       {Limit: 10, Interval: 2 * time.Second},
+	"/api/v5/trade/order-algo":          {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/trade/cancel-algos":        {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/trade/orders-algo-pending": {Limit: 20, Interval: 2 * time.Second},
+
+	"/api/v5/account/balance":                 {Limit: 10, Interval: 2 * time.Second},
+	"/api/v5/account/positions":               {Limit: 10, Interval: 2 * time.Second},
+	"/api/v5/account/config":                  {Limit: 5, Interval: 2 * time.Second},
+	"/api/v5/account/set-position-mode":       {Limit: 5, Interval: 2 * time.Second},
+	"/api/v5/account/set-leverage":            {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/account/position/margin-balance": {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/account/trade-fee":               {Limit: 5, Interval: 2 * time.Second},
+
+	"/api/v5/market/ticker":                {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/market/books":                 {Limit: 40, Interval: 2 * time.Second},
+	"/api/v5/public/instruments":           {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/public/funding-rate":          {Limit: 20, Interval: 2 * time.Second},
+	"/api/v5/public/mark-price":            {Limit: 10, Interval: 2 * time.Second},
+	"/api/v5/public/convert-contract-coin": {Limit: 10, Interval: 2 * time.Second},
+
+	"/api/v5/asset/currencies":         {Limit: 6, Interval: time.Second},
+	"/api/v5/asset/deposit-address":    {Limit: 6, Interval: time.Second},
+	"/api/v5/asset/transfer":           {Limit: 1, Interval: time.Second},
+	"/api/v5/asset/withdrawal":         {Limit: 6, Interval: time.Second},
+	"/api/v5/asset/withdrawal-history": {Limit: 6, Interval: time.Second},
+	"/api/v5/asset/deposit-history":    {Limit: 6, Interval: time.Second},
+
+	"/api/v5/users/subaccount/list":          {Limit: 2, Interval: 2 * time.Second},
+	"/api/v5/users/subaccount/apikey":        {Limit: 1, Interval: time.Second},
+	"/api/v5/users/subaccount/delete-apikey": {Limit: 1, Interval: time.Second},
+	"/api/v5/account/subaccount/balances":    {Limit: 6, Interval: 2 * time.Second},
+	"/api/v5/account/subaccount/transfer":    {Limit: 1, Interval: time.Second},
+}
+
+// orderEndpoints 计入子账户下单总数的接口
+var orderEndpoints = map[string]bool{
+	"/api/v5/trade/order":              true,
+	"/api/v5/trade/batch-orders":       true,
+	"/api/v5/trade/amend-order":        true,
+	"/api/v5/trade/amend-batch-orders": true,
+}
+
+// newLimiter 根据 params 中的 rateLimit 配置创建限速器
+func newLimiter(params []byte) (*ratelimit.Limiter, error) {
+	cfg, err := ratelimit.ParseConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Build(DefaultRateLimits)
+}
+
+// wait 请求前取令牌，ctx 结束时放弃等待。instIDs 为本次请求涉及的 instId，批量接口每个订单一个
+func (c *Client) wait(ctx context.Context, method, path string, instIDs []string) error {
+	if c.Limiter == nil {
+		return nil
+	}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	n := len(instIDs)
+	if n == 0 {
+		n = 1
+	}
+	var costs []ratelimit.Cost
+	if strings.HasPrefix(path, "/api/v5/trade/") && len(instIDs) > 0 {
+		perInst := make(map[string]int)
+		for _, id := range instIDs {
+			perInst[id]++
+		}
+		for id, k := range perInst {
+			costs = append(costs, ratelimit.Cost{Rule: path, Scope: id, N: k})
+		}
+	} else {
+		costs = append(costs, ratelimit.Cost{Rule: path, N: 1})
+	}
+	if method != "GET" && orderEndpoints[path] {
+		costs = append(costs, ratelimit.Cost{Rule: ordersRule, N: n})
+	}
+	return c.Limiter.Wait(ctx, costs...)
+}
+
+// limitTransport 请求前按接口路径和 instId 取令牌，SDK 发出的请求同样受限
+type limitTransport struct {
+	c    *Client
+	next http.RoundTripper
+}
+
+func (t *limitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var instIDs []string
+	if id := r.URL.Query().Get("instId"); id != "" {
+		instIDs = []string{id}
+	} else if r.Body != nil && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		b, _ := io.ReadAll(body)
+		body.Close()
+		instIDs = bodyInstIDs(b)
+	}
+	if err := t.c.wait(r.Context(), r.Method, r.URL.Path, instIDs); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(r)
+}
+
+// bodyInstIDs 从 POST 请求体中取 instId，批量请求为数组
+func bodyInstIDs(body []byte) []string {
+	var one struct {
+		InstID string `json:"instId"`
+	}
+	if json.Unmarshal(body, &one) == nil {
+		if one.InstID == "" {
+			return nil
+		}
+		return []string{one.InstID}
+	}
+	var many []struct {
+		InstID string `json:"instId"`
+	}
+	if json.Unmarshal(body, &many) != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(many))
+	for _, o := range many {
+		ids = append(ids, o.InstID)
+	}
+	return ids
+}
@@ -0,0 +1,41 @@
+package okx
+
+import (
+	"AxonTrading/models"
+	"AxonTrading/ratelimit"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimitWaitHonorsContext(t *testing.T) {
+	c, srv := newFakeClient(t)
+	c.Limiter = ratelimit.New(ratelimit.Block, map[string]ratelimit.Rule{
+		"/api/v5/account/positions": {Limit: 1, Interval: time.Hour},
+	})
+	if _, err := c.GetPositions(context.Background(), models.PositionFilter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 令牌用完后等待在 ctx 超时时结束，请求不会发出
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.GetPositions(ctx, models.PositionFilter{})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("rate limit wait ignored the request context")
+	}
+	if n := len(srv.Requests(http.MethodGet, "/api/v5/account/positions")); n != 1 {
+		t.Fatalf("requests = %d", n)
+	}
+}
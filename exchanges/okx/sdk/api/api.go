@@ -1,10 +1,10 @@
 package api
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api/rest"
+	"AxonTrading/exchanges/okx/sdk/api/ws"
 	"context"
-	"github.com/amir-the-h/okex"
-	"github.com/amir-the-h/okex/api/rest"
-	"github.com/amir-the-h/okex/api/ws"
 )
 
 // Client is the main api wrapper of okx
@@ -15,23 +15,32 @@ type Client struct {
 }
 
 // NewClient returns a pointer to a fresh Client
-func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, destination okex.Destination) (*Client, error) {
-	restURL := okex.RestURL
-	wsPubURL := okex.PublicWsURL
-	wsPriURL := okex.PrivateWsURL
+func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, destination sdk.Destination) (*Client, error) {
+	return NewClientWithURL(ctx, apiKey, secretKey, passphrase, "", destination)
+}
+
+// NewClientWithURL is NewClient with the rest base url overridden, e.g. to point at a test server.
+// An empty restURL uses the destination's default.
+func NewClientWithURL(ctx context.Context, apiKey, secretKey, passphrase string, restURL sdk.BaseURL, destination sdk.Destination) (*Client, error) {
+	defaultRestURL := sdk.RestURL
+	wsPubURL := sdk.PublicWsURL
+	wsPriURL := sdk.PrivateWsURL
 	switch destination {
-	case okex.AwsServer:
-		restURL = okex.AwsRestURL
-		wsPubURL = okex.AwsPublicWsURL
-		wsPriURL = okex.AwsPrivateWsURL
-	case okex.DemoServer:
-		restURL = okex.DemoRestURL
-		wsPubURL = okex.DemoPublicWsURL
-		wsPriURL = okex.DemoPrivateWsURL
+	case sdk.AwsServer:
+		defaultRestURL = sdk.AwsRestURL
+		wsPubURL = sdk.AwsPublicWsURL
+		wsPriURL = sdk.AwsPrivateWsURL
+	case sdk.DemoServer:
+		defaultRestURL = sdk.DemoRestURL
+		wsPubURL = sdk.DemoPublicWsURL
+		wsPriURL = sdk.DemoPrivateWsURL
+	}
+	if restURL == "" {
+		restURL = defaultRestURL
 	}
 
 	r := rest.NewClient(apiKey, secretKey, passphrase, restURL, destination)
-	c := ws.NewClient(ctx, apiKey, secretKey, passphrase, map[bool]okex.BaseURL{true: wsPriURL, false: wsPubURL})
+	c := ws.NewClient(ctx, apiKey, secretKey, passphrase, map[bool]sdk.BaseURL{true: wsPriURL, false: wsPubURL})
 
 	return &Client{r, c, ctx}, nil
 }
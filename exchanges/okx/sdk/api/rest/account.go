@@ -1,10 +1,9 @@
 package rest
 
 import (
-	"encoding/json"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/account"
-	responses "github.com/amir-the-h/okex/responses/account"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/account"
+	responses "AxonTrading/exchanges/okx/sdk/responses/account"
 	"net/http"
 	"strings"
 )
@@ -27,7 +26,7 @@ func NewAccount(c *ClientRest) *Account {
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-balance
 func (c *Account) GetBalance(req requests.GetBalance) (response responses.GetBalance, err error) {
 	p := "/api/v5/account/balance"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.Ccy) > 0 {
 		m["ccy"] = strings.Join(req.Ccy, ",")
 	}
@@ -35,9 +34,7 @@ func (c *Account) GetBalance(req requests.GetBalance) (response responses.GetBal
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -48,7 +45,7 @@ func (c *Account) GetBalance(req requests.GetBalance) (response responses.GetBal
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-positions
 func (c *Account) GetPositions(req requests.GetPositions) (response responses.GetPositions, err error) {
 	p := "/api/v5/account/positions"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.InstID) > 0 {
 		m["instId"] = strings.Join(req.InstID, ",")
 	}
@@ -59,9 +56,7 @@ func (c *Account) GetPositions(req requests.GetPositions) (response responses.Ge
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -72,14 +67,12 @@ func (c *Account) GetPositions(req requests.GetPositions) (response responses.Ge
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-account-and-position-risk
 func (c *Account) GetAccountAndPositionRisk(req requests.GetAccountAndPositionRisk) (response responses.GetAccountAndPositionRisk, err error) {
 	p := "/api/v5/account/positions"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -95,16 +88,14 @@ func (c *Account) GetAccountAndPositionRisk(req requests.GetAccountAndPositionRi
 func (c *Account) GetBills(req requests.GetBills, arc bool) (response responses.GetBills, err error) {
 	p := "/api/v5/account/bills"
 	if arc {
-		p = "/api/account/bills-archive"
+		p = "/api/v5/account/bills-archive"
 	}
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -119,9 +110,7 @@ func (c *Account) GetConfig() (response responses.GetConfig, err error) {
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -132,14 +121,12 @@ func (c *Account) GetConfig() (response responses.GetConfig, err error) {
 // https://www.okex.com/docs-v5/en/#rest-api-account-set-position-mode
 func (c *Account) SetPositionMode(req requests.SetPositionMode) (response responses.SetPositionMode, err error) {
 	p := "/api/v5/account/set-position-mode"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -157,14 +144,12 @@ func (c *Account) SetPositionMode(req requests.SetPositionMode) (response respon
 // https://www.okex.com/docs-v5/en/#rest-api-account-set-leverage
 func (c *Account) SetLeverage(req requests.SetLeverage) (response responses.Leverage, err error) {
 	p := "/api/v5/account/set-leverage"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -174,7 +159,7 @@ func (c *Account) SetLeverage(req requests.SetLeverage) (response responses.Leve
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-maximum-buy-sell-amount-or-open-amount
 func (c *Account) GetMaxBuySellAmount(req requests.GetMaxBuySellAmount) (response responses.GetMaxBuySellAmount, err error) {
 	p := "/api/v5/account/max-size"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.InstID) > 0 {
 		m["instId"] = strings.Join(req.InstID, ",")
 	}
@@ -182,9 +167,7 @@ func (c *Account) GetMaxBuySellAmount(req requests.GetMaxBuySellAmount) (respons
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -194,14 +177,12 @@ func (c *Account) GetMaxBuySellAmount(req requests.GetMaxBuySellAmount) (respons
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-maximum-available-tradable-amount
 func (c *Account) GetMaxAvailableTradeAmount(req requests.GetMaxAvailableTradeAmount) (response responses.GetMaxAvailableTradeAmount, err error) {
 	p := "/api/v5/account/max-avail-size"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -212,14 +193,12 @@ func (c *Account) GetMaxAvailableTradeAmount(req requests.GetMaxAvailableTradeAm
 // https://www.okex.com/docs-v5/en/#rest-api-account-increase-decrease-margin
 func (c *Account) IncreaseDecreaseMargin(req requests.IncreaseDecreaseMargin) (response responses.IncreaseDecreaseMargin, err error) {
 	p := "/api/v5/account/position/margin-balance"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -229,7 +208,7 @@ func (c *Account) IncreaseDecreaseMargin(req requests.IncreaseDecreaseMargin) (r
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-leverage
 func (c *Account) GetLeverage(req requests.GetLeverage) (response responses.Leverage, err error) {
 	p := "/api/v5/account/leverage-info"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.InstID) > 0 {
 		m["instId"] = strings.Join(req.InstID, ",")
 	}
@@ -237,9 +216,7 @@ func (c *Account) GetLeverage(req requests.GetLeverage) (response responses.Leve
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -249,14 +226,12 @@ func (c *Account) GetLeverage(req requests.GetLeverage) (response responses.Leve
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-the-maximum-loan-of-instrument
 func (c *Account) GetMaxLoan(req requests.GetMaxLoan) (response responses.GetMaxLoan, err error) {
 	p := "/api/v5/account/max-loan"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -266,14 +241,12 @@ func (c *Account) GetMaxLoan(req requests.GetMaxLoan) (response responses.GetMax
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-fee-rates
 func (c *Account) GetFeeRates(req requests.GetFeeRates) (response responses.GetFeeRates, err error) {
 	p := "/api/v5/account/trade-fee"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -283,14 +256,12 @@ func (c *Account) GetFeeRates(req requests.GetFeeRates) (response responses.GetF
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-interest-accrued
 func (c *Account) GetInterestAccrued(req requests.GetInterestAccrued) (response responses.GetInterestAccrued, err error) {
 	p := "/api/v5/account/interest-accrued"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -301,7 +272,7 @@ func (c *Account) GetInterestAccrued(req requests.GetInterestAccrued) (response
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-interest-rate
 func (c *Account) GetInterestRates(req requests.GetBalance) (response responses.GetInterestRates, err error) {
 	p := "/api/v5/account/interest-rate"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.Ccy) > 0 {
 		m["ccy"] = strings.Join(req.Ccy, ",")
 	}
@@ -309,9 +280,7 @@ func (c *Account) GetInterestRates(req requests.GetBalance) (response responses.
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -322,14 +291,12 @@ func (c *Account) GetInterestRates(req requests.GetBalance) (response responses.
 // https://www.okex.com/docs-v5/en/#rest-api-account-set-greeks-m-bs
 func (c *Account) SetGreeks(req requests.SetGreeks) (response responses.SetGreeks, err error) {
 	p := "/api/v5/account/set-greeks"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -339,7 +306,7 @@ func (c *Account) SetGreeks(req requests.SetGreeks) (response responses.SetGreek
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-maximum-withdrawals
 func (c *Account) GetMaxWithdrawals(req requests.GetBalance) (response responses.GetMaxWithdrawals, err error) {
 	p := "/api/v5/account/max-withdrawal"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.Ccy) > 0 {
 		m["ccy"] = strings.Join(req.Ccy, ",")
 	}
@@ -347,9 +314,7 @@ func (c *Account) GetMaxWithdrawals(req requests.GetBalance) (response responses
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
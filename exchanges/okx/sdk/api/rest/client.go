@@ -1,16 +1,19 @@
 package rest
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/public"
+	responses "AxonTrading/exchanges/okx/sdk/responses/public_data"
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/public"
-	responses "github.com/amir-the-h/okex/responses/public_data"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -27,13 +30,14 @@ type ClientRest struct {
 	apiKey      string
 	secretKey   []byte
 	passphrase  string
-	destination okex.Destination
-	baseURL     okex.BaseURL
+	destination sdk.Destination
+	baseURL     sdk.BaseURL
 	client      *http.Client
+	ctx         context.Context
 }
 
 // NewClient returns a pointer to a fresh ClientRest
-func NewClient(apiKey, secretKey, passphrase string, baseURL okex.BaseURL, destination okex.Destination) *ClientRest {
+func NewClient(apiKey, secretKey, passphrase string, baseURL sdk.BaseURL, destination sdk.Destination) *ClientRest {
 	c := &ClientRest{
 		apiKey:      apiKey,
 		secretKey:   []byte(secretKey),
@@ -41,7 +45,22 @@ func NewClient(apiKey, secretKey, passphrase string, baseURL okex.BaseURL, desti
 		baseURL:     baseURL,
 		destination: destination,
 		client:      http.DefaultClient,
+		ctx:         context.Background(),
 	}
+	c.bind()
+	return c
+}
+
+// WithContext returns a copy of the client whose requests carry ctx; cancelling ctx aborts
+// the request, including a wait in a rate limiting transport
+func (c *ClientRest) WithContext(ctx context.Context) *ClientRest {
+	cp := *c
+	cp.ctx = ctx
+	cp.bind()
+	return &cp
+}
+
+func (c *ClientRest) bind() {
 	c.Account = NewAccount(c)
 	c.SubAccount = NewSubAccount(c)
 	c.Trade = NewTrade(c)
@@ -49,52 +68,58 @@ func NewClient(apiKey, secretKey, passphrase string, baseURL okex.BaseURL, desti
 	c.Market = NewMarket(c)
 	c.PublicData = NewPublicData(c)
 	c.TradeData = NewTradeData(c)
-	return c
+}
+
+// SetHTTPClient replaces the http.Client used for requests, e.g. to add a timeout or rate limiting transport
+func (c *ClientRest) SetHTTPClient(h *http.Client) {
+	c.client = h
 }
 
 // Do the http request to the server
 func (c *ClientRest) Do(method, path string, private bool, params ...map[string]string) (*http.Response, error) {
-	u := fmt.Sprintf("%s%s", c.baseURL, path)
-	var (
-		r    *http.Request
-		err  error
-		j    []byte
-		body string
-	)
 	if method == http.MethodGet {
-		r, err = http.NewRequest(http.MethodGet, u, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(params) > 0 {
-			q := r.URL.Query()
+		if len(params) > 0 && len(params[0]) > 0 {
+			q := url.Values{}
 			for k, v := range params[0] {
 				q.Add(k, strings.ReplaceAll(v, "\"", ""))
 			}
-			r.URL.RawQuery = q.Encode()
-			if len(params[0]) > 0 {
-				path += "?" + r.URL.RawQuery
-			}
+			path += "?" + q.Encode()
 		}
-	} else {
+		return c.send(method, path, private, nil)
+	}
+	var j []byte
+	if len(params) > 0 {
+		var err error
 		j, err = json.Marshal(params[0])
 		if err != nil {
 			return nil, err
 		}
-		body = string(j)
-		if body == "{}" {
-			body = ""
-		}
-		r, err = http.NewRequest(method, u, bytes.NewBuffer(j))
-		if err != nil {
-			return nil, err
-		}
-		r.Header.Add("Content-Type", "application/json")
 	}
+	return c.send(method, path, private, j)
+}
+
+// DoBody sends a request with a raw JSON body, used by batch endpoints whose body is an array
+func (c *ClientRest) DoBody(method, path string, private bool, body []byte) (*http.Response, error) {
+	return c.send(method, path, private, body)
+}
+
+// send signs and sends the request, path includes the query string
+func (c *ClientRest) send(method, path string, private bool, j []byte) (*http.Response, error) {
+	body := string(j)
+	if body == "{}" {
+		body = ""
+	}
+	var rb io.Reader
+	if method != http.MethodGet {
+		rb = bytes.NewBuffer(j)
+	}
+	r, err := http.NewRequestWithContext(c.ctx, method, fmt.Sprintf("%s%s", c.baseURL, path), rb)
 	if err != nil {
 		return nil, err
 	}
+	if method != http.MethodGet {
+		r.Header.Add("Content-Type", "application/json")
+	}
 	if private {
 		timestamp, sign := c.sign(method, path, body)
 		r.Header.Add("OK-ACCESS-KEY", c.apiKey)
@@ -102,26 +127,45 @@ func (c *ClientRest) Do(method, path string, private bool, params ...map[string]
 		r.Header.Add("OK-ACCESS-SIGN", sign)
 		r.Header.Add("OK-ACCESS-TIMESTAMP", timestamp)
 	}
-	if c.destination == okex.DemoServer {
+	if c.destination == sdk.DemoServer {
 		r.Header.Add("x-simulated-trading", "1")
 	}
 	return c.client.Do(r)
 }
 
+// HTTPError is returned when the server answers with a status other than 200.
+// Body is the raw response, which may be an API error ({"code":"...","msg":"..."}) or a gateway page
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("okx: http status %d: %s", e.StatusCode, e.Body)
+}
+
+// decode reads the response into v and closes the body, non-200 responses are returned as *HTTPError
+func decode(res *http.Response, v interface{}) error {
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return &HTTPError{StatusCode: res.StatusCode, Body: body}
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
 // Status
 // Get event status of system upgrade
 //
 // https://www.okex.com/docs-v5/en/#rest-api-status
 func (c *ClientRest) Status(req requests.Status) (response responses.Status, err error) {
 	p := "/api/v5/system/status"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"AxonTrading/exchanges/exchangetest"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/trade"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func newTestClient(t *testing.T) (*ClientRest, *exchangetest.Server) {
+	t.Helper()
+	creds := exchangetest.Credentials{APIKey: "key", SecretKey: "secret", Passphrase: "pass"}
+	srv := exchangetest.NewOKX(creds)
+	t.Cleanup(srv.Close)
+	return NewClient(creds.APIKey, creds.SecretKey, creds.Passphrase, sdk.BaseURL(srv.URL), sdk.NormalServer), srv
+}
+
+func TestBatchOrdersSendArray(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	resp, err := c.Trade.CandleOrder([]requests.CancelOrder{
+		{InstID: "BTC-USDT", OrdID: "680800019749904390"},
+		{InstID: "BTC-USDT", OrdID: "680800019749904391"},
+	})
+	if err != nil || resp.Code != 0 {
+		t.Fatalf("cancel = %+v err = %v", resp, err)
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v5/trade/cancel-batch-orders")
+	if len(posts) != 1 {
+		t.Fatalf("requests = %+v", srv.Requests("", ""))
+	}
+	var body []map[string]string
+	if err := json.Unmarshal(posts[0].Body, &body); err != nil || len(body) != 2 || body[1]["ordId"] != "680800019749904391" {
+		t.Fatalf("body = %s err = %v", posts[0].Body, err)
+	}
+}
+
+func TestPlaceOrderKeepsOrderID(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	resp, err := c.Trade.PlaceOrder([]requests.PlaceOrder{{InstID: "BTC-USDT", TdMode: sdk.TradeCashMode, Side: sdk.OrderBuy, OrdType: sdk.OrderLimit, Sz: "0.01", Px: "36000"}})
+	if err != nil || resp.Code != 0 || len(resp.PlaceOrders) != 1 {
+		t.Fatalf("place = %+v err = %v", resp, err)
+	}
+	// 18 位订单号超出 float64 精度，必须按字符串解析
+	if id := resp.PlaceOrders[0].OrdID; len(id) != 18 {
+		t.Fatalf("ordId = %s", id)
+	}
+}
+
+func TestNon200ReturnsHTTPError(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.Handle(http.MethodGet, "/api/v5/trade/order", http.StatusGatewayTimeout, "<html>504 Gateway Time-out</html>")
+
+	_, err := c.Trade.GetOrderDetail(requests.OrderDetails{InstID: "BTC-USDT", OrdID: "1"})
+	// 网关返回的不是 JSON，状态码和响应体都要保留
+	var he *HTTPError
+	if !errors.As(err, &he) || he.StatusCode != http.StatusGatewayTimeout || string(he.Body) != "<html>504 Gateway Time-out</html>" {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -1,10 +1,9 @@
 package rest
 
 import (
-	"encoding/json"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/funding"
-	responses "github.com/amir-the-h/okex/responses/funding"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/funding"
+	responses "AxonTrading/exchanges/okx/sdk/responses/funding"
 	"net/http"
 	"strings"
 )
@@ -25,17 +24,14 @@ func NewFunding(c *ClientRest) *Funding {
 // Retrieve a list of all currencies. Not all currencies can be traded. Currencies that have not been defined in ISO 4217 may use a custom symbol.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-currencies
-func (c *Funding) GetCurrencies() (response responses.GetCurrencies, err error) {
+func (c *Funding) GetCurrencies(req requests.GetCurrencies) (response responses.GetCurrencies, err error) {
 	p := "/api/v5/asset/currencies"
-
-	res, err := c.client.Do(http.MethodGet, p, true)
+	m := sdk.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 
 	return
 }
@@ -46,7 +42,7 @@ func (c *Funding) GetCurrencies() (response responses.GetCurrencies, err error)
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-balance
 func (c *Funding) GetBalance(req requests.GetBalance) (response responses.GetBalance, err error) {
 	p := "/api/v5/asset/balances"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.Ccy) > 0 {
 		m["ccy"] = strings.Join(req.Ccy, ",")
 	}
@@ -54,9 +50,7 @@ func (c *Funding) GetBalance(req requests.GetBalance) (response responses.GetBal
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -66,14 +60,12 @@ func (c *Funding) GetBalance(req requests.GetBalance) (response responses.GetBal
 // https://www.okex.com/docs-v5/en/#rest-api-funding-funds-transfer
 func (c *Funding) FundsTransfer(req requests.FundsTransfer) (response responses.FundsTransfer, err error) {
 	p := "/api/v5/asset/transfer"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -83,14 +75,12 @@ func (c *Funding) FundsTransfer(req requests.FundsTransfer) (response responses.
 // https://www.okex.com/docs-v5/en/#rest-api-funding-asset-bills-details
 func (c *Funding) AssetBillsDetails(req requests.AssetBillsDetails) (response responses.AssetBillsDetails, err error) {
 	p := "/api/v5/asset/bills"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -100,14 +90,12 @@ func (c *Funding) AssetBillsDetails(req requests.AssetBillsDetails) (response re
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-deposit-address
 func (c *Funding) GetDepositAddress(req requests.GetDepositAddress) (response responses.GetDepositAddress, err error) {
 	p := "/api/v5/asset/deposit-address"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -117,14 +105,12 @@ func (c *Funding) GetDepositAddress(req requests.GetDepositAddress) (response re
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-deposit-history
 func (c *Funding) GetDepositHistory(req requests.GetDepositHistory) (response responses.GetDepositHistory, err error) {
 	p := "/api/v5/asset/deposit-history"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -134,14 +120,12 @@ func (c *Funding) GetDepositHistory(req requests.GetDepositHistory) (response re
 // https://www.okex.com/docs-v5/en/#rest-api-funding-withdrawal
 func (c *Funding) Withdrawal(req requests.Withdrawal) (response responses.Withdrawal, err error) {
 	p := "/api/v5/asset/withdrawal"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -151,14 +135,12 @@ func (c *Funding) Withdrawal(req requests.Withdrawal) (response responses.Withdr
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-withdrawal-history
 func (c *Funding) GetWithdrawalHistory(req requests.GetWithdrawalHistory) (response responses.GetWithdrawalHistory, err error) {
 	p := "/api/v5/asset/withdrawal-history"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -167,14 +149,12 @@ func (c *Funding) GetWithdrawalHistory(req requests.GetWithdrawalHistory) (respo
 // https://www.okex.com/docs-v5/en/#rest-api-funding-piggybank-purchase-redemption
 func (c *Funding) PiggyBankPurchaseRedemption(req requests.PiggyBankPurchaseRedemption) (response responses.PiggyBankPurchaseRedemption, err error) {
 	p := "/api/v5/asset/purchase_redempt"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -183,13 +163,11 @@ func (c *Funding) PiggyBankPurchaseRedemption(req requests.PiggyBankPurchaseRede
 // https://www.okex.com/docs-v5/en/#rest-api-funding-get-piggybank-balance
 func (c *Funding) GetPiggyBankBalance(req requests.GetPiggyBankBalance) (response responses.GetPiggyBankBalance, err error) {
 	p := "/api/v5/asset/piggy-balance"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
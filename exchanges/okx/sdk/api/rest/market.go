@@ -1,10 +1,9 @@
 package rest
 
 import (
-	"encoding/json"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/market"
-	responses "github.com/amir-the-h/okex/responses/market"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/market"
+	responses "AxonTrading/exchanges/okx/sdk/responses/market"
 	"net/http"
 )
 
@@ -26,14 +25,12 @@ func NewMarket(c *ClientRest) *Market {
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-tickers
 func (c *Market) GetTickers(req requests.GetTickers) (response responses.Ticker, err error) {
 	p := "/api/v5/market/tickers"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -41,16 +38,14 @@ func (c *Market) GetTickers(req requests.GetTickers) (response responses.Ticker,
 // Retrieve the latest price snapshot, best bid/ask price, and trading volume in the last 24 hours.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-ticker
-func (c *Market) GetTicker(req requests.GetTickers) (response responses.Ticker, err error) {
+func (c *Market) GetTicker(req requests.GetTicker) (response responses.Ticker, err error) {
 	p := "/api/v5/market/ticker"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -60,14 +55,12 @@ func (c *Market) GetTicker(req requests.GetTickers) (response responses.Ticker,
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-index-tickers
 func (c *Market) GetIndexTickers(req requests.GetIndexTickers) (response responses.Ticker, err error) {
 	p := "/api/v5/market/ticker"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -77,14 +70,12 @@ func (c *Market) GetIndexTickers(req requests.GetIndexTickers) (response respons
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-order-book
 func (c *Market) GetOrderBook(req requests.GetOrderBook) (response responses.OrderBook, err error) {
 	p := "/api/v5/market/books"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -94,14 +85,12 @@ func (c *Market) GetOrderBook(req requests.GetOrderBook) (response responses.Ord
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-candlesticks
 func (c *Market) GetCandlesticks(req requests.GetCandlesticks) (response responses.Candle, err error) {
 	p := "/api/v5/market/candles"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -111,14 +100,12 @@ func (c *Market) GetCandlesticks(req requests.GetCandlesticks) (response respons
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-candlesticks
 func (c *Market) GetCandlesticksHistory(req requests.GetCandlesticks) (response responses.Candle, err error) {
 	p := "/api/v5/market/history-candles"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -128,14 +115,12 @@ func (c *Market) GetCandlesticksHistory(req requests.GetCandlesticks) (response
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-index-candlesticks
 func (c *Market) GetIndexCandlesticks(req requests.GetCandlesticks) (response responses.IndexCandle, err error) {
 	p := "/api/v5/market/index-candles"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -145,14 +130,12 @@ func (c *Market) GetIndexCandlesticks(req requests.GetCandlesticks) (response re
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-mark-price-candlesticks
 func (c *Market) GetMarkPriceCandlesticks(req requests.GetCandlesticks) (response responses.CandleMarket, err error) {
 	p := "/api/v5/market/mark-price-candles"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -162,14 +145,12 @@ func (c *Market) GetMarkPriceCandlesticks(req requests.GetCandlesticks) (respons
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-trades
 func (c *Market) GetTrades(req requests.GetTrades) (response responses.Trade, err error) {
 	p := "/api/v5/market/trades"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -183,9 +164,7 @@ func (c *Market) Get24HTotalVolume() (response responses.TotalVolume24H, err err
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -195,13 +174,11 @@ func (c *Market) Get24HTotalVolume() (response responses.TotalVolume24H, err err
 // https://www.okex.com/docs-v5/en/#rest-api-market-data-get-index-components
 func (c *Market) GetIndexComponents(req requests.GetIndexComponents) (response responses.IndexComponent, err error) {
 	p := "/api/v5/market/index-components"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
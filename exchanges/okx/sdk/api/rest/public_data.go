@@ -1,10 +1,9 @@
 package rest
 
 import (
-	"encoding/json"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/public"
-	responses "github.com/amir-the-h/okex/responses/public_data"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/public"
+	responses "AxonTrading/exchanges/okx/sdk/responses/public_data"
 	"net/http"
 )
 
@@ -26,14 +25,12 @@ func NewPublicData(c *ClientRest) *PublicData {
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-instruments
 func (c *PublicData) GetInstruments(req requests.GetInstruments) (response responses.GetInstruments, err error) {
 	p := "/api/v5/public/instruments"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -43,14 +40,12 @@ func (c *PublicData) GetInstruments(req requests.GetInstruments) (response respo
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-instruments
 func (c *PublicData) GetDeliveryExerciseHistory(req requests.GetDeliveryExerciseHistory) (response responses.GetDeliveryExerciseHistory, err error) {
 	p := "/api/v5/public/delivery-exercise-history"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -60,14 +55,42 @@ func (c *PublicData) GetDeliveryExerciseHistory(req requests.GetDeliveryExercise
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-open-interest
 func (c *PublicData) GetOpenInterest(req requests.GetOpenInterest) (response responses.GetOpenInterest, err error) {
 	p := "/api/v5/public/open-interest"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
+	return
+}
+
+// GetFundingRate
+// Retrieve funding rate.
+//
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-get-funding-rate
+func (c *PublicData) GetFundingRate(req requests.GetFundingRate) (response responses.GetFundingRate, err error) {
+	p := "/api/v5/public/funding-rate"
+	m := sdk.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, false, m)
+	if err != nil {
+		return
+	}
+	err = decode(res, &response)
+	return
+}
+
+// ConvertContractCoin
+// Convert the crypto value to the number of contracts, or vice versa.
+//
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-unit-convert
+func (c *PublicData) ConvertContractCoin(req requests.ConvertContractCoin) (response responses.ConvertContractCoin, err error) {
+	p := "/api/v5/public/convert-contract-coin"
+	m := sdk.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, false, m)
+	if err != nil {
+		return
+	}
+	err = decode(res, &response)
 	return
 }
 
@@ -77,14 +100,12 @@ func (c *PublicData) GetOpenInterest(req requests.GetOpenInterest) (response res
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-limit-price
 func (c *PublicData) GetLimitPrice(req requests.GetLimitPrice) (response responses.GetLimitPrice, err error) {
 	p := "/api/v5/public/price-limit"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -94,14 +115,12 @@ func (c *PublicData) GetLimitPrice(req requests.GetLimitPrice) (response respons
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-option-market-data
 func (c *PublicData) GetOptionMarketData(req requests.GetOptionMarketData) (response responses.GetOptionMarketData, err error) {
 	p := "/api/v5/public/opt-summary"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -111,14 +130,12 @@ func (c *PublicData) GetOptionMarketData(req requests.GetOptionMarketData) (resp
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-estimated-delivery-Exercise-price
 func (c *PublicData) GetEstimatedDeliveryExercisePrice(req requests.GetEstimatedDeliveryExercisePrice) (response responses.GetEstimatedDeliveryExercisePrice, err error) {
 	p := "/api/v5/public/estimated-price"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -128,14 +145,12 @@ func (c *PublicData) GetEstimatedDeliveryExercisePrice(req requests.GetEstimated
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-discount-rate-and-interest-free-quota
 func (c *PublicData) GetDiscountRateAndInterestFreeQuota(req requests.GetDiscountRateAndInterestFreeQuota) (response responses.GetDiscountRateAndInterestFreeQuota, err error) {
 	p := "/api/v5/public/discount-rate-interest-free-quota"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -149,9 +164,7 @@ func (c *PublicData) GetSystemTime() (response responses.GetSystemTime, err erro
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -161,14 +174,12 @@ func (c *PublicData) GetSystemTime() (response responses.GetSystemTime, err erro
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-liquidation-orders
 func (c *PublicData) GetLiquidationOrders(req requests.GetLiquidationOrders) (response responses.GetLiquidationOrders, err error) {
 	p := "/api/v5/public/liquidation-orders"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -180,14 +191,12 @@ func (c *PublicData) GetLiquidationOrders(req requests.GetLiquidationOrders) (re
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-mark-price
 func (c *PublicData) GetMarkPrice(req requests.GetMarkPrice) (response responses.GetMarkPrice, err error) {
 	p := "/api/v5/public/mark-price"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -197,14 +206,12 @@ func (c *PublicData) GetMarkPrice(req requests.GetMarkPrice) (response responses
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-position-tiers
 func (c *PublicData) GetPositionTiers(req requests.GetPositionTiers) (response responses.GetPositionTiers, err error) {
 	p := "/api/v5/public/position-tiers"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -218,9 +225,7 @@ func (c *PublicData) GetInterestRateAndLoanQuota() (response responses.GetIntere
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -229,13 +234,11 @@ func (c *PublicData) GetInterestRateAndLoanQuota() (response responses.GetIntere
 // https://www.okex.com/docs-v5/en/#rest-api-public-data-get-underlying
 func (c *PublicData) GetUnderlying(req requests.GetUnderlying) (response responses.GetUnderlying, err error) {
 	p := "/api/v5/public/underlying"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, false, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
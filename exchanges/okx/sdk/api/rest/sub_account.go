@@ -1,10 +1,9 @@
 package rest
 
 import (
-	"encoding/json"
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/rest/subaccount"
-	responses "github.com/amir-the-h/okex/responses/sub_account"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/subaccount"
+	responses "AxonTrading/exchanges/okx/sdk/responses/sub_account"
 	"net/http"
 	"strings"
 )
@@ -27,14 +26,12 @@ func NewSubAccount(c *ClientRest) *SubAccount {
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-view-sub-account-list
 func (c *SubAccount) ViewList(req requests.ViewList) (response responses.ViewList, err error) {
 	p := "/api/v5/users/subaccount/list"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -44,7 +41,7 @@ func (c *SubAccount) ViewList(req requests.ViewList) (response responses.ViewLis
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-create-an-apikey-for-a-sub-account
 func (c *SubAccount) CreateAPIKey(req requests.CreateAPIKey) (response responses.APIKey, err error) {
 	p := "/api/v5/users/subaccount/apikey"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.IP) > 0 {
 		m["ip"] = strings.Join(req.IP, ",")
 	}
@@ -52,9 +49,7 @@ func (c *SubAccount) CreateAPIKey(req requests.CreateAPIKey) (response responses
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -64,14 +59,12 @@ func (c *SubAccount) CreateAPIKey(req requests.CreateAPIKey) (response responses
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-query-the-apikey-of-a-sub-account
 func (c *SubAccount) QueryAPIKey(req requests.QueryAPIKey) (response responses.APIKey, err error) {
 	p := "/api/v5/users/subaccount/apikey"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -79,9 +72,9 @@ func (c *SubAccount) QueryAPIKey(req requests.QueryAPIKey) (response responses.A
 // applies to master accounts only
 //
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-reset-the-apikey-of-a-sub-account
-func (c *SubAccount) ResetAPIKey(req requests.CreateAPIKey) (response responses.APIKey, err error) {
+func (c *SubAccount) ResetAPIKey(req requests.ModifyAPIKey) (response responses.APIKey, err error) {
 	p := "/api/v5/users/subaccount/modify-apikey"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(req.IP) > 0 {
 		m["ip"] = strings.Join(req.IP, ",")
 	}
@@ -89,9 +82,7 @@ func (c *SubAccount) ResetAPIKey(req requests.CreateAPIKey) (response responses.
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -101,14 +92,12 @@ func (c *SubAccount) ResetAPIKey(req requests.CreateAPIKey) (response responses.
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-delete-the-apikey-of-sub-accounts
 func (c *SubAccount) DeleteAPIKey(req requests.DeleteAPIKey) (response responses.APIKey, err error) {
 	p := "/api/v5/users/subaccount/delete-apikey"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -119,14 +108,12 @@ func (c *SubAccount) DeleteAPIKey(req requests.DeleteAPIKey) (response responses
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-get-sub-account-balance
 func (c *SubAccount) GetBalance(req requests.GetBalance) (response responses.GetBalance, err error) {
 	p := "/api/v5/account/subaccount/balances"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -136,14 +123,12 @@ func (c *SubAccount) GetBalance(req requests.GetBalance) (response responses.Get
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-history-of-sub-account-transfer
 func (c *SubAccount) HistoryTransfer(req requests.HistoryTransfer) (response responses.HistoryTransfer, err error) {
 	p := "/api/v5/account/subaccount/bills"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
 
@@ -153,13 +138,11 @@ func (c *SubAccount) HistoryTransfer(req requests.HistoryTransfer) (response res
 // https://www.okex.com/docs-v5/en/#rest-api-subaccount-master-accounts-manage-the-transfers-between-sub-accounts
 func (c *SubAccount) ManageTransfers(req requests.ManageTransfers) (response responses.ManageTransfer, err error) {
 	p := "/api/v5/account/subaccount/transfer"
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	res, err := c.client.Do(http.MethodPost, p, true, m)
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
-	d := json.NewDecoder(res.Body)
-	err = d.Decode(&response)
+	err = decode(res, &response)
 	return
 }
@@ -1,26 +1,35 @@
 package ws
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/events"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/amir-the-h/okex"
-	"github.com/amir-the-h/okex/events"
 	"github.com/gorilla/websocket"
 	"net/http"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ClientWs is the websocket api client
 //
+// A connection that drops (read or write error, or no message within pongWait) is redialed
+// with exponential backoff. The private connection logs in again, then every active
+// subscription is replayed. Progress is reported on ReconnectChan.
+//
 // https://www.okex.com/docs-v5/en/#websocket-api
 type ClientWs struct {
-	Cancel              context.CancelFunc
-	DoneChan            chan interface{}
+	Cancel   context.CancelFunc
+	DoneChan chan interface{}
+	// StructuredEventChan receives every parsed event after its typed channel, if any.
+	// Set it to nil when only typed channels are read, otherwise the dispatch queues fill up.
 	StructuredEventChan chan interface{}
 	RawEventChan        chan *events.Basic
 	ErrChan             chan *events.Error
@@ -28,20 +37,35 @@ type ClientWs struct {
 	UnsubscribeCh       chan *events.Unsubscribe
 	LoginChan           chan *events.Login
 	SuccessChan         chan *events.Success
-	sendChan            map[bool]chan []byte
-	url                 map[bool]okex.BaseURL
-	conn                map[bool]*websocket.Conn
-	apiKey              string
-	secretKey           []byte
-	passphrase          string
-	lastTransmit        map[bool]*time.Time
-	mu                  map[bool]*sync.RWMutex
-	AuthRequested       *time.Time
-	Authorized          bool
-	Private             *Private
-	Public              *Public
-	Trade               *Trade
-	ctx                 context.Context
+	// ReconnectChan receives disconnect, reconnect and resync events.
+	// It is buffered; events are dropped while it is full.
+	ReconnectChan chan *events.Reconnect
+	// ReconnectMinDelay and ReconnectMaxDelay bound the backoff between redials
+	ReconnectMinDelay time.Duration
+	ReconnectMaxDelay time.Duration
+	sendChan          map[bool]chan []byte
+	url               map[bool]sdk.BaseURL
+	conn              map[bool]*websocket.Conn
+	done              map[bool]chan struct{}
+	reconnecting      map[bool]bool
+	subs              map[bool]map[string]map[string]string
+	apiKey            string
+	secretKey         []byte
+	passphrase        string
+	lastTransmit      map[bool]*time.Time
+	mu                map[bool]*sync.RWMutex
+	dispatcher        *dispatcher
+	reqMu             sync.Mutex // guards pending
+	dialMu            sync.Mutex // serializes the dials of Request
+	reqSeq            atomic.Uint64
+	pending           map[string]*pending
+	authMu            sync.Mutex // guards AuthRequested and Authorized
+	AuthRequested     *time.Time
+	Authorized        bool
+	Private           *Private
+	Public            *Public
+	Trade             *Trade
+	ctx               context.Context
 }
 
 const (
@@ -49,10 +73,18 @@ const (
 	writeWait  = 3 * time.Second
 	pongWait   = 30 * time.Second
 	PingPeriod = (pongWait * 8) / 10
+
+	// DefaultReconnectMinDelay and DefaultReconnectMaxDelay are used when the client fields are zero
+	DefaultReconnectMinDelay = 500 * time.Millisecond
+	DefaultReconnectMaxDelay = 30 * time.Second
+
+	loginTimeout = 10 * time.Second
+	// maxSubscribeSize the total length of channels in one subscribe request
+	maxSubscribeSize = 4096
 )
 
 // NewClient returns a pointer to a fresh ClientWs
-func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, url map[bool]okex.BaseURL) *ClientWs {
+func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, url map[bool]sdk.BaseURL) *ClientWs {
 	ctx, cancel := context.WithCancel(ctx)
 	c := &ClientWs{
 		apiKey:              apiKey,
@@ -65,9 +97,15 @@ func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, url ma
 		DoneChan:            make(chan interface{}),
 		StructuredEventChan: make(chan interface{}),
 		RawEventChan:        make(chan *events.Basic),
+		ReconnectChan:       make(chan *events.Reconnect, 16),
 		conn:                make(map[bool]*websocket.Conn),
+		done:                make(map[bool]chan struct{}),
+		reconnecting:        make(map[bool]bool),
+		subs:                map[bool]map[string]map[string]string{true: {}, false: {}},
 		lastTransmit:        make(map[bool]*time.Time),
 		mu:                  map[bool]*sync.RWMutex{true: {}, false: {}},
+		dispatcher:          newDispatcher(ctx),
+		pending:             make(map[string]*pending),
 	}
 	c.Private = NewPrivate(c)
 	c.Public = NewPublic(c)
@@ -79,7 +117,11 @@ func NewClient(ctx context.Context, apiKey, secretKey, passphrase string, url ma
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-connect
 func (c *ClientWs) Connect(p bool) error {
-	if c.conn[p] != nil {
+	c.mu[p].RLock()
+	up := c.conn[p] != nil || c.reconnecting[p]
+	c.mu[p].RUnlock()
+	if up {
+		// while reconnecting, messages wait in sendChan until the connection is resynced
 		return nil
 	}
 	err := c.dial(p)
@@ -105,18 +147,22 @@ func (c *ClientWs) Connect(p bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-login
 func (c *ClientWs) Login() error {
-	if c.Authorized {
-		return nil
-	}
-	if c.AuthRequested != nil && time.Since(*c.AuthRequested).Seconds() < 30 {
+	c.authMu.Lock()
+	if c.Authorized || c.AuthRequested != nil && time.Since(*c.AuthRequested).Seconds() < 30 {
+		c.authMu.Unlock()
 		return nil
 	}
 	now := time.Now()
 	c.AuthRequested = &now
+	c.authMu.Unlock()
+	return c.Send(true, sdk.LoginOperation, c.loginArgs())
+}
+
+func (c *ClientWs) loginArgs() []map[string]string {
 	method := http.MethodGet
 	path := "/users/self/verify"
 	ts, sign := c.sign(method, path)
-	args := []map[string]string{
+	return []map[string]string{
 		{
 			"apiKey":     c.apiKey,
 			"passphrase": c.passphrase,
@@ -124,14 +170,14 @@ func (c *ClientWs) Login() error {
 			"sign":       sign,
 		},
 	}
-	return c.Send(true, okex.LoginOperation, args)
 }
 
 // Subscribe
 // Users can choose to subscribe to one or more channels, and the total length of multiple channels cannot exceed 4096 bytes.
+// Active subscriptions are replayed after a reconnect.
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-subscribe
-func (c *ClientWs) Subscribe(p bool, ch []okex.ChannelName, args map[string]string) error {
+func (c *ClientWs) Subscribe(p bool, ch []sdk.ChannelName, args map[string]string) error {
 	count := 1
 	if len(ch) != 0 {
 		count = len(ch)
@@ -145,13 +191,14 @@ func (c *ClientWs) Subscribe(p bool, ch []okex.ChannelName, args map[string]stri
 			tmpArgs[i][k] = v
 		}
 	}
-	return c.Send(p, okex.SubscribeOperation, tmpArgs)
+	c.track(p, sdk.SubscribeOperation, tmpArgs)
+	return c.Send(p, sdk.SubscribeOperation, tmpArgs)
 }
 
 // Unsubscribe into channel(s)
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-unsubscribe
-func (c *ClientWs) Unsubscribe(p bool, ch []okex.ChannelName, args map[string]string) error {
+func (c *ClientWs) Unsubscribe(p bool, ch []sdk.ChannelName, args map[string]string) error {
 	tmpArgs := make([]map[string]string, len(ch))
 	for i, name := range ch {
 		tmpArgs[i] = make(map[string]string)
@@ -160,12 +207,48 @@ func (c *ClientWs) Unsubscribe(p bool, ch []okex.ChannelName, args map[string]st
 			tmpArgs[i][k] = v
 		}
 	}
-	return c.Send(p, okex.UnsubscribeOperation, tmpArgs)
+	c.track(p, sdk.UnsubscribeOperation, tmpArgs)
+	return c.Send(p, sdk.UnsubscribeOperation, tmpArgs)
+}
+
+// Subscriptions returns the active subscriptions of either connection, in a stable order
+func (c *ClientWs) Subscriptions(p bool) []map[string]string {
+	c.mu[p].RLock()
+	defer c.mu[p].RUnlock()
+	keys := make([]string, 0, len(c.subs[p]))
+	for k := range c.subs[p] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	args := make([]map[string]string, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, c.subs[p][k])
+	}
+	return args
+}
+
+// track records subscribe/unsubscribe args so they can be replayed
+func (c *ClientWs) track(p bool, op sdk.Operation, args []map[string]string) {
+	c.mu[p].Lock()
+	defer c.mu[p].Unlock()
+	for _, arg := range args {
+		k, _ := json.Marshal(arg) // map keys are sorted, so equal args give equal keys
+		if op == sdk.SubscribeOperation {
+			c.subs[p][string(k)] = arg
+		} else {
+			delete(c.subs[p], string(k))
+		}
+	}
 }
 
 // Send message through either connections
-func (c *ClientWs) Send(p bool, op okex.Operation, args []map[string]string, extras ...map[string]string) error {
-	if op != okex.LoginOperation {
+func (c *ClientWs) Send(p bool, op sdk.Operation, args []map[string]string, extras ...map[string]string) error {
+	return c.send(p, op, args, extras...)
+}
+
+// send is Send with args of any JSON shape
+func (c *ClientWs) send(p bool, op sdk.Operation, args interface{}, extras ...map[string]string) error {
+	if op != sdk.LoginOperation {
 		err := c.Connect(p)
 		if err == nil {
 			if p {
@@ -207,7 +290,14 @@ func (c *ClientWs) SetChannels(errCh chan *events.Error, subCh chan *events.Subs
 
 // WaitForAuthorization waits for the auth response and try to log in if it was needed
 func (c *ClientWs) WaitForAuthorization() error {
-	if c.Authorized {
+	if c.authorized() {
+		return nil
+	}
+	c.mu[true].RLock()
+	reconnecting := c.reconnecting[true]
+	c.mu[true].RUnlock()
+	if reconnecting {
+		// the reconnect loop logs in before the queued messages are sent
 		return nil
 	}
 	if err := c.Login(); err != nil {
@@ -216,113 +306,284 @@ func (c *ClientWs) WaitForAuthorization() error {
 	ticker := time.NewTicker(time.Millisecond * 300)
 	defer ticker.Stop()
 	for range ticker.C {
-		if c.Authorized {
+		if c.authorized() {
 			return nil
 		}
 	}
 	return nil
 }
 
+func (c *ClientWs) authorized() bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.Authorized
+}
+
 func (c *ClientWs) dial(p bool) error {
-	c.mu[p].Lock()
-	conn, res, err := websocket.DefaultDialer.Dial(string(c.url[p]), nil)
+	conn, done, err := c.open(p)
+	if err != nil {
+		return err
+	}
+	go c.sender(p, conn, done)
+	return nil
+}
+
+// open dials the server and starts the receiver. The caller starts the sender, so that
+// login and resubscribe can go out before any queued message.
+func (c *ClientWs) open(p bool) (*websocket.Conn, chan struct{}, error) {
+	conn, res, err := websocket.DefaultDialer.DialContext(c.ctx, string(c.url[p]), nil)
 	if err != nil {
 		var statusCode int
 		if res != nil {
 			statusCode = res.StatusCode
 		}
+		return nil, nil, fmt.Errorf("error %d: %w", statusCode, err)
+	}
+	res.Body.Close()
+	done := make(chan struct{})
+	c.mu[p].Lock()
+	c.conn[p] = conn
+	c.done[p] = done
+	c.lastTransmit[p] = nil
+	c.mu[p].Unlock()
+	go c.receiver(p, conn)
+	return conn, done, nil
+}
+
+// drop tears down conn if it is still the current connection
+func (c *ClientWs) drop(p bool, conn *websocket.Conn) {
+	c.mu[p].Lock()
+	if c.conn[p] == conn {
+		c.teardown(p)
+	}
+	c.mu[p].Unlock()
+	conn.Close()
+}
+
+// teardown forgets the current connection and stops its sender, c.mu[p] must be held
+func (c *ClientWs) teardown(p bool) {
+	c.conn[p] = nil
+	close(c.done[p])
+	if p {
+		c.authMu.Lock()
+		c.Authorized = false
+		c.AuthRequested = nil
+		c.authMu.Unlock()
+	}
+}
+
+// reconnect handles a broken connection. Only the first caller for a connection starts redialing.
+func (c *ClientWs) reconnect(p bool, conn *websocket.Conn, cause error) {
+	defer conn.Close()
+	if c.ctx.Err() != nil {
+		return
+	}
+	c.mu[p].Lock()
+	if c.conn[p] != conn {
 		c.mu[p].Unlock()
-		return fmt.Errorf("error %d: %w", statusCode, err)
+		return
 	}
-	defer res.Body.Close()
-	go func() {
-		err := c.receiver(p)
-		if err != nil {
-			fmt.Printf("receiver error: %v\n", err)
+	c.teardown(p)
+	redialing := c.reconnecting[p]
+	c.reconnecting[p] = true
+	c.mu[p].Unlock()
+	c.failPending(p, cause)
+	c.emit(&events.Reconnect{Private: p, Stage: events.Disconnected, Err: cause})
+	if !redialing {
+		go c.redial(p)
+	}
+}
+
+// redial dials with exponential backoff until the connection is resynced or ctx is done
+func (c *ClientWs) redial(p bool) {
+	delay, maxDelay := c.ReconnectMinDelay, c.ReconnectMaxDelay
+	if delay <= 0 {
+		delay = DefaultReconnectMinDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultReconnectMaxDelay
+	}
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-c.ctx.Done():
+			c.mu[p].Lock()
+			c.reconnecting[p] = false
+			c.mu[p].Unlock()
+			return
 		}
-	}()
-	go func() {
-		err := c.sender(p)
-		if err != nil {
-			fmt.Printf("sender error: %v\n", err)
+		err := c.resume(p, attempt)
+		if err == nil {
+			return
 		}
-	}()
-	c.conn[p] = conn
+		c.emit(&events.Reconnect{Private: p, Stage: events.Disconnected, Attempt: attempt, Err: err})
+		if delay *= 2; delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// resume opens a new connection, logs in on the private one and replays the subscriptions
+func (c *ClientWs) resume(p bool, attempt int) error {
+	conn, done, err := c.open(p)
+	if err != nil {
+		return err
+	}
+	if p {
+		if err := c.relogin(conn); err != nil {
+			c.drop(p, conn)
+			return err
+		}
+	}
+	c.emit(&events.Reconnect{Private: p, Stage: events.Reconnected, Attempt: attempt})
+
+	args := c.Subscriptions(p)
+	for _, batch := range subscribeBatches(args) {
+		j, _ := json.Marshal(map[string]interface{}{"op": sdk.SubscribeOperation, "args": batch})
+		if err := c.write(p, conn, j); err != nil {
+			c.drop(p, conn)
+			return err
+		}
+	}
+	c.mu[p].Lock()
+	c.reconnecting[p] = false
 	c.mu[p].Unlock()
+	go c.sender(p, conn, done)
+	c.emit(&events.Reconnect{Private: p, Stage: events.Resynced, Attempt: attempt, Args: args})
+	return nil
+}
+
+// relogin writes the login request directly on conn and waits for the login event
+func (c *ClientWs) relogin(conn *websocket.Conn) error {
+	now := time.Now()
+	c.authMu.Lock()
+	c.AuthRequested = &now
+	c.authMu.Unlock()
+	j, _ := json.Marshal(map[string]interface{}{"op": sdk.LoginOperation, "args": c.loginArgs()})
+	if err := c.write(true, conn, j); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(loginTimeout)
+	for !c.authorized() {
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			return errors.New("login timed out")
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		}
+	}
+	return nil
+}
+
+// subscribeBatches splits args so that each subscribe request stays under maxSubscribeSize
+func subscribeBatches(args []map[string]string) [][]map[string]string {
+	var (
+		batches [][]map[string]string
+		batch   []map[string]string
+		size    int
+	)
+	for _, arg := range args {
+		j, _ := json.Marshal(arg)
+		if len(batch) > 0 && size+len(j) > maxSubscribeSize {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, arg)
+		size += len(j)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func (c *ClientWs) emit(e *events.Reconnect) {
+	select {
+	case c.ReconnectChan <- e:
+	default:
+	}
+}
+
+// write sends one text frame on conn
+func (c *ClientWs) write(p bool, conn *websocket.Conn, data []byte) error {
+	c.mu[p].Lock()
+	defer c.mu[p].Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return err
+	}
+	now := time.Now()
+	c.lastTransmit[p] = &now
 	return nil
 }
-func (c *ClientWs) sender(p bool) error {
+
+func (c *ClientWs) sender(p bool, conn *websocket.Conn, done chan struct{}) {
 	ticker := time.NewTicker(time.Millisecond * 300)
 	defer ticker.Stop()
 	for {
 		select {
+		case <-done:
+			return
+		default:
+		}
+		select {
+		case <-done:
+			return
 		case data := <-c.sendChan[p]:
-			c.mu[p].RLock()
-			err := c.conn[p].SetWriteDeadline(time.Now().Add(writeWait))
-			if err != nil {
-				c.mu[p].RUnlock()
-				return err
-			}
-			w, err := c.conn[p].NextWriter(websocket.TextMessage)
-			if err != nil {
-				c.mu[p].RUnlock()
-				return err
-			}
-			if _, err = w.Write(data); err != nil {
-				c.mu[p].RUnlock()
-				return err
-			}
-			now := time.Now()
-			c.lastTransmit[p] = &now
-			c.mu[p].RUnlock()
-			if err := w.Close(); err != nil {
-				return err
+			if err := c.write(p, conn, data); err != nil {
+				c.reconnect(p, conn, err)
+				return
 			}
 		case <-ticker.C:
-			if c.conn[p] != nil && (c.lastTransmit[p] == nil || (c.lastTransmit[p] != nil && time.Since(*c.lastTransmit[p]) > PingPeriod)) {
-				go func() {
-					c.sendChan[p] <- []byte("ping")
-				}()
+			c.mu[p].RLock()
+			idle := c.lastTransmit[p] == nil || time.Since(*c.lastTransmit[p]) > PingPeriod
+			c.mu[p].RUnlock()
+			if idle {
+				if err := c.write(p, conn, []byte("ping")); err != nil {
+					c.reconnect(p, conn, err)
+					return
+				}
 			}
 		case <-c.ctx.Done():
-			return c.handleCancel("sender")
+			conn.Close()
+			_ = c.handleCancel("sender")
+			return
 		}
 	}
 }
-func (c *ClientWs) receiver(p bool) error {
+
+// receiver reads until the connection breaks. A missing pong shows up as a read deadline error.
+func (c *ClientWs) receiver(p bool, conn *websocket.Conn) {
 	for {
-		select {
-		case <-c.ctx.Done():
-			return c.handleCancel("receiver")
-		default:
-			c.mu[p].RLock()
-			err := c.conn[p].SetReadDeadline(time.Now().Add(pongWait))
-			if err != nil {
-				c.mu[p].RUnlock()
-				return err
-			}
-			mt, data, err := c.conn[p].ReadMessage()
-			if err != nil {
-				c.mu[p].RUnlock()
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-					return c.conn[p].Close()
-				}
-				return err
+		if err := conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+			c.reconnect(p, conn, err)
+			return
+		}
+		mt, data, err := conn.ReadMessage()
+		if err != nil {
+			if c.ctx.Err() != nil {
+				_ = c.handleCancel("receiver")
+				return
 			}
-			c.mu[p].RUnlock()
-			now := time.Now()
-			c.mu[p].Lock()
-			c.lastTransmit[p] = &now
-			c.mu[p].Unlock()
-			if mt == websocket.TextMessage && string(data) != "pong" {
-				e := &events.Basic{}
-				if err := json.Unmarshal(data, &e); err != nil {
-					return err
-				}
-				go func() {
-					c.process(data, e)
-				}()
+			c.reconnect(p, conn, err)
+			return
+		}
+		now := time.Now()
+		c.mu[p].Lock()
+		c.lastTransmit[p] = &now
+		c.mu[p].Unlock()
+		if mt == websocket.TextMessage && string(data) != "pong" {
+			e := &events.Basic{}
+			if err := json.Unmarshal(data, &e); err != nil {
+				continue
 			}
+			// processed in order; the dispatch queues decide whether a slow consumer blocks the socket
+			c.process(data, e)
 		}
 	}
 }
@@ -344,49 +605,47 @@ func (c *ClientWs) handleCancel(msg string) error {
 
 // TODO: break each case into a separate function
 func (c *ClientWs) process(data []byte, e *events.Basic) bool {
+	// answers to Request go to the waiting caller only
+	if e.ID != "" && c.answer(e.ID, data) {
+		return true
+	}
 	switch e.Event {
 	case "error":
 		e := events.Error{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			c.ErrChan <- &e
-		}()
+		ch := c.ErrChan
+		c.dispatcher.push(EventQueue, "", func(ctx context.Context) bool {
+			return send(ctx, ch, &e)
+		})
 		return true
 	case "subscribe":
 		e := events.Subscribe{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.SubscribeChan != nil {
-				c.SubscribeChan <- &e
-			}
-			c.StructuredEventChan <- e
-		}()
+		enqueue(c, EventQueue, "", c.SubscribeChan, e)
 		return true
 	case "unsubscribe":
 		e := events.Unsubscribe{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.UnsubscribeCh != nil {
-				c.UnsubscribeCh <- &e
-			}
-			c.StructuredEventChan <- e
-		}()
+		enqueue(c, EventQueue, "", c.UnsubscribeCh, e)
 		return true
 	case "login":
+		c.authMu.Lock()
+		if c.AuthRequested == nil {
+			// answer to a login on a connection that has since been dropped
+			c.authMu.Unlock()
+			break
+		}
 		if time.Since(*c.AuthRequested).Seconds() > 30 {
 			c.AuthRequested = nil
+			c.authMu.Unlock()
 			_ = c.Login()
 			break
 		}
 		c.Authorized = true
+		c.authMu.Unlock()
 		e := events.Login{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.LoginChan != nil {
-				c.LoginChan <- &e
-			}
-			c.StructuredEventChan <- e
-		}()
+		enqueue(c, EventQueue, "", c.LoginChan, e)
 		return true
 	}
 	if c.Private.Process(data, e) {
@@ -403,14 +662,12 @@ func (c *ClientWs) process(data []byte, e *events.Basic) bool {
 		}
 		e := events.Success{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.SuccessChan != nil {
-				c.SuccessChan <- &e
-			}
-			c.StructuredEventChan <- e
-		}()
+		enqueue(c, EventQueue, "", c.SuccessChan, e)
 		return true
 	}
-	go func() { c.RawEventChan <- e }()
+	ch := c.RawEventChan
+	c.dispatcher.push(RawQueue, "", func(ctx context.Context) bool {
+		return send(ctx, ch, e)
+	})
 	return false
 }
@@ -0,0 +1,218 @@
+package ws
+
+import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeServer accepts websocket connections, answers login/subscribe and records requests
+type fakeServer struct {
+	*httptest.Server
+	msgs chan string
+
+	mu    sync.Mutex
+	conns []*websocket.Conn
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	return newAnsweringServer(t, nil)
+}
+
+// newAnsweringServer is a fakeServer that writes answer(op, id) back for every other operation,
+// one frame per line, unless it is empty
+func newAnsweringServer(t *testing.T, answer func(op, id string) string) *fakeServer {
+	t.Helper()
+	s := &fakeServer{msgs: make(chan string, 100)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "ping" {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte("pong"))
+				continue
+			}
+			s.msgs <- string(data)
+			var req struct {
+				ID   string              `json:"id"`
+				Op   string              `json:"op"`
+				Args []map[string]string `json:"args"`
+			}
+			_ = json.Unmarshal(data, &req)
+			switch req.Op {
+			case "login":
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":""}`))
+			case "subscribe":
+				for _, arg := range req.Args {
+					j, _ := json.Marshal(arg)
+					_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"subscribe","arg":`+string(j)+`}`))
+				}
+			default:
+				if answer != nil {
+					if a := answer(req.Op, req.ID); a != "" {
+						for _, line := range strings.Split(a, "\n") {
+							_ = conn.WriteMessage(websocket.TextMessage, []byte(line))
+						}
+					}
+				}
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// kill closes the latest server side connection
+func (s *fakeServer) kill() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns[len(s.conns)-1].Close()
+}
+
+func (s *fakeServer) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case m := <-s.msgs:
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message from client")
+		return ""
+	}
+}
+
+func newTestClient(t *testing.T, srv *fakeServer) *ClientWs {
+	t.Helper()
+	u := sdk.BaseURL("ws" + strings.TrimPrefix(srv.URL, "http"))
+	c := NewClient(context.Background(), "key", "secret", "pass", map[bool]sdk.BaseURL{true: u, false: u})
+	c.ReconnectMinDelay = 10 * time.Millisecond
+	c.ReconnectMaxDelay = 50 * time.Millisecond
+	t.Cleanup(c.Cancel)
+	return c
+}
+
+func waitStage(t *testing.T, c *ClientWs, stage events.ReconnectStage) *events.Reconnect {
+	t.Helper()
+	for {
+		select {
+		case e := <-c.ReconnectChan:
+			if e.Stage == stage {
+				return e
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s event", stage)
+			return nil
+		}
+	}
+}
+
+func TestReconnectReplaysSubscriptions(t *testing.T) {
+	srv := newFakeServer(t)
+	c := newTestClient(t, srv)
+
+	if err := c.Subscribe(false, []sdk.ChannelName{"tickers"}, map[string]string{"instId": "BTC-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Subscribe(false, []sdk.ChannelName{"books"}, map[string]string{"instId": "ETH-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.next(t)
+	srv.next(t)
+	if err := c.Unsubscribe(false, []sdk.ChannelName{"books"}, map[string]string{"instId": "ETH-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.next(t)
+
+	srv.kill()
+	if e := waitStage(t, c, events.Disconnected); e.Private || e.Err == nil {
+		t.Fatalf("disconnect = %+v", e)
+	}
+	e := waitStage(t, c, events.Resynced)
+	if len(e.Args) != 1 || e.Args[0]["channel"] != "tickers" {
+		t.Fatalf("resync = %+v", e)
+	}
+	if m := srv.next(t); m != `{"args":[{"channel":"tickers","instId":"BTC-USDT"}],"op":"subscribe"}` {
+		t.Fatalf("replayed = %s", m)
+	}
+
+	// 重连后新的订阅照常发送
+	if err := c.Subscribe(false, []sdk.ChannelName{"trades"}, map[string]string{"instId": "BTC-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.next(t); !strings.Contains(m, `"channel":"trades"`) {
+		t.Fatalf("subscribe after reconnect = %s", m)
+	}
+}
+
+func TestReconnectLogsInBeforeResubscribing(t *testing.T) {
+	srv := newFakeServer(t)
+	c := newTestClient(t, srv)
+
+	if err := c.Subscribe(true, []sdk.ChannelName{"orders"}, map[string]string{"instType": "SPOT"}); err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.next(t); !strings.Contains(m, `"op":"login"`) {
+		t.Fatalf("first message = %s", m)
+	}
+	if m := srv.next(t); !strings.Contains(m, `"channel":"orders"`) {
+		t.Fatalf("second message = %s", m)
+	}
+
+	srv.kill()
+	waitStage(t, c, events.Reconnected)
+	if m := srv.next(t); !strings.Contains(m, `"op":"login"`) {
+		t.Fatalf("first message after reconnect = %s", m)
+	}
+	if m := srv.next(t); m != `{"args":[{"channel":"orders","instType":"SPOT"}],"op":"subscribe"}` {
+		t.Fatalf("replayed = %s", m)
+	}
+	if e := waitStage(t, c, events.Resynced); !e.Private {
+		t.Fatalf("resync = %+v", e)
+	}
+}
+
+func TestReconnectBacksOff(t *testing.T) {
+	srv := newFakeServer(t)
+	c := newTestClient(t, srv)
+	if err := c.Subscribe(false, []sdk.ChannelName{"tickers"}, map[string]string{"instId": "BTC-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.next(t)
+
+	// 服务器下线后每次重连失败都会报告，间隔按指数增长到上限
+	srv.Listener.Close()
+	srv.kill()
+	waitStage(t, c, events.Disconnected)
+	start := time.Now()
+	var attempts []int
+	for len(attempts) < 4 {
+		e := waitStage(t, c, events.Disconnected)
+		attempts = append(attempts, e.Attempt)
+	}
+	if attempts[0] != 1 || attempts[3] != 4 {
+		t.Fatalf("attempts = %v", attempts)
+	}
+	// 10ms + 20ms + 40ms + 50ms
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Fatalf("4 attempts took %s", d)
+	}
+}
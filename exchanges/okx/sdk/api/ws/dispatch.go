@@ -0,0 +1,315 @@
+package ws
+
+import (
+	"AxonTrading/exchanges/okx/sdk/events"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy decides what a dispatch queue does with a new event while it is full
+type OverflowPolicy int
+
+const (
+	// Block waits for the consumer; the socket is not read meanwhile
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest queued event
+	DropOldest
+	// CoalesceLatest replaces a queued event of the same channel and instrument by the newer one,
+	// and drops the oldest event when the queue is full of distinct instruments.
+	// Only use it for snapshot channels (tickers, books5, bbo-tbt), never for incremental books.
+	CoalesceLatest
+)
+
+const (
+	// EventQueue carries login, subscribe, unsubscribe, error and order operation answers
+	EventQueue = "event"
+	// RawQueue carries the messages no typed channel handles
+	RawQueue = "raw"
+
+	DefaultQueueSize    = 1024
+	DefaultLagThreshold = time.Second
+)
+
+// QueueConfig configures the dispatch queue of one OKX channel
+type QueueConfig struct {
+	// Size is the number of events the queue holds before Policy applies
+	Size   int
+	Policy OverflowPolicy
+	// LagThreshold events that waited longer than this are counted as lagged
+	LagThreshold time.Duration
+}
+
+// QueueMetrics is a snapshot of a dispatch queue
+type QueueMetrics struct {
+	// Queued events waiting for the consumer
+	Queued int
+	// Lag is how long the oldest queued event has been waiting
+	Lag       time.Duration
+	Delivered uint64
+	Dropped   uint64
+	Coalesced uint64
+	// Lagged events that were delivered after more than LagThreshold
+	Lagged uint64
+	// MaxLag is the longest wait of a delivered event
+	MaxLag time.Duration
+}
+
+// defaultQueues snapshot channels where only the latest value matters
+var defaultQueues = map[string]QueueConfig{
+	"tickers":       {Size: 256, Policy: CoalesceLatest},
+	"index-tickers": {Size: 256, Policy: CoalesceLatest},
+	"mark-price":    {Size: 256, Policy: CoalesceLatest},
+	"books5":        {Size: 256, Policy: CoalesceLatest},
+	"bbo-tbt":       {Size: 256, Policy: CoalesceLatest},
+}
+
+// argKeys the parts of a channel argument that tell two streams of the same channel apart
+var argKeys = []string{"channel", "instId", "instType", "instFamily", "uly", "ccy"}
+
+// dispatcher delivers events to consumers in order, one queue and goroutine per OKX channel
+type dispatcher struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	configs map[string]QueueConfig
+	queues  map[string]*queue
+}
+
+func newDispatcher(ctx context.Context) *dispatcher {
+	return &dispatcher{ctx: ctx, configs: make(map[string]QueueConfig), queues: make(map[string]*queue)}
+}
+
+func (d *dispatcher) config(name string) QueueConfig {
+	cfg, ok := d.configs[name]
+	if !ok {
+		cfg = defaultQueues[name]
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultQueueSize
+	}
+	if cfg.LagThreshold <= 0 {
+		cfg.LagThreshold = DefaultLagThreshold
+	}
+	return cfg
+}
+
+func (d *dispatcher) set(name string, cfg QueueConfig) {
+	d.mu.Lock()
+	d.configs[name] = cfg
+	q := d.queues[name]
+	cfg = d.config(name)
+	d.mu.Unlock()
+	if q != nil {
+		q.mu.Lock()
+		q.cfg = cfg
+		q.mu.Unlock()
+		q.signal(q.space)
+	}
+}
+
+func (d *dispatcher) queue(name string) *queue {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q, ok := d.queues[name]
+	if !ok {
+		q = &queue{
+			ctx:   d.ctx,
+			cfg:   d.config(name),
+			keys:  make(map[string]*item),
+			ready: make(chan struct{}, 1),
+			space: make(chan struct{}, 1),
+		}
+		d.queues[name] = q
+		go q.run()
+	}
+	return q
+}
+
+func (d *dispatcher) push(name, key string, deliver func(ctx context.Context) bool) {
+	d.queue(name).push(&item{key: key, at: time.Now(), deliver: deliver})
+}
+
+func (d *dispatcher) metrics() map[string]QueueMetrics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := make(map[string]QueueMetrics, len(d.queues))
+	for name, q := range d.queues {
+		m[name] = q.metrics()
+	}
+	return m
+}
+
+type item struct {
+	key     string
+	at      time.Time
+	deliver func(ctx context.Context) bool
+}
+
+type queue struct {
+	ctx   context.Context
+	mu    sync.Mutex
+	cfg   QueueConfig
+	items []*item
+	// keys the queued item of each key, for CoalesceLatest
+	keys  map[string]*item
+	ready chan struct{}
+	space chan struct{}
+	m     QueueMetrics
+}
+
+func (q *queue) signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func (q *queue) push(it *item) {
+	q.mu.Lock()
+	if q.cfg.Policy == CoalesceLatest && it.key != "" {
+		if old, ok := q.keys[it.key]; ok {
+			// keep the place and age of the queued event so lag still shows how stale it is
+			old.deliver = it.deliver
+			q.m.Coalesced++
+			q.mu.Unlock()
+			return
+		}
+	}
+	for len(q.items) >= q.cfg.Size {
+		if q.cfg.Policy == Block {
+			q.mu.Unlock()
+			select {
+			case <-q.space:
+			case <-q.ctx.Done():
+				return
+			}
+			q.mu.Lock()
+			continue
+		}
+		q.pop()
+		q.m.Dropped++
+	}
+	q.items = append(q.items, it)
+	if q.cfg.Policy == CoalesceLatest && it.key != "" {
+		q.keys[it.key] = it
+	}
+	more := len(q.items) < q.cfg.Size
+	q.mu.Unlock()
+	q.signal(q.ready)
+	if more {
+		// let another blocked producer in
+		q.signal(q.space)
+	}
+}
+
+// pop removes the oldest item, q.mu must be held
+func (q *queue) pop() *item {
+	it := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	if q.keys[it.key] == it {
+		delete(q.keys, it.key)
+	}
+	return it
+}
+
+func (q *queue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.items) == 0 {
+			q.mu.Unlock()
+			select {
+			case <-q.ready:
+				continue
+			case <-q.ctx.Done():
+				return
+			}
+		}
+		it := q.pop()
+		deliver := it.deliver
+		q.mu.Unlock()
+		q.signal(q.space)
+
+		if !deliver(q.ctx) {
+			return
+		}
+		lag := time.Since(it.at)
+		q.mu.Lock()
+		q.m.Delivered++
+		if lag > q.m.MaxLag {
+			q.m.MaxLag = lag
+		}
+		if lag > q.cfg.LagThreshold {
+			q.m.Lagged++
+		}
+		q.mu.Unlock()
+	}
+}
+
+func (q *queue) metrics() QueueMetrics {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	m := q.m
+	m.Queued = len(q.items)
+	if len(q.items) > 0 {
+		m.Lag = time.Since(q.items[0].at)
+	}
+	return m
+}
+
+// send delivers v on ch, a nil ch is skipped; false once the client is cancelled
+func send[T any](ctx context.Context, ch chan T, v T) bool {
+	if ch == nil {
+		return true
+	}
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// deliver queues a channel push on the queue of its channel
+func deliver[T any](c *ClientWs, arg *events.Argument, ch chan *T, e T) {
+	name, key := queueKey(arg)
+	enqueue(c, name, key, ch, e)
+}
+
+// enqueue queues e for the typed channel ch (may be nil) and then StructuredEventChan
+func enqueue[T any](c *ClientWs, name, key string, ch chan *T, e T) {
+	structured := c.StructuredEventChan
+	c.dispatcher.push(name, key, func(ctx context.Context) bool {
+		return send(ctx, ch, &e) && send[interface{}](ctx, structured, e)
+	})
+}
+
+// queueKey the queue name (the channel) and coalescing key (channel and instrument) of a push
+func queueKey(arg *events.Argument) (string, string) {
+	if arg == nil {
+		return RawQueue, ""
+	}
+	parts := make([]string, 0, len(argKeys))
+	for _, k := range argKeys {
+		v, ok := arg.Get(k)
+		if !ok {
+			v = ""
+		}
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return parts[0], strings.Join(parts, "|")
+}
+
+// SetQueue configures the dispatch queue of an OKX channel such as "tickers", "books" or "orders".
+// EventQueue and RawQueue configure the control and unparsed messages.
+// By default queues hold DefaultQueueSize events and Block, snapshot channels use CoalesceLatest.
+func (c *ClientWs) SetQueue(channel string, cfg QueueConfig) {
+	c.dispatcher.set(channel, cfg)
+}
+
+// QueueMetrics returns a snapshot of every dispatch queue, keyed by channel
+func (c *ClientWs) QueueMetrics() map[string]QueueMetrics {
+	return c.dispatcher.metrics()
+}
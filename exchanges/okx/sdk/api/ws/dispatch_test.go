@@ -0,0 +1,183 @@
+package ws
+
+import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"AxonTrading/exchanges/okx/sdk/events/public"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newDispatchClient(t *testing.T) *ClientWs {
+	t.Helper()
+	c := NewClient(context.Background(), "key", "secret", "pass", map[bool]sdk.BaseURL{})
+	c.StructuredEventChan = nil
+	t.Cleanup(c.Cancel)
+	return c
+}
+
+// push feeds a channel message to the client as if it was read from the socket
+func push(t *testing.T, c *ClientWs, channel, instID, last string) {
+	t.Helper()
+	data := []byte(fmt.Sprintf(`{"arg":{"channel":%q,"instId":%q},"data":[{"instId":%q,"last":%q,"tradeId":%q}]}`, channel, instID, instID, last, last))
+	e := &events.Basic{}
+	if err := json.Unmarshal(data, e); err != nil {
+		t.Fatal(err)
+	}
+	if !c.process(data, e) {
+		t.Fatalf("message not handled: %s", data)
+	}
+}
+
+// waitMetrics waits until the metrics of queue satisfy ok; counters are updated right after a delivery
+func waitMetrics(t *testing.T, c *ClientWs, queue string, ok func(m QueueMetrics) bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !ok(c.QueueMetrics()[queue]) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s metrics = %+v", queue, c.QueueMetrics()[queue])
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitIdle waits until the queue's consumer took every event and is blocked delivering the last one
+func waitIdle(t *testing.T, c *ClientWs, queue string) {
+	t.Helper()
+	waitMetrics(t, c, queue, func(m QueueMetrics) bool { return m.Queued == 0 })
+}
+
+func readTickers(t *testing.T, ch chan *public.Tickers, n int) []string {
+	t.Helper()
+	var got []string
+	for i := 0; i < n; i++ {
+		select {
+		case e := <-ch:
+			got = append(got, e.Tickers[0].InstID+"@"+string(e.Tickers[0].Last))
+		case <-time.After(time.Second):
+			t.Fatalf("got %v, want %d events", got, n)
+		}
+	}
+	return got
+}
+
+func TestDispatchKeepsOrder(t *testing.T) {
+	c := newDispatchClient(t)
+	c.StructuredEventChan = make(chan interface{})
+	ch := make(chan *public.Trades)
+	c.Public.trCh = ch
+
+	for i := 1; i <= 100; i++ {
+		push(t, c, "trades", "BTC-USDT", fmt.Sprint(i))
+	}
+	for i := 1; i <= 100; i++ {
+		e := <-ch
+		if id := e.Trades[0].TradeID; id != fmt.Sprint(i) {
+			t.Fatalf("event %d has trade %s", i, id)
+		}
+		// the same event follows on StructuredEventChan
+		if s := (<-c.StructuredEventChan).(public.Trades); s.Trades[0].TradeID != fmt.Sprint(i) {
+			t.Fatalf("structured event %d has trade %s", i, s.Trades[0].TradeID)
+		}
+	}
+	waitMetrics(t, c, "trades", func(m QueueMetrics) bool { return m.Delivered == 100 && m.Dropped == 0 })
+}
+
+func TestDispatchDropOldest(t *testing.T) {
+	c := newDispatchClient(t)
+	c.SetQueue("tickers", QueueConfig{Size: 2, Policy: DropOldest})
+	ch := make(chan *public.Tickers)
+	c.Public.tCh = ch
+
+	push(t, c, "tickers", "BTC-USDT", "1")
+	waitIdle(t, c, "tickers")
+	for i := 2; i <= 5; i++ {
+		push(t, c, "tickers", "BTC-USDT", fmt.Sprint(i))
+	}
+	if m := c.QueueMetrics()["tickers"]; m.Queued != 2 || m.Dropped != 2 {
+		t.Fatalf("metrics = %+v", m)
+	}
+	got := fmt.Sprint(readTickers(t, ch, 3))
+	if got != "[BTC-USDT@1 BTC-USDT@4 BTC-USDT@5]" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestDispatchCoalesceLatest(t *testing.T) {
+	c := newDispatchClient(t)
+	ch := make(chan *public.Tickers)
+	c.Public.tCh = ch
+
+	// tickers coalesce by default
+	push(t, c, "tickers", "BTC-USDT", "1")
+	waitIdle(t, c, "tickers")
+	push(t, c, "tickers", "BTC-USDT", "2")
+	push(t, c, "tickers", "ETH-USDT", "3")
+	push(t, c, "tickers", "BTC-USDT", "4")
+	if m := c.QueueMetrics()["tickers"]; m.Queued != 2 || m.Coalesced != 1 {
+		t.Fatalf("metrics = %+v", m)
+	}
+	got := fmt.Sprint(readTickers(t, ch, 3))
+	if got != "[BTC-USDT@1 BTC-USDT@4 ETH-USDT@3]" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestDispatchBlock(t *testing.T) {
+	c := newDispatchClient(t)
+	c.SetQueue("tickers", QueueConfig{Size: 1, Policy: Block, LagThreshold: 10 * time.Millisecond})
+	ch := make(chan *public.Tickers)
+	c.Public.tCh = ch
+
+	push(t, c, "tickers", "BTC-USDT", "1")
+	waitIdle(t, c, "tickers")
+	push(t, c, "tickers", "BTC-USDT", "2")
+	pushed := make(chan struct{})
+	go func() {
+		push(t, c, "tickers", "BTC-USDT", "3")
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("push into a full blocking queue returned")
+	case <-time.After(50 * time.Millisecond):
+	}
+	m := c.QueueMetrics()["tickers"]
+	if m.Queued != 1 || m.Lag < 50*time.Millisecond {
+		t.Fatalf("metrics = %+v", m)
+	}
+
+	got := fmt.Sprint(readTickers(t, ch, 3))
+	<-pushed
+	if got != "[BTC-USDT@1 BTC-USDT@2 BTC-USDT@3]" {
+		t.Fatalf("got %s", got)
+	}
+	waitMetrics(t, c, "tickers", func(m QueueMetrics) bool {
+		return m.Delivered == 3 && m.Dropped == 0 && m.Lagged >= 2 && m.MaxLag >= 50*time.Millisecond
+	})
+}
+
+func TestDispatchControlEvents(t *testing.T) {
+	c := newDispatchClient(t)
+	c.SubscribeChan = make(chan *events.Subscribe)
+	c.ErrChan = make(chan *events.Error)
+
+	for _, data := range []string{
+		`{"event":"subscribe","arg":{"channel":"tickers","instId":"BTC-USDT"}}`,
+		`{"event":"error","code":"60012","msg":"Invalid request"}`,
+	} {
+		e := &events.Basic{}
+		_ = json.Unmarshal([]byte(data), e)
+		c.process([]byte(data), e)
+	}
+	if s := <-c.SubscribeChan; s.Arg == nil {
+		t.Fatalf("subscribe = %+v", s)
+	}
+	if e := <-c.ErrChan; e.Code != 60012 {
+		t.Fatalf("error = %+v", e)
+	}
+	waitMetrics(t, c, EventQueue, func(m QueueMetrics) bool { return m.Delivered == 2 })
+}
@@ -1,11 +1,11 @@
 package ws
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"AxonTrading/exchanges/okx/sdk/events/private"
+	requests "AxonTrading/exchanges/okx/sdk/requests/ws/private"
 	"encoding/json"
-	"github.com/amir-the-h/okex"
-	"github.com/amir-the-h/okex/events"
-	"github.com/amir-the-h/okex/events/private"
-	requests "github.com/amir-the-h/okex/requests/ws/private"
 )
 
 // Private
@@ -29,22 +29,22 @@ func NewPrivate(c *ClientWs) *Private {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-account-channel
 func (c *Private) Account(req requests.Account, ch ...chan *private.Account) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.aCh = ch[0]
 	}
-	return c.Subscribe(true, []okex.ChannelName{"account"}, m)
+	return c.Subscribe(true, []sdk.ChannelName{"account"}, m)
 }
 
 // UAccount
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-account-channel
 func (c *Private) UAccount(req requests.Account, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.aCh = nil
 	}
-	return c.Unsubscribe(true, []okex.ChannelName{"account"}, m)
+	return c.Unsubscribe(true, []sdk.ChannelName{"account"}, m)
 }
 
 // Position
@@ -52,22 +52,22 @@ func (c *Private) UAccount(req requests.Account, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-positions-channel
 func (c *Private) Position(req requests.Position, ch ...chan *private.Position) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.pCh = ch[0]
 	}
-	return c.Subscribe(true, []okex.ChannelName{"positions"}, m)
+	return c.Subscribe(true, []sdk.ChannelName{"positions"}, m)
 }
 
 // UPosition
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-positions-channel
 func (c *Private) UPosition(req requests.Position, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.pCh = nil
 	}
-	return c.Unsubscribe(true, []okex.ChannelName{"positions"}, m)
+	return c.Unsubscribe(true, []sdk.ChannelName{"positions"}, m)
 }
 
 // BalanceAndPosition
@@ -79,7 +79,7 @@ func (c *Private) BalanceAndPosition(ch ...chan *private.BalanceAndPosition) err
 	if len(ch) > 0 {
 		c.bnpCh = ch[0]
 	}
-	return c.Subscribe(true, []okex.ChannelName{"balance_and_position"}, m)
+	return c.Subscribe(true, []sdk.ChannelName{"balance_and_position"}, m)
 }
 
 // UBalanceAndPosition unsubscribes a position channel
@@ -90,7 +90,7 @@ func (c *Private) UBalanceAndPosition(rCh ...bool) error {
 	if len(rCh) > 0 && rCh[0] {
 		c.bnpCh = nil
 	}
-	return c.Unsubscribe(true, []okex.ChannelName{"balance_and_position"}, m)
+	return c.Unsubscribe(true, []sdk.ChannelName{"balance_and_position"}, m)
 }
 
 // Order
@@ -98,22 +98,22 @@ func (c *Private) UBalanceAndPosition(rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-order-channel
 func (c *Private) Order(req requests.Order, ch ...chan *private.Order) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.oCh = ch[0]
 	}
-	return c.Subscribe(true, []okex.ChannelName{"orders"}, m)
+	return c.Subscribe(true, []sdk.ChannelName{"orders"}, m)
 }
 
 // UOrder
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-private-channel-order-channel
 func (c *Private) UOrder(req requests.Order, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.oCh = nil
 	}
-	return c.Unsubscribe(true, []okex.ChannelName{"orders"}, m)
+	return c.Unsubscribe(true, []sdk.ChannelName{"orders"}, m)
 }
 
 func (c *Private) Process(data []byte, e *events.Basic) bool {
@@ -129,12 +129,7 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.aCh != nil {
-					c.aCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.aCh, e)
 			return true
 		case "positions":
 			e := private.Position{}
@@ -142,12 +137,7 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.pCh != nil {
-					c.pCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.pCh, e)
 			return true
 		case "balance_and_position":
 			e := private.BalanceAndPosition{}
@@ -155,12 +145,7 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.bnpCh != nil {
-					c.bnpCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.bnpCh, e)
 			return true
 		case "orders":
 			e := private.Order{}
@@ -168,12 +153,7 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.oCh != nil {
-					c.oCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.oCh, e)
 			return true
 		}
 	}
@@ -1,12 +1,12 @@
 package ws
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"AxonTrading/exchanges/okx/sdk/events/public"
+	requests "AxonTrading/exchanges/okx/sdk/requests/ws/public"
 	"encoding/json"
 	"fmt"
-	"github.com/amir-the-h/okex"
-	"github.com/amir-the-h/okex/events"
-	"github.com/amir-the-h/okex/events/public"
-	requests "github.com/amir-the-h/okex/requests/ws/public"
 	"strings"
 )
 
@@ -41,22 +41,22 @@ func NewPublic(c *ClientWs) *Public {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-instruments-channel
 func (c *Public) Instruments(req requests.Instruments, ch ...chan *public.Instruments) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.iCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"instruments"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"instruments"}, m)
 }
 
 // UInstruments
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-instruments-channel
 func (c *Public) UInstruments(req requests.Instruments, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.iCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"instruments"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"instruments"}, m)
 }
 
 // Tickers
@@ -64,22 +64,22 @@ func (c *Public) UInstruments(req requests.Instruments, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-tickers-channel
 func (c *Public) Tickers(req requests.Tickers, ch ...chan *public.Tickers) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.tCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"tickers"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"tickers"}, m)
 }
 
 // UTickers
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-tickers-channel
 func (c *Public) UTickers(req requests.Tickers, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.tCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"tickers"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"tickers"}, m)
 }
 
 // OpenInterest
@@ -87,22 +87,22 @@ func (c *Public) UTickers(req requests.Tickers, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-open-interest-channel
 func (c *Public) OpenInterest(req requests.OpenInterest, ch ...chan *public.OpenInterest) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.oiCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"open-interest"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"open-interest"}, m)
 }
 
 // UOpenInterest
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-open-interest-channel
 func (c *Public) UOpenInterest(req requests.OpenInterest, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.oiCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"open-interest"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"open-interest"}, m)
 }
 
 // Candlesticks
@@ -110,22 +110,22 @@ func (c *Public) UOpenInterest(req requests.OpenInterest, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-candlesticks-channel
 func (c *Public) Candlesticks(req requests.Candlesticks, ch ...chan *public.Candlesticks) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.cCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{}, m)
+	return c.Subscribe(false, []sdk.ChannelName{}, m)
 }
 
 // UCandlesticks
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-candlesticks-channel
 func (c *Public) UCandlesticks(req requests.Candlesticks, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.cCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{}, m)
 }
 
 // Trades
@@ -133,22 +133,22 @@ func (c *Public) UCandlesticks(req requests.Candlesticks, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-trades-channel
 func (c *Public) Trades(req requests.Trades, ch ...chan *public.Trades) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.trCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"trades"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"trades"}, m)
 }
 
 // UTrades
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-trades-channel
 func (c *Public) UTrades(req requests.Trades, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.trCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"trades"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"trades"}, m)
 }
 
 // EstimatedDeliveryExercisePrice
@@ -158,22 +158,22 @@ func (c *Public) UTrades(req requests.Trades, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-estimated-delivery-exercise-price-channel
 func (c *Public) EstimatedDeliveryExercisePrice(req requests.EstimatedDeliveryExercisePrice, ch ...chan *public.EstimatedDeliveryExercisePrice) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.edepCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"estimated-price"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"estimated-price"}, m)
 }
 
 // UEstimatedDeliveryExercisePrice
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-estimated-delivery-exercise-price-channel
 func (c *Public) UEstimatedDeliveryExercisePrice(req requests.EstimatedDeliveryExercisePrice, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.edepCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"estimated-price"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"estimated-price"}, m)
 }
 
 // MarkPrice
@@ -181,22 +181,22 @@ func (c *Public) UEstimatedDeliveryExercisePrice(req requests.EstimatedDeliveryE
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-mark-price-channel
 func (c *Public) MarkPrice(req requests.MarkPrice, ch ...chan *public.MarkPrice) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.mpCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"mark-price"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"mark-price"}, m)
 }
 
 // UMarkPrice
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-mark-price-channel
 func (c *Public) UMarkPrice(req requests.MarkPrice, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.mpCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"mark-price"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"mark-price"}, m)
 }
 
 // MarkPriceCandlesticks
@@ -204,24 +204,24 @@ func (c *Public) UMarkPrice(req requests.MarkPrice, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-mark-price-candlesticks-channel
 func (c *Public) MarkPriceCandlesticks(req requests.MarkPriceCandlesticks, ch ...chan *public.MarkPriceCandlesticks) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	m["channel"] = "mark-price-" + m["channel"]
 	if len(ch) > 0 {
 		c.mpcCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{}, m)
+	return c.Subscribe(false, []sdk.ChannelName{}, m)
 }
 
 // UMarkPriceCandlesticks
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-mark-price-candlesticks-channel
 func (c *Public) UMarkPriceCandlesticks(req requests.MarkPriceCandlesticks, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	m["channel"] = "mark-price-" + m["channel"]
 	if len(rCh) > 0 && rCh[0] {
 		c.mpcCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{}, m)
 }
 
 // PriceLimit
@@ -229,22 +229,22 @@ func (c *Public) UMarkPriceCandlesticks(req requests.MarkPriceCandlesticks, rCh
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-price-limit-channel
 func (c *Public) PriceLimit(req requests.PriceLimit, ch ...chan *public.PriceLimit) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.plCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"price-limit"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"price-limit"}, m)
 }
 
 // UPriceLimit
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-price-limit-channel
 func (c *Public) UPriceLimit(req requests.PriceLimit, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.plCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"price-limit"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"price-limit"}, m)
 }
 
 // OrderBook
@@ -254,22 +254,22 @@ func (c *Public) UPriceLimit(req requests.PriceLimit, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-order-book-channel
 func (c *Public) OrderBook(req requests.OrderBook, ch ...chan *public.OrderBook) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.obCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{}, m)
+	return c.Subscribe(false, []sdk.ChannelName{}, m)
 }
 
 // UOrderBook
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-order-book-channel
 func (c *Public) UOrderBook(req requests.OrderBook, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.obCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{okex.ChannelName(req.Channel)}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{sdk.ChannelName(req.Channel)}, m)
 }
 
 // OPTIONSummary
@@ -277,22 +277,22 @@ func (c *Public) UOrderBook(req requests.OrderBook, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-option-summary-channel
 func (c *Public) OPTIONSummary(req requests.OPTIONSummary, ch ...chan *public.OPTIONSummary) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.osCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"opt-summary"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"opt-summary"}, m)
 }
 
 // UOPTIONSummary
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-option-summary-channel
 func (c *Public) UOPTIONSummary(req requests.OPTIONSummary, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.osCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"opt-summary"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"opt-summary"}, m)
 }
 
 // FundingRate
@@ -300,22 +300,22 @@ func (c *Public) UOPTIONSummary(req requests.OPTIONSummary, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-funding-rate-channel
 func (c *Public) FundingRate(req requests.FundingRate, ch ...chan *public.FundingRate) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.frCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"funding-rate"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"funding-rate"}, m)
 }
 
 // UFundingRate
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-funding-rate-channel
 func (c *Public) UFundingRate(req requests.FundingRate, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.frCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"funding-rate"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"funding-rate"}, m)
 }
 
 // IndexCandlesticks
@@ -323,24 +323,24 @@ func (c *Public) UFundingRate(req requests.FundingRate, rCh ...bool) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-index-candlesticks-channel
 func (c *Public) IndexCandlesticks(req requests.IndexCandlesticks, ch ...chan *public.IndexCandlesticks) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	m["channel"] = req.Channel
 	if len(ch) > 0 {
 		c.icCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{}, m)
+	return c.Subscribe(false, []sdk.ChannelName{}, m)
 }
 
 // UIndexCandlesticks
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-index-candlesticks-channel
 func (c *Public) UIndexCandlesticks(req requests.IndexCandlesticks, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	m["channel"] = req.Channel
 	if len(rCh) > 0 && rCh[0] {
 		c.icCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{}, m)
 }
 
 // IndexTickers
@@ -348,22 +348,22 @@ func (c *Public) UIndexCandlesticks(req requests.IndexCandlesticks, rCh ...bool)
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-index-tickers-channel
 func (c *Public) IndexTickers(req requests.IndexTickers, ch ...chan *public.IndexTickers) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(ch) > 0 {
 		c.itCh = ch[0]
 	}
-	return c.Subscribe(false, []okex.ChannelName{"index-tickers"}, m)
+	return c.Subscribe(false, []sdk.ChannelName{"index-tickers"}, m)
 }
 
 // UIndexTickers
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-index-tickers-channel
 func (c *Public) UIndexTickers(req requests.IndexTickers, rCh ...bool) error {
-	m := okex.S2M(req)
+	m := sdk.S2M(req)
 	if len(rCh) > 0 && rCh[0] {
 		c.itCh = nil
 	}
-	return c.Unsubscribe(false, []okex.ChannelName{"index-tickers"}, m)
+	return c.Unsubscribe(false, []sdk.ChannelName{"index-tickers"}, m)
 }
 
 func (c *Public) Process(data []byte, e *events.Basic) bool {
@@ -379,12 +379,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.iCh != nil {
-					c.iCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.iCh, e)
 			return true
 		case "tickers":
 			e := public.Tickers{}
@@ -392,12 +387,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.tCh != nil {
-					c.tCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.tCh, e)
 			return true
 		case "open-interest":
 			e := public.OpenInterest{}
@@ -405,12 +395,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.oiCh != nil {
-					c.oiCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.oiCh, e)
 			return true
 		case "trades":
 			e := public.Trades{}
@@ -418,12 +403,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.trCh != nil {
-					c.trCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.trCh, e)
 			return true
 		case "estimated-price":
 			e := public.EstimatedDeliveryExercisePrice{}
@@ -431,12 +411,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.edepCh != nil {
-					c.edepCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.edepCh, e)
 			return true
 		case "mark-price":
 			e := public.MarkPrice{}
@@ -444,12 +419,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.mpCh != nil {
-					c.mpCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.mpCh, e)
 			return true
 		case "price-limit":
 			e := public.PriceLimit{}
@@ -457,12 +427,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.plCh != nil {
-					c.plCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.plCh, e)
 			return true
 		case "opt-summary":
 			e := public.OPTIONSummary{}
@@ -470,12 +435,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.osCh != nil {
-					c.osCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.osCh, e)
 			return true
 		case "funding-rate":
 			e := public.OPTIONSummary{}
@@ -483,12 +443,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.osCh != nil {
-					c.osCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.osCh, e)
 			return true
 		case "index-tickers":
 			e := public.IndexTickers{}
@@ -496,12 +451,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			go func() {
-				if c.itCh != nil {
-					c.itCh <- &e
-				}
-				c.StructuredEventChan <- e
-			}()
+			deliver(c.ClientWs, e.Arg, c.itCh, e)
 			return true
 		default:
 			// special cases
@@ -514,12 +464,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 				if err != nil {
 					return false
 				}
-				go func() {
-					if c.mpcCh != nil {
-						c.mpcCh <- &e
-					}
-					c.StructuredEventChan <- e
-				}()
+				deliver(c.ClientWs, e.Arg, c.mpcCh, e)
 				return true
 			}
 			// index chandlestick channels
@@ -529,12 +474,7 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 				if err != nil {
 					return false
 				}
-				go func() {
-					if c.icCh != nil {
-						c.icCh <- &e
-					}
-					c.StructuredEventChan <- e
-				}()
+				deliver(c.ClientWs, e.Arg, c.icCh, e)
 				return true
 			}
 			// candlestick channels
@@ -544,27 +484,17 @@ func (c *Public) Process(data []byte, e *events.Basic) bool {
 				if err != nil {
 					return false
 				}
-				go func() {
-					if c.cCh != nil {
-						c.cCh <- &e
-					}
-					c.StructuredEventChan <- e
-				}()
+				deliver(c.ClientWs, e.Arg, c.cCh, e)
 				return true
 			}
-			// order book channels
-			if strings.Contains(chName, "books") {
+			// order book channels, bbo-tbt is the top of book
+			if strings.Contains(chName, "books") || chName == "bbo-tbt" {
 				e := public.OrderBook{}
 				err := json.Unmarshal(data, &e)
 				if err != nil {
 					return false
 				}
-				go func() {
-					if c.obCh != nil {
-						c.obCh <- &e
-					}
-					c.StructuredEventChan <- e
-				}()
+				deliver(c.ClientWs, e.Arg, c.obCh, e)
 				return true
 			}
 		}
@@ -0,0 +1,154 @@
+package ws
+
+import (
+	"AxonTrading/exchanges/okx/sdk"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotSent the request was not handed to the connection (connect or login failed), so it had no effect
+	ErrNotSent = errors.New("okx ws: request not sent")
+	// ErrConnectionLost the connection dropped while waiting for the response; the request may or may not have been executed
+	ErrConnectionLost = errors.New("okx ws: connection lost before response")
+	// ErrDuplicateID another request with the same id is still waiting for its response
+	ErrDuplicateID = errors.New("okx ws: request id in use")
+
+	errReconnecting = errors.New("connection is reconnecting")
+)
+
+// pending a request waiting for the response carrying its id
+type pending struct {
+	private bool
+	ch      chan []byte
+	lost    chan error
+}
+
+// Request sends an operation tagged with id and waits for the response carrying the same id.
+// args is the JSON array of the operation, e.g. []map[string]string. An empty id is replaced by a
+// generated one. The raw response is returned whatever its code;
+// errors are ErrNotSent, ErrConnectionLost, ErrDuplicateID or ctx.Err().
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-ws-place-order
+func (c *ClientWs) Request(ctx context.Context, p bool, op sdk.Operation, id string, args interface{}) ([]byte, error) {
+	if id == "" {
+		id = strconv.FormatUint(c.reqSeq.Add(1), 10)
+	}
+	w := &pending{private: p, ch: make(chan []byte, 1), lost: make(chan error, 1)}
+	c.reqMu.Lock()
+	if _, ok := c.pending[id]; ok {
+		c.reqMu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateID, id)
+	}
+	c.pending[id] = w
+	c.reqMu.Unlock()
+	defer func() {
+		c.reqMu.Lock()
+		delete(c.pending, id)
+		c.reqMu.Unlock()
+	}()
+
+	if err := c.ready(ctx, p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSent, err)
+	}
+	if err := c.send(p, op, args, map[string]string{"id": id}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSent, err)
+	}
+	select {
+	case data := <-w.ch:
+		return data, nil
+	case err := <-w.lost:
+		return nil, fmt.Errorf("%w: %v", ErrConnectionLost, err)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.ctx.Done():
+		return nil, fmt.Errorf("%w: %v", ErrConnectionLost, c.ctx.Err())
+	}
+}
+
+// ready connects, and logs in on the private connection, within ctx. It dials once instead of
+// retrying like Connect, so the caller learns quickly that the socket is down. A connection that
+// is reconnecting is not ready either: the request would wait for the resync.
+func (c *ClientWs) ready(ctx context.Context, p bool) error {
+	up, reconnecting := c.state(p)
+	if reconnecting {
+		return errReconnecting
+	}
+	if !up {
+		dialed := make(chan error, 1)
+		go func() {
+			c.dialMu.Lock()
+			defer c.dialMu.Unlock()
+			if up, _ := c.state(p); up {
+				dialed <- nil
+				return
+			}
+			dialed <- c.dial(p)
+		}()
+		select {
+		case err := <-dialed:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if !p {
+		return nil
+	}
+	if err := c.Login(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for !c.authorized() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// state reports whether connection p is up or reconnecting
+func (c *ClientWs) state(p bool) (up, reconnecting bool) {
+	c.mu[p].RLock()
+	defer c.mu[p].RUnlock()
+	return c.conn[p] != nil, c.reconnecting[p]
+}
+
+// answer hands a response to the request waiting for id; false when nobody waits for it
+func (c *ClientWs) answer(id string, data []byte) bool {
+	c.reqMu.Lock()
+	w, ok := c.pending[id]
+	c.reqMu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case w.ch <- append([]byte(nil), data...):
+	default:
+	}
+	return true
+}
+
+// failPending fails every request waiting on a connection that dropped.
+// The responses of those requests never arrive on the new connection.
+func (c *ClientWs) failPending(p bool, cause error) {
+	c.reqMu.Lock()
+	defer c.reqMu.Unlock()
+	for _, w := range c.pending {
+		if w.private != p {
+			continue
+		}
+		select {
+		case w.lost <- cause:
+		default:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/ws/trade"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlaceOrderWaitMatchesID(t *testing.T) {
+	srv := newAnsweringServer(t, func(op, id string) string {
+		if op != string(sdk.BatchOrderOperation) {
+			return ""
+		}
+		// 先回一个其他请求的响应，只有 id 相同的响应交给调用方
+		return `{"id":"other","op":"batch-orders","code":"0","msg":"","data":[]}` + "\n" +
+			`{"id":"` + id + `","op":"batch-orders","code":"2","msg":"","data":[` +
+			`{"clOrdId":"a","ordId":"1","tag":"","sCode":"0","sMsg":""},` +
+			`{"clOrdId":"b","ordId":"","tag":"","sCode":"51008","sMsg":"Insufficient balance"}]}`
+	})
+	c := newTestClient(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := c.Trade.PlaceOrderWait(ctx,
+		requests.PlaceOrder{ID: "req1", InstID: "BTC-USDT", ClOrdID: "a", Sz: 1, Px: 100, TdMode: "cash", Side: "buy", OrdType: "limit"},
+		requests.PlaceOrder{InstID: "BTC-USDT", ClOrdID: "b", Sz: 1, Px: 100, TdMode: "cash", Side: "buy", OrdType: "limit"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Code != 2 || len(res.PlaceOrders) != 2 || res.PlaceOrders[0].OrdID != "1" || res.PlaceOrders[1].SCode != 51008 {
+		t.Fatalf("response = %+v", res)
+	}
+	srv.next(t) // login
+	if m := srv.next(t); !strings.Contains(m, `"id":"req1"`) || !strings.Contains(m, `"op":"batch-orders"`) {
+		t.Fatalf("request = %s", m)
+	}
+}
+
+func TestRequestFailsOnDisconnect(t *testing.T) {
+	srv := newAnsweringServer(t, func(op, id string) string { return "" })
+	c := newTestClient(t, srv)
+
+	// 没有响应时按 ctx 超时
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Trade.CancelOrderWait(ctx, requests.CancelOrder{InstID: "BTC-USDT", OrdID: "1"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+	srv.next(t) // login
+	srv.next(t)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.Trade.CancelOrderWait(context.Background(), requests.CancelOrder{InstID: "BTC-USDT", OrdID: "2"})
+		errCh <- err
+	}()
+	srv.next(t)
+	srv.kill()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrConnectionLost) {
+			t.Fatalf("err = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request still waiting after disconnect")
+	}
+}
@@ -1,8 +1,11 @@
 package ws
 
 import (
-	"github.com/amir-the-h/okex"
-	requests "github.com/amir-the-h/okex/requests/ws/trade"
+	"AxonTrading/exchanges/okx/sdk"
+	requests "AxonTrading/exchanges/okx/sdk/requests/ws/trade"
+	responses "AxonTrading/exchanges/okx/sdk/responses/trade"
+	"context"
+	"encoding/json"
 )
 
 // Trade
@@ -26,15 +29,28 @@ func NewTrade(c *ClientWs) *Trade {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-place-multiple-orders
 func (c *Trade) PlaceOrder(req ...requests.PlaceOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
-	op := okex.OrderOperation
+	op, args := placeArgs(req)
+	return c.Send(true, op, args, map[string]string{"id": req[0].ID})
+}
+
+// PlaceOrderWait is PlaceOrder that waits for the response to its request id.
+// Each order has its own sCode/sMsg; the response code is 1 when every order failed and 2 when some did.
+func (c *Trade) PlaceOrderWait(ctx context.Context, req ...requests.PlaceOrder) (response responses.PlaceOrder, err error) {
+	op, args := placeArgs(req)
+	err = c.wait(ctx, op, req[0].ID, args, &response)
+	return
+}
+
+func placeArgs(req []requests.PlaceOrder) (sdk.Operation, []map[string]string) {
+	args := make([]map[string]string, len(req))
+	op := sdk.OrderOperation
 	if len(req) > 1 {
-		op = okex.BatchOrderOperation
+		op = sdk.BatchOrderOperation
 	}
 	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+		args[i] = sdk.S2M(order)
 	}
-	return c.Send(true, op, tmpArgs, map[string]string{"id": req[0].ID})
+	return op, args
 }
 
 // CancelOrder
@@ -46,15 +62,27 @@ func (c *Trade) PlaceOrder(req ...requests.PlaceOrder) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-cancel-multiple-orders
 func (c *Trade) CancelOrder(req ...requests.CancelOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
-	op := okex.CancelOrderOperation
+	op, args := cancelArgs(req)
+	return c.Send(true, op, args, map[string]string{"id": req[0].ID})
+}
+
+// CancelOrderWait is CancelOrder that waits for the response to its request id
+func (c *Trade) CancelOrderWait(ctx context.Context, req ...requests.CancelOrder) (response responses.CancelOrder, err error) {
+	op, args := cancelArgs(req)
+	err = c.wait(ctx, op, req[0].ID, args, &response)
+	return
+}
+
+func cancelArgs(req []requests.CancelOrder) (sdk.Operation, []map[string]string) {
+	args := make([]map[string]string, len(req))
+	op := sdk.CancelOrderOperation
 	if len(req) > 1 {
-		op = okex.BatchCancelOrderOperation
+		op = sdk.BatchCancelOrderOperation
 	}
 	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+		args[i] = sdk.S2M(order)
 	}
-	return c.Send(true, op, tmpArgs, map[string]string{"id": req[0].ID})
+	return op, args
 }
 
 // AmendOrder
@@ -66,13 +94,34 @@ func (c *Trade) CancelOrder(req ...requests.CancelOrder) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-amend-multiple-orders
 func (c *Trade) AmendOrder(req ...requests.AmendOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
-	op := okex.AmendOrderOperation
+	op, args := amendArgs(req)
+	return c.Send(true, op, args, map[string]string{"id": req[0].ID})
+}
+
+// AmendOrderWait is AmendOrder that waits for the response to its request id
+func (c *Trade) AmendOrderWait(ctx context.Context, req ...requests.AmendOrder) (response responses.AmendOrder, err error) {
+	op, args := amendArgs(req)
+	err = c.wait(ctx, op, req[0].ID, args, &response)
+	return
+}
+
+func amendArgs(req []requests.AmendOrder) (sdk.Operation, []map[string]string) {
+	args := make([]map[string]string, len(req))
+	op := sdk.AmendOrderOperation
 	if len(req) > 1 {
-		op = okex.BatchAmendOrderOperation
+		op = sdk.BatchAmendOrderOperation
 	}
 	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+		args[i] = sdk.S2M(order)
+	}
+	return op, args
+}
+
+// wait sends the request on the private connection and decodes the response into v
+func (c *Trade) wait(ctx context.Context, op sdk.Operation, id string, args []map[string]string, v interface{}) error {
+	data, err := c.Request(ctx, true, op, id, args)
+	if err != nil {
+		return err
 	}
-	return c.Send(true, op, tmpArgs, map[string]string{"id": req[0].ID})
+	return json.Unmarshal(data, v)
 }
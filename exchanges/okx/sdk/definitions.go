@@ -1,7 +1,7 @@
-// Package okx is generally a golang Api wrapper of Okex V5 API
+// Package sdk is generally a golang Api wrapper of Okex V5 API
 //
 // https://www.okx.com/docs-v5/en
-package okex
+package sdk
 
 import (
 	"encoding/json"
@@ -51,6 +51,7 @@ type (
 	WithdrawalState       int8
 
 	JSONFloat64 float64
+	JSONDecimal string
 	JSONInt64   int64
 	JSONTime    time.Time
 
@@ -327,6 +328,13 @@ func (t *JSONFloat64) UnmarshalJSON(s []byte) (err error) {
 	*(*float64)(t) = q
 	return
 }
+
+// UnmarshalJSON keeps the number as OKX sent it; going through float64 would drop digits of large amounts.
+func (t *JSONDecimal) UnmarshalJSON(s []byte) (err error) {
+	*t = JSONDecimal(strings.Replace(string(s), `"`, ``, -1))
+	return
+}
+
 func (t *JSONInt64) UnmarshalJSON(s []byte) (err error) {
 	r := strings.Replace(string(s), `"`, ``, -1)
 	if r == "" {
@@ -1,41 +1,41 @@
 package events
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
 	"encoding/json"
-	"github.com/amir-the-h/okex"
 )
 
 type (
 	Basic struct {
-		ID    string         `json:"id,omitempty"`
-		Event string         `json:"event"`
-		Code  int            `json:"code,omitempty,string"`
-		Msg   string         `json:"msg,omitempty"`
-		Op    okex.Operation `json:"op,omitempty"`
-		Arg   *Argument      `json:"arg,omitempty"`
-		Args  []*Argument    `json:"args,omitempty"`
-		Data  []*Argument    `json:"data,omitempty"`
+		ID    string        `json:"id,omitempty"`
+		Event string        `json:"event"`
+		Code  int           `json:"code,omitempty,string"`
+		Msg   string        `json:"msg,omitempty"`
+		Op    sdk.Operation `json:"op,omitempty"`
+		Arg   *Argument     `json:"arg,omitempty"`
+		Args  []*Argument   `json:"args,omitempty"`
+		Data  []*Argument   `json:"data,omitempty"`
 	}
 	Argument struct {
 		arg        map[string]interface{}
 		untypedArg []interface{}
 	}
 	Success struct {
-		Code int            `json:"code,omitempty,string"`
-		Msg  string         `json:"msg,omitempty"`
-		ID   string         `json:"id,omitempty"`
-		Op   okex.Operation `json:"op,omitempty"`
-		Data []*Argument    `json:"data,omitempty"`
+		Code int           `json:"code,omitempty,string"`
+		Msg  string        `json:"msg,omitempty"`
+		ID   string        `json:"id,omitempty"`
+		Op   sdk.Operation `json:"op,omitempty"`
+		Data []*Argument   `json:"data,omitempty"`
 	}
 	Error struct {
-		Event string         `json:"event,omitempty"`
-		Msg   string         `json:"msg,omitempty"`
-		Op    string         `json:"op,omitempty"`
-		Code  okex.JSONInt64 `json:"code"`
-		Args  []*Argument    `json:"args,omitempty"`
-		Arg   *Argument      `json:"arg,omitempty"`
-		Data  []*Argument    `json:"data,omitempty"`
-		ID    string         `json:"id,omitempty"`
+		Event string        `json:"event,omitempty"`
+		Msg   string        `json:"msg,omitempty"`
+		Op    string        `json:"op,omitempty"`
+		Code  sdk.JSONInt64 `json:"code"`
+		Args  []*Argument   `json:"args,omitempty"`
+		Arg   *Argument     `json:"arg,omitempty"`
+		Data  []*Argument   `json:"data,omitempty"`
+		ID    string        `json:"id,omitempty"`
 	}
 	Login struct {
 		Event string `json:"event"`
@@ -50,6 +50,27 @@ type (
 		Event string    `json:"event"`
 		Arg   *Argument `json:"arg"`
 	}
+	// Reconnect reports the state of a websocket connection that dropped and is being restored
+	Reconnect struct {
+		Private bool
+		Stage   ReconnectStage
+		// Attempt is the number of dials since the connection dropped
+		Attempt int
+		// Err is the cause of the disconnect, or the last dial/login error
+		Err error
+		// Args are the subscriptions replayed on resync
+		Args []map[string]string
+	}
+	ReconnectStage string
+)
+
+const (
+	// Disconnected the connection dropped (read/write error or pong timeout)
+	Disconnected ReconnectStage = "disconnected"
+	// Reconnected a new connection is up, and logged in again on the private socket
+	Reconnected ReconnectStage = "reconnected"
+	// Resynced every active subscription was sent again on the new connection
+	Resynced ReconnectStage = "resynced"
 )
 
 func (a *Argument) Get(k string) (interface{}, bool) {
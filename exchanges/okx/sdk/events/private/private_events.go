@@ -1,9 +1,9 @@
 package private
 
 import (
-	"github.com/amir-the-h/okex/events"
-	"github.com/amir-the-h/okex/models/account"
-	"github.com/amir-the-h/okex/models/trade"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"AxonTrading/exchanges/okx/sdk/models/account"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
 )
 
 type (
@@ -1,9 +1,9 @@
 package public
 
 import (
-	"github.com/amir-the-h/okex/events"
-	"github.com/amir-the-h/okex/models/market"
-	"github.com/amir-the-h/okex/models/publicdata"
+	"AxonTrading/exchanges/okx/sdk/events"
+	"AxonTrading/exchanges/okx/sdk/models/market"
+	"AxonTrading/exchanges/okx/sdk/models/publicdata"
 )
 
 type (
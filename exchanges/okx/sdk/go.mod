@@ -1,5 +0,0 @@
-module github.com/amir-the-h/okex
-
-go 1.17
-
-require github.com/gorilla/websocket v1.4.2
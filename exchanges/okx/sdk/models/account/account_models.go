@@ -1,206 +1,212 @@
 package account
 
 import (
-	"github.com/amir-the-h/okex"
+	"AxonTrading/exchanges/okx/sdk"
 )
 
 type (
 	Balance struct {
-		TotalEq     okex.JSONFloat64  `json:"totalEq"`
-		IsoEq       okex.JSONFloat64  `json:"isoEq"`
-		AdjEq       okex.JSONFloat64  `json:"adjEq,omitempty"`
-		OrdFroz     okex.JSONFloat64  `json:"ordFroz,omitempty"`
-		Imr         okex.JSONFloat64  `json:"imr,omitempty"`
-		Mmr         okex.JSONFloat64  `json:"mmr,omitempty"`
-		MgnRatio    okex.JSONFloat64  `json:"mgnRatio,omitempty"`
-		NotionalUsd okex.JSONFloat64  `json:"notionalUsd,omitempty"`
+		TotalEq     sdk.JSONDecimal   `json:"totalEq"`
+		IsoEq       sdk.JSONDecimal   `json:"isoEq"`
+		AdjEq       sdk.JSONDecimal   `json:"adjEq,omitempty"`
+		OrdFroz     sdk.JSONDecimal   `json:"ordFroz,omitempty"`
+		Imr         sdk.JSONDecimal   `json:"imr,omitempty"`
+		Mmr         sdk.JSONDecimal   `json:"mmr,omitempty"`
+		MgnRatio    sdk.JSONDecimal   `json:"mgnRatio,omitempty"`
+		NotionalUsd sdk.JSONDecimal   `json:"notionalUsd,omitempty"`
 		Details     []*BalanceDetails `json:"details,omitempty"`
-		UTime       okex.JSONTime     `json:"uTime"`
+		UTime       sdk.JSONTime      `json:"uTime"`
 	}
 	BalanceDetails struct {
-		Ccy           string           `json:"ccy"`
-		Eq            okex.JSONFloat64 `json:"eq"`
-		CashBal       okex.JSONFloat64 `json:"cashBal"`
-		IsoEq         okex.JSONFloat64 `json:"isoEq,omitempty"`
-		AvailEq       okex.JSONFloat64 `json:"availEq,omitempty"`
-		DisEq         okex.JSONFloat64 `json:"disEq"`
-		AvailBal      okex.JSONFloat64 `json:"availBal"`
-		FrozenBal     okex.JSONFloat64 `json:"frozenBal"`
-		OrdFrozen     okex.JSONFloat64 `json:"ordFrozen"`
-		Liab          okex.JSONFloat64 `json:"liab,omitempty"`
-		Upl           okex.JSONFloat64 `json:"upl,omitempty"`
-		UplLib        okex.JSONFloat64 `json:"uplLib,omitempty"`
-		CrossLiab     okex.JSONFloat64 `json:"crossLiab,omitempty"`
-		IsoLiab       okex.JSONFloat64 `json:"isoLiab,omitempty"`
-		MgnRatio      okex.JSONFloat64 `json:"mgnRatio,omitempty"`
-		Interest      okex.JSONFloat64 `json:"interest,omitempty"`
-		Twap          okex.JSONFloat64 `json:"twap,omitempty"`
-		MaxLoan       okex.JSONFloat64 `json:"maxLoan,omitempty"`
-		EqUsd         okex.JSONFloat64 `json:"eqUsd"`
-		NotionalLever okex.JSONFloat64 `json:"notionalLever,omitempty"`
-		StgyEq        okex.JSONFloat64 `json:"stgyEq"`
-		IsoUpl        okex.JSONFloat64 `json:"isoUpl,omitempty"`
-		UTime         okex.JSONTime    `json:"uTime"`
+		Ccy           string          `json:"ccy"`
+		Eq            sdk.JSONDecimal `json:"eq"`
+		CashBal       sdk.JSONDecimal `json:"cashBal"`
+		IsoEq         sdk.JSONDecimal `json:"isoEq,omitempty"`
+		AvailEq       sdk.JSONDecimal `json:"availEq,omitempty"`
+		DisEq         sdk.JSONDecimal `json:"disEq"`
+		AvailBal      sdk.JSONDecimal `json:"availBal"`
+		FrozenBal     sdk.JSONDecimal `json:"frozenBal"`
+		OrdFrozen     sdk.JSONDecimal `json:"ordFrozen"`
+		Liab          sdk.JSONDecimal `json:"liab,omitempty"`
+		Upl           sdk.JSONDecimal `json:"upl,omitempty"`
+		UplLib        sdk.JSONDecimal `json:"uplLib,omitempty"`
+		Imr           sdk.JSONDecimal `json:"imr,omitempty"`
+		Mmr           sdk.JSONDecimal `json:"mmr,omitempty"`
+		CrossLiab     sdk.JSONDecimal `json:"crossLiab,omitempty"`
+		IsoLiab       sdk.JSONDecimal `json:"isoLiab,omitempty"`
+		MgnRatio      sdk.JSONDecimal `json:"mgnRatio,omitempty"`
+		Interest      sdk.JSONDecimal `json:"interest,omitempty"`
+		Twap          sdk.JSONDecimal `json:"twap,omitempty"`
+		MaxLoan       sdk.JSONDecimal `json:"maxLoan,omitempty"`
+		EqUsd         sdk.JSONDecimal `json:"eqUsd"`
+		NotionalLever sdk.JSONDecimal `json:"notionalLever,omitempty"`
+		StgyEq        sdk.JSONDecimal `json:"stgyEq"`
+		IsoUpl        sdk.JSONDecimal `json:"isoUpl,omitempty"`
+		UTime         sdk.JSONTime    `json:"uTime"`
 	}
 	Position struct {
-		InstID      string              `json:"instId"`
-		PosCcy      string              `json:"posCcy,omitempty"`
-		LiabCcy     string              `json:"liabCcy,omitempty"`
-		OptVal      string              `json:"optVal,omitempty"`
-		Ccy         string              `json:"ccy"`
-		PosID       string              `json:"posId"`
-		TradeID     string              `json:"tradeId"`
-		Pos         okex.JSONFloat64    `json:"pos"`
-		AvailPos    okex.JSONFloat64    `json:"availPos,omitempty"`
-		AvgPx       okex.JSONFloat64    `json:"avgPx"`
-		Upl         okex.JSONFloat64    `json:"upl"`
-		UplRatio    okex.JSONFloat64    `json:"uplRatio"`
-		Lever       okex.JSONFloat64    `json:"lever"`
-		LiqPx       okex.JSONFloat64    `json:"liqPx,omitempty"`
-		Imr         okex.JSONFloat64    `json:"imr,omitempty"`
-		Margin      okex.JSONFloat64    `json:"margin,omitempty"`
-		MgnRatio    okex.JSONFloat64    `json:"mgnRatio"`
-		Mmr         okex.JSONFloat64    `json:"mmr"`
-		Liab        okex.JSONFloat64    `json:"liab,omitempty"`
-		Interest    okex.JSONFloat64    `json:"interest"`
-		NotionalUsd okex.JSONFloat64    `json:"notionalUsd"`
-		ADL         okex.JSONFloat64    `json:"adl"`
-		Last        okex.JSONFloat64    `json:"last"`
-		DeltaBS     okex.JSONFloat64    `json:"deltaBS"`
-		DeltaPA     okex.JSONFloat64    `json:"deltaPA"`
-		GammaBS     okex.JSONFloat64    `json:"gammaBS"`
-		GammaPA     okex.JSONFloat64    `json:"gammaPA"`
-		ThetaBS     okex.JSONFloat64    `json:"thetaBS"`
-		ThetaPA     okex.JSONFloat64    `json:"thetaPA"`
-		VegaBS      okex.JSONFloat64    `json:"vegaBS"`
-		VegaPA      okex.JSONFloat64    `json:"vegaPA"`
-		PosSide     okex.PositionSide   `json:"posSide"`
-		MgnMode     okex.MarginMode     `json:"mgnMode"`
-		InstType    okex.InstrumentType `json:"instType"`
-		CTime       okex.JSONTime       `json:"cTime"`
-		UTime       okex.JSONTime       `json:"uTime"`
+		InstID      string             `json:"instId"`
+		PosCcy      string             `json:"posCcy,omitempty"`
+		LiabCcy     string             `json:"liabCcy,omitempty"`
+		OptVal      string             `json:"optVal,omitempty"`
+		Ccy         string             `json:"ccy"`
+		PosID       string             `json:"posId"`
+		TradeID     string             `json:"tradeId"`
+		Pos         sdk.JSONDecimal    `json:"pos"`
+		AvailPos    sdk.JSONDecimal    `json:"availPos,omitempty"`
+		AvgPx       sdk.JSONDecimal    `json:"avgPx"`
+		MarkPx      sdk.JSONDecimal    `json:"markPx"`
+		Upl         sdk.JSONDecimal    `json:"upl"`
+		UplRatio    sdk.JSONDecimal    `json:"uplRatio"`
+		RealizedPnl sdk.JSONDecimal    `json:"realizedPnl"`
+		Lever       sdk.JSONDecimal    `json:"lever"`
+		LiqPx       sdk.JSONDecimal    `json:"liqPx,omitempty"`
+		Imr         sdk.JSONDecimal    `json:"imr,omitempty"`
+		Margin      sdk.JSONDecimal    `json:"margin,omitempty"`
+		MgnRatio    sdk.JSONDecimal    `json:"mgnRatio"`
+		Mmr         sdk.JSONDecimal    `json:"mmr"`
+		Liab        sdk.JSONDecimal    `json:"liab,omitempty"`
+		Interest    sdk.JSONDecimal    `json:"interest"`
+		NotionalUsd sdk.JSONDecimal    `json:"notionalUsd"`
+		ADL         sdk.JSONInt64      `json:"adl"`
+		Last        sdk.JSONDecimal    `json:"last"`
+		DeltaBS     sdk.JSONDecimal    `json:"deltaBS"`
+		DeltaPA     sdk.JSONDecimal    `json:"deltaPA"`
+		GammaBS     sdk.JSONDecimal    `json:"gammaBS"`
+		GammaPA     sdk.JSONDecimal    `json:"gammaPA"`
+		ThetaBS     sdk.JSONDecimal    `json:"thetaBS"`
+		ThetaPA     sdk.JSONDecimal    `json:"thetaPA"`
+		VegaBS      sdk.JSONDecimal    `json:"vegaBS"`
+		VegaPA      sdk.JSONDecimal    `json:"vegaPA"`
+		PosSide     sdk.PositionSide   `json:"posSide"`
+		MgnMode     sdk.MarginMode     `json:"mgnMode"`
+		InstType    sdk.InstrumentType `json:"instType"`
+		CTime       sdk.JSONTime       `json:"cTime"`
+		UTime       sdk.JSONTime       `json:"uTime"`
 	}
 	BalanceAndPosition struct {
-		EventType okex.EventType    `json:"eventType"`
-		PTime     okex.JSONTime     `json:"pTime"`
-		UTime     okex.JSONTime     `json:"uTime"`
+		EventType sdk.EventType     `json:"eventType"`
+		PTime     sdk.JSONTime      `json:"pTime"`
+		UTime     sdk.JSONTime      `json:"uTime"`
 		PosData   []*Position       `json:"posData"`
 		BalData   []*BalanceDetails `json:"balData"`
 	}
 	PositionAndAccountRisk struct {
-		AdjEq   okex.JSONFloat64                     `json:"adjEq,omitempty"`
+		AdjEq   sdk.JSONDecimal                      `json:"adjEq,omitempty"`
 		BalData []*PositionAndAccountRiskBalanceData `json:"balData"`
 		PosData []*PositionAndAccountRiskBalanceData `json:"posData"`
-		TS      okex.JSONTime                        `json:"ts"`
+		TS      sdk.JSONTime                         `json:"ts"`
 	}
 	PositionAndAccountRiskBalanceData struct {
-		Ccy   string           `json:"ccy"`
-		Eq    okex.JSONFloat64 `json:"eq"`
-		DisEq okex.JSONFloat64 `json:"disEq"`
+		Ccy   string          `json:"ccy"`
+		Eq    sdk.JSONDecimal `json:"eq"`
+		DisEq sdk.JSONDecimal `json:"disEq"`
 	}
 	PositionAndAccountRiskPositionData struct {
-		InstID      string              `json:"instId"`
-		PosCcy      string              `json:"posCcy,omitempty"`
-		Ccy         string              `json:"ccy"`
-		NotionalCcy okex.JSONFloat64    `json:"notionalCcy"`
-		Pos         okex.JSONFloat64    `json:"pos"`
-		NotionalUsd okex.JSONFloat64    `json:"notionalUsd"`
-		PosSide     okex.PositionSide   `json:"posSide"`
-		InstType    okex.InstrumentType `json:"instType"`
-		MgnMode     okex.MarginMode     `json:"mgnMode"`
+		InstID      string             `json:"instId"`
+		PosCcy      string             `json:"posCcy,omitempty"`
+		Ccy         string             `json:"ccy"`
+		NotionalCcy sdk.JSONDecimal    `json:"notionalCcy"`
+		Pos         sdk.JSONDecimal    `json:"pos"`
+		NotionalUsd sdk.JSONDecimal    `json:"notionalUsd"`
+		PosSide     sdk.PositionSide   `json:"posSide"`
+		InstType    sdk.InstrumentType `json:"instType"`
+		MgnMode     sdk.MarginMode     `json:"mgnMode"`
 	}
 	Bill struct {
-		Ccy       string              `json:"ccy"`
-		InstID    string              `json:"instId"`
-		Notes     string              `json:"notes"`
-		BillID    string              `json:"billId"`
-		OrdID     string              `json:"ordId"`
-		BalChg    okex.JSONFloat64    `json:"balChg"`
-		PosBalChg okex.JSONFloat64    `json:"posBalChg"`
-		Bal       okex.JSONFloat64    `json:"bal"`
-		PosBal    okex.JSONFloat64    `json:"posBal"`
-		Sz        okex.JSONFloat64    `json:"sz"`
-		Pnl       okex.JSONFloat64    `json:"pnl"`
-		Fee       okex.JSONFloat64    `json:"fee"`
-		From      okex.AccountType    `json:"from,string"`
-		To        okex.AccountType    `json:"to,string"`
-		InstType  okex.InstrumentType `json:"instType"`
-		MgnMode   okex.MarginMode     `json:"MgnMode"`
-		Type      okex.BillType       `json:"type,string"`
-		SubType   okex.BillSubType    `json:"subType,string"`
-		TS        okex.JSONTime       `json:"ts"`
+		Ccy       string             `json:"ccy"`
+		InstID    string             `json:"instId"`
+		Notes     string             `json:"notes"`
+		BillID    string             `json:"billId"`
+		OrdID     string             `json:"ordId"`
+		BalChg    sdk.JSONDecimal    `json:"balChg"`
+		PosBalChg sdk.JSONDecimal    `json:"posBalChg"`
+		Bal       sdk.JSONDecimal    `json:"bal"`
+		PosBal    sdk.JSONDecimal    `json:"posBal"`
+		Sz        sdk.JSONDecimal    `json:"sz"`
+		Pnl       sdk.JSONDecimal    `json:"pnl"`
+		Fee       sdk.JSONDecimal    `json:"fee"`
+		From      sdk.AccountType    `json:"from,string"`
+		To        sdk.AccountType    `json:"to,string"`
+		InstType  sdk.InstrumentType `json:"instType"`
+		MgnMode   sdk.MarginMode     `json:"MgnMode"`
+		Type      sdk.BillType       `json:"type,string"`
+		SubType   sdk.BillSubType    `json:"subType,string"`
+		TS        sdk.JSONTime       `json:"ts"`
 	}
 	Config struct {
-		Level      string            `json:"level"`
-		LevelTmp   string            `json:"levelTmp"`
-		AcctLv     string            `json:"acctLv"`
-		AutoLoan   bool              `json:"autoLoan"`
-		UID        string            `json:"uid"`
-		GreeksType okex.GreekType    `json:"greeksType"`
-		PosMode    okex.PositionType `json:"posMode"`
+		Level      string           `json:"level"`
+		LevelTmp   string           `json:"levelTmp"`
+		AcctLv     string           `json:"acctLv"`
+		AutoLoan   bool             `json:"autoLoan"`
+		UID        string           `json:"uid"`
+		GreeksType sdk.GreekType    `json:"greeksType"`
+		PosMode    sdk.PositionType `json:"posMode"`
 	}
 	PositionMode struct {
-		PosMode okex.PositionType `json:"posMode"`
+		PosMode sdk.PositionType `json:"posMode"`
 	}
 	Leverage struct {
-		InstID  string            `json:"instId"`
-		Lever   okex.JSONFloat64  `json:"lever"`
-		MgnMode okex.MarginMode   `json:"mgnMode"`
-		PosSide okex.PositionSide `json:"posSide"`
+		InstID  string           `json:"instId"`
+		Lever   sdk.JSONDecimal  `json:"lever"`
+		MgnMode sdk.MarginMode   `json:"mgnMode"`
+		PosSide sdk.PositionSide `json:"posSide"`
 	}
 	MaxBuySellAmount struct {
-		InstID  string           `json:"instId"`
-		Ccy     string           `json:"ccy"`
-		MaxBuy  okex.JSONFloat64 `json:"maxBuy"`
-		MaxSell okex.JSONFloat64 `json:"maxSell"`
+		InstID  string          `json:"instId"`
+		Ccy     string          `json:"ccy"`
+		MaxBuy  sdk.JSONDecimal `json:"maxBuy"`
+		MaxSell sdk.JSONDecimal `json:"maxSell"`
 	}
 	MaxAvailableTradeAmount struct {
-		InstID    string           `json:"instId"`
-		AvailBuy  okex.JSONFloat64 `json:"availBuy"`
-		AvailSell okex.JSONFloat64 `json:"availSell"`
+		InstID    string          `json:"instId"`
+		AvailBuy  sdk.JSONDecimal `json:"availBuy"`
+		AvailSell sdk.JSONDecimal `json:"availSell"`
 	}
 	MarginBalanceAmount struct {
-		InstID  string            `json:"instId"`
-		Amt     okex.JSONFloat64  `json:"amt"`
-		PosSide okex.PositionSide `json:"posSide,string"`
-		Type    okex.CountAction  `json:"type,string"`
+		InstID  string           `json:"instId"`
+		Amt     sdk.JSONDecimal  `json:"amt"`
+		PosSide sdk.PositionSide `json:"posSide"`
+		Type    sdk.CountAction  `json:"type"`
 	}
 	Loan struct {
-		InstID  string           `json:"instId"`
-		MgnCcy  string           `json:"mgnCcy"`
-		Ccy     string           `json:"ccy"`
-		MaxLoan okex.JSONFloat64 `json:"maxLoan"`
-		MgnMode okex.MarginMode  `json:"mgnMode"`
-		Side    okex.OrderSide   `json:"side,string"`
+		InstID  string          `json:"instId"`
+		MgnCcy  string          `json:"mgnCcy"`
+		Ccy     string          `json:"ccy"`
+		MaxLoan sdk.JSONDecimal `json:"maxLoan"`
+		MgnMode sdk.MarginMode  `json:"mgnMode"`
+		Side    sdk.OrderSide   `json:"side,string"`
 	}
 	Fee struct {
-		Level    string              `json:"level"`
-		Taker    okex.JSONFloat64    `json:"taker"`
-		Maker    okex.JSONFloat64    `json:"maker"`
-		Delivery okex.JSONFloat64    `json:"delivery,omitempty"`
-		Exercise okex.JSONFloat64    `json:"exercise,omitempty"`
-		Category okex.FeeCategory    `json:"category,string"`
-		InstType okex.InstrumentType `json:"instType"`
-		TS       okex.JSONTime       `json:"ts"`
+		Level    string             `json:"level"`
+		Taker    sdk.JSONDecimal    `json:"taker"`
+		Maker    sdk.JSONDecimal    `json:"maker"`
+		TakerU   sdk.JSONDecimal    `json:"takerU,omitempty"`
+		MakerU   sdk.JSONDecimal    `json:"makerU,omitempty"`
+		Delivery sdk.JSONDecimal    `json:"delivery,omitempty"`
+		Exercise sdk.JSONDecimal    `json:"exercise,omitempty"`
+		Category sdk.FeeCategory    `json:"category,string"`
+		InstType sdk.InstrumentType `json:"instType"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	InterestAccrued struct {
-		InstID       string           `json:"instId"`
-		Ccy          string           `json:"ccy"`
-		Interest     okex.JSONFloat64 `json:"interest"`
-		InterestRate okex.JSONFloat64 `json:"interestRate"`
-		Liab         okex.JSONFloat64 `json:"liab"`
-		MgnMode      okex.MarginMode  `json:"mgnMode"`
-		TS           okex.JSONTime    `json:"ts"`
+		InstID       string          `json:"instId"`
+		Ccy          string          `json:"ccy"`
+		Interest     sdk.JSONDecimal `json:"interest"`
+		InterestRate sdk.JSONDecimal `json:"interestRate"`
+		Liab         sdk.JSONDecimal `json:"liab"`
+		MgnMode      sdk.MarginMode  `json:"mgnMode"`
+		TS           sdk.JSONTime    `json:"ts"`
 	}
 	InterestRate struct {
-		Ccy          string           `json:"ccy"`
-		InterestRate okex.JSONFloat64 `json:"interestRate"`
+		Ccy          string          `json:"ccy"`
+		InterestRate sdk.JSONDecimal `json:"interestRate"`
 	}
 	Greek struct {
 		GreeksType string `json:"greeksType"`
 	}
 	MaxWithdrawal struct {
-		Ccy   string           `json:"ccy"`
-		MaxWd okex.JSONFloat64 `json:"maxWd"`
+		Ccy   string          `json:"ccy"`
+		MaxWd sdk.JSONDecimal `json:"maxWd"`
 	}
 )
@@ -1,58 +1,63 @@
 package market
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
 	"encoding/json"
 	"fmt"
-	"github.com/amir-the-h/okex"
 	"strconv"
 	"time"
 )
 
 type (
 	Ticker struct {
-		InstID    string              `json:"instId"`
-		Last      okex.JSONFloat64    `json:"last"`
-		LastSz    okex.JSONFloat64    `json:"lastSz"`
-		AskPx     okex.JSONFloat64    `json:"askPx"`
-		AskSz     okex.JSONFloat64    `json:"askSz"`
-		BidPx     okex.JSONFloat64    `json:"bidPx"`
-		BidSz     okex.JSONFloat64    `json:"bidSz"`
-		Open24h   okex.JSONFloat64    `json:"open24h"`
-		High24h   okex.JSONFloat64    `json:"high24h"`
-		Low24h    okex.JSONFloat64    `json:"low24h"`
-		VolCcy24h okex.JSONFloat64    `json:"volCcy24h"`
-		Vol24h    okex.JSONFloat64    `json:"vol24h"`
-		SodUtc0   okex.JSONFloat64    `json:"sodUtc0"`
-		SodUtc8   okex.JSONFloat64    `json:"sodUtc8"`
-		InstType  okex.InstrumentType `json:"instType"`
-		TS        okex.JSONTime       `json:"ts"`
+		InstID    string             `json:"instId"`
+		Last      sdk.JSONDecimal    `json:"last"`
+		LastSz    sdk.JSONDecimal    `json:"lastSz"`
+		AskPx     sdk.JSONDecimal    `json:"askPx"`
+		AskSz     sdk.JSONDecimal    `json:"askSz"`
+		BidPx     sdk.JSONDecimal    `json:"bidPx"`
+		BidSz     sdk.JSONDecimal    `json:"bidSz"`
+		Open24h   sdk.JSONDecimal    `json:"open24h"`
+		High24h   sdk.JSONDecimal    `json:"high24h"`
+		Low24h    sdk.JSONDecimal    `json:"low24h"`
+		VolCcy24h sdk.JSONDecimal    `json:"volCcy24h"`
+		Vol24h    sdk.JSONDecimal    `json:"vol24h"`
+		SodUtc0   sdk.JSONDecimal    `json:"sodUtc0"`
+		SodUtc8   sdk.JSONDecimal    `json:"sodUtc8"`
+		InstType  sdk.InstrumentType `json:"instType"`
+		TS        sdk.JSONTime       `json:"ts"`
 	}
 	IndexTicker struct {
-		InstID  string           `json:"instId"`
-		IdxPx   okex.JSONFloat64 `json:"idxPx"`
-		High24h okex.JSONFloat64 `json:"high24h"`
-		Low24h  okex.JSONFloat64 `json:"low24h"`
-		Open24h okex.JSONFloat64 `json:"open24h"`
-		SodUtc0 okex.JSONFloat64 `json:"sodUtc0"`
-		SodUtc8 okex.JSONFloat64 `json:"sodUtc8"`
-		TS      okex.JSONTime    `json:"ts"`
+		InstID  string          `json:"instId"`
+		IdxPx   sdk.JSONDecimal `json:"idxPx"`
+		High24h sdk.JSONDecimal `json:"high24h"`
+		Low24h  sdk.JSONDecimal `json:"low24h"`
+		Open24h sdk.JSONDecimal `json:"open24h"`
+		SodUtc0 sdk.JSONDecimal `json:"sodUtc0"`
+		SodUtc8 sdk.JSONDecimal `json:"sodUtc8"`
+		TS      sdk.JSONTime    `json:"ts"`
 	}
 	OrderBook struct {
 		Asks []*OrderBookEntity `json:"asks"`
 		Bids []*OrderBookEntity `json:"bids"`
-		TS   okex.JSONTime      `json:"ts"`
+		TS   sdk.JSONTime       `json:"ts"`
 	}
 	OrderBookWs struct {
-		Asks     []*OrderBookEntity `json:"asks"`
-		Bids     []*OrderBookEntity `json:"bids"`
-		Checksum int                `json:"checksum"`
-		TS       okex.JSONTime      `json:"ts"`
+		Asks      []*OrderBookEntity `json:"asks"`
+		Bids      []*OrderBookEntity `json:"bids"`
+		Checksum  int                `json:"checksum"`
+		SeqID     int64              `json:"seqId"`
+		PrevSeqID int64              `json:"prevSeqId"`
+		TS        sdk.JSONTime       `json:"ts"`
 	}
 	OrderBookEntity struct {
 		DepthPrice      float64
 		Size            float64
 		LiquidatedOrder int
 		OrderNumbers    int
+		// Px and Sz are the price and size as sent, the book checksum is computed over them
+		Px string
+		Sz string
 	}
 	Candle struct {
 		O      float64
@@ -61,40 +66,40 @@ type (
 		C      float64
 		Vol    float64
 		VolCcy float64
-		TS     okex.JSONTime
+		TS     sdk.JSONTime
 	}
 	IndexCandle struct {
 		O  float64
 		H  float64
 		L  float64
 		C  float64
-		TS okex.JSONTime
+		TS sdk.JSONTime
 	}
 	Trade struct {
-		InstID  string           `json:"instId"`
-		TradeID okex.JSONFloat64 `json:"tradeId"`
-		Px      okex.JSONFloat64 `json:"px"`
-		Sz      okex.JSONFloat64 `json:"sz"`
-		Side    okex.TradeSide   `json:"side,string"`
-		TS      okex.JSONTime    `json:"ts"`
+		InstID  string          `json:"instId"`
+		TradeID string          `json:"tradeId"`
+		Px      sdk.JSONDecimal `json:"px"`
+		Sz      sdk.JSONDecimal `json:"sz"`
+		Side    sdk.TradeSide   `json:"side"`
+		TS      sdk.JSONTime    `json:"ts"`
 	}
 	TotalVolume24H struct {
-		VolUsd okex.JSONFloat64 `json:"volUsd"`
-		VolCny okex.JSONFloat64 `json:"volCny"`
-		TS     okex.JSONTime    `json:"ts"`
+		VolUsd sdk.JSONDecimal `json:"volUsd"`
+		VolCny sdk.JSONDecimal `json:"volCny"`
+		TS     sdk.JSONTime    `json:"ts"`
 	}
 	IndexComponent struct {
-		Index      string           `json:"index"`
-		Last       okex.JSONFloat64 `json:"last"`
-		Components []*Component     `json:"components"`
-		TS         okex.JSONTime    `json:"ts"`
+		Index      string          `json:"index"`
+		Last       sdk.JSONDecimal `json:"last"`
+		Components []*Component    `json:"components"`
+		TS         sdk.JSONTime    `json:"ts"`
 	}
 	Component struct {
-		Exch   string           `json:"exch"`
-		Symbol string           `json:"symbol"`
-		SymPx  okex.JSONFloat64 `json:"symPx"`
-		Wgt    okex.JSONFloat64 `json:"wgt"`
-		CnvPx  okex.JSONFloat64 `json:"cnvPx"`
+		Exch   string          `json:"exch"`
+		Symbol string          `json:"symbol"`
+		SymPx  sdk.JSONDecimal `json:"symPx"`
+		Wgt    sdk.JSONDecimal `json:"wgt"`
+		CnvPx  sdk.JSONDecimal `json:"cnvPx"`
 	}
 )
 
@@ -112,6 +117,7 @@ func (o *OrderBookEntity) UnmarshalJSON(buf []byte) error {
 	if g, e := len(tmp), wantLen; g != e {
 		return fmt.Errorf("wrong number of fields in OrderBookEntity: %d != %d", g, e)
 	}
+	o.Px, o.Sz = dp, s
 	o.DepthPrice, err = strconv.ParseFloat(dp, 64)
 	if err != nil {
 		return err
@@ -1,139 +1,149 @@
 package publicdata
 
 import (
-	"github.com/amir-the-h/okex"
+	"AxonTrading/exchanges/okx/sdk"
 )
 
 type (
 	Instrument struct {
-		InstID    string               `json:"instId"`
-		Uly       string               `json:"uly,omitempty"`
-		BaseCcy   string               `json:"baseCcy,omitempty"`
-		QuoteCcy  string               `json:"quoteCcy,omitempty"`
-		SettleCcy string               `json:"settleCcy,omitempty"`
-		CtValCcy  string               `json:"ctValCcy,omitempty"`
-		CtVal     okex.JSONFloat64     `json:"ctVal,omitempty"`
-		CtMult    okex.JSONFloat64     `json:"ctMult,omitempty"`
-		Stk       okex.JSONFloat64     `json:"stk,omitempty"`
-		TickSz    okex.JSONFloat64     `json:"tickSz,omitempty"`
-		LotSz     okex.JSONFloat64     `json:"lotSz,omitempty"`
-		MinSz     okex.JSONFloat64     `json:"minSz,omitempty"`
-		Lever     okex.JSONFloat64     `json:"lever"`
-		InstType  okex.InstrumentType  `json:"instType"`
-		Category  okex.FeeCategory     `json:"category,string"`
-		OptType   okex.OptionType      `json:"optType,omitempty"`
-		ListTime  okex.JSONTime        `json:"listTime"`
-		ExpTime   okex.JSONTime        `json:"expTime,omitempty"`
-		CtType    okex.ContractType    `json:"ctType,omitempty"`
-		Alias     okex.AliasType       `json:"alias,omitempty"`
-		State     okex.InstrumentState `json:"state"`
+		InstID    string              `json:"instId"`
+		Uly       string              `json:"uly,omitempty"`
+		BaseCcy   string              `json:"baseCcy,omitempty"`
+		QuoteCcy  string              `json:"quoteCcy,omitempty"`
+		SettleCcy string              `json:"settleCcy,omitempty"`
+		CtValCcy  string              `json:"ctValCcy,omitempty"`
+		CtVal     sdk.JSONDecimal     `json:"ctVal,omitempty"`
+		CtMult    sdk.JSONDecimal     `json:"ctMult,omitempty"`
+		Stk       sdk.JSONDecimal     `json:"stk,omitempty"`
+		TickSz    sdk.JSONDecimal     `json:"tickSz,omitempty"`
+		LotSz     sdk.JSONDecimal     `json:"lotSz,omitempty"`
+		MinSz     sdk.JSONDecimal     `json:"minSz,omitempty"`
+		MaxLmtSz  sdk.JSONDecimal     `json:"maxLmtSz,omitempty"`
+		MaxMktSz  sdk.JSONDecimal     `json:"maxMktSz,omitempty"`
+		Lever     sdk.JSONDecimal     `json:"lever"`
+		InstType  sdk.InstrumentType  `json:"instType"`
+		Category  sdk.FeeCategory     `json:"category,string"`
+		OptType   sdk.OptionType      `json:"optType,omitempty"`
+		ListTime  sdk.JSONTime        `json:"listTime"`
+		ExpTime   sdk.JSONTime        `json:"expTime,omitempty"`
+		CtType    sdk.ContractType    `json:"ctType,omitempty"`
+		Alias     sdk.AliasType       `json:"alias,omitempty"`
+		State     sdk.InstrumentState `json:"state"`
 	}
 	DeliveryExerciseHistory struct {
 		Details []*DeliveryExerciseHistoryDetails `json:"details"`
-		TS      okex.JSONTime                     `json:"ts"`
+		TS      sdk.JSONTime                      `json:"ts"`
 	}
 	DeliveryExerciseHistoryDetails struct {
-		InstID string                    `json:"instId"`
-		Px     okex.JSONFloat64          `json:"px"`
-		Type   okex.DeliveryExerciseType `json:"type"`
+		InstID string                   `json:"instId"`
+		Px     sdk.JSONDecimal          `json:"px"`
+		Type   sdk.DeliveryExerciseType `json:"type"`
 	}
 	OpenInterest struct {
-		InstID   string              `json:"instId"`
-		Oi       okex.JSONFloat64    `json:"oi"`
-		OiCcy    okex.JSONFloat64    `json:"oiCcy"`
-		InstType okex.InstrumentType `json:"instType"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		Oi       sdk.JSONDecimal    `json:"oi"`
+		OiCcy    sdk.JSONDecimal    `json:"oiCcy"`
+		InstType sdk.InstrumentType `json:"instType"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	FundingRate struct {
-		InstID          string              `json:"instId"`
-		InstType        okex.InstrumentType `json:"instType"`
-		FundingRate     okex.JSONFloat64    `json:"fundingRate"`
-		NextFundingRate okex.JSONFloat64    `json:"NextFundingRate"`
-		FundingTime     okex.JSONTime       `json:"fundingTime"`
-		NextFundingTime okex.JSONTime       `json:"nextFundingTime"`
+		InstID          string             `json:"instId"`
+		InstType        sdk.InstrumentType `json:"instType"`
+		FundingRate     sdk.JSONDecimal    `json:"fundingRate"`
+		NextFundingRate sdk.JSONDecimal    `json:"nextFundingRate"`
+		FundingTime     sdk.JSONTime       `json:"fundingTime"`
+		NextFundingTime sdk.JSONTime       `json:"nextFundingTime"`
+	}
+	// ContractCoin keeps Sz and Px as strings, Sz is the converted amount used as an order size.
+	ContractCoin struct {
+		InstID string `json:"instId"`
+		Type   string `json:"type"`
+		Sz     string `json:"sz"`
+		Px     string `json:"px"`
+		Unit   string `json:"unit"`
 	}
 	LimitPrice struct {
-		InstID   string              `json:"instId"`
-		InstType okex.InstrumentType `json:"instType"`
-		BuyLmt   okex.JSONFloat64    `json:"buyLmt"`
-		SellLmt  okex.JSONFloat64    `json:"sellLmt"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		InstType sdk.InstrumentType `json:"instType"`
+		BuyLmt   sdk.JSONDecimal    `json:"buyLmt"`
+		SellLmt  sdk.JSONDecimal    `json:"sellLmt"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	EstimatedDeliveryExercisePrice struct {
-		InstID   string              `json:"instId"`
-		InstType okex.InstrumentType `json:"instType"`
-		SettlePx okex.JSONFloat64    `json:"settlePx"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		InstType sdk.InstrumentType `json:"instType"`
+		SettlePx sdk.JSONDecimal    `json:"settlePx"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	OptionMarketData struct {
-		InstID   string              `json:"instId"`
-		Uly      string              `json:"uly"`
-		InstType okex.InstrumentType `json:"instType"`
-		Delta    okex.JSONFloat64    `json:"delta"`
-		Gamma    okex.JSONFloat64    `json:"gamma"`
-		Vega     okex.JSONFloat64    `json:"vega"`
-		Theta    okex.JSONFloat64    `json:"theta"`
-		DeltaBS  okex.JSONFloat64    `json:"deltaBS"`
-		GammaBS  okex.JSONFloat64    `json:"gammaBS"`
-		VegaBS   okex.JSONFloat64    `json:"vegaBS"`
-		ThetaBS  okex.JSONFloat64    `json:"thetaBS"`
-		Lever    okex.JSONFloat64    `json:"lever"`
-		MarkVol  okex.JSONFloat64    `json:"markVol"`
-		BidVol   okex.JSONFloat64    `json:"bidVol"`
-		AskVol   okex.JSONFloat64    `json:"askVol"`
-		RealVol  okex.JSONFloat64    `json:"realVol"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		Uly      string             `json:"uly"`
+		InstType sdk.InstrumentType `json:"instType"`
+		Delta    sdk.JSONDecimal    `json:"delta"`
+		Gamma    sdk.JSONDecimal    `json:"gamma"`
+		Vega     sdk.JSONDecimal    `json:"vega"`
+		Theta    sdk.JSONDecimal    `json:"theta"`
+		DeltaBS  sdk.JSONDecimal    `json:"deltaBS"`
+		GammaBS  sdk.JSONDecimal    `json:"gammaBS"`
+		VegaBS   sdk.JSONDecimal    `json:"vegaBS"`
+		ThetaBS  sdk.JSONDecimal    `json:"thetaBS"`
+		Lever    sdk.JSONDecimal    `json:"lever"`
+		MarkVol  sdk.JSONDecimal    `json:"markVol"`
+		BidVol   sdk.JSONDecimal    `json:"bidVol"`
+		AskVol   sdk.JSONDecimal    `json:"askVol"`
+		RealVol  sdk.JSONDecimal    `json:"realVol"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	GetDiscountRateAndInterestFreeQuota struct {
-		Ccy          string           `json:"ccy"`
-		Amt          okex.JSONFloat64 `json:"amt"`
-		DiscountLv   okex.JSONInt64   `json:"discountLv"`
-		DiscountInfo []*DiscountInfo  `json:"discountInfo"`
+		Ccy          string          `json:"ccy"`
+		Amt          sdk.JSONDecimal `json:"amt"`
+		DiscountLv   sdk.JSONInt64   `json:"discountLv"`
+		DiscountInfo []*DiscountInfo `json:"discountInfo"`
 	}
 	DiscountInfo struct {
-		DiscountRate okex.JSONInt64 `json:"discountRate"`
-		MaxAmt       okex.JSONInt64 `json:"maxAmt"`
-		MinAmt       okex.JSONInt64 `json:"minAmt"`
+		DiscountRate sdk.JSONInt64 `json:"discountRate"`
+		MaxAmt       sdk.JSONInt64 `json:"maxAmt"`
+		MinAmt       sdk.JSONInt64 `json:"minAmt"`
 	}
 	SystemTime struct {
-		TS okex.JSONTime `json:"ts"`
+		TS sdk.JSONTime `json:"ts"`
 	}
 	LiquidationOrder struct {
 		InstID    string                    `json:"instId"`
 		Uly       string                    `json:"uly,omitempty"`
-		InstType  okex.InstrumentType       `json:"instType"`
-		TotalLoss okex.JSONFloat64          `json:"totalLoss"`
+		InstType  sdk.InstrumentType        `json:"instType"`
+		TotalLoss sdk.JSONDecimal           `json:"totalLoss"`
 		Details   []*LiquidationOrderDetail `json:"details"`
 	}
 	LiquidationOrderDetail struct {
-		Ccy     string            `json:"ccy,omitempty"`
-		Side    okex.OrderSide    `json:"side"`
-		OosSide okex.PositionSide `json:"posSide"`
-		BkPx    okex.JSONFloat64  `json:"bkPx"`
-		Sz      okex.JSONFloat64  `json:"sz"`
-		BkLoss  okex.JSONFloat64  `json:"bkLoss"`
-		TS      okex.JSONTime     `json:"ts"`
+		Ccy     string           `json:"ccy,omitempty"`
+		Side    sdk.OrderSide    `json:"side"`
+		OosSide sdk.PositionSide `json:"posSide"`
+		BkPx    sdk.JSONDecimal  `json:"bkPx"`
+		Sz      sdk.JSONDecimal  `json:"sz"`
+		BkLoss  sdk.JSONDecimal  `json:"bkLoss"`
+		TS      sdk.JSONTime     `json:"ts"`
 	}
 	MarkPrice struct {
-		InstID   string              `json:"instId"`
-		InstType okex.InstrumentType `json:"instType"`
-		MarkPx   okex.JSONFloat64    `json:"markPx"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		InstType sdk.InstrumentType `json:"instType"`
+		MarkPx   sdk.JSONDecimal    `json:"markPx"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	PositionTier struct {
-		InstID       string              `json:"instId"`
-		Uly          string              `json:"uly,omitempty"`
-		InstType     okex.InstrumentType `json:"instType"`
-		Tier         okex.JSONInt64      `json:"tier"`
-		MinSz        okex.JSONFloat64    `json:"minSz"`
-		MaxSz        okex.JSONFloat64    `json:"maxSz"`
-		Mmr          okex.JSONFloat64    `json:"mmr"`
-		Imr          okex.JSONFloat64    `json:"imr"`
-		OptMgnFactor okex.JSONFloat64    `json:"optMgnFactor,omitempty"`
-		QuoteMaxLoan okex.JSONFloat64    `json:"quoteMaxLoan,omitempty"`
-		BaseMaxLoan  okex.JSONFloat64    `json:"baseMaxLoan,omitempty"`
-		MaxLever     okex.JSONFloat64    `json:"maxLever"`
-		TS           okex.JSONTime       `json:"ts"`
+		InstID       string             `json:"instId"`
+		Uly          string             `json:"uly,omitempty"`
+		InstType     sdk.InstrumentType `json:"instType"`
+		Tier         sdk.JSONInt64      `json:"tier"`
+		MinSz        sdk.JSONDecimal    `json:"minSz"`
+		MaxSz        sdk.JSONDecimal    `json:"maxSz"`
+		Mmr          sdk.JSONDecimal    `json:"mmr"`
+		Imr          sdk.JSONDecimal    `json:"imr"`
+		OptMgnFactor sdk.JSONDecimal    `json:"optMgnFactor,omitempty"`
+		QuoteMaxLoan sdk.JSONDecimal    `json:"quoteMaxLoan,omitempty"`
+		BaseMaxLoan  sdk.JSONDecimal    `json:"baseMaxLoan,omitempty"`
+		MaxLever     sdk.JSONDecimal    `json:"maxLever"`
+		TS           sdk.JSONTime       `json:"ts"`
 	}
 	InterestRateAndLoanQuota struct {
 		Basic   []*InterestRateAndLoanBasic `json:"basic"`
@@ -141,23 +151,23 @@ type (
 		Regular []*InterestRateAndLoanUser  `json:"regular"`
 	}
 	InterestRateAndLoanBasic struct {
-		Ccy   string           `json:"ccy"`
-		Rate  okex.JSONFloat64 `json:"rate"`
-		Quota okex.JSONFloat64 `json:"quota"`
+		Ccy   string          `json:"ccy"`
+		Rate  sdk.JSONDecimal `json:"rate"`
+		Quota sdk.JSONDecimal `json:"quota"`
 	}
 	InterestRateAndLoanUser struct {
-		Level         string           `json:"level"`
-		IrDiscount    okex.JSONFloat64 `json:"irDiscount"`
-		LoanQuotaCoef int              `json:"loanQuotaCoef,string"`
+		Level         string          `json:"level"`
+		IrDiscount    sdk.JSONDecimal `json:"irDiscount"`
+		LoanQuotaCoef int             `json:"loanQuotaCoef,string"`
 	}
 	State struct {
-		Title       string        `json:"title"`
-		State       string        `json:"state"`
-		Href        string        `json:"href"`
-		ServiceType string        `json:"serviceType"`
-		System      string        `json:"system"`
-		ScheDesc    string        `json:"scheDesc"`
-		Begin       okex.JSONTime `json:"begin"`
-		End         okex.JSONTime `json:"end"`
+		Title       string       `json:"title"`
+		State       string       `json:"state"`
+		Href        string       `json:"href"`
+		ServiceType string       `json:"serviceType"`
+		System      string       `json:"system"`
+		ScheDesc    string       `json:"scheDesc"`
+		Begin       sdk.JSONTime `json:"begin"`
+		End         sdk.JSONTime `json:"end"`
 	}
 )
@@ -1,139 +1,146 @@
 package trade
 
 import (
-	"github.com/amir-the-h/okex"
+	"AxonTrading/exchanges/okx/sdk"
 )
 
 type (
 	PlaceOrder struct {
-		ClOrdID string           `json:"clOrdId"`
-		Tag     string           `json:"tag"`
-		SMsg    string           `json:"sMsg"`
-		SCode   okex.JSONInt64   `json:"sCode"`
-		OrdID   okex.JSONFloat64 `json:"ordId"`
+		ClOrdID string        `json:"clOrdId"`
+		Tag     string        `json:"tag"`
+		SMsg    string        `json:"sMsg"`
+		SCode   sdk.JSONInt64 `json:"sCode"`
+		OrdID   string        `json:"ordId"`
 	}
 	CancelOrder struct {
-		OrdID   string           `json:"ordId"`
-		ClOrdID string           `json:"clOrdId"`
-		SMsg    string           `json:"sMsg"`
-		SCode   okex.JSONFloat64 `json:"sCode"`
+		OrdID   string        `json:"ordId"`
+		ClOrdID string        `json:"clOrdId"`
+		SMsg    string        `json:"sMsg"`
+		SCode   sdk.JSONInt64 `json:"sCode"`
 	}
 	AmendOrder struct {
-		OrdID   string           `json:"ordId"`
-		ClOrdID string           `json:"clOrdId"`
-		ReqID   string           `json:"reqId"`
-		SMsg    string           `json:"sMsg"`
-		SCode   okex.JSONFloat64 `json:"sCode"`
+		OrdID   string        `json:"ordId"`
+		ClOrdID string        `json:"clOrdId"`
+		ReqID   string        `json:"reqId"`
+		SMsg    string        `json:"sMsg"`
+		SCode   sdk.JSONInt64 `json:"sCode"`
 	}
 	ClosePosition struct {
-		InstID  string            `json:"instId"`
-		PosSide okex.PositionSide `json:"posSide"`
+		InstID  string           `json:"instId"`
+		PosSide sdk.PositionSide `json:"posSide"`
 	}
 	Order struct {
-		InstID      string              `json:"instId"`
-		Ccy         string              `json:"ccy"`
-		OrdID       string              `json:"ordId"`
-		ClOrdID     string              `json:"clOrdId"`
-		TradeID     string              `json:"tradeId"`
-		Tag         string              `json:"tag"`
-		Category    string              `json:"category"`
-		FeeCcy      string              `json:"feeCcy"`
-		RebateCcy   string              `json:"rebateCcy"`
-		Px          okex.JSONFloat64    `json:"px"`
-		Sz          okex.JSONFloat64    `json:"sz"`
-		Pnl         okex.JSONFloat64    `json:"pnl"`
-		AccFillSz   okex.JSONFloat64    `json:"accFillSz"`
-		FillPx      okex.JSONFloat64    `json:"fillPx"`
-		FillSz      okex.JSONFloat64    `json:"fillSz"`
-		FillTime    okex.JSONFloat64    `json:"fillTime"`
-		AvgPx       okex.JSONFloat64    `json:"avgPx"`
-		Lever       okex.JSONFloat64    `json:"lever"`
-		TpTriggerPx okex.JSONFloat64    `json:"tpTriggerPx"`
-		TpOrdPx     okex.JSONFloat64    `json:"tpOrdPx"`
-		SlTriggerPx okex.JSONFloat64    `json:"slTriggerPx"`
-		SlOrdPx     okex.JSONFloat64    `json:"slOrdPx"`
-		Fee         okex.JSONFloat64    `json:"fee"`
-		Rebate      okex.JSONFloat64    `json:"rebate"`
-		State       okex.OrderState     `json:"state"`
-		TdMode      okex.TradeMode      `json:"tdMode"`
-		PosSide     okex.PositionSide   `json:"posSide"`
-		Side        okex.OrderSide      `json:"side"`
-		OrdType     okex.OrderType      `json:"ordType"`
-		InstType    okex.InstrumentType `json:"instType"`
-		TgtCcy      okex.QuantityType   `json:"tgtCcy"`
-		UTime       okex.JSONTime       `json:"uTime"`
-		CTime       okex.JSONTime       `json:"cTime"`
+		InstID      string             `json:"instId"`
+		Ccy         string             `json:"ccy"`
+		OrdID       string             `json:"ordId"`
+		ClOrdID     string             `json:"clOrdId"`
+		TradeID     string             `json:"tradeId"`
+		Tag         string             `json:"tag"`
+		Category    string             `json:"category"`
+		FeeCcy      string             `json:"feeCcy"`
+		RebateCcy   string             `json:"rebateCcy"`
+		Px          sdk.JSONDecimal    `json:"px"`
+		Sz          sdk.JSONDecimal    `json:"sz"`
+		Pnl         sdk.JSONDecimal    `json:"pnl"`
+		AccFillSz   sdk.JSONDecimal    `json:"accFillSz"`
+		FillPx      sdk.JSONDecimal    `json:"fillPx"`
+		FillSz      sdk.JSONDecimal    `json:"fillSz"`
+		FillTime    sdk.JSONInt64      `json:"fillTime"`
+		AvgPx       sdk.JSONDecimal    `json:"avgPx"`
+		Lever       sdk.JSONDecimal    `json:"lever"`
+		TpTriggerPx sdk.JSONDecimal    `json:"tpTriggerPx"`
+		TpOrdPx     sdk.JSONDecimal    `json:"tpOrdPx"`
+		SlTriggerPx sdk.JSONDecimal    `json:"slTriggerPx"`
+		SlOrdPx     sdk.JSONDecimal    `json:"slOrdPx"`
+		Fee         sdk.JSONDecimal    `json:"fee"`
+		FillFee     sdk.JSONDecimal    `json:"fillFee"`
+		FillFeeCcy  string             `json:"fillFeeCcy"`
+		ExecType    string             `json:"execType"`
+		ReduceOnly  string             `json:"reduceOnly"`
+		Rebate      sdk.JSONDecimal    `json:"rebate"`
+		State       sdk.OrderState     `json:"state"`
+		TdMode      sdk.TradeMode      `json:"tdMode"`
+		PosSide     sdk.PositionSide   `json:"posSide"`
+		Side        sdk.OrderSide      `json:"side"`
+		OrdType     sdk.OrderType      `json:"ordType"`
+		InstType    sdk.InstrumentType `json:"instType"`
+		TgtCcy      sdk.QuantityType   `json:"tgtCcy"`
+		UTime       sdk.JSONTime       `json:"uTime"`
+		CTime       sdk.JSONTime       `json:"cTime"`
 	}
 	TransactionDetail struct {
-		InstID   string              `json:"instId"`
-		OrdID    string              `json:"ordId"`
-		TradeID  string              `json:"tradeId"`
-		ClOrdID  string              `json:"clOrdId"`
-		BillID   string              `json:"billId"`
-		Tag      okex.JSONFloat64    `json:"tag"`
-		FillPx   okex.JSONFloat64    `json:"fillPx"`
-		FillSz   okex.JSONFloat64    `json:"fillSz"`
-		FeeCcy   okex.JSONFloat64    `json:"feeCcy"`
-		Fee      okex.JSONFloat64    `json:"fee"`
-		InstType okex.InstrumentType `json:"instType"`
-		Side     okex.OrderSide      `json:"side"`
-		PosSide  okex.PositionSide   `json:"posSide"`
-		ExecType okex.OrderFlowType  `json:"execType"`
-		TS       okex.JSONTime       `json:"ts"`
+		InstID   string             `json:"instId"`
+		OrdID    string             `json:"ordId"`
+		TradeID  string             `json:"tradeId"`
+		ClOrdID  string             `json:"clOrdId"`
+		BillID   string             `json:"billId"`
+		Tag      string             `json:"tag"`
+		FillPx   string             `json:"fillPx"`
+		FillSz   string             `json:"fillSz"`
+		FeeCcy   string             `json:"feeCcy"`
+		Fee      string             `json:"fee"`
+		InstType sdk.InstrumentType `json:"instType"`
+		Side     sdk.OrderSide      `json:"side"`
+		PosSide  sdk.PositionSide   `json:"posSide"`
+		ExecType sdk.OrderFlowType  `json:"execType"`
+		TS       sdk.JSONTime       `json:"ts"`
 	}
 	PlaceAlgoOrder struct {
-		AlgoID string         `json:"algoId"`
-		SMsg   string         `json:"sMsg"`
-		SCode  okex.JSONInt64 `json:"sCode"`
+		AlgoID string        `json:"algoId"`
+		SMsg   string        `json:"sMsg"`
+		SCode  sdk.JSONInt64 `json:"sCode"`
 	}
 	CancelAlgoOrder struct {
-		AlgoID string         `json:"algoId"`
-		SMsg   string         `json:"sMsg"`
-		SCode  okex.JSONInt64 `json:"sCode"`
+		AlgoID string        `json:"algoId"`
+		SMsg   string        `json:"sMsg"`
+		SCode  sdk.JSONInt64 `json:"sCode"`
 	}
 	AlgoOrder struct {
-		InstID       string              `json:"instId"`
-		Ccy          string              `json:"ccy"`
-		OrdID        string              `json:"ordId"`
-		AlgoID       string              `json:"algoId"`
-		ClOrdID      string              `json:"clOrdId"`
-		TradeID      string              `json:"tradeId"`
-		Tag          string              `json:"tag"`
-		Category     string              `json:"category"`
-		FeeCcy       string              `json:"feeCcy"`
-		RebateCcy    string              `json:"rebateCcy"`
-		TimeInterval string              `json:"timeInterval"`
-		Px           okex.JSONFloat64    `json:"px"`
-		PxVar        okex.JSONFloat64    `json:"pxVar"`
-		PxSpread     okex.JSONFloat64    `json:"pxSpread"`
-		PxLimit      okex.JSONFloat64    `json:"pxLimit"`
-		Sz           okex.JSONFloat64    `json:"sz"`
-		SzLimit      okex.JSONFloat64    `json:"szLimit"`
-		ActualSz     okex.JSONFloat64    `json:"actualSz"`
-		ActualPx     okex.JSONFloat64    `json:"actualPx"`
-		Pnl          okex.JSONFloat64    `json:"pnl"`
-		AccFillSz    okex.JSONFloat64    `json:"accFillSz"`
-		FillPx       okex.JSONFloat64    `json:"fillPx"`
-		FillSz       okex.JSONFloat64    `json:"fillSz"`
-		FillTime     okex.JSONFloat64    `json:"fillTime"`
-		AvgPx        okex.JSONFloat64    `json:"avgPx"`
-		Lever        okex.JSONFloat64    `json:"lever"`
-		TpTriggerPx  okex.JSONFloat64    `json:"tpTriggerPx"`
-		TpOrdPx      okex.JSONFloat64    `json:"tpOrdPx"`
-		SlTriggerPx  okex.JSONFloat64    `json:"slTriggerPx"`
-		SlOrdPx      okex.JSONFloat64    `json:"slOrdPx"`
-		OrdPx        okex.JSONFloat64    `json:"ordPx"`
-		Fee          okex.JSONFloat64    `json:"fee"`
-		Rebate       okex.JSONFloat64    `json:"rebate"`
-		State        okex.OrderState     `json:"state"`
-		TdMode       okex.TradeMode      `json:"tdMode"`
-		ActualSide   okex.PositionSide   `json:"actualSide"`
-		PosSide      okex.PositionSide   `json:"posSide"`
-		Side         okex.OrderSide      `json:"side"`
-		OrdType      okex.AlgoOrderType  `json:"ordType"`
-		InstType     okex.InstrumentType `json:"instType"`
-		TgtCcy       okex.QuantityType   `json:"tgtCcy"`
-		CTime        okex.JSONTime       `json:"cTime"`
-		TriggerTime  okex.JSONTime       `json:"triggerTime"`
+		InstID        string             `json:"instId"`
+		Ccy           string             `json:"ccy"`
+		OrdID         string             `json:"ordId"`
+		AlgoID        string             `json:"algoId"`
+		ClOrdID       string             `json:"clOrdId"`
+		TradeID       string             `json:"tradeId"`
+		Tag           string             `json:"tag"`
+		Category      string             `json:"category"`
+		FeeCcy        string             `json:"feeCcy"`
+		RebateCcy     string             `json:"rebateCcy"`
+		TimeInterval  string             `json:"timeInterval"`
+		Px            sdk.JSONDecimal    `json:"px"`
+		PxVar         sdk.JSONDecimal    `json:"pxVar"`
+		PxSpread      sdk.JSONDecimal    `json:"pxSpread"`
+		PxLimit       sdk.JSONDecimal    `json:"pxLimit"`
+		Sz            sdk.JSONDecimal    `json:"sz"`
+		SzLimit       sdk.JSONDecimal    `json:"szLimit"`
+		ActualSz      sdk.JSONDecimal    `json:"actualSz"`
+		ActualPx      sdk.JSONDecimal    `json:"actualPx"`
+		Pnl           sdk.JSONDecimal    `json:"pnl"`
+		AccFillSz     sdk.JSONDecimal    `json:"accFillSz"`
+		FillPx        sdk.JSONDecimal    `json:"fillPx"`
+		FillSz        sdk.JSONDecimal    `json:"fillSz"`
+		FillTime      sdk.JSONInt64      `json:"fillTime"`
+		AvgPx         sdk.JSONDecimal    `json:"avgPx"`
+		Lever         sdk.JSONDecimal    `json:"lever"`
+		TpTriggerPx   sdk.JSONDecimal    `json:"tpTriggerPx"`
+		TpOrdPx       sdk.JSONDecimal    `json:"tpOrdPx"`
+		SlTriggerPx   sdk.JSONDecimal    `json:"slTriggerPx"`
+		SlOrdPx       sdk.JSONDecimal    `json:"slOrdPx"`
+		TriggerPx     sdk.JSONDecimal    `json:"triggerPx"`
+		OrdPx         sdk.JSONDecimal    `json:"ordPx"`
+		CallbackRatio sdk.JSONDecimal    `json:"callbackRatio"`
+		ActivePx      sdk.JSONDecimal    `json:"activePx"`
+		Fee           sdk.JSONDecimal    `json:"fee"`
+		Rebate        sdk.JSONDecimal    `json:"rebate"`
+		State         sdk.OrderState     `json:"state"`
+		TdMode        sdk.TradeMode      `json:"tdMode"`
+		ActualSide    sdk.PositionSide   `json:"actualSide"`
+		PosSide       sdk.PositionSide   `json:"posSide"`
+		Side          sdk.OrderSide      `json:"side"`
+		OrdType       sdk.AlgoOrderType  `json:"ordType"`
+		InstType      sdk.InstrumentType `json:"instType"`
+		TgtCcy        sdk.QuantityType   `json:"tgtCcy"`
+		CTime         sdk.JSONTime       `json:"cTime"`
+		TriggerTime   sdk.JSONTime       `json:"triggerTime"`
 	}
 )
@@ -1,9 +1,9 @@
 package tradedata
 
 import (
+	"AxonTrading/exchanges/okx/sdk"
 	"encoding/json"
 	"fmt"
-	"github.com/amir-the-h/okex"
 	"strconv"
 	"time"
 )
@@ -17,29 +17,29 @@ type (
 	TakerVolume struct {
 		SellVol float64
 		BuyVol  float64
-		TS      okex.JSONTime
+		TS      sdk.JSONTime
 	}
 	Ratio struct {
 		Ratio float64
-		TS    okex.JSONTime
+		TS    sdk.JSONTime
 	}
 	InterestAndVolumeRatio struct {
 		Oi  float64
 		Vol float64
-		TS  okex.JSONTime
+		TS  sdk.JSONTime
 	}
 	PutCallRatio struct {
 		OiRatio  float64
 		VolRatio float64
-		TS       okex.JSONTime
+		TS       sdk.JSONTime
 	}
 	InterestAndVolumeExpiry struct {
 		CallOI  float64
 		PutOI   float64
 		CallVol float64
 		PutVol  float64
-		ExpTime okex.JSONTime
-		TS      okex.JSONTime
+		ExpTime sdk.JSONTime
+		TS      sdk.JSONTime
 	}
 	InterestAndVolumeStrike struct {
 		Strike  float64
@@ -47,7 +47,7 @@ type (
 		PutOI   float64
 		CallVol float64
 		PutVol  float64
-		TS      okex.JSONTime
+		TS      sdk.JSONTime
 	}
 	TakerFlow struct {
 		CallBuyVol   float64
@@ -56,7 +56,7 @@ type (
 		PutSellVol   float64
 		CallBlockVol float64
 		PutBlockVol  float64
-		TS           okex.JSONTime
+		TS           sdk.JSONTime
 	}
 )
 
@@ -1,62 +1,70 @@
 package funding
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
+	GetCurrencies struct {
+		Ccy string `json:"ccy,omitempty"`
+	}
 	GetBalance struct {
 		Ccy []string `json:"ccy,omitempty"`
 	}
+	// FundsTransfer keeps Amt as a string so amounts are sent without float rounding.
 	FundsTransfer struct {
-		Ccy      string            `json:"ccy"`
-		Amt      float64           `json:"amt,string"`
-		SubAcct  string            `json:"subAcct,omitempty"`
-		InstID   string            `json:"instID,omitempty"`
-		ToInstID string            `json:"instId,omitempty"`
-		Type     okex.TransferType `json:"type,omitempty,string"`
-		From     okex.AccountType  `json:"from,string"`
-		To       okex.AccountType  `json:"to,string"`
+		Ccy      string           `json:"ccy"`
+		Amt      string           `json:"amt"`
+		ClientID string           `json:"clientId,omitempty"`
+		SubAcct  string           `json:"subAcct,omitempty"`
+		InstID   string           `json:"instID,omitempty"`
+		ToInstID string           `json:"instId,omitempty"`
+		Type     sdk.TransferType `json:"type,omitempty,string"`
+		From     sdk.AccountType  `json:"from,string"`
+		To       sdk.AccountType  `json:"to,string"`
 	}
 	AssetBillsDetails struct {
-		Type   okex.BillType `json:"type,string,omitempty"`
-		After  int64         `json:"after,string,omitempty"`
-		Before int64         `json:"before,string,omitempty"`
-		Limit  int64         `json:"limit,string,omitempty"`
+		Type   sdk.BillType `json:"type,string,omitempty"`
+		After  int64        `json:"after,string,omitempty"`
+		Before int64        `json:"before,string,omitempty"`
+		Limit  int64        `json:"limit,string,omitempty"`
 	}
 	GetDepositAddress struct {
 		Ccy string `json:"ccy"`
 	}
 	GetDepositHistory struct {
-		Ccy    string            `json:"ccy,omitempty"`
-		TxID   string            `json:"txId,omitempty"`
-		After  int64             `json:"after,omitempty,string"`
-		Before int64             `json:"before,omitempty,string"`
-		Limit  int64             `json:"limit,omitempty,string"`
-		State  okex.DepositState `json:"state,omitempty,string"`
+		Ccy    string           `json:"ccy,omitempty"`
+		DepID  string           `json:"depId,omitempty"`
+		TxID   string           `json:"txId,omitempty"`
+		After  int64            `json:"after,omitempty,string"`
+		Before int64            `json:"before,omitempty,string"`
+		Limit  int64            `json:"limit,omitempty,string"`
+		State  sdk.DepositState `json:"state,omitempty,string"`
 	}
+	// Withdrawal keeps Amt and Fee as strings so amounts are sent without float rounding.
 	Withdrawal struct {
-		Ccy    string                     `json:"ccy"`
-		Chain  string                     `json:"chain,omitempty"`
-		ToAddr string                     `json:"toAddr"`
-		Pwd    string                     `json:"pwd"`
-		Amt    float64                    `json:"amt,string"`
-		Fee    float64                    `json:"fee,string"`
-		Dest   okex.WithdrawalDestination `json:"dest,string"`
+		Ccy      string                    `json:"ccy"`
+		Chain    string                    `json:"chain,omitempty"`
+		ToAddr   string                    `json:"toAddr"`
+		Amt      string                    `json:"amt"`
+		Fee      string                    `json:"fee,omitempty"`
+		Dest     sdk.WithdrawalDestination `json:"dest,string"`
+		ClientID string                    `json:"clientId,omitempty"`
 	}
 	GetWithdrawalHistory struct {
-		Ccy    string               `json:"ccy,omitempty"`
-		TxID   string               `json:"txId,omitempty"`
-		After  int64                `json:"after,omitempty,string"`
-		Before int64                `json:"before,omitempty,string"`
-		Limit  int64                `json:"limit,omitempty,string"`
-		State  okex.WithdrawalState `json:"state,omitempty,string"`
+		Ccy    string              `json:"ccy,omitempty"`
+		WdID   string              `json:"wdId,omitempty"`
+		TxID   string              `json:"txId,omitempty"`
+		After  int64               `json:"after,omitempty,string"`
+		Before int64               `json:"before,omitempty,string"`
+		Limit  int64               `json:"limit,omitempty,string"`
+		State  sdk.WithdrawalState `json:"state,omitempty,string"`
 	}
 	PiggyBankPurchaseRedemption struct {
-		Ccy    string               `json:"ccy,omitempty"`
-		TxID   string               `json:"txId,omitempty"`
-		After  int64                `json:"after,omitempty,string"`
-		Before int64                `json:"before,omitempty,string"`
-		Limit  int64                `json:"limit,omitempty,string"`
-		State  okex.WithdrawalState `json:"state,omitempty,string"`
+		Ccy    string              `json:"ccy,omitempty"`
+		TxID   string              `json:"txId,omitempty"`
+		After  int64               `json:"after,omitempty,string"`
+		Before int64               `json:"before,omitempty,string"`
+		Limit  int64               `json:"limit,omitempty,string"`
+		State  sdk.WithdrawalState `json:"state,omitempty,string"`
 	}
 	GetPiggyBankBalance struct {
 		Ccy string `json:"ccy,omitempty"`
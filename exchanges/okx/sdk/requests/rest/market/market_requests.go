@@ -1,11 +1,14 @@
 package market
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
 	GetTickers struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
+	}
+	GetTicker struct {
+		InstID string `json:"instId"`
 	}
 	GetIndexTickers struct {
 		InstID   string `json:"instId,omitempty"`
@@ -16,11 +19,11 @@ type (
 		Sz     int    `json:"sz,omitempty,string"`
 	}
 	GetCandlesticks struct {
-		InstID string       `json:"instId"`
-		After  int64        `json:"after,omitempty,string"`
-		Before int64        `json:"before,omitempty,string"`
-		Limit  int64        `json:"limit,omitempty,string"`
-		Bar    okex.BarSize `json:"bar,omitempty"`
+		InstID string      `json:"instId"`
+		After  int64       `json:"after,omitempty,string"`
+		Before int64       `json:"before,omitempty,string"`
+		Limit  int64       `json:"limit,omitempty,string"`
+		Bar    sdk.BarSize `json:"bar,omitempty"`
 	}
 	GetTrades struct {
 		InstID string `json:"instId"`
@@ -1,24 +1,25 @@
 package public
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
 	GetInstruments struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly        string             `json:"uly,omitempty"`
+		InstFamily string             `json:"instFamily,omitempty"`
+		InstID     string             `json:"instId,omitempty"`
+		InstType   sdk.InstrumentType `json:"instType"`
 	}
 	GetDeliveryExerciseHistory struct {
-		Uly      string              `json:"uly"`
-		After    int64               `json:"after,omitempty,string"`
-		Before   int64               `json:"before,omitempty,string"`
-		Limit    int64               `json:"limit,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly"`
+		After    int64              `json:"after,omitempty,string"`
+		Before   int64              `json:"before,omitempty,string"`
+		Limit    int64              `json:"limit,omitempty,string"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 	GetOpenInterest struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 	GetFundingRate struct {
 		InstID string `json:"instId"`
@@ -40,31 +41,39 @@ type (
 		DiscountLv float64 `json:"discountLv,string"`
 	}
 	GetLiquidationOrders struct {
-		InstID   string              `json:"instId,omitempty"`
-		Ccy      string              `json:"ccy,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		After    int64               `json:"after,omitempty,string"`
-		Before   int64               `json:"before,omitempty,string"`
-		Limit    int64               `json:"limit,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType"`
-		MgnMode  okex.MarginMode     `json:"mgnMode,omitempty"`
-		Alias    okex.AliasType      `json:"alias,omitempty"`
-		State    okex.OrderState     `json:"state,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		Ccy      string             `json:"ccy,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		After    int64              `json:"after,omitempty,string"`
+		Before   int64              `json:"before,omitempty,string"`
+		Limit    int64              `json:"limit,omitempty,string"`
+		InstType sdk.InstrumentType `json:"instType"`
+		MgnMode  sdk.MarginMode     `json:"mgnMode,omitempty"`
+		Alias    sdk.AliasType      `json:"alias,omitempty"`
+		State    sdk.OrderState     `json:"state,omitempty"`
 	}
 	GetMarkPrice struct {
-		InstID   string              `json:"instId,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		InstID   string             `json:"instId,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 	GetPositionTiers struct {
-		InstID   string              `json:"instId,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
-		TdMode   okex.TradeMode      `json:"tdMode"`
-		Tier     okex.JSONInt64      `json:"tier,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
+		TdMode   sdk.TradeMode      `json:"tdMode"`
+		Tier     sdk.JSONInt64      `json:"tier,omitempty"`
 	}
 	GetUnderlying struct {
-		InstType okex.InstrumentType `json:"instType"`
+		InstType sdk.InstrumentType `json:"instType"`
+	}
+	// ConvertContractCoin Type is 1 (currency to contract) or 2 (contract to currency), Sz and Px are decimal strings.
+	ConvertContractCoin struct {
+		Type   string `json:"type,omitempty"`
+		InstID string `json:"instId"`
+		Sz     string `json:"sz"`
+		Px     string `json:"px,omitempty"`
+		Unit   string `json:"unit,omitempty"`
 	}
 	Status struct {
 		State string `json:"state,omitempty"`
@@ -1,6 +1,6 @@
 package subaccount
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
 	ViewList struct {
@@ -11,19 +11,26 @@ type (
 		Limit   int64  `json:"limit,omitempty,string"`
 	}
 	CreateAPIKey struct {
-		Pwd        string            `json:"pwd"`
-		SubAcct    string            `json:"subAcct"`
-		Label      string            `json:"label"`
-		Passphrase string            `json:"Passphrase"`
-		IP         []string          `json:"ip,omitempty"`
-		Perm       okex.APIKeyAccess `json:"perm,omitempty"`
+		Pwd        string           `json:"pwd,omitempty"`
+		SubAcct    string           `json:"subAcct"`
+		Label      string           `json:"label"`
+		Passphrase string           `json:"passphrase"`
+		IP         []string         `json:"ip,omitempty"`
+		Perm       sdk.APIKeyAccess `json:"perm,omitempty"`
+	}
+	ModifyAPIKey struct {
+		SubAcct string           `json:"subAcct"`
+		APIKey  string           `json:"apiKey"`
+		Label   string           `json:"label,omitempty"`
+		IP      []string         `json:"ip,omitempty"`
+		Perm    sdk.APIKeyAccess `json:"perm,omitempty"`
 	}
 	QueryAPIKey struct {
 		APIKey  string `json:"apiKey"`
 		SubAcct string `json:"subAcct"`
 	}
 	DeleteAPIKey struct {
-		Pwd     string `json:"pwd"`
+		Pwd     string `json:"pwd,omitempty"`
 		APIKey  string `json:"apiKey"`
 		SubAcct string `json:"subAcct"`
 	}
@@ -31,19 +38,19 @@ type (
 		SubAcct string `json:"subAcct"`
 	}
 	HistoryTransfer struct {
-		Ccy     string            `json:"ccy,omitempty"`
-		SubAcct string            `json:"subAcct,omitempty"`
-		After   int64             `json:"after,omitempty,string"`
-		Before  int64             `json:"before,omitempty,string"`
-		Limit   int64             `json:"limit,omitempty,string"`
-		Type    okex.TransferType `json:"type,omitempty,string"`
+		Ccy     string           `json:"ccy,omitempty"`
+		SubAcct string           `json:"subAcct,omitempty"`
+		After   int64            `json:"after,omitempty,string"`
+		Before  int64            `json:"before,omitempty,string"`
+		Limit   int64            `json:"limit,omitempty,string"`
+		Type    sdk.TransferType `json:"type,omitempty,string"`
 	}
 	ManageTransfers struct {
-		Ccy            string           `json:"ccy"`
-		FromSubAccount string           `json:"fromSubAccount"`
-		ToSubAccount   string           `json:"tiSubAccount"`
-		Amt            float64          `json:"amt,string"`
-		From           okex.AccountType `json:"from,string"`
-		To             okex.AccountType `json:"to,string"`
+		Ccy            string          `json:"ccy"`
+		FromSubAccount string          `json:"fromSubAccount"`
+		ToSubAccount   string          `json:"toSubAccount"`
+		Amt            string          `json:"amt"`
+		From           sdk.AccountType `json:"from,string"`
+		To             sdk.AccountType `json:"to,string"`
 	}
 )
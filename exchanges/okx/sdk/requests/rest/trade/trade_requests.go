@@ -1,24 +1,25 @@
 package trade
 
 import (
-	"github.com/amir-the-h/okex"
+	"AxonTrading/exchanges/okx/sdk"
 )
 
 type (
+	// PlaceOrder keeps Sz and Px as strings so sizes and prices are sent without float rounding.
 	PlaceOrder struct {
-		ID         string            `json:"-"`
-		InstID     string            `json:"instId"`
-		Ccy        string            `json:"ccy,omitempty"`
-		ClOrdID    string            `json:"clOrdId,omitempty"`
-		Tag        string            `json:"tag,omitempty"`
-		ReduceOnly bool              `json:"reduceOnly,omitempty"`
-		Sz         float64           `json:"sz,string"`
-		Px         float64           `json:"px,omitempty,string"`
-		TdMode     okex.TradeMode    `json:"tdMode"`
-		Side       okex.OrderSide    `json:"side"`
-		PosSide    okex.PositionSide `json:"posSide,omitempty"`
-		OrdType    okex.OrderType    `json:"ordType"`
-		TgtCcy     okex.QuantityType `json:"tgtCcy,omitempty"`
+		ID         string           `json:"-"`
+		InstID     string           `json:"instId"`
+		Ccy        string           `json:"ccy,omitempty"`
+		ClOrdID    string           `json:"clOrdId,omitempty"`
+		Tag        string           `json:"tag,omitempty"`
+		ReduceOnly bool             `json:"reduceOnly,omitempty"`
+		Sz         string           `json:"sz"`
+		Px         string           `json:"px,omitempty"`
+		TdMode     sdk.TradeMode    `json:"tdMode"`
+		Side       sdk.OrderSide    `json:"side"`
+		PosSide    sdk.PositionSide `json:"posSide,omitempty"`
+		OrdType    sdk.OrderType    `json:"ordType"`
+		TgtCcy     sdk.QuantityType `json:"tgtCcy,omitempty"`
 	}
 	CancelOrder struct {
 		ID      string `json:"-"`
@@ -26,21 +27,22 @@ type (
 		OrdID   string `json:"ordId,omitempty"`
 		ClOrdID string `json:"clOrdId,omitempty"`
 	}
+	// AmendOrder keeps NewSz and NewPx as strings, empty means unchanged.
 	AmendOrder struct {
-		ID        string  `json:"-"`
-		InstID    string  `json:"instId"`
-		OrdID     string  `json:"ordId,omitempty"`
-		ClOrdID   string  `json:"clOrdId,omitempty"`
-		ReqID     string  `json:"reqId,omitempty"`
-		NewSz     int64   `json:"newSz,omitempty,string"`
-		NewPx     float64 `json:"newPx,omitempty,string"`
-		CxlOnFail bool    `json:"cxlOnFail,omitempty"`
+		ID        string `json:"-"`
+		InstID    string `json:"instId"`
+		OrdID     string `json:"ordId,omitempty"`
+		ClOrdID   string `json:"clOrdId,omitempty"`
+		ReqID     string `json:"reqId,omitempty"`
+		NewSz     string `json:"newSz,omitempty"`
+		NewPx     string `json:"newPx,omitempty"`
+		CxlOnFail bool   `json:"cxlOnFail,omitempty"`
 	}
 	ClosePosition struct {
-		InstID  string            `json:"instId"`
-		Ccy     string            `json:"ccy,omitempty"`
-		PosSide okex.PositionSide `json:"posSide,omitempty"`
-		MgnMode okex.MarginMode   `json:"mgnMode"`
+		InstID  string           `json:"instId"`
+		Ccy     string           `json:"ccy,omitempty"`
+		PosSide sdk.PositionSide `json:"posSide,omitempty"`
+		MgnMode sdk.MarginMode   `json:"mgnMode"`
 	}
 	OrderDetails struct {
 		InstID  string `json:"instId"`
@@ -48,71 +50,82 @@ type (
 		ClOrdID string `json:"clOrdId,omitempty"`
 	}
 	OrderList struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		After    float64             `json:"after,omitempty,string"`
-		Before   float64             `json:"before,omitempty,string"`
-		Limit    float64             `json:"limit,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType,omitempty"`
-		OrdType  okex.OrderType      `json:"ordType,omitempty"`
-		State    okex.OrderState     `json:"state,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		After    string             `json:"after,omitempty"`
+		Before   string             `json:"before,omitempty"`
+		Limit    float64            `json:"limit,omitempty,string"`
+		InstType sdk.InstrumentType `json:"instType,omitempty"`
+		OrdType  sdk.OrderType      `json:"ordType,omitempty"`
+		State    sdk.OrderState     `json:"state,omitempty"`
 	}
+	// TransactionDetails pages by billId (After/Before); bill ids do not fit in a float64.
+	// Begin/End filter by fill time in Unix milliseconds.
 	TransactionDetails struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		OrdID    string              `json:"ordId,omitempty"`
-		After    float64             `json:"after,omitempty,string"`
-		Before   float64             `json:"before,omitempty,string"`
-		Limit    float64             `json:"limit,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		OrdID    string             `json:"ordId,omitempty"`
+		After    string             `json:"after,omitempty"`
+		Before   string             `json:"before,omitempty"`
+		Begin    string             `json:"begin,omitempty"`
+		End      string             `json:"end,omitempty"`
+		Limit    float64            `json:"limit,omitempty,string"`
+		InstType sdk.InstrumentType `json:"instType,omitempty"`
 	}
+	// PlaceAlgoOrder is sent as a flat string map, sizes and prices are decimal strings
+	// and ReduceOnly is sent as "true".
 	PlaceAlgoOrder struct {
-		InstID     string             `json:"instId"`
-		TdMode     okex.TradeMode     `json:"tdMode"`
-		Ccy        string             `json:"ccy,omitempty"`
-		Side       okex.OrderSide     `json:"side"`
-		PosSide    okex.PositionSide  `json:"posSide,omitempty"`
-		OrdType    okex.AlgoOrderType `json:"ordType"`
-		Sz         int64              `json:"sz,string"`
-		ReduceOnly bool               `json:"reduceOnly,omitempty"`
-		TgtCcy     okex.QuantityType  `json:"tgtCcy,omitempty"`
+		InstID     string            `json:"instId"`
+		TdMode     sdk.TradeMode     `json:"tdMode"`
+		Ccy        string            `json:"ccy,omitempty"`
+		Side       sdk.OrderSide     `json:"side"`
+		PosSide    sdk.PositionSide  `json:"posSide,omitempty"`
+		OrdType    sdk.AlgoOrderType `json:"ordType"`
+		Sz         string            `json:"sz"`
+		ReduceOnly bool              `json:"reduceOnly,omitempty,string"`
+		TgtCcy     sdk.QuantityType  `json:"tgtCcy,omitempty"`
 		StopOrder
 		TriggerOrder
 		IcebergOrder
 		TWAPOrder
+		MoveOrderStop
 	}
 	StopOrder struct {
-		TpTriggerPx float64 `json:"tpTriggerPx,string,omitempty"`
-		TpOrdPx     float64 `json:"tpOrdPx,string,omitempty"`
-		SlTriggerPx float64 `json:"slTriggerPx,string,omitempty"`
-		SlOrdPx     float64 `json:"slOrdPx,string,omitempty"`
+		TpTriggerPx string `json:"tpTriggerPx,omitempty"`
+		TpOrdPx     string `json:"tpOrdPx,omitempty"`
+		SlTriggerPx string `json:"slTriggerPx,omitempty"`
+		SlOrdPx     string `json:"slOrdPx,omitempty"`
 	}
 	TriggerOrder struct {
-		TriggerPx float64 `json:"triggerPx,string,omitempty"`
-		OrdPx     float64 `json:"ordPx,string,omitempty"`
+		TriggerPx string `json:"triggerPx,omitempty"`
+		OrderPx   string `json:"orderPx,omitempty"`
 	}
 	IcebergOrder struct {
-		PxVar    float64 `json:"pxVar,string,omitempty"`
-		PxSpread float64 `json:"pxSpread,string,omitempty"`
-		SzLimit  int64   `json:"szLimit,string"`
-		PxLimit  float64 `json:"pxLimit,string"`
+		PxVar    string `json:"pxVar,omitempty"`
+		PxSpread string `json:"pxSpread,omitempty"`
+		SzLimit  string `json:"szLimit,omitempty"`
+		PxLimit  string `json:"pxLimit,omitempty"`
 	}
 	TWAPOrder struct {
 		IcebergOrder
-		TimeInterval string `json:"timeInterval"`
+		TimeInterval string `json:"timeInterval,omitempty"`
+	}
+	MoveOrderStop struct {
+		CallbackRatio string `json:"callbackRatio,omitempty"`
+		ActivePx      string `json:"activePx,omitempty"`
 	}
 	CancelAlgoOrder struct {
 		InstID string `json:"instId"`
-		AlgoID string `json:"AlgoId"`
+		AlgoID string `json:"algoId"`
 	}
 	AlgoOrderList struct {
-		InstType okex.InstrumentType `json:"instType,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		After    float64             `json:"after,omitempty,string"`
-		Before   float64             `json:"before,omitempty,string"`
-		Limit    float64             `json:"limit,omitempty,string"`
-		OrdType  okex.AlgoOrderType  `json:"ordType,omitempty"`
-		State    okex.OrderState     `json:"state,omitempty"`
+		InstType sdk.InstrumentType `json:"instType,omitempty"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		After    string             `json:"after,omitempty"`
+		Before   string             `json:"before,omitempty"`
+		Limit    float64            `json:"limit,omitempty,string"`
+		OrdType  sdk.AlgoOrderType  `json:"ordType,omitempty"`
+		State    sdk.OrderState     `json:"state,omitempty"`
 	}
 )
@@ -1,24 +1,24 @@
 package tradedata
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
 	GetTakerVolume struct {
-		Ccy      string              `json:"ccy"`
-		Begin    int64               `json:"before,omitempty,string"`
-		End      int64               `json:"limit,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType"`
-		Period   okex.BarSize        `json:"period,string,omitempty"`
+		Ccy      string             `json:"ccy"`
+		Begin    int64              `json:"before,omitempty,string"`
+		End      int64              `json:"limit,omitempty,string"`
+		InstType sdk.InstrumentType `json:"instType"`
+		Period   sdk.BarSize        `json:"period,string,omitempty"`
 	}
 	GetRatio struct {
-		Ccy    string       `json:"ccy"`
-		Begin  int64        `json:"before,omitempty,string"`
-		End    int64        `json:"limit,omitempty,string"`
-		Period okex.BarSize `json:"period,string,omitempty"`
+		Ccy    string      `json:"ccy"`
+		Begin  int64       `json:"before,omitempty,string"`
+		End    int64       `json:"limit,omitempty,string"`
+		Period sdk.BarSize `json:"period,string,omitempty"`
 	}
 	GetOpenInterestAndVolumeStrike struct {
-		Ccy     string       `json:"ccy"`
-		ExpTime string       `json:"expTime"`
-		Period  okex.BarSize `json:"period,string,omitempty"`
+		Ccy     string      `json:"ccy"`
+		ExpTime string      `json:"expTime"`
+		Period  sdk.BarSize `json:"period,string,omitempty"`
 	}
 )
@@ -1,24 +1,24 @@
 package private
 
-import "github.com/amir-the-h/okex"
+import "AxonTrading/exchanges/okx/sdk"
 
 type (
 	Account struct {
 		Ccy string `json:"ccy,omitempty"`
 	}
 	Position struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 	Order struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 	AlgoOrder struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly      string             `json:"uly,omitempty"`
+		InstID   string             `json:"instId,omitempty"`
+		InstType sdk.InstrumentType `json:"instType"`
 	}
 )
@@ -1,8 +1,8 @@
 package account
 
 import (
-	models "github.com/amir-the-h/okex/models/account"
-	"github.com/amir-the-h/okex/responses"
+	models "AxonTrading/exchanges/okx/sdk/models/account"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
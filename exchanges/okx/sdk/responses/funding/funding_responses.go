@@ -1,8 +1,8 @@
 package funding
 
 import (
-	models "github.com/amir-the-h/okex/models/funding"
-	"github.com/amir-the-h/okex/responses"
+	models "AxonTrading/exchanges/okx/sdk/models/funding"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
@@ -1,8 +1,8 @@
 package market
 
 import (
-	"github.com/amir-the-h/okex/models/market"
-	"github.com/amir-the-h/okex/responses"
+	"AxonTrading/exchanges/okx/sdk/models/market"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
@@ -1,8 +1,8 @@
 package public_data
 
 import (
-	"github.com/amir-the-h/okex/models/publicdata"
-	"github.com/amir-the-h/okex/responses"
+	"AxonTrading/exchanges/okx/sdk/models/publicdata"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
@@ -22,6 +22,10 @@ type (
 		responses.Basic
 		FundingRates []*publicdata.FundingRate `json:"data,omitempty"`
 	}
+	ConvertContractCoin struct {
+		responses.Basic
+		ContractCoins []*publicdata.ContractCoin `json:"data,omitempty"`
+	}
 	GetLimitPrice struct {
 		responses.Basic
 		LimitPrices []*publicdata.LimitPrice `json:"data,omitempty"`
@@ -1,9 +1,9 @@
 package sub_account
 
 import (
-	"github.com/amir-the-h/okex/models/account"
-	models "github.com/amir-the-h/okex/models/subaccount"
-	"github.com/amir-the-h/okex/responses"
+	"AxonTrading/exchanges/okx/sdk/models/account"
+	models "AxonTrading/exchanges/okx/sdk/models/subaccount"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
@@ -1,8 +1,8 @@
 package trade
 
 import (
-	"github.com/amir-the-h/okex/models/trade"
-	"github.com/amir-the-h/okex/responses"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
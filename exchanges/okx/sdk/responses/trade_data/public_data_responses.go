@@ -1,8 +1,8 @@
 package trade_data
 
 import (
-	"github.com/amir-the-h/okex/models/tradedata"
-	"github.com/amir-the-h/okex/responses"
+	"AxonTrading/exchanges/okx/sdk/models/tradedata"
+	"AxonTrading/exchanges/okx/sdk/responses"
 )
 
 type (
@@ -0,0 +1,204 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	submodels "AxonTrading/exchanges/okx/sdk/models/subaccount"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/funding"
+	subrequests "AxonTrading/exchanges/okx/sdk/requests/rest/subaccount"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 子账户管理（store/exchange.SubAccountManager）的实现，使用 rest.SubAccount，只能用母账户的 API key 调用。
+// 母子账户之间的划转走 /api/v5/asset/transfer，子账户之间走 /api/v5/account/subaccount/transfer
+
+// subAccountPage 子账户列表每页的最大条数
+const subAccountPage = 100
+
+// apiKeyPerms 统一权限对应的 OKX 权限，OKX 子账户 API key 不能提币
+var apiKeyPerms = map[string]sdk.APIKeyAccess{
+	base.PermRead:  sdk.APIKeyReadOnly,
+	base.PermTrade: sdk.APIKeyTrade,
+}
+
+// GetSubAccounts 全部子账户，按创建时间从新到旧翻页
+func (c *Client) GetSubAccounts(ctx context.Context) ([]models.SubAccount, error) {
+	var (
+		subs  []models.SubAccount
+		after int64
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := c.rest(ctx).SubAccount.ViewList(subrequests.ViewList{After: after, Limit: subAccountPage})
+		if err != nil {
+			return nil, restError(err)
+		}
+		if resp.Code != 0 {
+			return nil, sdkError(resp.Basic)
+		}
+		last := after
+		for _, s := range resp.SubAccounts {
+			ts := time.Time(s.TS).UnixMilli()
+			subs = append(subs, models.SubAccount{Name: s.SubAcct, Label: s.Label, Enabled: s.Enable, Time: ts})
+			if last == 0 || ts < last {
+				last = ts
+			}
+		}
+		// 同一毫秒创建的子账户超过一页时 after 不再前进，避免死循环
+		if len(resp.SubAccounts) < subAccountPage || last == after {
+			return subs, nil
+		}
+		after = last
+	}
+}
+
+// GetSubAccountBalances 子账户交易账户的余额，Free 为可用余额，Locked 为冻结余额
+func (c *Client) GetSubAccountBalances(ctx context.Context, sub string) ([]models.Balance, error) {
+	if sub == "" {
+		return nil, fmt.Errorf("%w: empty sub account", models.ErrInvalidRequest)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp, err := c.rest(ctx).SubAccount.GetBalance(subrequests.GetBalance{SubAcct: sub})
+	if err != nil {
+		return nil, restError(err)
+	}
+	if resp.Code != 0 {
+		return nil, sdkError(resp.Basic)
+	}
+	var balances []models.Balance
+	for _, b := range resp.Balances {
+		for _, d := range b.Details {
+			free, locked := decimalOf(d.AvailBal), decimalOf(d.FrozenBal)
+			if free.IsZero() && locked.IsZero() {
+				continue
+			}
+			balances = append(balances, models.Balance{Asset: d.Ccy, Free: free, Locked: locked})
+		}
+	}
+	return balances, nil
+}
+
+// SubAccountTransfer 母子账户或子账户之间划转，账户类型只能是资金账户或交易账户
+func (c *Client) SubAccountTransfer(ctx context.Context, req models.SubAccountTransferRequest) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
+	}
+	fromAccount, toAccount := req.Accounts()
+	from, to := accountTypes[fromAccount], accountTypes[toAccount]
+	if err := ctx.Err(); err != nil {
+		return models.Transfer{}, err
+	}
+	var (
+		id  string
+		err error
+	)
+	switch {
+	case req.FromSub == "":
+		id, err = c.fundsTransfer(requests.FundsTransfer{Ccy: req.Currency, Amt: req.Amount.String(), From: from, To: to,
+			Type: sdk.MasterAccountToSubAccount, SubAcct: req.ToSub})
+	case req.ToSub == "":
+		id, err = c.fundsTransfer(requests.FundsTransfer{Ccy: req.Currency, Amt: req.Amount.String(), From: from, To: to,
+			Type: sdk.MasterSubAccountToAccount, SubAcct: req.FromSub})
+	default:
+		id, err = c.subAccountTransfer(subrequests.ManageTransfers{Ccy: req.Currency, Amt: req.Amount.String(), From: from, To: to,
+			FromSubAccount: req.FromSub, ToSubAccount: req.ToSub})
+	}
+	if err != nil {
+		return models.Transfer{}, err
+	}
+	return models.Transfer{TransferID: id, Currency: req.Currency, Amount: req.Amount, From: fromAccount, To: toAccount}, nil
+}
+
+func (c *Client) subAccountTransfer(req subrequests.ManageTransfers) (string, error) {
+	resp, err := c.API().Rest.SubAccount.ManageTransfers(req)
+	if err != nil {
+		return "", restError(err)
+	}
+	if resp.Code != 0 || len(resp.Transfers) == 0 {
+		return "", sdkError(resp.Basic)
+	}
+	return resp.Transfers[0].TransID, nil
+}
+
+// CreateSubAccountAPIKey 为子账户创建 API key，OKX 要求 passphrase，权限为空时只读
+func (c *Client) CreateSubAccountAPIKey(ctx context.Context, req models.SubAccountAPIKeyRequest) (models.SubAccountAPIKey, error) {
+	if err := req.Validate(); err != nil {
+		return models.SubAccountAPIKey{}, err
+	}
+	if req.Passphrase == "" {
+		return models.SubAccountAPIKey{}, fmt.Errorf("%w: okx api key needs a passphrase", models.ErrInvalidRequest)
+	}
+	perms := make([]string, 0, len(req.Permissions))
+	for _, p := range req.Permissions {
+		perm, ok := apiKeyPerms[p]
+		if !ok {
+			return models.SubAccountAPIKey{}, fmt.Errorf("%w: %s permission for sub-account api key", base.ErrNotSupported, p)
+		}
+		perms = append(perms, string(perm))
+	}
+	if err := ctx.Err(); err != nil {
+		return models.SubAccountAPIKey{}, err
+	}
+	resp, err := c.rest(ctx).SubAccount.CreateAPIKey(subrequests.CreateAPIKey{
+		SubAcct:    req.SubAccount,
+		Label:      req.Label,
+		Passphrase: req.Passphrase,
+		IP:         req.IPs,
+		Perm:       sdk.APIKeyAccess(strings.Join(perms, ",")),
+	})
+	if err != nil {
+		return models.SubAccountAPIKey{}, restError(err)
+	}
+	if resp.Code != 0 || len(resp.APIKeys) == 0 {
+		return models.SubAccountAPIKey{}, sdkError(resp.Basic)
+	}
+	return convertAPIKey(resp.APIKeys[0]), nil
+}
+
+func convertAPIKey(k *submodels.APIKey) models.SubAccountAPIKey {
+	key := models.SubAccountAPIKey{
+		SubAccount: k.SubAcct,
+		Label:      k.Label,
+		APIKey:     k.APIKey,
+		SecretKey:  k.SecretKey,
+		Passphrase: k.Passphrase,
+		Time:       time.Time(k.TS).UnixMilli(),
+	}
+	for _, p := range strings.Split(k.Perm, ",") {
+		for unified, perm := range apiKeyPerms {
+			if string(perm) == p {
+				key.Permissions = append(key.Permissions, unified)
+			}
+		}
+	}
+	if k.IP != "" {
+		key.IPs = strings.Split(k.IP, ",")
+	}
+	return key
+}
+
+// DeleteSubAccountAPIKey 删除子账户的 API key
+func (c *Client) DeleteSubAccountAPIKey(ctx context.Context, sub, apiKey string) error {
+	if sub == "" || apiKey == "" {
+		return fmt.Errorf("%w: delete api key needs sub account and api key", models.ErrInvalidRequest)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	resp, err := c.rest(ctx).SubAccount.DeleteAPIKey(subrequests.DeleteAPIKey{SubAcct: sub, APIKey: apiKey})
+	if err != nil {
+		return restError(err)
+	}
+	if resp.Code != 0 {
+		return sdkError(resp.Basic)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetSubAccounts(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	subs, err := c.GetSubAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 || subs[0].Name != "grid01" || subs[0].Label != "grid strategy" || !subs[0].Enabled || subs[0].Time != 1700000200000 || subs[1].Enabled {
+		t.Fatalf("subs = %+v", subs)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/users/subaccount/list")[0].Query; q.Get("limit") != "100" || q.Get("after") != "" {
+		t.Fatalf("query = %v", q)
+	}
+
+	balances, err := c.GetSubAccountBalances(ctx, "grid01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Asset != "USDT" || balances[0].Free.String() != "800.25" || balances[0].Locked.String() != "200" {
+		t.Fatalf("balances = %+v", balances)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/account/subaccount/balances")[0].Query; q.Get("subAcct") != "grid01" {
+		t.Fatalf("query = %v", q)
+	}
+}
+
+func TestSubAccountTransfer(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	amount := models.ParseDecimalOrZero("50")
+
+	// 母账户资金账户 -> 子账户交易账户
+	tr, err := c.SubAccountTransfer(ctx, models.SubAccountTransferRequest{Currency: "USDT", Amount: amount, ToSub: "grid01", FromAccount: base.FUNDING})
+	if err != nil || tr.TransferID != "754147" || tr.From != base.FUNDING || tr.To != base.SPOT {
+		t.Fatalf("transfer = %+v err = %v", tr, err)
+	}
+	// 子账户 -> 母账户
+	if _, err := c.SubAccountTransfer(ctx, models.SubAccountTransferRequest{Currency: "USDT", Amount: amount, FromSub: "grid01"}); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.Requests(http.MethodPost, "/api/v5/asset/transfer")
+	var in, out map[string]string
+	if err := json.Unmarshal(reqs[0].Body, &in); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(reqs[1].Body, &out); err != nil {
+		t.Fatal(err)
+	}
+	if in["type"] != "1" || in["subAcct"] != "grid01" || in["from"] != "6" || in["to"] != "18" || in["amt"] != "50" {
+		t.Fatalf("master to sub = %v", in)
+	}
+	if out["type"] != "2" || out["subAcct"] != "grid01" || out["from"] != "18" || out["to"] != "18" {
+		t.Fatalf("sub to master = %v", out)
+	}
+
+	// 子账户之间
+	tr, err = c.SubAccountTransfer(ctx, models.SubAccountTransferRequest{Currency: "USDT", Amount: amount, FromSub: "grid01", ToSub: "arb01"})
+	if err != nil || tr.TransferID != "12345" {
+		t.Fatalf("sub to sub = %+v err = %v", tr, err)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/account/subaccount/transfer")[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["fromSubAccount"] != "grid01" || body["toSubAccount"] != "arb01" || body["amt"] != "50" || body["from"] != "18" {
+		t.Fatalf("body = %v", body)
+	}
+}
+
+func TestSubAccountAPIKey(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	req := models.SubAccountAPIKeyRequest{
+		SubAccount:  "grid01",
+		Label:       "grid-bot",
+		Passphrase:  "Grid-pass1",
+		Permissions: []string{base.PermRead, base.PermTrade},
+		IPs:         []string{"10.0.0.1", "10.0.0.2"},
+	}
+	key, err := c.CreateSubAccountAPIKey(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.APIKey != "a7b1c2d3-key" || key.SecretKey != "5F2C8E-secret" || key.Passphrase != "Grid-pass1" ||
+		len(key.Permissions) != 2 || len(key.IPs) != 2 || key.Time != 1700000300000 {
+		t.Fatalf("key = %+v", key)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/users/subaccount/apikey")[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["subAcct"] != "grid01" || body["passphrase"] != "Grid-pass1" || body["perm"] != "read_only,trade" || body["ip"] != "10.0.0.1,10.0.0.2" {
+		t.Fatalf("body = %v", body)
+	}
+
+	// OKX 子账户 key 不能提币，passphrase 必填
+	req.Permissions = []string{base.PermWithdraw}
+	if _, err := c.CreateSubAccountAPIKey(ctx, req); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("withdraw permission err = %v", err)
+	}
+	req.Permissions, req.Passphrase = nil, ""
+	if _, err := c.CreateSubAccountAPIKey(ctx, req); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("no passphrase err = %v", err)
+	}
+
+	if err := c.DeleteSubAccountAPIKey(ctx, "grid01", "a7b1c2d3-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/users/subaccount/delete-apikey")[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["apiKey"] != "a7b1c2d3-key" || body["subAcct"] != "grid01" {
+		t.Fatalf("body = %v", body)
+	}
+	srv.Handle(http.MethodPost, "/api/v5/users/subaccount/delete-apikey", http.StatusOK, `{"code":"59505","msg":"API key does not exist","data":[]}`)
+	if err := c.DeleteSubAccountAPIKey(ctx, "grid01", "missing"); !errors.Is(err, base.ErrResponse) {
+		t.Fatalf("delete missing err = %v", err)
+	}
+}
@@ -0,0 +1,452 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api/ws"
+	"AxonTrading/exchanges/okx/sdk/events/private"
+	"AxonTrading/exchanges/okx/sdk/events/public"
+	"AxonTrading/exchanges/okx/sdk/models/account"
+	"AxonTrading/exchanges/okx/sdk/models/market"
+	"AxonTrading/exchanges/okx/sdk/models/trade"
+	privatereq "AxonTrading/exchanges/okx/sdk/requests/ws/private"
+	publicreq "AxonTrading/exchanges/okx/sdk/requests/ws/public"
+	"AxonTrading/feed"
+	"AxonTrading/models"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 统一推送接口（store/exchange.StreamingExchange）的实现。
+// 所有订阅共用一个 websocket 客户端（公共、私有各一条连接），同一频道只向交易所订阅一次，
+// 最后一个订阅取消时退订。统一符号经 Instruments 转成 instId，旧格式按 instId 原样使用；
+// 推送中的 Symbol 与传入的一致
+
+// bookDepth5 不超过 5 档时订阅 books5（每次全量推送），否则订阅 400 档的 books
+const bookDepth5 = 5
+
+// streamer 推送订阅共用的 websocket 客户端和分发，websocket 下单（WsTrade）也使用它的私有连接
+type streamer struct {
+	ws    *ws.ClientWs
+	books *BookManager
+
+	bookHub   feed.Hub[*OrderBook]
+	trades    feed.Hub[*market.Trade]
+	tickers   feed.Hub[*market.Ticker]
+	orders    feed.Hub[*trade.Order]
+	positions feed.Hub[*account.Position]
+	balances  feed.Hub[*account.BalanceDetails]
+
+	tradeCh    chan *public.Trades
+	tickerCh   chan *public.Tickers
+	orderCh    chan *private.Order
+	positionCh chan *private.Position
+	accountCh  chan *private.Account
+
+	mu    sync.Mutex
+	refs  map[string]int  // 频道订阅的引用数，key 为 channel|instId
+	wired map[string]bool // 已把 channel 交给 SDK 的频道
+}
+
+// streamer 第一次订阅时创建，使用 WsPublicURL / WsPrivateURL，为空时使用生产地址
+func (c *Client) streamer() *streamer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stream != nil {
+		return c.stream
+	}
+	urls := map[bool]sdk.BaseURL{false: sdk.PublicWsURL, true: sdk.PrivateWsURL}
+	if c.WsPublicURL != "" {
+		urls[false] = sdk.BaseURL(c.WsPublicURL)
+	}
+	if c.WsPrivateURL != "" {
+		urls[true] = sdk.BaseURL(c.WsPrivateURL)
+	}
+	w := ws.NewClient(context.Background(), c.AccessKey, c.SecretKey, c.Password, urls)
+	// 只使用各频道的 channel，其余事件丢弃
+	w.StructuredEventChan = nil
+	w.RawEventChan = nil
+	s := &streamer{
+		ws:         w,
+		books:      NewBookManager(w),
+		tradeCh:    make(chan *public.Trades),
+		tickerCh:   make(chan *public.Tickers),
+		orderCh:    make(chan *private.Order),
+		positionCh: make(chan *private.Position),
+		accountCh:  make(chan *private.Account),
+		refs:       make(map[string]int),
+		wired:      make(map[string]bool),
+	}
+	s.books.OnUpdate(s.bookHub.Publish)
+	go s.run()
+	c.stream = s
+	return s
+}
+
+// run 把 SDK 的推送拆成单条分发给订阅者
+func (s *streamer) run() {
+	for {
+		select {
+		case e := <-s.tradeCh:
+			for _, t := range e.Trades {
+				s.trades.Publish(t)
+			}
+		case e := <-s.tickerCh:
+			for _, t := range e.Tickers {
+				s.tickers.Publish(t)
+			}
+		case e := <-s.orderCh:
+			for _, o := range e.Orders {
+				s.orders.Publish(o)
+			}
+		case e := <-s.positionCh:
+			for _, p := range e.Positions {
+				s.positions.Publish(p)
+			}
+		case e := <-s.accountCh:
+			for _, b := range e.Balances {
+				for _, d := range b.Details {
+					s.balances.Publish(d)
+				}
+			}
+		case <-s.ws.DoneChan:
+			return
+		}
+	}
+}
+
+// wire 频道第一次订阅时返回 true，此时需要把 channel 交给 SDK；之后的订阅不再改写 SDK 的 channel。
+// 在 acquire 的 subscribe 中调用，s.mu 已加锁
+func (s *streamer) wire(channel string) bool {
+	if s.wired[channel] {
+		return false
+	}
+	s.wired[channel] = true
+	return true
+}
+
+// acquire 增加频道的引用，第一次时调用 subscribe
+func (s *streamer) acquire(key string, subscribe func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs[key] == 0 {
+		if err := subscribe(); err != nil {
+			return err
+		}
+	}
+	s.refs[key]++
+	return nil
+}
+
+// release 减少频道的引用，最后一个时调用 unsubscribe
+func (s *streamer) release(key string, unsubscribe func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs[key]--; s.refs[key] > 0 {
+		return nil
+	}
+	delete(s.refs, key)
+	return unsubscribe()
+}
+
+// subscribeHub 订阅频道并从 hub 中取出匹配的推送
+func subscribeHub[T, Out any](ctx context.Context, s *streamer, hub *feed.Hub[T], key string,
+	subscribe, unsubscribe func() error, convert func(T) (Out, bool)) (<-chan Out, *feed.Subscription, error) {
+	if err := s.acquire(key, subscribe); err != nil {
+		return nil, nil, err
+	}
+	ch, remove := hub.Subscribe()
+	sub := feed.NewSubscription(ctx, func() error {
+		remove()
+		return s.release(key, unsubscribe)
+	})
+	return feed.Pipe(sub.Done(), ch, convert), sub, nil
+}
+
+// instType 由 instId 推断产品类型：BTC-USDT 现货，BTC-USDT-SWAP 永续，BTC-USD-240927 交割，BTC-USD-240927-50000-C 期权
+func instType(instID string) sdk.InstrumentType {
+	parts := strings.Split(instID, "-")
+	switch {
+	case len(parts) == 2:
+		return sdk.SpotInstrument
+	case strings.HasSuffix(instID, "-SWAP"):
+		return sdk.SwapInstrument
+	case len(parts) == 5:
+		return sdk.OptionsInstrument
+	}
+	return sdk.FuturesInstrument
+}
+
+// SubscribeOrderBook 本地维护并校验的订单簿，每次更新推送买卖各前 depth 档
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbol string, depth int) (<-chan models.WsData, *feed.Subscription, error) {
+	s := c.streamer()
+	instID := c.spotID(symbol)
+	channel := "books"
+	if depth > 0 && depth <= bookDepth5 {
+		channel = "books5"
+	}
+	return subscribeHub(ctx, s, &s.bookHub, channel+"|"+instID,
+		func() error {
+			_, err := s.books.Subscribe(channel, instID)
+			return err
+		},
+		func() error { return s.books.Unsubscribe(channel, instID) },
+		func(b *OrderBook) (models.WsData, bool) {
+			if b.Channel != channel || b.InstID != instID {
+				return models.WsData{}, false
+			}
+			return b.Depth(depth), true
+		})
+}
+
+// SubscribeTrades 公开成交
+func (c *Client) SubscribeTrades(ctx context.Context, symbol string) (<-chan models.Trade, *feed.Subscription, error) {
+	s := c.streamer()
+	req := publicreq.Trades{InstID: c.spotID(symbol)}
+	return subscribeHub(ctx, s, &s.trades, "trades|"+req.InstID,
+		func() error {
+			if s.wire("trades") {
+				return s.ws.Public.Trades(req, s.tradeCh)
+			}
+			return s.ws.Public.Trades(req)
+		},
+		func() error { return s.ws.Public.UTrades(req) },
+		func(t *market.Trade) (models.Trade, bool) {
+			if t.InstID != req.InstID {
+				return models.Trade{}, false
+			}
+			return models.Trade{
+				Symbol:   echoSymbol(symbol, t.InstID),
+				TradeID:  t.TradeID,
+				Price:    decimalOf(t.Px),
+				Quantity: decimalOf(t.Sz),
+				Side:     orderSide(string(t.Side)),
+				Time:     time.Time(t.TS).UnixMilli(),
+			}, true
+		})
+}
+
+// SubscribeTicker 最新成交价和最优买卖价
+func (c *Client) SubscribeTicker(ctx context.Context, symbol string) (<-chan models.Ticker, *feed.Subscription, error) {
+	s := c.streamer()
+	req := publicreq.Tickers{InstID: c.spotID(symbol)}
+	return subscribeHub(ctx, s, &s.tickers, "tickers|"+req.InstID,
+		func() error {
+			if s.wire("tickers") {
+				return s.ws.Public.Tickers(req, s.tickerCh)
+			}
+			return s.ws.Public.Tickers(req)
+		},
+		func() error { return s.ws.Public.UTickers(req) },
+		func(t *market.Ticker) (models.Ticker, bool) {
+			if t.InstID != req.InstID {
+				return models.Ticker{}, false
+			}
+			return models.Ticker{
+				Symbol:   echoSymbol(symbol, t.InstID),
+				Last:     decimalOf(t.Last),
+				BidPrice: decimalOf(t.BidPx),
+				BidQty:   decimalOf(t.BidSz),
+				AskPrice: decimalOf(t.AskPx),
+				AskQty:   decimalOf(t.AskSz),
+				Time:     time.Time(t.TS).UnixMilli(),
+			}, true
+		})
+}
+
+// subscribeOrderChannel 订阅 orders 频道，订单推送和成交推送共用
+func subscribeOrderChannel[Out any](ctx context.Context, c *Client, symbol string, convert func(o *trade.Order) (Out, bool)) (<-chan Out, *feed.Subscription, error) {
+	s := c.streamer()
+	instID := c.spotID(symbol)
+	req := privatereq.Order{InstID: instID, InstType: instType(instID)}
+	return subscribeHub(ctx, s, &s.orders, "orders|"+instID,
+		func() error {
+			if s.wire("orders") {
+				return s.ws.Private.Order(req, s.orderCh)
+			}
+			return s.ws.Private.Order(req)
+		},
+		func() error { return s.ws.Private.UOrder(req) },
+		func(o *trade.Order) (Out, bool) {
+			if o.InstID != instID {
+				var zero Out
+				return zero, false
+			}
+			return convert(o)
+		})
+}
+
+// SubscribeOrders symbol 的订单状态推送
+func (c *Client) SubscribeOrders(ctx context.Context, symbol string) (<-chan models.OrderUpdate, *feed.Subscription, error) {
+	return subscribeOrderChannel(ctx, c, symbol, func(o *trade.Order) (models.OrderUpdate, bool) {
+		return models.OrderUpdate{
+			Symbol:        echoSymbol(symbol, o.InstID),
+			OrderID:       o.OrdID,
+			ClientOrderID: o.ClOrdID,
+			Side:          orderSide(string(o.Side)),
+			PositionSide:  positionSide(string(o.PosSide)),
+			Type:          orderType(string(o.OrdType)),
+			Price:         decimalOf(o.Px),
+			Quantity:      decimalOf(o.Sz),
+			Filled:        decimalOf(o.AccFillSz),
+			AvgPrice:      decimalOf(o.AvgPx),
+			Status:        orderState(string(o.State)),
+			ReduceOnly:    o.ReduceOnly == "true",
+			Time:          time.Time(o.UTime).UnixMilli(),
+		}, true
+	})
+}
+
+// SubscribeFills symbol 的成交推送，取自 orders 频道中带 tradeId 的推送
+func (c *Client) SubscribeFills(ctx context.Context, symbol string) (<-chan models.Fill, *feed.Subscription, error) {
+	return subscribeOrderChannel(ctx, c, symbol, func(o *trade.Order) (models.Fill, bool) {
+		if o.TradeID == "" || decimalOf(o.FillSz).IsZero() {
+			return models.Fill{}, false
+		}
+		return models.Fill{
+			Symbol:        echoSymbol(symbol, o.InstID),
+			OrderID:       o.OrdID,
+			ClientOrderID: o.ClOrdID,
+			TradeID:       o.TradeID,
+			Side:          orderSide(string(o.Side)),
+			Price:         decimalOf(o.FillPx),
+			Quantity:      decimalOf(o.FillSz),
+			// OKX 的手续费扣除为负、返佣为正，统一模型相反
+			Fee:      decimalOf(o.FillFee).Neg(),
+			FeeAsset: o.FillFeeCcy,
+			Maker:    o.ExecType == "M",
+			Time:     int64(o.FillTime),
+		}, true
+	})
+}
+
+// SubscribePositions 持仓推送，symbol 为空时推送全部持仓（instId）
+func (c *Client) SubscribePositions(ctx context.Context, symbol string) (<-chan models.PositionInfo, *feed.Subscription, error) {
+	s := c.streamer()
+	req := privatereq.Position{InstType: sdk.InstrumentType("ANY")}
+	if symbol != "" {
+		req.InstID = c.swapID(symbol)
+		req.InstType = instType(req.InstID)
+	}
+	return subscribeHub(ctx, s, &s.positions, "positions|"+req.InstID,
+		func() error {
+			if s.wire("positions") {
+				return s.ws.Private.Position(req, s.positionCh)
+			}
+			return s.ws.Private.Position(req)
+		},
+		func() error { return s.ws.Private.UPosition(req) },
+		func(p *account.Position) (models.PositionInfo, bool) {
+			if req.InstID != "" && p.InstID != req.InstID {
+				return models.PositionInfo{}, false
+			}
+			return positionInfo(symbol, p), true
+		})
+}
+
+// SubscribeBalances 余额推送。OKX 为统一账户，future 不影响结果
+func (c *Client) SubscribeBalances(ctx context.Context, future bool) (<-chan models.BalanceUpdate, *feed.Subscription, error) {
+	s := c.streamer()
+	req := privatereq.Account{}
+	return subscribeHub(ctx, s, &s.balances, "account|",
+		func() error {
+			if s.wire("account") {
+				return s.ws.Private.Account(req, s.accountCh)
+			}
+			return s.ws.Private.Account(req)
+		},
+		func() error { return s.ws.Private.UAccount(req) },
+		func(d *account.BalanceDetails) (models.BalanceUpdate, bool) {
+			return models.BalanceUpdate{
+				Asset:  d.Ccy,
+				Total:  decimalOf(d.CashBal),
+				Free:   decimalOf(d.AvailBal),
+				Locked: decimalOf(d.FrozenBal),
+				Time:   time.Time(d.UTime).UnixMilli(),
+			}, true
+		})
+}
+
+// decimalOf SDK 模型中保留原始文本的数值，空字符串为 0
+func decimalOf(f sdk.JSONDecimal) models.Decimal {
+	return models.ParseDecimalOrZero(string(f))
+}
+
+// positionInfo 推送和 GetPositionRisk 共用的持仓转换
+func positionInfo(symbol string, p *account.Position) models.PositionInfo {
+	var marginType string
+	if p.MgnMode == "cross" {
+		marginType = base.CROSSED
+	} else if p.MgnMode == "isolated" {
+		marginType = base.ISOLATED
+	}
+	return models.PositionInfo{
+		Symbol:           echoSymbol(symbol, p.InstID),
+		PositionAmt:      decimalOf(p.Pos),
+		EntryPrice:       decimalOf(p.AvgPx),
+		MarkPrice:        decimalOf(p.MarkPx),
+		UnRealizedProfit: decimalOf(p.Upl),
+		LiquidationPrice: decimalOf(p.LiqPx),
+		Leverage:         decimalOf(p.Lever),
+		MarginType:       marginType,
+		IsolatedMargin:   decimalOf(p.Imr),
+		PositionSide:     positionSide(string(p.PosSide)),
+		UpdateTime:       millis(p.UTime),
+	}
+}
+
+// millis 毫秒时间戳，零值时间为 0
+func millis(t sdk.JSONTime) int64 {
+	if time.Time(t).IsZero() {
+		return 0
+	}
+	return time.Time(t).UnixMilli()
+}
+
+func orderSide(s string) string {
+	if s == "buy" {
+		return base.BID
+	}
+	return base.ASK
+}
+
+// positionSide 买卖模式（net）下为空，与 GetPositionRisk 一致
+func positionSide(s string) string {
+	switch s {
+	case "long":
+		return base.LONG
+	case "short":
+		return base.SHORT
+	}
+	return ""
+}
+
+func orderState(s string) string {
+	switch s {
+	case "live":
+		return base.OPEN
+	case "partially_filled":
+		return base.PARTIALLY
+	case "filled":
+		return base.FILLED
+	case "canceled", "mmp_canceled":
+		return base.CANCELED
+	}
+	return s
+}
+
+func orderType(s string) string {
+	switch s {
+	case "limit":
+		return base.LIMIT
+	case "market":
+		return base.MARKET
+	case "post_only":
+		return base.MAKER
+	case "ioc", "fok":
+		return base.TAKER
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newPushServer 应答 login 的 websocket 服务器，订阅某个频道后推送 pushes 中该频道的消息；ops 记录收到的 op 和频道。
+// 其他 op 回复 pushes[op]，其中的 {id}、{clOrdId} 替换为请求的 id 和第一个参数的 clOrdId
+func newPushServer(t *testing.T, pushes map[string]string) (url string, ops chan string) {
+	t.Helper()
+	ops = make(chan string, 20)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ID   string              `json:"id"`
+				Op   string              `json:"op"`
+				Args []map[string]string `json:"args"`
+			}
+			_ = json.Unmarshal(data, &req)
+			switch req.Op {
+			case "login":
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":""}`))
+			case "subscribe", "unsubscribe":
+				for _, arg := range req.Args {
+					ops <- req.Op + " " + arg["channel"]
+					if push, ok := pushes[arg["channel"]]; ok && req.Op == "subscribe" {
+						_ = conn.WriteMessage(websocket.TextMessage, []byte(push))
+					}
+				}
+			default:
+				ops <- req.Op
+				if answer, ok := pushes[req.Op]; ok {
+					var clOrdID string
+					if len(req.Args) > 0 {
+						clOrdID = req.Args[0]["clOrdId"]
+					}
+					answer = strings.NewReplacer("{id}", req.ID, "{clOrdId}", clOrdID).Replace(answer)
+					_ = conn.WriteMessage(websocket.TextMessage, []byte(answer))
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http"), ops
+}
+
+func nextOp(t *testing.T, ops chan string) string {
+	t.Helper()
+	select {
+	case op := <-ops:
+		return op
+	case <-time.After(5 * time.Second):
+		t.Fatal("no subscribe request")
+		return ""
+	}
+}
+
+func recvPush[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("no push")
+		var zero T
+		return zero
+	}
+}
+
+func TestSubscribeTradesSharesChannel(t *testing.T) {
+	url, ops := newPushServer(t, map[string]string{
+		"trades": `{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"1","px":"100.5","sz":"0.2","side":"sell","ts":"1700000000000"}]}`,
+	})
+	c := &Client{WsPublicURL: url}
+	t.Cleanup(func() { c.streamer().ws.Cancel() })
+
+	ch1, sub1, err := c.SubscribeTrades(context.Background(), "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr := recvPush(t, ch1); tr.TradeID != "1" || tr.Price.String() != "100.5" || tr.Side != base.ASK || tr.Symbol != "BTC-USDT" {
+		t.Fatalf("trade = %+v", tr)
+	}
+	if op := nextOp(t, ops); op != "subscribe trades" {
+		t.Fatalf("op = %s", op)
+	}
+	_, sub2, err := c.SubscribeTrades(context.Background(), "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 还有订阅者时不退订
+	_ = sub1.Unsubscribe()
+	if _, ok := <-ch1; ok {
+		t.Fatal("channel not closed")
+	}
+	_ = sub2.Unsubscribe()
+	if op := nextOp(t, ops); op != "unsubscribe trades" {
+		t.Fatalf("op = %s", op)
+	}
+}
+
+func TestSubscribeOrdersAndFills(t *testing.T) {
+	url, ops := newPushServer(t, map[string]string{
+		"orders": `{"arg":{"channel":"orders","instType":"SPOT","instId":"BTC-USDT"},"data":[` +
+			`{"instId":"ETH-USDT","ordId":"8","state":"live"},` +
+			`{"instId":"BTC-USDT","ordId":"9","clOrdId":"c1","side":"buy","posSide":"net","ordType":"post_only","px":"100","sz":"1","accFillSz":"1","avgPx":"100","state":"filled",` +
+			`"tradeId":"77","fillPx":"100","fillSz":"1","fillFee":"-0.001","fillFeeCcy":"BTC","execType":"M","fillTime":"1700000000000","uTime":"1700000000000","reduceOnly":"false"}]}`,
+	})
+	c := &Client{WsPrivateURL: url, AccessKey: "key", SecretKey: "secret", Password: "pass"}
+	t.Cleanup(func() { c.streamer().ws.Cancel() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fills, _, err := c.SubscribeFills(ctx, "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := recvPush(t, fills)
+	if f.TradeID != "77" || f.OrderID != "9" || f.Fee.String() != "0.001" || f.FeeAsset != "BTC" || !f.Maker || f.Time != 1700000000000 {
+		t.Fatalf("fill = %+v", f)
+	}
+	if op := nextOp(t, ops); op != "subscribe orders" {
+		t.Fatalf("op = %s", op)
+	}
+	// 订单订阅共用已订阅的 orders 频道，不再向交易所订阅
+	orders, _, err := c.SubscribeOrders(ctx, "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case o := <-orders:
+		t.Fatalf("unexpected order %+v", o)
+	case op := <-ops:
+		t.Fatalf("unexpected op %s", op)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,311 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/models/funding"
+	requests "AxonTrading/exchanges/okx/sdk/requests/rest/funding"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 资金（store/exchange.TreasuryExchange）的实现，使用 rest.Funding。链上提币从资金账户发出；
+// 现货和永续共用交易账户，两者之间的划转返回 base.ErrNotSupported
+
+// accountTypes 统一账户类型对应的 OKX 账户
+var accountTypes = map[string]sdk.AccountType{
+	base.FUNDING: sdk.FundingAccount,
+	base.SPOT:    sdk.UnifiedAccount,
+	base.FUTURES: sdk.UnifiedAccount,
+}
+
+// historyPage 充提记录接口每页的最大条数
+const historyPage = 100
+
+// chainName 去掉 OKX 链名的币种前缀，USDT-TRC20 -> TRC20
+func chainName(ccy, chain string) string {
+	return strings.TrimPrefix(chain, ccy+"-")
+}
+
+// GetChains 币种在各条链上的充提信息，currency 为空时返回全部币种
+func (c *Client) GetChains(ctx context.Context, currency string) ([]models.ChainInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp, err := c.rest(ctx).Funding.GetCurrencies(requests.GetCurrencies{Ccy: currency})
+	if err != nil {
+		return nil, restError(err)
+	}
+	if resp.Code != 0 {
+		return nil, sdkError(resp.Basic)
+	}
+	chains := make([]models.ChainInfo, 0, len(resp.Currencies))
+	for _, cur := range resp.Currencies {
+		info := models.ChainInfo{
+			Currency:       cur.Ccy,
+			Chain:          chainName(cur.Ccy, cur.Chain),
+			CanDeposit:     cur.CanDep,
+			CanWithdraw:    cur.CanWd,
+			WithdrawFee:    models.ParseDecimalOrZero(cur.MinFee),
+			MaxWithdrawFee: models.ParseDecimalOrZero(cur.MaxFee),
+			MinWithdraw:    models.ParseDecimalOrZero(cur.MinWd),
+			MaxWithdraw:    models.ParseDecimalOrZero(cur.MaxWd),
+			MinDeposit:     models.ParseDecimalOrZero(cur.MinDep),
+			NeedTag:        cur.NeedTag,
+		}
+		// wdTickSz 为小数位数
+		if n, err := strconv.Atoi(cur.WdTickSz); err == nil {
+			info.WithdrawStep = models.NewDecimal(1, int32(n))
+		}
+		chains = append(chains, info)
+	}
+	return chains, nil
+}
+
+// chainInfo currency 在 chain 上的充提信息，chain 不带币种前缀
+func (c *Client) chainInfo(ctx context.Context, currency, chain string) (models.ChainInfo, error) {
+	chains, err := c.GetChains(ctx, currency)
+	if err != nil {
+		return models.ChainInfo{}, err
+	}
+	for _, info := range chains {
+		if info.Chain == chain {
+			return info, nil
+		}
+	}
+	return models.ChainInfo{}, fmt.Errorf("%w: unknown chain %s for %s", models.ErrInvalidRequest, chain, currency)
+}
+
+// SubmitWithdrawal 从资金账户链上提币，手续费取链上最低手续费。需要 tag 的链地址按 OKX 的格式拼成 address:tag
+func (c *Client) SubmitWithdrawal(ctx context.Context, req models.WithdrawRequest) (models.Withdrawal, error) {
+	if err := req.Validate(); err != nil {
+		return models.Withdrawal{}, err
+	}
+	info, err := c.chainInfo(ctx, req.Currency, req.Chain)
+	if err != nil {
+		return models.Withdrawal{}, err
+	}
+	if !info.CanWithdraw {
+		return models.Withdrawal{}, fmt.Errorf("%w: %s withdrawal on %s is suspended", models.ErrInvalidRequest, req.Currency, req.Chain)
+	}
+	if req.Amount.LessThan(info.MinWithdraw) {
+		return models.Withdrawal{}, fmt.Errorf("%w: amount %s below minimum %s", models.ErrInvalidRequest, req.Amount, info.MinWithdraw)
+	}
+	if info.NeedTag && req.Tag == "" {
+		return models.Withdrawal{}, fmt.Errorf("%w: %s on %s needs a tag", models.ErrInvalidRequest, req.Currency, req.Chain)
+	}
+	addr := req.Address
+	if req.Tag != "" {
+		addr += ":" + req.Tag
+	}
+	resp, err := c.rest(ctx).Funding.Withdrawal(requests.Withdrawal{
+		Ccy:    req.Currency,
+		Chain:  req.Currency + "-" + req.Chain,
+		ToAddr: addr,
+		Amt:    req.Amount.String(),
+		Fee:    info.WithdrawFee.String(),
+		Dest:   sdk.WithdrawalDigitalAddressDestination,
+	})
+	if err != nil {
+		return models.Withdrawal{}, restError(err)
+	}
+	if resp.Code != 0 || len(resp.Withdrawals) == 0 {
+		return models.Withdrawal{}, sdkError(resp.Basic)
+	}
+	return models.Withdrawal{
+		WithdrawID: resp.Withdrawals[0].WdID,
+		Currency:   req.Currency,
+		Chain:      req.Chain,
+		Amount:     req.Amount,
+		Fee:        info.WithdrawFee,
+		Address:    req.Address,
+		Tag:        req.Tag,
+		Status:     base.PROCESSING,
+		Time:       time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetWithdrawal 按提币 id 查询，查不到时返回 base.ErrWithdrawNotFound
+func (c *Client) GetWithdrawal(ctx context.Context, currency, id string) (models.Withdrawal, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Withdrawal{}, err
+	}
+	resp, err := c.rest(ctx).Funding.GetWithdrawalHistory(requests.GetWithdrawalHistory{Ccy: currency, WdID: id})
+	if err != nil {
+		return models.Withdrawal{}, restError(err)
+	}
+	if resp.Code != 0 {
+		return models.Withdrawal{}, sdkError(resp.Basic)
+	}
+	for _, w := range resp.WithdrawalHistories {
+		if w.WdID == id {
+			return convertWithdrawal(w), nil
+		}
+	}
+	return models.Withdrawal{}, fmt.Errorf("%w: %s", base.ErrWithdrawNotFound, id)
+}
+
+// GetWithdrawals [since, until] 内的提币记录，按时间升序；currency 为空时返回全部币种
+func (c *Client) GetWithdrawals(ctx context.Context, currency string, since, until time.Time) ([]models.Withdrawal, error) {
+	since, until, err := models.HistoryRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	records, err := history(ctx, since, until, func(after, before int64) ([]*funding.WithdrawalHistory, error) {
+		resp, err := c.rest(ctx).Funding.GetWithdrawalHistory(requests.GetWithdrawalHistory{Ccy: currency, After: after, Before: before, Limit: historyPage})
+		if err != nil {
+			return nil, restError(err)
+		}
+		if resp.Code != 0 {
+			return nil, sdkError(resp.Basic)
+		}
+		return resp.WithdrawalHistories, nil
+	}, func(w *funding.WithdrawalHistory) (string, time.Time) { return w.WdID, time.Time(w.TS) })
+	if err != nil {
+		return nil, err
+	}
+	ws := make([]models.Withdrawal, 0, len(records))
+	for _, w := range records {
+		ws = append(ws, convertWithdrawal(w))
+	}
+	sort.SliceStable(ws, func(i, j int) bool { return ws[i].Time < ws[j].Time })
+	return ws, nil
+}
+
+func convertWithdrawal(w *funding.WithdrawalHistory) models.Withdrawal {
+	status := base.PROCESSING
+	switch w.State {
+	case sdk.WithdrawalCanceled:
+		status = base.CANCELED
+	case sdk.WithdrawalFailed:
+		status = base.FAILED
+	case sdk.WithdrawalSent:
+		status = base.SUCCEEDED
+	}
+	return models.Withdrawal{
+		WithdrawID: w.WdID,
+		Currency:   w.Ccy,
+		Chain:      chainName(w.Ccy, w.Chain),
+		Amount:     models.ParseDecimalOrZero(w.Amt),
+		Fee:        models.ParseDecimalOrZero(w.Fee),
+		Address:    w.To,
+		Tag:        w.Tag,
+		TxID:       w.TxID,
+		Status:     status,
+		Time:       time.Time(w.TS).UnixMilli(),
+	}
+}
+
+// GetDeposits [since, until] 内的充值记录，按时间升序；currency 为空时返回全部币种
+func (c *Client) GetDeposits(ctx context.Context, currency string, since, until time.Time) ([]models.Deposit, error) {
+	since, until, err := models.HistoryRange(since, until, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	records, err := history(ctx, since, until, func(after, before int64) ([]*funding.DepositHistory, error) {
+		resp, err := c.rest(ctx).Funding.GetDepositHistory(requests.GetDepositHistory{Ccy: currency, After: after, Before: before, Limit: historyPage})
+		if err != nil {
+			return nil, restError(err)
+		}
+		if resp.Code != 0 {
+			return nil, sdkError(resp.Basic)
+		}
+		return resp.DepositHistories, nil
+	}, func(d *funding.DepositHistory) (string, time.Time) { return d.DepId, time.Time(d.TS) })
+	if err != nil {
+		return nil, err
+	}
+	deposits := make([]models.Deposit, 0, len(records))
+	for _, d := range records {
+		status := base.PROCESSING
+		switch d.State {
+		case sdk.DepositCredited, sdk.DepositSuccessful:
+			status = base.SUCCEEDED
+		case 11, 12, 13, 14:
+			// 地址黑名单、账户冻结、子账户拦截、KYC 限制，需要人工处理
+			status = base.FAILED
+		}
+		deposits = append(deposits, models.Deposit{
+			DepositID: d.DepId,
+			Currency:  d.Ccy,
+			Chain:     chainName(d.Ccy, d.Chain),
+			Amount:    models.ParseDecimalOrZero(d.Amt),
+			Address:   d.To,
+			TxID:      d.TxID,
+			Status:    status,
+			Time:      time.Time(d.TS).UnixMilli(),
+		})
+	}
+	sort.SliceStable(deposits, func(i, j int) bool { return deposits[i].Time < deposits[j].Time })
+	return deposits, nil
+}
+
+// history 充提记录从新到旧按 ts 翻页，直到某页不满或没有新记录。
+// after 取上一页最早的 ts + 1，同一毫秒跨页的记录按 id 去重
+func history[T any](ctx context.Context, since, until time.Time, page func(after, before int64) ([]T, error), key func(T) (string, time.Time)) ([]T, error) {
+	var (
+		records []T
+		seen    = make(map[string]bool)
+		after   = until.UnixMilli() + 1
+		before  = since.UnixMilli() - 1
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rows, err := page(after, before)
+		if err != nil {
+			return nil, err
+		}
+		added := false
+		for _, r := range rows {
+			id, ts := key(r)
+			if seen[id] {
+				continue
+			}
+			seen[id], added = true, true
+			records = append(records, r)
+			if ms := ts.UnixMilli() + 1; ms < after {
+				after = ms
+			}
+		}
+		if len(rows) < historyPage || !added {
+			return records, nil
+		}
+	}
+}
+
+// Transfer 资金账户和交易账户之间划转，返回 transId
+func (c *Client) Transfer(ctx context.Context, req models.TransferRequest) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
+	}
+	from, to := accountTypes[req.From], accountTypes[req.To]
+	if from == to {
+		return models.Transfer{}, fmt.Errorf("%w: %s and %s share the trading account", base.ErrNotSupported, req.From, req.To)
+	}
+	if err := ctx.Err(); err != nil {
+		return models.Transfer{}, err
+	}
+	id, err := c.fundsTransfer(requests.FundsTransfer{Ccy: req.Currency, Amt: req.Amount.String(), From: from, To: to})
+	if err != nil {
+		return models.Transfer{}, err
+	}
+	return models.Transfer{TransferID: id, Currency: req.Currency, Amount: req.Amount, From: req.From, To: req.To}, nil
+}
+
+func (c *Client) fundsTransfer(req requests.FundsTransfer) (string, error) {
+	resp, err := c.API().Rest.Funding.FundsTransfer(req)
+	if err != nil {
+		return "", restError(err)
+	}
+	if resp.Code != 0 || len(resp.Transfers) == 0 {
+		return "", sdkError(resp.Basic)
+	}
+	return resp.Transfers[0].TransID, nil
+}
@@ -0,0 +1,209 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetChains(t *testing.T) {
+	c, srv := newFakeClient(t)
+	chains, err := c.GetChains(context.Background(), "USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chains) != 2 {
+		t.Fatalf("chains = %+v", chains)
+	}
+	trc := chains[0]
+	if trc.Chain != "TRC20" || !trc.CanWithdraw || trc.WithdrawFee.String() != "1" || trc.MaxWithdrawFee.String() != "2" ||
+		trc.MinWithdraw.String() != "2" || trc.WithdrawStep.String() != "0.000001" || trc.MinDeposit.String() != "0.00000001" {
+		t.Fatalf("trc20 = %+v", trc)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/asset/currencies")[0].Query; q.Get("ccy") != "USDT" {
+		t.Fatalf("query = %v", q)
+	}
+
+	fee, err := c.CurrencyInfo("USDT", "ERC20")
+	if err != nil || fee != "3.2" {
+		t.Fatalf("fee = %s err = %v", fee, err)
+	}
+	if _, err := c.CurrencyInfo("USDT", "SOL"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("unknown chain err = %v", err)
+	}
+}
+
+func TestSubmitWithdrawal(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	req := models.WithdrawRequest{Currency: "USDT", Chain: "TRC20", Address: "TXeQW3", Amount: models.ParseDecimalOrZero("100")}
+	w, err := c.SubmitWithdrawal(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.WithdrawID != "67485" || w.Status != base.PROCESSING || w.Fee.String() != "1" {
+		t.Fatalf("withdrawal = %+v", w)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/asset/withdrawal")[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["chain"] != "USDT-TRC20" || body["amt"] != "100" || body["fee"] != "1" || body["dest"] != "4" || body["toAddr"] != "TXeQW3" {
+		t.Fatalf("body = %v", body)
+	}
+
+	// ERC20 暂停提币，数量低于最小值
+	req.Chain = "ERC20"
+	if _, err := c.SubmitWithdrawal(ctx, req); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("suspended err = %v", err)
+	}
+	req.Chain, req.Amount = "TRC20", models.ParseDecimalOrZero("1")
+	if _, err := c.SubmitWithdrawal(ctx, req); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("minimum err = %v", err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/asset/withdrawal")); n != 1 {
+		t.Fatalf("withdrawal requests = %d", n)
+	}
+}
+
+func TestWithdrawalHistory(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	w, err := c.GetWithdrawal(ctx, "USDT", "67480")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Status != base.SUCCEEDED || w.Chain != "TRC20" || w.Amount.String() != "50" || w.TxID == "" || !w.Done() {
+		t.Fatalf("withdrawal = %+v", w)
+	}
+	if q := srv.Requests(http.MethodGet, "/api/v5/asset/withdrawal-history")[0].Query; q.Get("wdId") != "67480" || q.Get("ccy") != "USDT" {
+		t.Fatalf("query = %v", q)
+	}
+	if _, err := c.GetWithdrawal(ctx, "USDT", "1"); !errors.Is(err, base.ErrWithdrawNotFound) {
+		t.Fatalf("not found err = %v", err)
+	}
+
+	since, until := time.UnixMilli(1699990000000), time.UnixMilli(1700010000000)
+	ws, err := c.GetWithdrawals(ctx, "USDT", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ws) != 2 || ws[0].WithdrawID != "67480" || ws[1].Status != base.PROCESSING {
+		t.Fatalf("withdrawals = %+v", ws)
+	}
+	q := srv.Requests(http.MethodGet, "/api/v5/asset/withdrawal-history")[2].Query
+	if q.Get("after") != "1700010000001" || q.Get("before") != "1699989999999" || q.Get("limit") != "100" {
+		t.Fatalf("query = %v", q)
+	}
+
+	deposits, err := c.GetDeposits(ctx, "USDT", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 2 || deposits[0].DepositID != "88165462" || deposits[0].Status != base.SUCCEEDED || deposits[1].Status != base.PROCESSING {
+		t.Fatalf("deposits = %+v", deposits)
+	}
+}
+
+func TestDepositHistoryPaginates(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// 第一页满 100 条（ts 从新到旧），第二页与第一页最后一条同一毫秒的记录重复返回，加上 1 条更早的
+	srv.HandleFunc(http.MethodGet, "/api/v5/asset/deposit-history", func(w http.ResponseWriter, r *http.Request) {
+		ids := make([]int, 0, historyPage)
+		if r.URL.Query().Get("after") == "1700000000902" {
+			ids = append(ids, 901, 900)
+		} else {
+			for id := 1000; id > 1000-historyPage; id-- {
+				ids = append(ids, id)
+			}
+		}
+		var data []string
+		for _, id := range ids {
+			data = append(data, fmt.Sprintf(`{"ccy":"USDT","chain":"USDT-TRC20","depId":"%d","amt":"1","state":"2","ts":"%d"}`, id, 1700000000000+int64(id)))
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[` + strings.Join(data, ",") + `]}`))
+	})
+	deposits, err := c.GetDeposits(context.Background(), "", time.UnixMilli(1700000000000), time.UnixMilli(1700000001000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 101 || deposits[0].DepositID != "900" || deposits[100].DepositID != "1000" {
+		t.Fatalf("deposits = %d, first %+v", len(deposits), deposits[0])
+	}
+	if reqs := srv.Requests(http.MethodGet, "/api/v5/asset/deposit-history"); len(reqs) != 2 || reqs[0].Query.Get("ccy") != "" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	c, srv := newFakeClient(t)
+	ctx := context.Background()
+	tr, err := c.Transfer(ctx, models.TransferRequest{Currency: "USDT", Amount: models.ParseDecimalOrZero("100"), From: base.SPOT, To: base.FUNDING})
+	if err != nil || tr.TransferID != "754147" {
+		t.Fatalf("transfer = %+v err = %v", tr, err)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(srv.Requests(http.MethodPost, "/api/v5/asset/transfer")[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["from"] != "18" || body["to"] != "6" || body["ccy"] != "USDT" || body["amt"] != "100" {
+		t.Fatalf("body = %v", body)
+	}
+	if _, err := c.Transfer(ctx, models.TransferRequest{Currency: "USDT", Amount: models.ParseDecimalOrZero("1"), From: base.SPOT, To: base.FUTURES}); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("spot to futures err = %v", err)
+	}
+	if id, err := c.InnerTrans("USDT", "5", "6", "18"); err != nil || id != "754147" {
+		t.Fatalf("inner transfer = %s err = %v", id, err)
+	}
+	if _, err := c.InnerTrans("USDT", "5", "funding", "18"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("bad account err = %v", err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/asset/transfer")); n != 2 {
+		t.Fatalf("transfer requests = %d", n)
+	}
+}
+
+func TestWithdrawTransfersShortfall(t *testing.T) {
+	c, srv := newFakeClient(t)
+
+	// 100 加手续费 1，资金账户可用 40，从交易账户划入 61
+	id, err := c.Withdraw("USDT", "TRC20", "TXeQW3", "100")
+	if err != nil || id != "67485" {
+		t.Fatalf("id = %s err = %v", id, err)
+	}
+	transfers := srv.Requests(http.MethodPost, "/api/v5/asset/transfer")
+	if len(transfers) != 1 {
+		t.Fatalf("transfers = %d", len(transfers))
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(transfers[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["amt"] != "61" || fmt.Sprint(body["from"]) != "18" || fmt.Sprint(body["to"]) != "6" {
+		t.Fatalf("transfer = %v", body)
+	}
+
+	// 资金账户余额足够时不划转
+	if _, err := c.Withdraw("USDT", "TRC20", "TXeQW3", "39"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/asset/transfer")); n != 1 {
+		t.Fatalf("transfers = %d", n)
+	}
+
+	// 划转失败时不提币
+	srv.Handle(http.MethodPost, "/api/v5/asset/transfer", http.StatusOK, `{"code":"58350","msg":"Insufficient balance","data":[]}`)
+	if _, err := c.Withdraw("USDT", "TRC20", "TXeQW3", "100"); err == nil {
+		t.Fatal("withdraw after failed transfer succeeded")
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/asset/withdrawal")); n != 2 {
+		t.Fatalf("withdrawal requests = %d", n)
+	}
+}
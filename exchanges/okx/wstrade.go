@@ -0,0 +1,69 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/okx/sdk"
+	"AxonTrading/exchanges/okx/sdk/api/ws"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// websocket 下单、撤单。WsTrade 为 true 时下单（placeOrder）和 CancelOrder/CancelFutureOrder 先经私有
+// websocket 发送，与推送订阅共用连接；请求没有发出（连接、登录失败或正在重连）时改走 REST。
+// 发出后连接中断或超时则结果不确定，返回 base.ErrUnknownStatus，下单由 placeOrder 按 clOrdId 对账
+
+// wsTradeTimeout 等待 websocket 响应的时间
+const wsTradeTimeout = 5 * time.Second
+
+// errWsUnavailable 没有经 websocket 发出请求，调用方改走 REST
+var errWsUnavailable = errors.New("okx: websocket trade unavailable")
+
+// wsTrade 经 websocket 发送交易请求并返回响应原文，响应 code 不为 0 时同时返回 responseError
+func (c *Client) wsTrade(op sdk.Operation, args interface{}) ([]byte, error) {
+	if !c.WsTrade {
+		return nil, errWsUnavailable
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), wsTradeTimeout)
+	defer cancel()
+	data, err := c.streamer().ws.Request(ctx, true, op, "", args)
+	switch {
+	case errors.Is(err, ws.ErrNotSent):
+		return nil, fmt.Errorf("%w: %v", errWsUnavailable, err)
+	case errors.Is(err, ws.ErrConnectionLost):
+		return nil, fmt.Errorf("%w: %v", base.ErrUnknownStatus, err)
+	case err != nil:
+		// 超时为 context.DeadlineExceeded，同样视为结果不确定
+		return nil, err
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return data, err
+	}
+	if body.Code != "0" {
+		return data, responseError(0, data)
+	}
+	return data, nil
+}
+
+// submitOrdersWs 经 websocket 下单，单个订单为 order，多个为 batch-orders
+func (c *Client) submitOrdersWs(req []PlaceOrder) (response PlaceOrderResp, err error) {
+	op := sdk.OrderOperation
+	if len(req) > 1 {
+		op = sdk.BatchOrderOperation
+	}
+	data, err := c.wsTrade(op, req)
+	if data != nil {
+		_ = json.Unmarshal(data, &response)
+	}
+	return response, err
+}
+
+// cancelOrderWs 经 websocket 撤单，返回响应原文，格式与 REST 的 cancel-order 相同
+func (c *Client) cancelOrderWs(instID, ordID string) ([]byte, error) {
+	return c.wsTrade(sdk.CancelOrderOperation, []map[string]string{{"instId": instID, "ordId": ordID}})
+}
@@ -0,0 +1,63 @@
+package okx
+
+import (
+	"AxonTrading/base"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWsTradeOrderAndCancel(t *testing.T) {
+	c, srv := newFakeClient(t)
+	url, ops := newPushServer(t, map[string]string{
+		"order":        `{"id":"{id}","op":"order","code":"0","msg":"","data":[{"clOrdId":"{clOrdId}","ordId":"ws-1","tag":"","sCode":"0","sMsg":""}]}`,
+		"cancel-order": `{"id":"{id}","op":"cancel-order","code":"1","msg":"","data":[{"clOrdId":"","ordId":"ws-1","sCode":"51400","sMsg":"Order does not exist"}]}`,
+	})
+	c.WsPrivateURL, c.WsTrade = url, true
+	t.Cleanup(func() { c.streamer().ws.Cancel() })
+
+	id, err := c.LimitOrder("BTC-USDT", base.BID, "100", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "ws-1" {
+		t.Fatalf("order id = %s", id)
+	}
+	if op := nextOp(t, ops); op != "order" {
+		t.Fatalf("op = %s", op)
+	}
+	// 撤单失败的 sCode 按 REST 相同的方式映射
+	if _, err := c.CancelFutureOrder("BTC-USDT-SWAP", "ws-1"); !errors.Is(err, base.ErrOrderNotFound) {
+		t.Fatalf("cancel err = %v", err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/trade/order")) + len(srv.Requests(http.MethodPost, "/api/v5/trade/cancel-order")); n != 0 {
+		t.Fatalf("rest requests = %d", n)
+	}
+}
+
+func TestWsTradeFallsBackToRest(t *testing.T) {
+	c, srv := newFakeClient(t)
+	// REST 地址不接受 websocket 升级，请求没有发出，改走 REST
+	c.WsPrivateURL, c.WsTrade = "ws"+strings.TrimPrefix(c.BaseUrl, "http"), true
+	t.Cleanup(func() { c.streamer().ws.Cancel() })
+	srv.HandleFunc(http.MethodPost, "/api/v5/trade/order", func(w http.ResponseWriter, r *http.Request) {
+		var o PlaceOrder
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &o)
+		fmt.Fprintf(w, `{"code":"0","msg":"","data":[{"clOrdId":%q,"ordId":"rest-1","sCode":"0","sMsg":""}]}`, o.ClOrdID)
+	})
+
+	if id, err := c.LimitOrder("BTC-USDT", base.BID, "100", "1"); err != nil || id != "rest-1" {
+		t.Fatalf("order = %s %v", id, err)
+	}
+	if ok, err := c.CancelOrder("BTC-USDT", "1"); err != nil || !ok {
+		t.Fatalf("cancel = %v %v", ok, err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/trade/cancel-order")); n != 1 {
+		t.Fatalf("rest cancels = %d", n)
+	}
+}
@@ -0,0 +1,69 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"sort"
+)
+
+// GetAccount 现货余额按 /USDT 盘口的最新价估值，没有盘口的币种 USDValue 为 0；
+// 期货账户为 USDT 钱包，模拟交易所没有维持保证金，MarginRatio 恒为 0
+func (c *Client) GetAccount(ctx context.Context) (models.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Account{}, err
+	}
+	c.lock()
+	defer c.mu.Unlock()
+	acct := models.Account{Mode: base.AccountSingleCurrency, DualSide: c.dual, Time: c.millis()}
+	for name, b := range c.balances {
+		if b.free.IsZero() && b.locked.IsZero() {
+			continue
+		}
+		equity := b.free.Add(b.locked)
+		acct.Balances = append(acct.Balances, models.AssetBalance{
+			Account:  base.SPOT,
+			Asset:    name,
+			Free:     b.free,
+			Locked:   b.locked,
+			Equity:   equity,
+			USDValue: c.usdValue(name, equity),
+		})
+	}
+	sort.Slice(acct.Balances, func(i, j int) bool { return acct.Balances[i].Asset < acct.Balances[j].Asset })
+
+	upl, initial := c.futureMargins()
+	if !c.wallet.IsZero() || !initial.IsZero() {
+		equity := c.wallet.Add(upl)
+		acct.Balances = append(acct.Balances, models.AssetBalance{
+			Account:       base.FUTURES,
+			Asset:         "USDT",
+			Free:          equity.Sub(initial),
+			Locked:        initial,
+			Equity:        equity,
+			USDValue:      equity,
+			InitialMargin: initial,
+		})
+		acct.InitialMargin = initial
+	}
+	for _, b := range acct.Balances {
+		acct.TotalEquity = acct.TotalEquity.Add(b.USDValue)
+	}
+	return acct, nil
+}
+
+// usdValue amount 个 asset 按 asset/USDT 最新价折算的美元价值
+func (c *Client) usdValue(asset string, amount models.Decimal) models.Decimal {
+	if models.IsUSDStable(asset) {
+		return amount
+	}
+	b, ok := c.spot[asset+"/USDT"]
+	if !ok {
+		return models.Decimal{}
+	}
+	px, err := b.price()
+	if err != nil {
+		return models.Decimal{}
+	}
+	return amount.Mul(px)
+}
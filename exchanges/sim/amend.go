@@ -0,0 +1,111 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// AmendOrder 修改挂单的价格和数量，newPrice、newSize 为空表示不变，newSize 为含已成交部分的总数量。
+// 现货和期货订单都按订单号查找；现货按新的价格和数量重新冻结资金，期货改单后可用保证金不能为负。
+// 新价格穿过外部深度时立即按 taker 成交
+func (c *Client) AmendOrder(ctx context.Context, symbol, id, newPrice, newSize string) (models.OrderInfo, error) {
+	if newPrice == "" && newSize == "" {
+		return models.OrderInfo{}, fmt.Errorf("%w: nothing to amend", models.ErrInvalidRequest)
+	}
+	c.lock()
+	defer c.mu.Unlock()
+
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+	n, err := strconv.Atoi(id)
+	o, ok := c.orders[n]
+	if err != nil || !ok || o.book.key != key {
+		return models.OrderInfo{}, fmt.Errorf("%w: %q", base.ErrOrderNotFound, id)
+	}
+	if !o.active() {
+		return models.OrderInfo{}, fmt.Errorf("%w: order %s is %s", base.ErrOrderNotFound, id, o.status)
+	}
+	if o.price.IsZero() {
+		return models.OrderInfo{}, fmt.Errorf("%w: cannot amend %s order", models.ErrInvalidRequest, o.typ)
+	}
+	px, qty := o.price, o.qty
+	if newPrice != "" {
+		if px, err = models.RequireDecimal("price", newPrice); err != nil {
+			return models.OrderInfo{}, err
+		}
+		if px.Sign() <= 0 {
+			return models.OrderInfo{}, fmt.Errorf("%w: price must be positive", models.ErrInvalidRequest)
+		}
+	}
+	if newSize != "" {
+		if qty, err = models.RequireDecimal("size", newSize); err != nil {
+			return models.OrderInfo{}, err
+		}
+		if !qty.GreaterThan(o.filled) {
+			return models.OrderInfo{}, fmt.Errorf("%w: size %s not above filled %s", models.ErrInvalidRequest, qty, o.filled)
+		}
+	}
+	b := o.book
+	if o.typ == base.MAKER && b.wouldTake(o.side, px) {
+		return models.OrderInfo{}, fmt.Errorf("%w: post-only order would take liquidity", models.ErrInvalidRequest)
+	}
+
+	oldPx, oldQty := o.price, o.qty
+	c.release(o)
+	o.price, o.qty = px, qty
+	if err := c.amendable(o); err != nil {
+		o.price, o.qty = oldPx, oldQty
+		_ = c.freeze(o)
+		return models.OrderInfo{}, err
+	}
+	o.update = c.millis()
+
+	if o.matchable() {
+		for _, f := range b.take(o.side, o.price, o.remaining(), true) {
+			c.fill(o, f.price, f.qty, false)
+		}
+		b.prune()
+	}
+	return o.info(), nil
+}
+
+// amendable 现货冻结改单后的资金，期货检查可用保证金
+func (c *Client) amendable(o *order) error {
+	if !o.book.future {
+		return c.freeze(o)
+	}
+	if avail := c.available(); avail.Sign() < 0 {
+		return fmt.Errorf("%w: available margin %s after amend", base.ErrInsufficientFunds, avail)
+	}
+	return nil
+}
+
+// freeze 冻结现货限价单未成交部分需要的资金，与 release 相对
+func (c *Client) freeze(o *order) error {
+	if o.book.future {
+		return nil
+	}
+	left := o.remaining()
+	if o.side == base.BID {
+		quote := c.asset(o.book.quote)
+		need := o.price.Mul(left)
+		if quote.free.LessThan(need) {
+			return fmt.Errorf("%w: %s free %s < %s", base.ErrInsufficientFunds, o.book.quote, quote.free, need)
+		}
+		quote.free = quote.free.Sub(need)
+		quote.locked = quote.locked.Add(need)
+		return nil
+	}
+	bal := c.asset(o.book.base)
+	if bal.free.LessThan(left) {
+		return fmt.Errorf("%w: %s free %s < %s", base.ErrInsufficientFunds, o.book.base, bal.free, left)
+	}
+	bal.free = bal.free.Sub(left)
+	bal.locked = bal.locked.Add(left)
+	return nil
+}
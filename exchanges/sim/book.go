@@ -0,0 +1,315 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// order 模拟订单，现货和期货共用
+type order struct {
+	id     int
+	symbol string // 下单时调用方传入的符号，查询时原样返回
+	book   *book
+	side   string
+	typ    string
+	price  models.Decimal // 市价单为 0
+	qty    models.Decimal
+	ice    models.Decimal // 冰山单每次展示的数量
+
+	filled models.Decimal
+	quote  models.Decimal // 累计成交额
+	fee    models.Decimal
+	feeAs  string
+	status string
+	time   int64
+	update int64
+
+	// 期货
+	positionSide  string
+	stopPrice     models.Decimal
+	triggered     bool
+	closePosition bool
+	priceProtect  bool
+}
+
+func (o *order) remaining() models.Decimal {
+	return o.qty.Sub(o.filled)
+}
+
+func (o *order) active() bool {
+	return o.status == base.OPEN || o.status == base.PARTIALLY
+}
+
+func isStop(typ string) bool {
+	return typ == base.STOP || typ == base.STOPMARKET || typ == base.TAKEPROFIT || typ == base.TAKEPROFITMARKET
+}
+
+// matchable 是否可以作为挂单被成交：有价格，且止盈止损单已触发
+func (o *order) matchable() bool {
+	return o.active() && !o.price.IsZero() && (!isStop(o.typ) || o.triggered)
+}
+
+// crosses 挂单价是否优于等于 px
+func (o *order) crosses(px models.Decimal) bool {
+	if o.side == base.BID {
+		return o.price.Cmp(px) >= 0
+	}
+	return o.price.Cmp(px) <= 0
+}
+
+func (o *order) info() models.OrderInfo {
+	return models.OrderInfo{
+		OrderID:  strconv.Itoa(o.id),
+		Symbol:   o.symbol,
+		Side:     o.side,
+		Price:    o.price,
+		Quantity: o.qty,
+		Type:     o.typ,
+		Filled:   o.filled,
+		USDT:     o.quote,
+		Status:   o.status,
+		Time:     o.time,
+	}
+}
+
+// book 一个币对的盘口：外部流动性加上用户挂单
+type book struct {
+	key    string
+	base   string
+	quote  string // 现货计价币，期货保证金币种
+	future bool
+
+	bids []models.PriceLevel // 价格从高到低
+	asks []models.PriceLevel // 价格从低到高
+	last models.Decimal
+
+	resting []*order // 用户挂单，按下单顺序
+}
+
+type fill struct {
+	price models.Decimal
+	qty   models.Decimal
+}
+
+func (b *book) setDepth(bids, asks []models.PriceLevel) {
+	b.bids = append([]models.PriceLevel(nil), bids...)
+	b.asks = append([]models.PriceLevel(nil), asks...)
+	sort.SliceStable(b.bids, func(i, j int) bool { return b.bids[i].Price.GreaterThan(b.bids[j].Price) })
+	sort.SliceStable(b.asks, func(i, j int) bool { return b.asks[i].Price.LessThan(b.asks[j].Price) })
+}
+
+// opposite 吃单方向对应的外部深度
+func (b *book) opposite(side string) *[]models.PriceLevel {
+	if side == base.BID {
+		return &b.asks
+	}
+	return &b.bids
+}
+
+// wouldTake 以 price 挂单是否会立即吃掉外部深度
+func (b *book) wouldTake(side string, price models.Decimal) bool {
+	levels := *b.opposite(side)
+	if len(levels) == 0 {
+		return false
+	}
+	if side == base.BID {
+		return levels[0].Price.Cmp(price) <= 0
+	}
+	return levels[0].Price.Cmp(price) >= 0
+}
+
+// take 按价格优先吃外部深度，limit 为 0 表示市价不限价；consume 为 false 时只试算不改盘口
+func (b *book) take(side string, limit, qty models.Decimal, consume bool) []fill {
+	levels := b.opposite(side)
+	var fills []fill
+	i := 0
+	for ; i < len(*levels) && qty.Sign() > 0; i++ {
+		lv := &(*levels)[i]
+		if !limit.IsZero() {
+			if side == base.BID && lv.Price.GreaterThan(limit) || side == base.ASK && lv.Price.LessThan(limit) {
+				break
+			}
+		}
+		n := lv.Quantity
+		if qty.LessThan(n) {
+			n = qty
+		}
+		fills = append(fills, fill{price: lv.Price, qty: n})
+		qty = qty.Sub(n)
+		if consume {
+			lv.Quantity = lv.Quantity.Sub(n)
+			if lv.Quantity.Sign() > 0 {
+				break
+			}
+		}
+	}
+	if consume {
+		for len(*levels) > 0 && (*levels)[0].Quantity.Sign() <= 0 {
+			*levels = (*levels)[1:]
+		}
+		if len(fills) > 0 {
+			b.last = fills[len(fills)-1].price
+		}
+	}
+	return fills
+}
+
+// queue 可成交的挂单，按价格优先、时间优先排序
+func (b *book) queue(side string) []*order {
+	var q []*order
+	for _, o := range b.resting {
+		if o.side == side && o.matchable() {
+			q = append(q, o)
+		}
+	}
+	sort.SliceStable(q, func(i, j int) bool {
+		if q[i].price.Equal(q[j].price) {
+			return q[i].id < q[j].id
+		}
+		if side == base.BID {
+			return q[i].price.GreaterThan(q[j].price)
+		}
+		return q[i].price.LessThan(q[j].price)
+	})
+	return q
+}
+
+// prune 把已结束的订单移出挂单列表
+func (b *book) prune() {
+	rest := b.resting[:0]
+	for _, o := range b.resting {
+		if o.active() {
+			rest = append(rest, o)
+		}
+	}
+	for i := len(rest); i < len(b.resting); i++ {
+		b.resting[i] = nil
+	}
+	b.resting = rest
+}
+
+// price 最新成交价，没有成交时取盘口中间价
+func (b *book) price() (models.Decimal, error) {
+	if !b.last.IsZero() {
+		return b.last, nil
+	}
+	if len(b.bids) > 0 && len(b.asks) > 0 {
+		return b.bids[0].Price.Add(b.asks[0].Price).Div(models.NewDecimalFromInt(2), 16)
+	}
+	if len(b.bids) > 0 {
+		return b.bids[0].Price, nil
+	}
+	if len(b.asks) > 0 {
+		return b.asks[0].Price, nil
+	}
+	return models.Decimal{}, fmt.Errorf("%w: %s", ErrNoMarketData, b.key)
+}
+
+// depth 合并外部深度和非隐藏的用户挂单，limit 不大于 0 时返回全部档位
+func (b *book) depth(limit int, now int64) models.WsData {
+	merge := func(side string, ext []models.PriceLevel, better func(a, b models.Decimal) bool) []models.PriceLevel {
+		levels := append([]models.PriceLevel(nil), ext...)
+		for _, o := range b.resting {
+			if o.side != side || !o.matchable() || o.typ == base.LIMITHIDDEN {
+				continue
+			}
+			n := o.remaining()
+			if o.typ == base.ICEBERG && !o.ice.IsZero() && o.ice.LessThan(n) {
+				n = o.ice
+			}
+			merged := false
+			for i := range levels {
+				if levels[i].Price.Equal(o.price) {
+					levels[i].Quantity = levels[i].Quantity.Add(n)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				levels = append(levels, models.PriceLevel{Price: o.price, Quantity: n})
+			}
+		}
+		sort.SliceStable(levels, func(i, j int) bool { return better(levels[i].Price, levels[j].Price) })
+		if limit > 0 && len(levels) > limit {
+			levels = levels[:limit]
+		}
+		return levels
+	}
+	return models.WsData{
+		Time: now,
+		Bids: merge(base.BID, b.bids, models.Decimal.GreaterThan),
+		Asks: merge(base.ASK, b.asks, models.Decimal.LessThan),
+	}
+}
+
+// fill 记一笔成交，按现货或期货结算
+func (c *Client) fill(o *order, px, qty models.Decimal, maker bool) {
+	fee := o.fee
+	if o.book.future {
+		c.fillFuture(o, px, qty, maker)
+	} else {
+		c.fillSpot(o, px, qty, maker)
+	}
+	o.filled = o.filled.Add(qty)
+	o.quote = o.quote.Add(px.Mul(qty))
+	o.update = c.millis()
+	c.record(o, px, qty, o.fee.Sub(fee), maker)
+	if o.remaining().Sign() <= 0 {
+		o.status = base.FILLED
+	} else {
+		o.status = base.PARTIALLY
+	}
+}
+
+// sweep 新深度注入后，穿价的挂单按挂单价吃掉外部深度
+func (c *Client) sweep(b *book) {
+	for _, side := range []string{base.BID, base.ASK} {
+		for _, o := range b.queue(side) {
+			for o.active() && b.wouldTake(o.side, o.price) {
+				fs := b.take(o.side, o.price, o.remaining(), true)
+				if len(fs) == 0 {
+					break
+				}
+				for _, f := range fs {
+					c.fill(o, o.price, f.qty, true)
+				}
+			}
+		}
+	}
+	b.prune()
+}
+
+// trade 市场成交 price/size：成交价更优的挂单按挂单价成交，然后触发止盈止损单
+func (c *Client) trade(b *book, price, size string) error {
+	px, err := models.RequireDecimal("price", price)
+	if err != nil {
+		return err
+	}
+	qty, err := models.RequireDecimal("size", size)
+	if err != nil {
+		return err
+	}
+	b.last = px
+	for _, side := range []string{base.BID, base.ASK} {
+		left := qty
+		for _, o := range b.queue(side) {
+			if left.Sign() <= 0 || !o.crosses(px) {
+				break
+			}
+			n := o.remaining()
+			if left.LessThan(n) {
+				n = left
+			}
+			c.fill(o, o.price, n, true)
+			left = left.Sub(n)
+		}
+	}
+	b.prune()
+	if b.future {
+		c.trigger(b, px)
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+// Package sim 是内存撮合的模拟交易所，实现 store/exchange.Exchange，用于离线、可复现地测试策略和各交易所的调用方。
+//
+// 现货和 U 本位永续各有一套盘口。外部流动性由 SetDepth / SetFutureDepth 注入，
+// 用户订单先吃外部深度（taker），剩余部分挂单；挂单在 Trade / FutureTrade 打出成交价、
+// 或新注入的深度穿过挂单价时按挂单价成交（maker）。时间取自 Client.Now，订单号按下单顺序递增。
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoMarketData 币对还没有注入深度或成交价
+var ErrNoMarketData = errors.New("sim: no market data")
+
+// 未配置时使用的默认值
+var (
+	DefaultMakerFee       = models.NewDecimal(1, 3) // 0.001
+	DefaultTakerFee       = models.NewDecimal(1, 3) // 0.001
+	DefaultFutureMakerFee = models.NewDecimal(2, 4) // 0.0002
+	DefaultFutureTakerFee = models.NewDecimal(5, 4) // 0.0005
+	DefaultLeverage       = 20
+	// FundingInterval 资金费结算周期
+	FundingInterval = 8 * time.Hour
+)
+
+// Config 是 New / NewFuture 的参数，字段都可省略
+//
+//	{"makerFee":"0.001","takerFee":"0.001","balances":{"USDT":"10000"},"futureBalance":"10000","leverage":20}
+type Config struct {
+	MakerFee       string            `json:"makerFee"`
+	TakerFee       string            `json:"takerFee"`
+	FutureMakerFee string            `json:"futureMakerFee"`
+	FutureTakerFee string            `json:"futureTakerFee"`
+	Balances       map[string]string `json:"balances"`
+	FutureBalance  string            `json:"futureBalance"`
+	Leverage       int               `json:"leverage"`
+}
+
+// Client 模拟交易所，零值可用，所有方法并发安全
+type Client struct {
+	// Now 模拟时钟，为空时使用 time.Now
+	Now func() time.Time
+
+	mu sync.Mutex
+
+	makerFee       models.Decimal
+	takerFee       models.Decimal
+	futureMakerFee models.Decimal
+	futureTakerFee models.Decimal
+
+	balances map[string]*balance
+	pairs    map[string]models.PairInfo
+	spot     map[string]*book
+	futures  map[string]*book
+	orders   map[int]*order
+	fills    []fillRecord
+	nextID   int
+	nextWd   int
+
+	// 期货账户，U 本位，保证金币种为币对的计价币
+	wallet     models.Decimal
+	dual       bool
+	positions  map[posKey]*position
+	leverage   map[string]int
+	marginType map[string]string
+	marks      map[string]models.Decimal
+	funding    map[string]models.Decimal
+	defaultLev int
+}
+
+type balance struct {
+	free   models.Decimal
+	locked models.Decimal
+}
+
+// lock 加锁并在第一次使用时初始化状态
+func (c *Client) lock() {
+	c.mu.Lock()
+	if c.orders == nil {
+		c.makerFee, c.takerFee = DefaultMakerFee, DefaultTakerFee
+		c.futureMakerFee, c.futureTakerFee = DefaultFutureMakerFee, DefaultFutureTakerFee
+		c.balances = make(map[string]*balance)
+		c.pairs = make(map[string]models.PairInfo)
+		c.spot = make(map[string]*book)
+		c.futures = make(map[string]*book)
+		c.orders = make(map[int]*order)
+		c.positions = make(map[posKey]*position)
+		c.leverage = make(map[string]int)
+		c.marginType = make(map[string]string)
+		c.marks = make(map[string]models.Decimal)
+		c.funding = make(map[string]models.Decimal)
+		c.defaultLev = DefaultLeverage
+		c.nextID = 1
+		c.nextWd = 1
+	}
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Client) millis() int64 {
+	return c.now().UnixMilli()
+}
+
+func (c *Client) New(params []byte) error {
+	c.lock()
+	defer c.mu.Unlock()
+	return c.configure(params)
+}
+
+func (c *Client) NewFuture(params []byte) error {
+	c.lock()
+	defer c.mu.Unlock()
+	return c.configure(params)
+}
+
+// Has 模拟交易所支持全部可选功能
+func (c *Client) Has(capability string) bool {
+	switch capability {
+	case base.CapWithdraw, base.CapIceberg, base.CapHiddenOrder, base.CapFeeFromFilled, base.CapMarginType:
+		return true
+	}
+	return false
+}
+
+// configure 应用配置，现货和期货共用同一个模拟账户
+func (c *Client) configure(params []byte) error {
+	if len(params) == 0 {
+		return nil
+	}
+	var cfg Config
+	if err := json.Unmarshal(params, &cfg); err != nil {
+		return err
+	}
+	fees := []struct {
+		name string
+		s    string
+		dst  *models.Decimal
+	}{
+		{"makerFee", cfg.MakerFee, &c.makerFee},
+		{"takerFee", cfg.TakerFee, &c.takerFee},
+		{"futureMakerFee", cfg.FutureMakerFee, &c.futureMakerFee},
+		{"futureTakerFee", cfg.FutureTakerFee, &c.futureTakerFee},
+	}
+	for _, f := range fees {
+		if f.s == "" {
+			continue
+		}
+		d, err := models.RequireDecimal(f.name, f.s)
+		if err != nil {
+			return err
+		}
+		*f.dst = d
+	}
+	for asset, s := range cfg.Balances {
+		d, err := models.RequireDecimal("balances."+asset, s)
+		if err != nil {
+			return err
+		}
+		c.asset(asset).free = d
+	}
+	if cfg.FutureBalance != "" {
+		d, err := models.RequireDecimal("futureBalance", cfg.FutureBalance)
+		if err != nil {
+			return err
+		}
+		c.wallet = d
+	}
+	if cfg.Leverage > 0 {
+		c.defaultLev = cfg.Leverage
+	}
+	return nil
+}
+
+func (c *Client) asset(name string) *balance {
+	name = strings.ToUpper(name)
+	b, ok := c.balances[name]
+	if !ok {
+		b = &balance{}
+		c.balances[name] = b
+	}
+	return b
+}
+
+// pair 拆分统一符号或原生符号，返回 base、quote 和内部使用的盘口键 BASE/QUOTE
+func pair(symbol string) (string, string, string, error) {
+	var parts []string
+	if instrument.IsUnified(symbol) {
+		s, err := instrument.Parse(symbol)
+		if err != nil {
+			return "", "", "", err
+		}
+		parts = []string{s.Base, s.Quote}
+	} else {
+		parts = instrument.SplitNative(symbol)
+	}
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("%w: %q", base.ErrInvalidSymbol, symbol)
+	}
+	return parts[0], parts[1], parts[0] + "/" + parts[1], nil
+}
+
+func (c *Client) spotBook(symbol string) (*book, error) {
+	baseAsset, quote, key, err := pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := c.spot[key]
+	if !ok {
+		b = &book{key: key, base: baseAsset, quote: quote}
+		c.spot[key] = b
+	}
+	return b, nil
+}
+
+func (c *Client) futureBook(symbol string) (*book, error) {
+	baseAsset, quote, key, err := pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := c.futures[key]
+	if !ok {
+		b = &book{key: key, base: baseAsset, quote: quote, future: true}
+		c.futures[key] = b
+	}
+	return b, nil
+}
+
+// SetBalance 设置现货币种的可用余额
+func (c *Client) SetBalance(asset, free string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	d, err := models.RequireDecimal("free", free)
+	if err != nil {
+		return err
+	}
+	c.asset(asset).free = d
+	return nil
+}
+
+// SetFutureBalance 设置期货账户钱包余额
+func (c *Client) SetFutureBalance(amount string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	d, err := models.RequireDecimal("amount", amount)
+	if err != nil {
+		return err
+	}
+	c.wallet = d
+	return nil
+}
+
+// SetPairInfo 设置 GetPairInfo 返回的交易规则
+func (c *Client) SetPairInfo(symbol string, info models.PairInfo) error {
+	c.lock()
+	defer c.mu.Unlock()
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return err
+	}
+	c.pairs[key] = info
+	return nil
+}
+
+// SetDepth 替换现货外部深度，穿价的挂单立即按挂单价成交
+func (c *Client) SetDepth(symbol string, bids, asks []models.PriceLevel) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return err
+	}
+	b.setDepth(bids, asks)
+	c.sweep(b)
+	return nil
+}
+
+// SetFutureDepth 替换期货外部深度，穿价的挂单立即按挂单价成交
+func (c *Client) SetFutureDepth(symbol string, bids, asks []models.PriceLevel) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return err
+	}
+	b.setDepth(bids, asks)
+	c.sweep(b)
+	return nil
+}
+
+// Trade 模拟现货市场打出一笔成交，价格更优的挂单按挂单价成交，最多成交 size
+func (c *Client) Trade(symbol, price, size string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return err
+	}
+	return c.trade(b, price, size)
+}
+
+// FutureTrade 模拟期货市场打出一笔成交，同时按成交价触发止盈止损单
+func (c *Client) FutureTrade(symbol, price, size string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return err
+	}
+	return c.trade(b, price, size)
+}
+
+// SetMarkPrice 设置期货标记价格，未设置时使用最新成交价
+func (c *Client) SetMarkPrice(symbol, price string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return err
+	}
+	d, err := models.RequireDecimal("price", price)
+	if err != nil {
+		return err
+	}
+	c.marks[key] = d
+	return nil
+}
+
+// SetFundingRate 设置当前资金费率
+func (c *Client) SetFundingRate(symbol, rate string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return err
+	}
+	d, err := models.RequireDecimal("rate", rate)
+	if err != nil {
+		return err
+	}
+	c.funding[key] = d
+	return nil
+}
+
+// SettleFunding 按标记价格和当前资金费率结算一次资金费：费率为正时多头付给空头
+func (c *Client) SettleFunding(symbol string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return err
+	}
+	mark, err := c.markPrice(b)
+	if err != nil {
+		return err
+	}
+	rate := c.funding[b.key]
+	for k, p := range c.positions {
+		if k.key != b.key || p.amt.IsZero() {
+			continue
+		}
+		funding := p.amt.Mul(mark).Mul(rate)
+		c.wallet = c.wallet.Sub(funding)
+		p.realized = p.realized.Sub(funding)
+		p.update = c.millis()
+	}
+	return nil
+}
+
+func (c *Client) GetTradingFee(symbol string) (models.TradingFee, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	baseAsset, quote, key, err := pair(symbol)
+	if err != nil {
+		return models.TradingFee{}, err
+	}
+	fee := models.TradingFee{
+		Symbol:          symbol,
+		TakerFeeFromApi: c.takerFee,
+		MakerFeeFromApi: c.makerFee,
+	}
+	until := c.now()
+	fee.SetRealFees(c.filter(key, false, until.Add(-models.DefaultFillWindow), until), baseAsset, quote, models.NewDecimalFromInt(1))
+	return fee, nil
+}
+
+func (c *Client) GetFutureTradingFee(symbol string) (models.TradingFee, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	baseAsset, quote, key, err := pair(symbol)
+	if err != nil {
+		return models.TradingFee{}, err
+	}
+	fee := models.TradingFee{
+		Symbol:          symbol,
+		TakerFeeFromApi: c.futureTakerFee,
+		MakerFeeFromApi: c.futureMakerFee,
+	}
+	until := c.now()
+	fee.SetRealFees(c.filter(key, true, until.Add(-models.DefaultFillWindow), until), baseAsset, quote, models.NewDecimalFromInt(1))
+	return fee, nil
+}
+
+// GetPairInfo 返回 SetPairInfo 设置的规则，未设置时为 8 位精度
+func (c *Client) GetPairInfo(symbol string) (models.PairInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return models.PairInfo{}, err
+	}
+	if info, ok := c.pairs[key]; ok {
+		return info, nil
+	}
+	step := models.NewDecimal(1, 8)
+	return models.PairInfo{
+		AmountPrecision: 8,
+		Precision:       8,
+		TickSize:        step,
+		LotSize:         step,
+		MinSize:         step,
+	}, nil
+}
+
+func (c *Client) GetDepositAddress(token, chain string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("%w: empty token", models.ErrInvalidRequest)
+	}
+	return "sim-" + strings.ToLower(token) + "-" + strings.ToLower(chain), nil
+}
+
+// Withdraw 从现货可用余额中扣除提币数量，返回提币单号
+func (c *Client) Withdraw(token, chain, to, amount string) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	if token == "" || to == "" {
+		return "", fmt.Errorf("%w: withdraw needs token and address", models.ErrInvalidRequest)
+	}
+	d, err := models.RequireDecimal("amount", amount)
+	if err != nil {
+		return "", err
+	}
+	if d.Sign() <= 0 {
+		return "", fmt.Errorf("%w: amount must be positive", models.ErrInvalidRequest)
+	}
+	bal := c.asset(token)
+	if bal.free.LessThan(d) {
+		return "", fmt.Errorf("%w: %s free %s < %s", base.ErrInsufficientFunds, strings.ToUpper(token), bal.free, d)
+	}
+	bal.free = bal.free.Sub(d)
+	id := fmt.Sprintf("W%d", c.nextWd)
+	c.nextWd++
+	return id, nil
+}
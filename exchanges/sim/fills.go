@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"AxonTrading/instrument"
+	"AxonTrading/models"
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// fillRecord 一笔成交，future 区分现货和期货盘口（两者的 key 相同）
+type fillRecord struct {
+	key    string
+	future bool
+	fill   models.Fill
+}
+
+// record 记录成交，成交 id 按成交顺序递增
+func (c *Client) record(o *order, px, qty, fee models.Decimal, maker bool) {
+	c.fills = append(c.fills, fillRecord{key: o.book.key, future: o.book.future, fill: models.Fill{
+		Symbol:   o.symbol,
+		OrderID:  strconv.Itoa(o.id),
+		TradeID:  strconv.Itoa(len(c.fills) + 1),
+		Side:     o.side,
+		Price:    px,
+		Quantity: qty,
+		Fee:      fee,
+		FeeAsset: o.feeAs,
+		Maker:    maker,
+		Time:     c.millis(),
+	}})
+}
+
+// GetFills 返回 [since, until] 内的成交，按时间升序；since 为零时取 until 前 24 小时，until 为零时取 Now。
+// 统一符号按类型区分现货和期货，旧格式的符号返回两者的成交
+func (c *Client) GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	_, _, key, err := pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if since, until, err = models.FillRange(since, until, c.now()); err != nil {
+		return nil, err
+	}
+	if !instrument.IsUnified(symbol) {
+		fills := append(c.filter(key, false, since, until), c.filter(key, true, since, until)...)
+		sort.SliceStable(fills, func(i, j int) bool { return fills[i].Time < fills[j].Time })
+		return fills, nil
+	}
+	sym, err := instrument.Parse(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return c.filter(key, sym.Kind != instrument.Spot, since, until), nil
+}
+
+func (c *Client) filter(key string, future bool, since, until time.Time) []models.Fill {
+	var fills []models.Fill
+	for _, r := range c.fills {
+		if r.key == key && r.future == future && r.fill.Time >= since.UnixMilli() && r.fill.Time <= until.UnixMilli() {
+			fills = append(fills, r.fill)
+		}
+	}
+	return fills
+}
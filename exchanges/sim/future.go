@@ -0,0 +1,756 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// bothSide 单向持仓模式下的持仓方向，与 binance 的 BOTH 一致
+const bothSide = "BOTH"
+
+// futureTypes 期货支持的订单类型
+var futureTypes = map[string]bool{
+	base.LIMIT:            true,
+	base.MARKET:           true,
+	base.STOP:             true,
+	base.STOPMARKET:       true,
+	base.TAKEPROFIT:       true,
+	base.TAKEPROFITMARKET: true,
+}
+
+func isMarketType(typ string) bool {
+	return typ == base.MARKET || typ == base.STOPMARKET || typ == base.TAKEPROFITMARKET
+}
+
+type posKey struct {
+	key  string
+	side string // base.LONG / base.SHORT / bothSide
+}
+
+// position 持仓，多头 amt 为正，空头为负
+type position struct {
+	amt    models.Decimal
+	entry  models.Decimal
+	margin models.Decimal // 逐仓追加的保证金
+	update int64
+
+	open     int64          // 开仓时间，反手时重新计算
+	realized models.Decimal // 开仓以来的已实现盈亏，含手续费和资金费
+}
+
+// apply 按成交调整持仓，返回减仓部分的已实现盈亏
+func (p *position) apply(delta, px models.Decimal) models.Decimal {
+	if p.amt.IsZero() || p.amt.Sign() == delta.Sign() {
+		// 加仓，开仓均价按数量加权
+		size := p.amt.Abs().Add(delta.Abs())
+		p.entry, _ = p.entry.Mul(p.amt.Abs()).Add(px.Mul(delta.Abs())).Div(size, 16)
+		p.amt = p.amt.Add(delta)
+		return models.Decimal{}
+	}
+	closing := delta.Abs()
+	if p.amt.Abs().LessThan(closing) {
+		closing = p.amt.Abs()
+	}
+	pnl := px.Sub(p.entry).Mul(closing)
+	if p.amt.Sign() < 0 {
+		pnl = pnl.Neg()
+	}
+	flipped := delta.Abs().GreaterThan(p.amt.Abs())
+	p.amt = p.amt.Add(delta)
+	switch {
+	case p.amt.IsZero():
+		p.entry, p.margin = models.Decimal{}, models.Decimal{}
+	case flipped:
+		p.entry, p.margin = px, models.Decimal{}
+	}
+	return pnl
+}
+
+func (c *Client) position(k posKey) *position {
+	p, ok := c.positions[k]
+	if !ok {
+		p = &position{}
+		c.positions[k] = p
+	}
+	return p
+}
+
+func (c *Client) lev(key string) int {
+	if l, ok := c.leverage[key]; ok {
+		return l
+	}
+	return c.defaultLev
+}
+
+func (c *Client) margin(key string) string {
+	if t, ok := c.marginType[key]; ok {
+		return t
+	}
+	return base.CROSSED
+}
+
+// markPrice 标记价格，未设置时使用最新成交价
+func (c *Client) markPrice(b *book) (models.Decimal, error) {
+	if m, ok := c.marks[b.key]; ok {
+		return m, nil
+	}
+	return b.price()
+}
+
+// initialMargin notional / leverage
+func (c *Client) initialMargin(key string, notional models.Decimal) models.Decimal {
+	m, _ := notional.Abs().Div(models.NewDecimalFromInt(int64(c.lev(key))), 16)
+	return m
+}
+
+// futureMargins 期货账户的未实现盈亏和占用的保证金（持仓保证金 + 逐仓追加保证金 + 挂单占用保证金）
+func (c *Client) futureMargins() (upl, initial models.Decimal) {
+	for k, p := range c.positions {
+		if p.amt.IsZero() {
+			continue
+		}
+		mark := p.entry
+		if b, ok := c.futures[k.key]; ok {
+			if m, err := c.markPrice(b); err == nil {
+				mark = m
+			}
+		}
+		upl = upl.Add(p.amt.Mul(mark.Sub(p.entry)))
+		initial = initial.Add(c.initialMargin(k.key, p.amt.Mul(mark))).Add(p.margin)
+	}
+	for _, b := range c.futures {
+		for _, o := range b.resting {
+			if !o.active() || o.price.IsZero() || c.closing(o) {
+				continue
+			}
+			initial = initial.Add(c.initialMargin(b.key, o.price.Mul(o.remaining())))
+		}
+	}
+	return upl, initial
+}
+
+// available 可用保证金 = 钱包余额 + 未实现盈亏 - 占用的保证金
+func (c *Client) available() models.Decimal {
+	upl, initial := c.futureMargins()
+	return c.wallet.Add(upl).Sub(initial)
+}
+
+// closing 订单是否只减仓
+func (c *Client) closing(o *order) bool {
+	if o.closePosition {
+		return true
+	}
+	switch o.positionSide {
+	case base.LONG:
+		return o.side == base.ASK
+	case base.SHORT:
+		return o.side == base.BID
+	}
+	return false
+}
+
+// opening 订单中会增加敞口的数量
+func (c *Client) opening(o *order) models.Decimal {
+	if c.closing(o) {
+		return models.Decimal{}
+	}
+	qty := o.remaining()
+	if o.positionSide != bothSide {
+		return qty
+	}
+	p := c.position(posKey{o.book.key, bothSide})
+	if p.amt.IsZero() || (p.amt.Sign() > 0) == (o.side == base.BID) {
+		return qty
+	}
+	if qty.LessThan(p.amt.Abs()) {
+		return models.Decimal{}
+	}
+	return qty.Sub(p.amt.Abs())
+}
+
+// closable 平仓单可以平掉的数量
+func (c *Client) closable(o *order) models.Decimal {
+	p := c.position(posKey{o.book.key, o.positionSide})
+	if p.amt.IsZero() {
+		return models.Decimal{}
+	}
+	// 卖单平多，买单平空
+	if (p.amt.Sign() > 0) != (o.side == base.ASK) {
+		return models.Decimal{}
+	}
+	return p.amt.Abs()
+}
+
+func (c *Client) NewFutureOrder(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+
+	if side != base.BID && side != base.ASK {
+		return "", fmt.Errorf("%w: unknown side %q", models.ErrInvalidRequest, side)
+	}
+	if !futureTypes[typ] {
+		return "", fmt.Errorf("%w: unknown order type %q", models.ErrInvalidRequest, typ)
+	}
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return "", err
+	}
+	pSide := bothSide
+	if c.dual {
+		if positionSide != base.LONG && positionSide != base.SHORT {
+			return "", fmt.Errorf("%w: dual position mode needs position side, got %q", models.ErrInvalidRequest, positionSide)
+		}
+		pSide = positionSide
+	}
+	var qty, px, stop models.Decimal
+	if !closePosition {
+		if qty, err = models.RequireDecimal("size", size); err != nil {
+			return "", err
+		}
+		if qty.Sign() <= 0 {
+			return "", fmt.Errorf("%w: size must be positive", models.ErrInvalidRequest)
+		}
+	}
+	if !isMarketType(typ) {
+		if px, err = models.RequireDecimal("price", price); err != nil {
+			return "", err
+		}
+		if px.Sign() <= 0 {
+			return "", fmt.Errorf("%w: price must be positive", models.ErrInvalidRequest)
+		}
+	}
+	if isStop(typ) {
+		if stop, err = models.RequireDecimal("stopPrice", stopPrice); err != nil {
+			return "", err
+		}
+		if stop.Sign() <= 0 {
+			return "", fmt.Errorf("%w: stop price must be positive", models.ErrInvalidRequest)
+		}
+	}
+	if positionType == base.ISOLATED || positionType == base.CROSSED {
+		if !c.hasExposure(b.key) {
+			c.marginType[b.key] = positionType
+		}
+	}
+
+	o := &order{
+		symbol:        symbol,
+		book:          b,
+		side:          side,
+		typ:           typ,
+		price:         px,
+		qty:           qty,
+		status:        base.OPEN,
+		time:          c.millis(),
+		positionSide:  pSide,
+		stopPrice:     stop,
+		closePosition: closePosition,
+		priceProtect:  priceProtect,
+	}
+	o.update = o.time
+	if closePosition {
+		if o.qty = c.closable(o); o.qty.IsZero() {
+			return "", fmt.Errorf("%w: no %s position to close", models.ErrInvalidRequest, pSide)
+		}
+	} else if pSide != bothSide && c.closing(o) && c.closable(o).LessThan(qty) {
+		return "", fmt.Errorf("%w: reduce size %s exceeds %s position", models.ErrInvalidRequest, qty, pSide)
+	}
+	if open := c.opening(o); open.Sign() > 0 {
+		ref := px
+		if ref.IsZero() {
+			ref = stop
+		}
+		if ref.IsZero() {
+			if ref, err = b.price(); err != nil {
+				return "", err
+			}
+		}
+		need := c.initialMargin(b.key, ref.Mul(open)).Add(ref.Mul(qty).Mul(c.futureTakerFee))
+		if avail := c.available(); avail.LessThan(need) {
+			return "", fmt.Errorf("%w: available margin %s < %s", base.ErrInsufficientFunds, avail, need)
+		}
+	}
+
+	o.id = c.nextID
+	c.nextID++
+	c.orders[o.id] = o
+	if isStop(typ) {
+		b.resting = append(b.resting, o)
+	} else {
+		c.execFuture(b, o)
+	}
+	return strconv.Itoa(o.id), nil
+}
+
+// execFuture 吃外部深度，限价单剩余部分挂单，市价单剩余部分撤销
+func (c *Client) execFuture(b *book, o *order) {
+	if o.closePosition {
+		if left := c.closable(o); left.LessThan(o.remaining()) {
+			o.qty = o.filled.Add(left)
+		}
+	}
+	for _, f := range b.take(o.side, o.price, o.remaining(), true) {
+		c.fill(o, f.price, f.qty, false)
+	}
+	if !o.active() {
+		return
+	}
+	if o.price.IsZero() || o.remaining().Sign() <= 0 {
+		o.status = base.CANCELED
+		o.update = c.millis()
+		return
+	}
+	b.resting = append(b.resting, o)
+}
+
+// fillFuture 期货成交结算，手续费和已实现盈亏计入钱包余额
+func (c *Client) fillFuture(o *order, px, qty models.Decimal, maker bool) {
+	rate := c.futureTakerFee
+	if maker {
+		rate = c.futureMakerFee
+	}
+	fee := px.Mul(qty).Mul(rate)
+	delta := qty
+	if o.side == base.ASK {
+		delta = delta.Neg()
+	}
+	p := c.position(posKey{o.book.key, o.positionSide})
+	prev := p.amt
+	pnl := p.apply(delta, px)
+	c.wallet = c.wallet.Sub(fee).Add(pnl)
+	p.update = c.millis()
+	if prev.IsZero() || (!p.amt.IsZero() && p.amt.Sign() != prev.Sign()) {
+		// 新开仓或反手，平掉的旧仓位的盈亏不计入
+		p.open, p.realized = p.update, fee.Neg()
+	} else {
+		p.realized = p.realized.Add(pnl).Sub(fee)
+	}
+	o.fee = o.fee.Add(fee)
+	o.feeAs = o.book.quote
+}
+
+// triggers 止损买单和止盈卖单在价格上涨到触发价时触发，另外两种在下跌时触发
+func triggers(o *order, px models.Decimal) bool {
+	stopLoss := o.typ == base.STOP || o.typ == base.STOPMARKET
+	if stopLoss == (o.side == base.BID) {
+		return px.Cmp(o.stopPrice) >= 0
+	}
+	return px.Cmp(o.stopPrice) <= 0
+}
+
+// trigger 按最新成交价触发止盈止损单，按下单顺序执行
+func (c *Client) trigger(b *book, px models.Decimal) {
+	var fired []*order
+	rest := b.resting[:0]
+	for _, o := range b.open() {
+		if isStop(o.typ) && !o.triggered && o.active() && triggers(o, px) {
+			o.triggered = true
+			fired = append(fired, o)
+			continue
+		}
+		rest = append(rest, o)
+	}
+	b.resting = rest
+	for _, o := range fired {
+		c.execFuture(b, o)
+	}
+}
+
+// hasExposure 币对上是否有持仓或期货挂单
+func (c *Client) hasExposure(key string) bool {
+	for k, p := range c.positions {
+		if k.key == key && !p.amt.IsZero() {
+			return true
+		}
+	}
+	if b, ok := c.futures[key]; ok && len(b.resting) > 0 {
+		return true
+	}
+	return false
+}
+
+func (c *Client) futureInfo(o *order) models.FutureOrderInfo {
+	var avg models.Decimal
+	if !o.filled.IsZero() {
+		avg, _ = o.quote.Div(o.filled, 16)
+	}
+	pSide := o.positionSide
+	var tif string
+	if !isMarketType(o.typ) {
+		tif = "GTC"
+	}
+	return models.FutureOrderInfo{
+		AvgPrice:      avg,
+		CumQuote:      o.quote,
+		ExecutedQty:   o.filled,
+		OrderId:       o.id,
+		OrigQty:       o.qty,
+		OrigType:      o.typ,
+		Price:         o.price,
+		ReduceOnly:    c.closing(o),
+		Side:          o.side,
+		PositionSide:  pSide,
+		Status:        o.status,
+		StopPrice:     o.stopPrice,
+		ClosePosition: o.closePosition,
+		Symbol:        o.symbol,
+		Time:          o.time,
+		TimeInForce:   tif,
+		Type:          o.typ,
+		UpdateTime:    o.update,
+		PriceProtect:  o.priceProtect,
+	}
+}
+
+func (c *Client) GetFutureOrder(symbol, orderID string) (models.FutureOrderInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return models.FutureOrderInfo{}, err
+	}
+	o, err := c.lookup(b, orderID)
+	if err != nil {
+		return models.FutureOrderInfo{}, err
+	}
+	return c.futureInfo(o), nil
+}
+
+func (c *Client) CancelFutureOrder(symbol, orderID string) (bool, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return false, err
+	}
+	return c.cancel(b, orderID)
+}
+
+func (c *Client) CancelFutureOrders(symbol string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return err
+	}
+	for _, o := range b.open() {
+		if _, err := c.cancel(b, strconv.Itoa(o.id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetFutureOpenOrders(symbol string) ([]models.FutureOrderInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var opens []models.FutureOrderInfo
+	for _, o := range b.open() {
+		opens = append(opens, c.futureInfo(o))
+	}
+	return opens, nil
+}
+
+// GetFutureBalance 钱包余额和可用保证金，U 本位账户只有 USDT
+func (c *Client) GetFutureBalance() (models.FutureBalance, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	return models.FutureBalance{
+		Asset:            "USDT",
+		TotalBalance:     c.wallet,
+		CrossBalance:     c.wallet,
+		AvailableBalance: c.available(),
+	}, nil
+}
+
+func (c *Client) FutureDepth(symbol, limit string) (models.WsData, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return models.WsData{}, err
+	}
+	n, err := depthLimit(limit)
+	if err != nil {
+		return models.WsData{}, err
+	}
+	return b.depth(n, c.millis()), nil
+}
+
+func (c *Client) GetFutureMarketPrice(symbol string) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return "", err
+	}
+	px, err := b.price()
+	if err != nil {
+		return "", err
+	}
+	return px.String(), nil
+}
+
+func (c *Client) GetMarkPriceAndFundingRate(symbol string) (models.FundingRate, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return models.FundingRate{}, err
+	}
+	mark, err := c.markPrice(b)
+	if err != nil {
+		return models.FundingRate{}, err
+	}
+	now := c.now()
+	return models.FundingRate{
+		Symbol:               symbol,
+		MarkPrice:            mark,
+		IndexPrice:           mark,
+		EstimatedSettlePrice: mark,
+		LastFundingRate:      c.funding[b.key],
+		NextFundingTime:      now.Truncate(FundingInterval).Add(FundingInterval).UnixMilli(),
+		Time:                 now.UnixMilli(),
+	}, nil
+}
+
+// Dual 有持仓或挂单时不能切换持仓模式
+func (c *Client) Dual(dualSize bool) (bool, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	for key := range c.futures {
+		if c.hasExposure(key) {
+			return false, fmt.Errorf("%w: cannot change position mode with open positions or orders", models.ErrInvalidRequest)
+		}
+	}
+	c.dual = dualSize
+	return true, nil
+}
+
+func (c *Client) CheckDual() (bool, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	return c.dual, nil
+}
+
+// ChangeLeverage 返回 "杠杆倍数 symbol"，与 binance 一致
+func (c *Client) ChangeLeverage(symbol string, leverage int) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return "", err
+	}
+	if leverage < 1 || leverage > 125 {
+		return "", fmt.Errorf("%w: leverage %d out of range", models.ErrInvalidRequest, leverage)
+	}
+	c.leverage[b.key] = leverage
+	return strconv.Itoa(leverage) + " " + symbol, nil
+}
+
+// ChangeMarginType 有持仓或挂单时不能切换
+func (c *Client) ChangeMarginType(symbol, typ string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return err
+	}
+	if typ != base.ISOLATED && typ != base.CROSSED {
+		return fmt.Errorf("%w: unknown margin type %q", models.ErrInvalidRequest, typ)
+	}
+	if c.margin(b.key) == typ {
+		return nil
+	}
+	if c.hasExposure(b.key) {
+		return fmt.Errorf("%w: cannot change margin type with open positions or orders", models.ErrInvalidRequest)
+	}
+	c.marginType[b.key] = typ
+	return nil
+}
+
+// ChangePositionMargin 调整逐仓保证金，typ 为 base.ADDMARGIN 或 base.REMOVEMARGIN
+func (c *Client) ChangePositionMargin(symbol, positionSide, amount string, typ int) (bool, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.futureBook(symbol)
+	if err != nil {
+		return false, err
+	}
+	if c.margin(b.key) != base.ISOLATED {
+		return false, fmt.Errorf("%w: %s is not isolated", models.ErrInvalidRequest, symbol)
+	}
+	pSide := bothSide
+	if c.dual {
+		pSide = positionSide
+	}
+	p, ok := c.positions[posKey{b.key, pSide}]
+	if !ok || p.amt.IsZero() {
+		return false, fmt.Errorf("%w: no %s position", models.ErrInvalidRequest, pSide)
+	}
+	amt, err := models.RequireDecimal("amount", amount)
+	if err != nil {
+		return false, err
+	}
+	if amt.Sign() <= 0 {
+		return false, fmt.Errorf("%w: amount must be positive", models.ErrInvalidRequest)
+	}
+	switch typ {
+	case base.ADDMARGIN:
+		if avail := c.available(); avail.LessThan(amt) {
+			return false, fmt.Errorf("%w: available margin %s < %s", base.ErrInsufficientFunds, avail, amt)
+		}
+		p.margin = p.margin.Add(amt)
+	case base.REMOVEMARGIN:
+		if p.margin.LessThan(amt) {
+			return false, fmt.Errorf("%w: added margin %s < %s", base.ErrInsufficientFunds, p.margin, amt)
+		}
+		p.margin = p.margin.Sub(amt)
+	default:
+		return false, fmt.Errorf("%w: unknown margin change type %d", models.ErrInvalidRequest, typ)
+	}
+	p.update = c.millis()
+	return true, nil
+}
+
+// GetPositionRisk symbol 为空时返回所有非零持仓；否则按持仓模式返回该币对的 BOTH 或 long/short 两条
+func (c *Client) GetPositionRisk(symbol string) ([]models.PositionInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	var keys []posKey
+	if symbol == "" {
+		for k, p := range c.positions {
+			if !p.amt.IsZero() {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].key != keys[j].key {
+				return keys[i].key < keys[j].key
+			}
+			return keys[i].side < keys[j].side
+		})
+	} else {
+		b, err := c.futureBook(symbol)
+		if err != nil {
+			return nil, err
+		}
+		if c.dual {
+			keys = []posKey{{b.key, base.LONG}, {b.key, base.SHORT}}
+		} else {
+			keys = []posKey{{b.key, bothSide}}
+		}
+	}
+
+	var infos []models.PositionInfo
+	for _, k := range keys {
+		p := c.position(k)
+		mark := p.entry
+		if m, err := c.markPrice(c.futures[k.key]); err == nil {
+			mark = m
+		}
+		name := symbol
+		if name == "" {
+			name = k.key
+		}
+		marginType := c.margin(k.key)
+		var isolated models.Decimal
+		if marginType == base.ISOLATED && !p.amt.IsZero() {
+			isolated = c.initialMargin(k.key, p.amt.Mul(p.entry)).Add(p.margin)
+		}
+		infos = append(infos, models.PositionInfo{
+			Symbol:           name,
+			PositionAmt:      p.amt,
+			EntryPrice:       p.entry,
+			MarkPrice:        mark,
+			UnRealizedProfit: p.amt.Mul(mark.Sub(p.entry)),
+			Leverage:         models.NewDecimalFromInt(int64(c.lev(k.key))),
+			MarginType:       marginType,
+			IsolatedMargin:   isolated,
+			IsAutoAddMargin:  "false",
+			PositionSide:     k.side,
+			Notional:         p.amt.Mul(mark),
+			IsolatedWallet:   isolated,
+			UpdateTime:       p.update,
+		})
+	}
+	return infos, nil
+}
+
+// GetPositions 全部非零持仓，都是 U本位永续。Symbol 为统一符号，InstrumentID 为内部的盘口键；
+// 模拟交易所没有维持保证金和自动减仓，MaintMargin、MarginRatio 和 ADLRank 为 0
+func (c *Client) GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !filter.HasKind(models.PositionSwap) {
+		return nil, nil
+	}
+	keys := make(map[string]bool, len(filter.Symbols))
+	for _, s := range filter.Symbols {
+		_, _, key, err := pair(s)
+		if err != nil {
+			return nil, err
+		}
+		keys[key] = true
+	}
+	c.lock()
+	defer c.mu.Unlock()
+	var positions []models.Position
+	for k, p := range c.positions {
+		if p.amt.IsZero() || (len(keys) > 0 && !keys[k.key]) {
+			continue
+		}
+		b := c.futures[k.key]
+		mark := p.entry
+		if m, err := c.markPrice(b); err == nil {
+			mark = m
+		}
+		pos := models.Position{
+			Symbol:        k.key + ":" + b.quote,
+			InstrumentID:  k.key,
+			Kind:          models.PositionSwap,
+			Side:          base.LONG,
+			MarginType:    c.margin(k.key),
+			MarginAsset:   b.quote,
+			Quantity:      p.amt.Abs(),
+			Available:     p.amt.Abs(),
+			EntryPrice:    p.entry,
+			MarkPrice:     mark,
+			Leverage:      models.NewDecimalFromInt(int64(c.lev(k.key))),
+			Notional:      p.amt.Mul(mark).Abs(),
+			InitialMargin: c.initialMargin(k.key, p.amt.Mul(mark)),
+			UnrealizedPnl: p.amt.Mul(mark.Sub(p.entry)),
+			RealizedPnl:   p.realized,
+			OpenTime:      p.open,
+			UpdateTime:    p.update,
+		}
+		if p.amt.Sign() < 0 {
+			pos.Side = base.SHORT
+		}
+		if k.side != bothSide {
+			pos.PositionSide = k.side
+		}
+		pos.Margin = pos.InitialMargin
+		if pos.MarginType == base.ISOLATED {
+			pos.Margin = c.initialMargin(k.key, p.amt.Mul(p.entry)).Add(p.margin)
+		}
+		pos.MarginRatio = pos.IsolatedMarginRatio()
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].InstrumentID != positions[j].InstrumentID {
+			return positions[i].InstrumentID < positions[j].InstrumentID
+		}
+		return positions[i].PositionSide < positions[j].PositionSide
+	})
+	return positions, nil
+}
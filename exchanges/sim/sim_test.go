@@ -0,0 +1,395 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func levels(pairs ...string) []models.PriceLevel {
+	var ls []models.PriceLevel
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ls = append(ls, models.PriceLevel{
+			Price:    models.ParseDecimalOrZero(pairs[i]),
+			Quantity: models.ParseDecimalOrZero(pairs[i+1]),
+		})
+	}
+	return ls
+}
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	c := &Client{Now: func() time.Time { return time.Unix(1700000000, 0) }}
+	params := []byte(`{"makerFee":"0.001","takerFee":"0.002","balances":{"USDT":"10000","BTC":"1"},"futureBalance":"10000","leverage":10}`)
+	if err := c.New(params); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.NewFuture(params); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func wantBalance(t *testing.T, c *Client, asset, free, locked string) {
+	t.Helper()
+	b, err := c.GetAccountBalance(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != free || b[1] != locked {
+		t.Fatalf("%s balance = %v, want free %s locked %s", asset, b, free, locked)
+	}
+}
+
+func TestSpotTakerAndMaker(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.SetDepth("BTC/USDT", levels("99", "1"), levels("100", "0.5", "101", "1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 限价 100.5 买 1：吃掉 100 的 0.5，剩余 0.5 挂单
+	id, err := c.LimitOrder("BTC-USDT", base.BID, "100.5", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := c.GetOrder("BTCUSDT", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != base.PARTIALLY || o.Filled.String() != "0.5" || o.USDT.String() != "50" {
+		t.Fatalf("order = %+v", o)
+	}
+	// 成交 0.5@100 花费 50，剩余 0.5 按 100.5 冻结 50.25；taker 手续费 0.001 BTC
+	wantBalance(t, c, "USDT", "9899.75", "50.25")
+	wantBalance(t, c, "BTC", "1.499", "0")
+
+	depth, err := c.Depth("BTC/USDT", "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(depth.Bids) != 2 || depth.Bids[0].Price.String() != "100.5" || depth.Asks[0].Price.String() != "101" {
+		t.Fatalf("depth = %+v", depth)
+	}
+
+	// 市场在 100.2 成交，挂单按挂单价 100.5 作为 maker 成交
+	if err := c.Trade("BTC/USDT", "100.2", "3"); err != nil {
+		t.Fatal(err)
+	}
+	o, _ = c.GetOrder("BTC/USDT", id)
+	if o.Status != base.FILLED {
+		t.Fatalf("status = %s, want filled", o.Status)
+	}
+	wantBalance(t, c, "USDT", "9899.75", "0")
+	wantBalance(t, c, "BTC", "1.9985", "0")
+	fee, asset, err := c.GetFeeFromFilled("BTC/USDT", id)
+	if err != nil || fee != "0.0015" || asset != "BTC" {
+		t.Fatalf("fee = %s %s %v", fee, asset, err)
+	}
+	fills, err := c.GetFills(context.Background(), "BTC/USDT", time.Time{}, time.Time{})
+	if err != nil || len(fills) != 2 {
+		t.Fatalf("fills = %+v err = %v", fills, err)
+	}
+	if fills[0].Maker || fills[0].Price.String() != "100" || fills[0].Fee.String() != "0.001" ||
+		!fills[1].Maker || fills[1].Price.String() != "100.5" || fills[1].Fee.String() != "0.0005" || fills[1].OrderID != id {
+		t.Fatalf("fills = %+v", fills)
+	}
+	if fills, _ := c.GetFills(context.Background(), "BTC/USDT:USDT", time.Time{}, time.Time{}); len(fills) != 0 {
+		t.Fatalf("future fills = %+v", fills)
+	}
+	tf, err := c.GetTradingFee("BTC/USDT")
+	if err != nil || tf.TakerFeeFromRealOrder.String() != "0.002" || tf.MakerFeeFromRealOrder.String() != "0.001" {
+		t.Fatalf("trading fee = %+v err = %v", tf, err)
+	}
+	if px, _ := c.GetMarketPrice("BTC/USDT"); px != "100.2" {
+		t.Fatalf("market price = %s", px)
+	}
+}
+
+func TestSpotOrderRules(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.SetDepth("BTC/USDT", levels("99", "0.6"), levels("100", "1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.MakerOrder("BTC/USDT", base.BID, "100", "0.1"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("post-only err = %v", err)
+	}
+	if _, err := c.LimitOrder("BTC/USDT", base.ASK, "120", "2"); !errors.Is(err, base.ErrInsufficientFunds) {
+		t.Fatalf("oversell err = %v", err)
+	}
+
+	// IOC 吃不到的部分撤销并解冻
+	id, err := c.TakerOrder("BTC/USDT", base.ASK, "99", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, _ := c.GetOrder("BTC/USDT", id)
+	if o.Status != base.CANCELED || o.Filled.String() != "0.6" {
+		t.Fatalf("taker order = %+v", o)
+	}
+	// 卖出 0.6@99 得 59.4，手续费 0.1188 USDT
+	wantBalance(t, c, "BTC", "0.4", "0")
+	wantBalance(t, c, "USDT", "10059.2812", "0")
+
+	ids, err := c.LimitOrders("BTC/USDT", []models.OrderList{
+		{Side: base.BID, Price: "90", Size: "1"},
+		{Side: base.BID, Price: "91", Size: "1"},
+	})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("ids = %v err = %v", ids, err)
+	}
+	buys, sells, err := c.GetOpenSplitOrders("BTC/USDT")
+	if err != nil || len(buys) != 2 || len(sells) != 0 {
+		t.Fatalf("buys = %v sells = %v err = %v", buys, sells, err)
+	}
+	if ok, err := c.CancelOrder("BTC/USDT", ids[0]); !ok || err != nil {
+		t.Fatalf("cancel = %v %v", ok, err)
+	}
+	if _, err := c.CancelOrder("BTC/USDT", ids[0]); !errors.Is(err, base.ErrOrderNotFound) {
+		t.Fatalf("second cancel err = %v", err)
+	}
+	if err := c.CancelOrders("BTC/USDT"); err != nil {
+		t.Fatal(err)
+	}
+	wantBalance(t, c, "USDT", "10059.2812", "0")
+
+	if _, err := c.Withdraw("USDT", "TRC20", "addr", "59.2812"); err != nil {
+		t.Fatal(err)
+	}
+	wantBalance(t, c, "USDT", "10000", "0")
+}
+
+func TestFuturePositionAndFunding(t *testing.T) {
+	c := newTestClient(t)
+	sym := "ETH/USDT:USDT"
+	if err := c.SetFutureDepth(sym, levels("1999", "10"), levels("2000", "10")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.NewFutureOrder(sym, base.BID, "", base.MARKET, "1", "", "", base.CROSSED, false, false); err != nil {
+		t.Fatal(err)
+	}
+	pos, err := c.GetPositionRisk(sym)
+	if err != nil || len(pos) != 1 {
+		t.Fatalf("positions = %v err = %v", pos, err)
+	}
+	if pos[0].PositionAmt.String() != "1" || pos[0].EntryPrice.String() != "2000" || pos[0].PositionSide != "BOTH" {
+		t.Fatalf("position = %+v", pos[0])
+	}
+	// taker 手续费 2000 * 0.0005 = 1
+	bal, _ := c.GetFutureBalance()
+	if bal.TotalBalance.String() != "9999" {
+		t.Fatalf("wallet = %s", bal.TotalBalance)
+	}
+
+	// 多头在费率为正时支付资金费：1 * 2100 * 0.0001
+	if err := c.SetMarkPrice(sym, "2100"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetFundingRate(sym, "0.0001"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SettleFunding(sym); err != nil {
+		t.Fatal(err)
+	}
+	bal, _ = c.GetFutureBalance()
+	if bal.TotalBalance.String() != "9998.79" {
+		t.Fatalf("wallet after funding = %s", bal.TotalBalance)
+	}
+	// 已实现盈亏含开仓手续费和资金费
+	ps, err := c.GetPositions(context.Background(), models.PositionFilter{Symbols: []string{"ETHUSDT"}})
+	if err != nil || len(ps) != 1 {
+		t.Fatalf("positions = %+v err = %v", ps, err)
+	}
+	if p := ps[0]; p.Symbol != sym || p.Side != base.LONG || p.PositionSide != "" || p.Quantity.String() != "1" || p.UnrealizedPnl.String() != "100" ||
+		p.InitialMargin.String() != "210" || p.RealizedPnl.String() != "-1.21" || p.OpenTime != 1700000000000 {
+		t.Fatalf("position = %+v", p)
+	}
+	if ps, _ := c.GetPositions(context.Background(), models.PositionFilter{Kinds: []string{models.PositionOption}}); len(ps) != 0 {
+		t.Fatalf("options = %+v", ps)
+	}
+	fr, err := c.GetMarkPriceAndFundingRate(sym)
+	if err != nil || fr.MarkPrice.String() != "2100" || fr.NextFundingTime != 1700006400000 {
+		t.Fatalf("funding = %+v err = %v", fr, err)
+	}
+
+	// 止盈单在成交价涨到 2100 时触发，以 2100 限价吃掉盘口平仓
+	if err := c.SetFutureDepth(sym, levels("2100", "5"), levels("2101", "5")); err != nil {
+		t.Fatal(err)
+	}
+	id, err := c.NewFutureOrder(sym, base.ASK, "", base.TAKEPROFIT, "", "2100", "2100", "", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.FutureTrade(sym, "2100", "1"); err != nil {
+		t.Fatal(err)
+	}
+	o, err := c.GetFutureOrder(sym, id)
+	if err != nil || o.Status != base.FILLED || !o.ClosePosition || o.AvgPrice.String() != "2100" {
+		t.Fatalf("take profit = %+v err = %v", o, err)
+	}
+	// 盈利 100，手续费 2100 * 0.0005 = 1.05
+	bal, _ = c.GetFutureBalance()
+	if bal.TotalBalance.String() != "10097.74" {
+		t.Fatalf("wallet after close = %s", bal.TotalBalance)
+	}
+	pos, _ = c.GetPositionRisk(sym)
+	if !pos[0].PositionAmt.IsZero() {
+		t.Fatalf("position not closed: %+v", pos[0])
+	}
+}
+
+func TestFutureDualAndMargin(t *testing.T) {
+	c := newTestClient(t)
+	sym := "BTCUSDT"
+	if err := c.SetFutureDepth(sym, levels("49999", "10"), levels("50000", "10")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Dual(true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewFutureOrder(sym, base.BID, "", base.MARKET, "0.1", "", "", "", false, false); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("missing position side err = %v", err)
+	}
+	// 10 倍杠杆下 1 BTC 需要 5000 保证金，2.1 BTC 超出可用余额
+	if _, err := c.NewFutureOrder(sym, base.BID, base.LONG, base.MARKET, "2.1", "", "", "", false, false); !errors.Is(err, base.ErrInsufficientFunds) {
+		t.Fatalf("oversize err = %v", err)
+	}
+	if err := c.ChangeMarginType(sym, base.ISOLATED); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewFutureOrder(sym, base.BID, base.LONG, base.MARKET, "0.1", "", "", "", false, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewFutureOrder(sym, base.ASK, base.SHORT, base.LIMIT, "0.1", "49999", "", "", false, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Dual(false); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("dual switch with positions err = %v", err)
+	}
+	if err := c.ChangeMarginType(sym, base.CROSSED); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("margin type switch err = %v", err)
+	}
+	if ok, err := c.ChangePositionMargin(sym, base.LONG, "100", base.ADDMARGIN); !ok || err != nil {
+		t.Fatalf("add margin = %v %v", ok, err)
+	}
+
+	pos, err := c.GetPositionRisk(sym)
+	if err != nil || len(pos) != 2 {
+		t.Fatalf("positions = %v err = %v", pos, err)
+	}
+	long, short := pos[0], pos[1]
+	if long.PositionSide != base.LONG || long.PositionAmt.String() != "0.1" || long.IsolatedMargin.String() != "600" {
+		t.Fatalf("long = %+v", long)
+	}
+	if short.PositionSide != base.SHORT || short.PositionAmt.String() != "-0.1" || short.EntryPrice.String() != "49999" {
+		t.Fatalf("short = %+v", short)
+	}
+
+	// 平空单超过持仓数量被拒绝
+	if _, err := c.NewFutureOrder(sym, base.BID, base.SHORT, base.MARKET, "0.2", "", "", "", false, false); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("over-reduce err = %v", err)
+	}
+	if lev, err := c.ChangeLeverage(sym, 5); err != nil || lev != "5 BTCUSDT" {
+		t.Fatalf("leverage = %s %v", lev, err)
+	}
+}
+
+func TestAmendOrder(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	if err := c.SetDepth("BTC/USDT", levels("99", "1"), levels("100", "0.5", "101", "1")); err != nil {
+		t.Fatal(err)
+	}
+	id, err := c.LimitOrder("BTC/USDT", base.BID, "98", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 改价改量后按新的价格和数量冻结
+	o, err := c.AmendOrder(ctx, "BTCUSDT", id, "97", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OrderID != id || o.Price.String() != "97" || o.Quantity.String() != "2" || o.Status != base.OPEN {
+		t.Fatalf("order = %+v", o)
+	}
+	wantBalance(t, c, "USDT", "9806", "194")
+
+	// 资金不足时订单不变
+	if _, err := c.AmendOrder(ctx, "BTC/USDT", id, "", "200"); !errors.Is(err, base.ErrInsufficientFunds) {
+		t.Fatalf("err = %v", err)
+	}
+	wantBalance(t, c, "USDT", "9806", "194")
+
+	// 穿价后吃掉 100 的 0.5，剩余 1.5 按 100 冻结
+	if o, err = c.AmendOrder(ctx, "BTC/USDT", id, "100", ""); err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != base.PARTIALLY || o.Filled.String() != "0.5" {
+		t.Fatalf("order = %+v", o)
+	}
+	wantBalance(t, c, "USDT", "9800", "150")
+
+	if _, err := c.AmendOrder(ctx, "BTC/USDT", id, "", "0.5"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("amend to filled size err = %v", err)
+	}
+	if _, err := c.AmendOrder(ctx, "ETH/USDT", id, "99", ""); !errors.Is(err, base.ErrOrderNotFound) {
+		t.Fatalf("wrong symbol err = %v", err)
+	}
+
+	sym := "ETH/USDT:USDT"
+	if err := c.SetFutureDepth(sym, levels("1999", "10"), levels("2000", "10")); err != nil {
+		t.Fatal(err)
+	}
+	fid, err := c.NewFutureOrder(sym, base.BID, "", base.LIMIT, "1", "1990", "", base.CROSSED, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 100 * 1990 / 10 超过可用保证金
+	if _, err := c.AmendOrder(ctx, sym, fid, "", "100"); !errors.Is(err, base.ErrInsufficientFunds) {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := c.AmendOrder(ctx, sym, fid, "1995", "2"); err != nil {
+		t.Fatal(err)
+	}
+	fo, err := c.GetFutureOrder(sym, fid)
+	if err != nil || fo.Price.String() != "1995" || fo.OrigQty.String() != "2" || fo.Status != base.OPEN {
+		t.Fatalf("future order = %+v err = %v", fo, err)
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.SetDepth("BTC/USDT", levels("99", "1"), levels("100", "1")); err != nil {
+		t.Fatal(err)
+	}
+	sym := "ETH/USDT:USDT"
+	if err := c.SetFutureDepth(sym, levels("1999", "10"), levels("2000", "10")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewFutureOrder(sym, base.BID, "", base.MARKET, "1", "", "", base.CROSSED, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetMarkPrice(sym, "2100"); err != nil {
+		t.Fatal(err)
+	}
+	acct, err := c.GetAccount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acct.Balances) != 3 || acct.Balances[0].Asset != "BTC" || acct.Balances[0].USDValue.String() != "99.5" {
+		t.Fatalf("balances = %+v", acct.Balances)
+	}
+	// 钱包 9999，未实现盈亏 100，保证金 2100 / 10
+	fut := acct.Balance(base.FUTURES, "USDT")
+	if fut.Equity.String() != "10099" || fut.Locked.String() != "210" || fut.Free.String() != "9889" {
+		t.Fatalf("futures = %+v", fut)
+	}
+	if acct.TotalEquity.String() != "20198.5" || acct.InitialMargin.String() != "210" || !acct.MarginRatio.IsZero() || acct.Time != 1700000000000 {
+		t.Fatalf("account = %+v", acct)
+	}
+}
@@ -0,0 +1,395 @@
+package sim
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// resting 现货可以挂单的订单类型，其余类型未成交的部分直接撤销
+var resting = map[string]bool{
+	base.LIMIT:       true,
+	base.LIMITHIDDEN: true,
+	base.MAKER:       true,
+	base.ICEBERG:     true,
+}
+
+// GetAccountBalance 返回 [可用, 冻结, 总额]
+func (c *Client) GetAccountBalance(currency string) ([]string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b := c.asset(currency)
+	return []string{b.free.String(), b.locked.String(), b.free.Add(b.locked).String()}, nil
+}
+
+func (c *Client) MarketOrder(symbol, side, size string) (string, error) {
+	return c.placeSpot(symbol, side, base.MARKET, "", size, "")
+}
+
+func (c *Client) LimitOrder(symbol, side, price, size string) (string, error) {
+	return c.placeSpot(symbol, side, base.LIMIT, price, size, "")
+}
+
+func (c *Client) LimitHiddenOrder(symbol, side, price, size string) (string, error) {
+	return c.placeSpot(symbol, side, base.LIMITHIDDEN, price, size, "")
+}
+
+// MakerOrder 只挂单，会立即成交时拒绝
+func (c *Client) MakerOrder(symbol, side, price, size string) (string, error) {
+	return c.placeSpot(symbol, side, base.MAKER, price, size, "")
+}
+
+// TakerOrder 限价 IOC，未成交部分撤销
+func (c *Client) TakerOrder(symbol, side, price, size string) (string, error) {
+	return c.placeSpot(symbol, side, base.TAKER, price, size, "")
+}
+
+// IceBergOrder 冰山单按限价单撮合，深度中只展示 ice 数量
+func (c *Client) IceBergOrder(symbol, side, typ, price, size, ice string) (string, error) {
+	return c.placeSpot(symbol, side, base.ICEBERG, price, size, ice)
+}
+
+func (c *Client) LimitOrders(symbol string, ol []models.OrderList) ([]string, error) {
+	return c.placeSpotList(symbol, base.LIMIT, ol)
+}
+
+func (c *Client) MakerOrders(symbol string, ol []models.OrderList) ([]string, error) {
+	return c.placeSpotList(symbol, base.MAKER, ol)
+}
+
+func (c *Client) TakerOrders(symbol string, ol []models.OrderList) ([]string, error) {
+	return c.placeSpotList(symbol, base.TAKER, ol)
+}
+
+func (c *Client) LimitHiddenOrders(symbol string, ol []models.OrderList) ([]string, error) {
+	return c.placeSpotList(symbol, base.LIMITHIDDEN, ol)
+}
+
+// placeSpotList 逐个下单，遇到错误时返回已经下成功的订单号
+func (c *Client) placeSpotList(symbol, typ string, ol []models.OrderList) ([]string, error) {
+	ids := make([]string, 0, len(ol))
+	for _, o := range ol {
+		id, err := c.placeSpot(symbol, o.Side, typ, o.Price, o.Size, "")
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (c *Client) placeSpot(symbol, side, typ, price, size, ice string) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+
+	if side != base.BID && side != base.ASK {
+		return "", fmt.Errorf("%w: unknown side %q", models.ErrInvalidRequest, side)
+	}
+	qty, err := models.RequireDecimal("size", size)
+	if err != nil {
+		return "", err
+	}
+	if qty.Sign() <= 0 {
+		return "", fmt.Errorf("%w: size must be positive", models.ErrInvalidRequest)
+	}
+	var px models.Decimal
+	if typ != base.MARKET {
+		if px, err = models.RequireDecimal("price", price); err != nil {
+			return "", err
+		}
+		if px.Sign() <= 0 {
+			return "", fmt.Errorf("%w: price must be positive", models.ErrInvalidRequest)
+		}
+	}
+	var iceQty models.Decimal
+	if ice != "" {
+		if iceQty, err = models.RequireDecimal("ice", ice); err != nil {
+			return "", err
+		}
+	}
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return "", err
+	}
+	baseAsset, quoteAsset := b.base, b.quote
+	if typ == base.MAKER && b.wouldTake(side, px) {
+		return "", fmt.Errorf("%w: post-only order would take liquidity", models.ErrInvalidRequest)
+	}
+
+	// 冻结资金：限价买单按限价冻结计价币，卖单冻结基础币，市价买单按盘口试算
+	if side == base.BID {
+		quote := c.asset(quoteAsset)
+		need := px.Mul(qty)
+		if typ == base.MARKET {
+			need = models.Decimal{}
+			for _, f := range b.take(side, px, qty, false) {
+				need = need.Add(f.price.Mul(f.qty))
+			}
+		}
+		if quote.free.LessThan(need) {
+			return "", fmt.Errorf("%w: %s free %s < %s", base.ErrInsufficientFunds, quoteAsset, quote.free, need)
+		}
+		if typ != base.MARKET {
+			quote.free = quote.free.Sub(need)
+			quote.locked = quote.locked.Add(need)
+		}
+	} else {
+		bal := c.asset(baseAsset)
+		if bal.free.LessThan(qty) {
+			return "", fmt.Errorf("%w: %s free %s < %s", base.ErrInsufficientFunds, baseAsset, bal.free, qty)
+		}
+		bal.free = bal.free.Sub(qty)
+		bal.locked = bal.locked.Add(qty)
+	}
+
+	o := &order{
+		id:     c.nextID,
+		symbol: symbol,
+		book:   b,
+		side:   side,
+		typ:    typ,
+		price:  px,
+		qty:    qty,
+		ice:    iceQty,
+		status: base.OPEN,
+		time:   c.millis(),
+	}
+	o.update = o.time
+	c.nextID++
+	c.orders[o.id] = o
+
+	for _, f := range b.take(side, px, qty, true) {
+		c.fill(o, f.price, f.qty, false)
+	}
+	if o.active() {
+		if resting[typ] {
+			b.resting = append(b.resting, o)
+		} else {
+			c.release(o)
+			o.status = base.CANCELED
+		}
+	}
+	return strconv.Itoa(o.id), nil
+}
+
+// fillSpot 现货成交结算，手续费从收到的币中扣除
+func (c *Client) fillSpot(o *order, px, qty models.Decimal, maker bool) {
+	baseAsset, quoteAsset := o.book.base, o.book.quote
+	rate := c.takerFee
+	if maker {
+		rate = c.makerFee
+	}
+	notional := px.Mul(qty)
+	bal, quote := c.asset(baseAsset), c.asset(quoteAsset)
+	var fee models.Decimal
+	if o.side == base.BID {
+		if o.price.IsZero() {
+			quote.free = quote.free.Sub(notional)
+		} else {
+			// 按限价冻结的资金多出的部分退回
+			frozen := o.price.Mul(qty)
+			quote.locked = quote.locked.Sub(frozen)
+			quote.free = quote.free.Add(frozen.Sub(notional))
+		}
+		fee = qty.Mul(rate)
+		bal.free = bal.free.Add(qty.Sub(fee))
+		o.feeAs = baseAsset
+	} else {
+		bal.locked = bal.locked.Sub(qty)
+		fee = notional.Mul(rate)
+		quote.free = quote.free.Add(notional.Sub(fee))
+		o.feeAs = quoteAsset
+	}
+	o.fee = o.fee.Add(fee)
+}
+
+// release 解冻订单未成交部分占用的资金
+func (c *Client) release(o *order) {
+	if o.book.future {
+		return
+	}
+	baseAsset, quoteAsset := o.book.base, o.book.quote
+	left := o.remaining()
+	if o.side == base.BID {
+		if o.price.IsZero() {
+			return
+		}
+		frozen := o.price.Mul(left)
+		quote := c.asset(quoteAsset)
+		quote.locked = quote.locked.Sub(frozen)
+		quote.free = quote.free.Add(frozen)
+		return
+	}
+	bal := c.asset(baseAsset)
+	bal.locked = bal.locked.Sub(left)
+	bal.free = bal.free.Add(left)
+}
+
+// lookup 按订单号查找 book 上的订单
+func (c *Client) lookup(b *book, id string) (*order, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", base.ErrOrderNotFound, id)
+	}
+	o, ok := c.orders[n]
+	if !ok || o.book != b {
+		return nil, fmt.Errorf("%w: %q", base.ErrOrderNotFound, id)
+	}
+	return o, nil
+}
+
+// cancel 撤销挂单，已结束的订单返回 ErrOrderNotFound
+func (c *Client) cancel(b *book, id string) (bool, error) {
+	o, err := c.lookup(b, id)
+	if err != nil {
+		return false, err
+	}
+	if !o.active() {
+		return false, fmt.Errorf("%w: order %s is %s", base.ErrOrderNotFound, id, o.status)
+	}
+	c.release(o)
+	o.status = base.CANCELED
+	o.update = c.millis()
+	b.prune()
+	return true, nil
+}
+
+// open 当前挂单，按下单顺序
+func (b *book) open() []*order {
+	opens := append([]*order(nil), b.resting...)
+	sort.Slice(opens, func(i, j int) bool { return opens[i].id < opens[j].id })
+	return opens
+}
+
+func (c *Client) CancelOrder(symbol, id string) (bool, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return false, err
+	}
+	return c.cancel(b, id)
+}
+
+func (c *Client) CancelOrders(symbol string) error {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return err
+	}
+	for _, o := range b.open() {
+		if _, err := c.cancel(b, strconv.Itoa(o.id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetOrder(symbol, id string) (models.OrderInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+	o, err := c.lookup(b, id)
+	if err != nil {
+		return models.OrderInfo{}, err
+	}
+	return o.info(), nil
+}
+
+func (c *Client) GetOpenOrders(symbol string) ([]models.OrderInfo, error) {
+	return c.GetOpenOrdersWithSide(symbol, "")
+}
+
+// GetOpenOrdersWithSide side 为空时返回两边的挂单
+func (c *Client) GetOpenOrdersWithSide(symbol, side string) ([]models.OrderInfo, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var opens []models.OrderInfo
+	for _, o := range b.open() {
+		if side == "" || o.side == side {
+			opens = append(opens, o.info())
+		}
+	}
+	return opens, nil
+}
+
+// GetOpenSplitOrders 返回 (买单, 卖单)
+func (c *Client) GetOpenSplitOrders(symbol string) ([]models.OrderInfo, []models.OrderInfo, error) {
+	opens, err := c.GetOpenOrders(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	var buys, sells []models.OrderInfo
+	for _, o := range opens {
+		if o.Side == base.BID {
+			buys = append(buys, o)
+		} else {
+			sells = append(sells, o)
+		}
+	}
+	return buys, sells, nil
+}
+
+func (c *Client) GetMarketPrice(symbol string) (string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return "", err
+	}
+	px, err := b.price()
+	if err != nil {
+		return "", err
+	}
+	return px.String(), nil
+}
+
+func (c *Client) Depth(symbol, limit string) (models.WsData, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return models.WsData{}, err
+	}
+	n, err := depthLimit(limit)
+	if err != nil {
+		return models.WsData{}, err
+	}
+	return b.depth(n, c.millis()), nil
+}
+
+func depthLimit(limit string) (int, error) {
+	if limit == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil {
+		return 0, fmt.Errorf("%w: limit: %v", models.ErrInvalidRequest, err)
+	}
+	return n, nil
+}
+
+// GetFeeFromFilled 返回订单累计手续费和手续费币种
+func (c *Client) GetFeeFromFilled(symbol, id string) (string, string, error) {
+	c.lock()
+	defer c.mu.Unlock()
+	b, err := c.spotBook(symbol)
+	if err != nil {
+		return "", "", err
+	}
+	o, err := c.lookup(b, id)
+	if err != nil {
+		return "", "", err
+	}
+	return o.fee.String(), o.feeAs, nil
+}
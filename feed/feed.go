@@ -0,0 +1,138 @@
+// Package feed 推送订阅的公共部分。
+//
+// 订阅返回只读 channel 和 Subscription 句柄，Unsubscribe 或 ctx 取消后 channel 被关闭。
+// 推送在消费者读取前阻塞而不是丢弃，订单、成交等账户推送不能丢；
+// 消费者需要及时读取，否则同一连接上的其他订阅也会被拖慢。
+package feed
+
+import (
+	"context"
+	"sync"
+)
+
+// Buffer 订阅 channel 的缓冲大小
+const Buffer = 256
+
+// Subscription 订阅句柄
+type Subscription struct {
+	once sync.Once
+	stop func() error
+	err  error
+	done chan struct{}
+}
+
+// NewSubscription stop 在取消订阅时调用一次；ctx 取消时自动取消订阅
+func NewSubscription(ctx context.Context, stop func() error) *Subscription {
+	s := &Subscription{stop: stop, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.Unsubscribe()
+		case <-s.done:
+		}
+	}()
+	return s
+}
+
+// Unsubscribe 取消订阅，可重复调用，返回第一次取消时的错误
+func (s *Subscription) Unsubscribe() error {
+	s.once.Do(func() {
+		if s.stop != nil {
+			s.err = s.stop()
+		}
+		close(s.done)
+	})
+	return s.err
+}
+
+// Done 取消订阅后关闭
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Pipe 把 in 经 convert 转发到新的 channel，convert 返回 false 时跳过。
+// in 关闭或 done 关闭后停止并关闭返回的 channel
+func Pipe[In, Out any](done <-chan struct{}, in <-chan In, convert func(In) (Out, bool)) <-chan Out {
+	out := make(chan Out, Buffer)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				o, ok := convert(v)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- o:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Same 原样转发，用作 Pipe 的 convert
+func Same[T any](v T) (T, bool) {
+	return v, true
+}
+
+// Hub 把一路推送分发给多个订阅者，零值可用
+type Hub[T any] struct {
+	mu   sync.Mutex
+	subs map[*hubSub[T]]struct{}
+}
+
+type hubSub[T any] struct {
+	ch   chan T
+	done chan struct{}
+}
+
+// Subscribe 添加订阅者。remove 移除订阅者并关闭 channel，返回剩余的订阅者数
+func (h *Hub[T]) Subscribe() (ch <-chan T, remove func() int) {
+	s := &hubSub[T]{ch: make(chan T, Buffer), done: make(chan struct{})}
+	h.mu.Lock()
+	if h.subs == nil {
+		h.subs = make(map[*hubSub[T]]struct{})
+	}
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	var once sync.Once
+	return s.ch, func() int {
+		once.Do(func() {
+			// 先让阻塞中的 Publish 放弃这个订阅者，再在锁内关闭 channel
+			close(s.done)
+			h.mu.Lock()
+			delete(h.subs, s)
+			close(s.ch)
+			h.mu.Unlock()
+		})
+		return h.Len()
+	}
+}
+
+// Publish 推送给所有订阅者，在每个订阅者的 channel 有空位前阻塞
+func (h *Hub[T]) Publish(v T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs {
+		select {
+		case s.ch <- v:
+		case <-s.done:
+		}
+	}
+}
+
+// Len 当前订阅者数
+func (h *Hub[T]) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
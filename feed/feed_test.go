@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHubFanOut(t *testing.T) {
+	var h Hub[int]
+	a, removeA := h.Subscribe()
+	b, removeB := h.Subscribe()
+	h.Publish(1)
+	if <-a != 1 || <-b != 1 {
+		t.Fatal("both subscribers should get the push")
+	}
+	if n := removeA(); n != 1 {
+		t.Fatalf("remaining = %d", n)
+	}
+	if _, ok := <-a; ok {
+		t.Fatal("removed subscriber's channel not closed")
+	}
+	h.Publish(2)
+	if <-b != 2 {
+		t.Fatal("push after remove")
+	}
+	removeA()
+	if n := removeB(); n != 0 {
+		t.Fatalf("remaining = %d", n)
+	}
+}
+
+func TestHubRemoveUnblocksPublish(t *testing.T) {
+	var h Hub[int]
+	_, remove := h.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i <= Buffer; i++ {
+			h.Publish(i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("publish into a full subscriber returned")
+	case <-time.After(20 * time.Millisecond):
+	}
+	remove()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish still blocked after remove")
+	}
+}
+
+func TestSubscriptionPipe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopErr := errors.New("stop")
+	stops := 0
+	sub := NewSubscription(ctx, func() error {
+		stops++
+		return stopErr
+	})
+	in := make(chan int)
+	out := Pipe(sub.Done(), in, func(v int) (string, bool) {
+		return string(rune('a' + v)), v%2 == 0
+	})
+	go func() {
+		for i := 0; i < 4; i++ {
+			in <- i
+		}
+	}()
+	if v1, v2 := <-out, <-out; v1 != "a" || v2 != "c" {
+		t.Fatalf("got %s %s", v1, v2)
+	}
+
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("ctx cancel did not unsubscribe")
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("channel not closed")
+	}
+	if err := sub.Unsubscribe(); err != stopErr || stops != 1 {
+		t.Fatalf("err = %v stops = %d", err, stops)
+	}
+}
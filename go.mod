@@ -5,10 +5,10 @@ go 1.21
 require (
 	github.com/adshao/go-binance/v2 v2.5.1
 	github.com/bitly/go-simplejson v0.5.1
+	github.com/gorilla/websocket v1.5.0
 )
 
 require (
-	github.com/gorilla/websocket v1.5.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
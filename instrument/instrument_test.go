@@ -0,0 +1,211 @@
+package instrument
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Symbol
+		str  string
+	}{
+		{"BTC/USDT", Symbol{Base: "BTC", Quote: "USDT", Kind: Spot}, "BTC/USDT"},
+		{"btc/usdt:usdt", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Kind: Swap}, "BTC/USDT:USDT"},
+		{"BTC/USDT:USDT-SWAP", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Kind: Swap}, "BTC/USDT:USDT"},
+		{"BTC/USD:BTC-240927", Symbol{Base: "BTC", Quote: "USD", Settle: "BTC", Kind: Futures, Expiry: "240927"}, "BTC/USD:BTC-240927"},
+		{"BTC/USD:BTC-240927-50000-C", Symbol{Base: "BTC", Quote: "USD", Settle: "BTC", Kind: Option, Expiry: "240927", Strike: "50000", OptionType: "C"}, "BTC/USD:BTC-240927-50000-C"},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+		if got.String() != c.str {
+			t.Errorf("Parse(%q).String() = %q, want %q", c.in, got.String(), c.str)
+		}
+	}
+
+	for _, bad := range []string{"BTCUSDT", "BTC-USDT", "/USDT", "BTC/USDT:", "BTC/USD:BTC-2409", "BTC/USD:BTC-240927-50000-X"} {
+		if _, err := Parse(bad); !errors.Is(err, ErrInvalidSymbol) {
+			t.Errorf("Parse(%q) err = %v, want ErrInvalidSymbol", bad, err)
+		}
+	}
+}
+
+func TestNativeRoundTrip(t *testing.T) {
+	cases := []struct {
+		exchange string
+		symbol   string
+		native   string
+	}{
+		{base.OKEX, "BTC/USDT", "BTC-USDT"},
+		{base.OKEX, "BTC/USDT:USDT", "BTC-USDT-SWAP"},
+		{base.OKEX, "BTC/USD:BTC", "BTC-USD-SWAP"},
+		{base.OKEX, "BTC/USD:BTC-240927", "BTC-USD-240927"},
+		{base.OKEX, "BTC/USD:BTC-240927-50000-C", "BTC-USD-240927-50000-C"},
+		{base.BINANCE, "BTC/USDT", "BTCUSDT"},
+		{base.BINANCE, "ETH/BTC", "ETHBTC"},
+		{base.BINANCE, "BTC/USDT:USDT", "BTCUSDT"},
+		{base.BINANCE, "BTC/USD:BTC", "BTCUSD_PERP"},
+		{base.BINANCE, "BTC/USDT:USDT-240927", "BTCUSDT_240927"},
+		{base.BINANCE, "BTC/USDT:USDT-240927-50000-P", "BTC-240927-50000-P"},
+	}
+	var r *Registry // nil 注册表只按命名规则转换
+	for _, c := range cases {
+		native, err := r.Native(c.exchange, c.symbol)
+		if err != nil || native != c.native {
+			t.Errorf("Native(%s, %s) = %q, %v, want %q", c.exchange, c.symbol, native, err, c.native)
+			continue
+		}
+		sym, _ := Parse(c.symbol)
+		unified, err := r.Unified(c.exchange, sym.Kind, native)
+		if err != nil || unified != c.symbol {
+			t.Errorf("Unified(%s, %s, %s) = %q, %v, want %q", c.exchange, sym.Kind, native, unified, err, c.symbol)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	err := r.Add(
+		Instrument{Symbol: "BTC/USDT", Exchange: base.BINANCE, NativeID: "BTCUSDT", TickSize: models.NewDecimal(1, 2)},
+		Instrument{Symbol: "btc/usdt:usdt", Exchange: base.BINANCE, NativeID: "BTCUSDT", ContractValue: models.NewDecimalFromInt(1)},
+		Instrument{Symbol: "1000PEPE/USDT:USDT", Exchange: base.BINANCE, NativeID: "1000PEPEUSDT"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(Instrument{Symbol: "BTCUSDT", Exchange: base.BINANCE}); !errors.Is(err, ErrInvalidSymbol) {
+		t.Fatalf("Add non-canonical err = %v", err)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", r.Len())
+	}
+
+	inst, err := r.Get(base.BINANCE, "BTC/USDT:USDT")
+	if err != nil || inst.Kind != Swap || inst.Settle != "USDT" {
+		t.Fatalf("Get swap = %+v, %v", inst, err)
+	}
+	if _, err := r.Get(base.OKEX, "BTC/USDT"); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("Get other exchange err = %v", err)
+	}
+
+	// 现货和永续的原生 id 相同，按 kind 区分
+	spot, err := r.Lookup(base.BINANCE, Spot, "BTCUSDT")
+	if err != nil || spot.Symbol != "BTC/USDT" || !spot.TickSize.Equal(models.NewDecimal(1, 2)) {
+		t.Fatalf("Lookup spot = %+v, %v", spot, err)
+	}
+	if u, _ := r.Unified(base.BINANCE, Swap, "BTCUSDT"); u != "BTC/USDT:USDT" {
+		t.Fatalf("Unified swap = %q", u)
+	}
+	// 命名规则无法拆分的币对依赖注册表
+	if n, _ := r.Native(base.BINANCE, "1000PEPE/USDT:USDT"); n != "1000PEPEUSDT" {
+		t.Fatalf("Native = %q", n)
+	}
+	if got := len(r.List(base.BINANCE, Swap)); got != 2 {
+		t.Fatalf("List swap = %d, want 2", got)
+	}
+}
+
+func TestSplitNative(t *testing.T) {
+	cases := map[string][]string{
+		"BTC-USDT": {"BTC", "USDT"},
+		"eth_btc":  {"ETH", "BTC"},
+		"SOLUSDC":  {"SOL", "USDC"},
+		"BNBFDUSD": {"BNB", "FDUSD"},
+		"FOO":      nil,
+	}
+	for in, want := range cases {
+		got := SplitNative(in)
+		if len(got) != len(want) || (want != nil && (got[0] != want[0] || got[1] != want[1])) {
+			t.Errorf("SplitNative(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	d := models.ParseDecimalOrZero
+	inst := Instrument{
+		Symbol:        "BTC/USDT",
+		TickSize:      d("0.01"),
+		LotSize:       d("0.001"),
+		MinSize:       d("0.002"),
+		MaxLimitSize:  d("10"),
+		MaxMarketSize: d("5"),
+		MinNotional:   d("10"),
+	}
+
+	price, size, err := inst.Quantize(base.LIMIT, d("30000.126"), d("0.0109"))
+	if err != nil || price.String() != "30000.13" || size.String() != "0.01" {
+		t.Fatalf("Quantize = %s, %s, %v", price, size, err)
+	}
+
+	cases := []struct {
+		typ, price, size string
+		field            string
+	}{
+		{base.LIMIT, "30000", "0.0009", "size"},   // 截断后为 0
+		{base.LIMIT, "30000", "0.0015", "size"},   // 小于 MinSize
+		{base.LIMIT, "30000", "11", "size"},       // 超过 MaxLimitSize
+		{base.MARKET, "", "6", "size"},            // 超过 MaxMarketSize
+		{base.LIMIT, "1000", "0.005", "notional"}, // 5 < 10
+		{base.LIMIT, "0.001", "1", "price"},       // 对齐后为 0
+	}
+	for _, c := range cases {
+		_, _, err := inst.Quantize(c.typ, d(c.price), d(c.size))
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Field != c.field {
+			t.Errorf("Quantize(%s, %s, %s) err = %v, want %s error", c.typ, c.price, c.size, err, c.field)
+			continue
+		}
+		if !errors.Is(err, models.ErrInvalidRequest) {
+			t.Errorf("ValidationError should wrap ErrInvalidRequest")
+		}
+	}
+	// 市价单没有价格时不检查名义价值
+	if _, _, err := inst.Quantize(base.MARKET, models.Decimal{}, d("0.002")); err != nil {
+		t.Fatalf("market order: %v", err)
+	}
+}
+
+func TestQuantizeOrder(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Add(Instrument{
+		Symbol: "BTC/USDT:USDT", Exchange: base.OKEX, NativeID: "BTC-USDT-SWAP",
+		TickSize: models.ParseDecimalOrZero("0.1"), LotSize: models.ParseDecimalOrZero("0.1"),
+		MinSize: models.ParseDecimalOrZero("0.1"), ContractValue: models.ParseDecimalOrZero("0.01"),
+	})
+
+	// 0.0157 BTC = 1.57 张，截断为 1.5 张 = 0.015 BTC
+	price, size, err := r.QuantizeOrder(base.OKEX, Swap, "BTC-USDT-SWAP", base.LIMIT, "30000.04", "0.0157")
+	if err != nil || price != "30000" || size != "0.015" {
+		t.Fatalf("QuantizeOrder = %s, %s, %v", price, size, err)
+	}
+	if _, _, err := r.QuantizeOrder(base.OKEX, Swap, "BTC/USDT:USDT", base.LIMIT, "30000", "0.0005"); err == nil {
+		t.Fatal("expected size below one lot to fail")
+	}
+	if _, _, err := r.QuantizeOrder(base.OKEX, Swap, "BTC-USDT-SWAP", base.LIMIT, "30000", "abc"); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Fatalf("bad size err = %v", err)
+	}
+
+	// 未加载的合约原样返回
+	var empty *Registry
+	price, size, err = empty.QuantizeOrder(base.OKEX, Spot, "ETH-USDT", base.LIMIT, "1.23456", "7")
+	if err != nil || price != "1.23456" || size != "7" {
+		t.Fatalf("unknown instrument = %s, %s, %v", price, size, err)
+	}
+	// 已加载该类型的合约后，未知 id 返回错误
+	if _, _, err := r.QuantizeOrder(base.OKEX, Swap, "ETH-USDT-SWAP", base.LIMIT, "2000", "1"); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("unknown id err = %v", err)
+	}
+	if _, _, err := r.QuantizeOrder(base.OKEX, Spot, "ETH-USDT", base.LIMIT, "2000", "1"); err != nil {
+		t.Fatalf("kind not loaded err = %v", err)
+	}
+}
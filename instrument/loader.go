@@ -0,0 +1,38 @@
+package instrument
+
+import (
+	"sync"
+	"time"
+)
+
+// loadRetry 加载失败后再次尝试的间隔，避免交易所接口故障时每次下单都请求一次
+const loadRetry = time.Minute
+
+// Loader 第一次下单前加载注册表，成功后不再加载；失败时间隔 loadRetry 再试。零值可以直接使用
+type Loader struct {
+	mu     sync.Mutex
+	loaded bool
+	failed time.Time
+}
+
+// Ensure 还没有加载成功时调用 load，返回本次加载的错误
+func (l *Loader) Ensure(load func() error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loaded || (!l.failed.IsZero() && time.Since(l.failed) < loadRetry) {
+		return nil
+	}
+	if err := load(); err != nil {
+		l.failed = time.Now()
+		return err
+	}
+	l.loaded = true
+	return nil
+}
+
+// Reset 下次 Ensure 时重新加载，例如新建了合约客户端
+func (l *Loader) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.loaded, l.failed = false, time.Time{}
+}
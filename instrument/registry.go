@@ -0,0 +1,284 @@
+package instrument
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Instrument 单个交易所上的一个合约
+type Instrument struct {
+	Symbol        string         `json:"symbol"` // 统一符号
+	Exchange      string         `json:"exchange"`
+	Kind          Kind           `json:"kind"`
+	NativeID      string         `json:"native_id"`
+	Base          string         `json:"base"`
+	Quote         string         `json:"quote"`
+	Settle        string         `json:"settle"`
+	TickSize      models.Decimal `json:"tick_size"`
+	LotSize       models.Decimal `json:"lot_size"`
+	ContractValue models.Decimal `json:"contract_value"` // 合约面值，现货为 0
+	MinSize       models.Decimal `json:"min_size"`
+	MaxLimitSize  models.Decimal `json:"max_limit_size"`  // 限价单最大数量，0 表示不限制
+	MaxMarketSize models.Decimal `json:"max_market_size"` // 市价单最大数量，0 表示不限制
+	MinNotional   models.Decimal `json:"min_notional"`    // 最小名义价值（计价币），0 表示不限制
+	Live          bool           `json:"live"`
+}
+
+type symbolKey struct {
+	exchange string
+	symbol   string
+}
+
+type nativeKey struct {
+	exchange string
+	kind     Kind
+	native   string
+}
+
+// Registry 合约注册表，并发安全。
+// nil *Registry 也可以使用，此时只按命名规则做符号转换，不包含交易规则。
+type Registry struct {
+	mu       sync.RWMutex
+	bySymbol map[symbolKey]Instrument
+	byNative map[nativeKey]string
+}
+
+// NewRegistry 创建空的注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		bySymbol: make(map[symbolKey]Instrument),
+		byNative: make(map[nativeKey]string),
+	}
+}
+
+// Add 注册合约，同一交易所的同一统一符号会被覆盖
+func (r *Registry) Add(insts ...Instrument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, inst := range insts {
+		sym, err := Parse(inst.Symbol)
+		if err != nil {
+			return err
+		}
+		inst.Symbol = sym.String()
+		if inst.Kind == "" {
+			inst.Kind = sym.Kind
+		}
+		if inst.Base == "" {
+			inst.Base, inst.Quote, inst.Settle = sym.Base, sym.Quote, sym.Settle
+		}
+		r.bySymbol[symbolKey{inst.Exchange, inst.Symbol}] = inst
+		r.byNative[nativeKey{inst.Exchange, inst.Kind, inst.NativeID}] = inst.Symbol
+	}
+	return nil
+}
+
+// Len 注册的合约数量
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.bySymbol)
+}
+
+// Get 按统一符号查询合约
+func (r *Registry) Get(exchange, symbol string) (Instrument, error) {
+	canonical, err := Canonical(symbol)
+	if err != nil {
+		return Instrument{}, err
+	}
+	if r != nil {
+		r.mu.RLock()
+		inst, ok := r.bySymbol[symbolKey{exchange, canonical}]
+		r.mu.RUnlock()
+		if ok {
+			return inst, nil
+		}
+	}
+	return Instrument{}, fmt.Errorf("%w: %s on %s", ErrUnknownInstrument, canonical, exchange)
+}
+
+// Lookup 按统一符号或交易所原生 id 查询合约，原生 id 需要指定 kind
+func (r *Registry) Lookup(exchange string, kind Kind, symbol string) (Instrument, error) {
+	if IsUnified(symbol) {
+		return r.Get(exchange, symbol)
+	}
+	if r != nil {
+		r.mu.RLock()
+		unified, ok := r.byNative[nativeKey{exchange, kind, symbol}]
+		r.mu.RUnlock()
+		if ok {
+			return r.Get(exchange, unified)
+		}
+	}
+	return Instrument{}, fmt.Errorf("%w: %s %s on %s", ErrUnknownInstrument, kind, symbol, exchange)
+}
+
+// Loaded 是否已经加载了某交易所某类型的合约
+func (r *Registry) Loaded(exchange string, kind Kind) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for k, inst := range r.bySymbol {
+		if k.exchange == exchange && inst.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// List 返回某交易所某类型的全部合约，kind 为空时返回全部类型
+func (r *Registry) List(exchange string, kind Kind) []Instrument {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []Instrument
+	for k, inst := range r.bySymbol {
+		if k.exchange == exchange && (kind == "" || inst.Kind == kind) {
+			out = append(out, inst)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Symbol < out[j].Symbol })
+	return out
+}
+
+// Native 统一符号转交易所原生 id，注册表中没有时按命名规则推导
+func (r *Registry) Native(exchange, symbol string) (string, error) {
+	sym, err := Parse(symbol)
+	if err != nil {
+		return "", err
+	}
+	if inst, err := r.Get(exchange, symbol); err == nil {
+		return inst.NativeID, nil
+	}
+	return FormatNative(exchange, sym)
+}
+
+// Unified 交易所原生 id 转统一符号，注册表中没有时按命名规则推导
+func (r *Registry) Unified(exchange string, kind Kind, native string) (string, error) {
+	if r != nil {
+		r.mu.RLock()
+		unified, ok := r.byNative[nativeKey{exchange, kind, native}]
+		r.mu.RUnlock()
+		if ok {
+			return unified, nil
+		}
+	}
+	sym, err := ParseNative(exchange, kind, native)
+	if err != nil {
+		return "", err
+	}
+	return sym.String(), nil
+}
+
+// FormatNative 按交易所的命名规则把统一符号转成原生 id
+func FormatNative(exchange string, sym Symbol) (string, error) {
+	switch exchange {
+	case base.OKEX:
+		id := sym.Base + "-" + sym.Quote
+		switch sym.Kind {
+		case Spot:
+			return id, nil
+		case Swap:
+			return id + "-SWAP", nil
+		case Futures:
+			return id + "-" + sym.Expiry, nil
+		case Option:
+			return id + "-" + sym.Expiry + "-" + sym.Strike + "-" + sym.OptionType, nil
+		}
+	case base.BINANCE:
+		id := sym.Base + sym.Quote
+		inverse := sym.Settle != "" && sym.Settle == sym.Base
+		switch sym.Kind {
+		case Spot:
+			return id, nil
+		case Swap:
+			if inverse {
+				return id + "_PERP", nil
+			}
+			return id, nil
+		case Futures:
+			return id + "_" + sym.Expiry, nil
+		case Option:
+			return sym.Base + "-" + sym.Expiry + "-" + sym.Strike + "-" + sym.OptionType, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s not supported on %s", ErrUnknownInstrument, sym, exchange)
+}
+
+// ParseNative 按交易所的命名规则把原生 id 解析为统一符号
+func ParseNative(exchange string, kind Kind, native string) (Symbol, error) {
+	sym := Symbol{Kind: kind}
+	bad := fmt.Errorf("%w: cannot parse %s id %q on %s", ErrUnknownInstrument, kind, native, exchange)
+
+	switch exchange {
+	case base.OKEX:
+		parts := strings.Split(native, "-")
+		if len(parts) < 2 {
+			return sym, bad
+		}
+		sym.Base, sym.Quote = parts[0], parts[1]
+		switch {
+		case kind == Spot && len(parts) == 2:
+			return sym, nil
+		case kind == Swap && len(parts) == 3 && parts[2] == "SWAP":
+		case kind == Futures && len(parts) == 3:
+			sym.Expiry = parts[2]
+		case kind == Option && len(parts) == 5:
+			sym.Expiry, sym.Strike, sym.OptionType = parts[2], parts[3], parts[4]
+		default:
+			return sym, bad
+		}
+		// OKX 的 USD 合约为币本位，其余为 U 本位
+		sym.Settle = sym.Quote
+		if sym.Quote == "USD" {
+			sym.Settle = sym.Base
+		}
+		return sym, nil
+
+	case base.BINANCE:
+		if kind == Option {
+			parts := strings.Split(native, "-")
+			if len(parts) != 4 {
+				return sym, bad
+			}
+			sym.Base, sym.Quote, sym.Settle = parts[0], "USDT", "USDT"
+			sym.Expiry, sym.Strike, sym.OptionType = parts[1], parts[2], parts[3]
+			return sym, nil
+		}
+		pair, suffix, _ := strings.Cut(native, "_")
+		tokens := SplitNative(pair)
+		if tokens == nil {
+			return sym, bad
+		}
+		sym.Base, sym.Quote = tokens[0], tokens[1]
+		switch {
+		case kind == Spot && suffix == "":
+			return sym, nil
+		case kind == Swap && suffix == "":
+			sym.Settle = sym.Quote
+		case kind == Swap && suffix == "PERP":
+			sym.Settle = sym.Base
+		case kind == Futures && isExpiry(suffix):
+			sym.Expiry = suffix
+			sym.Settle = sym.Quote
+			if sym.Quote == "USD" {
+				sym.Settle = sym.Base
+			}
+		default:
+			return sym, bad
+		}
+		return sym, nil
+	}
+	return sym, bad
+}
@@ -0,0 +1,140 @@
+// Package instrument 维护统一的交易对（合约）注册表。
+//
+// 统一符号格式为 BASE/QUOTE[:SETTLE][-TYPE]：
+//
+//	BTC/USDT                     现货
+//	BTC/USDT:USDT                永续合约（U本位），等价于 BTC/USDT:USDT-SWAP
+//	BTC/USD:BTC                  永续合约（币本位）
+//	BTC/USD:BTC-240927           交割合约，TYPE 为到期日 YYMMDD
+//	BTC/USD:BTC-240927-50000-C   期权，TYPE 为 到期日-行权价-C/P
+//
+// 注册表从各交易所的合约列表加载，负责统一符号与交易所原生 id 之间的双向转换。
+package instrument
+
+import (
+	"AxonTrading/base"
+	"fmt"
+	"strings"
+)
+
+// Kind 合约类型
+type Kind string
+
+const (
+	Spot    Kind = "SPOT"
+	Swap    Kind = "SWAP"
+	Futures Kind = "FUTURES"
+	Option  Kind = "OPTION"
+)
+
+var (
+	// ErrInvalidSymbol 统一符号格式错误，errors.Is(err, base.ErrInvalidSymbol) 为 true
+	ErrInvalidSymbol = fmt.Errorf("invalid unified symbol: %w", base.ErrInvalidSymbol)
+	// ErrUnknownInstrument 注册表中找不到该合约，errors.Is(err, base.ErrInvalidSymbol) 为 true
+	ErrUnknownInstrument = fmt.Errorf("unknown instrument: %w", base.ErrInvalidSymbol)
+)
+
+// Symbol 解析后的统一符号
+type Symbol struct {
+	Base       string
+	Quote      string
+	Settle     string
+	Kind       Kind
+	Expiry     string // YYMMDD，交割合约和期权
+	Strike     string // 行权价，期权
+	OptionType string // C / P，期权
+}
+
+// IsUnified 判断是否为统一符号（包含 "/"），旧代码传入的 BTC-USDT、BTCUSDT 返回 false
+func IsUnified(s string) bool {
+	return strings.Contains(s, "/")
+}
+
+// Parse 解析统一符号
+func Parse(s string) (Symbol, error) {
+	var sym Symbol
+	pair, rest, hasSettle := strings.Cut(strings.ToUpper(strings.TrimSpace(s)), ":")
+	base, quote, ok := strings.Cut(pair, "/")
+	if !ok || base == "" || quote == "" || strings.ContainsAny(quote, "-/") {
+		return sym, fmt.Errorf("%w: %q", ErrInvalidSymbol, s)
+	}
+	sym.Base, sym.Quote = base, quote
+
+	if !hasSettle {
+		sym.Kind = Spot
+		return sym, nil
+	}
+
+	parts := strings.Split(rest, "-")
+	sym.Settle = parts[0]
+	if sym.Settle == "" {
+		return sym, fmt.Errorf("%w: %q", ErrInvalidSymbol, s)
+	}
+	switch {
+	case len(parts) == 1 || (len(parts) == 2 && parts[1] == string(Swap)):
+		sym.Kind = Swap
+	case len(parts) == 2 && isExpiry(parts[1]):
+		sym.Kind = Futures
+		sym.Expiry = parts[1]
+	case len(parts) == 4 && isExpiry(parts[1]) && parts[2] != "" && (parts[3] == "C" || parts[3] == "P"):
+		sym.Kind = Option
+		sym.Expiry, sym.Strike, sym.OptionType = parts[1], parts[2], parts[3]
+	default:
+		return sym, fmt.Errorf("%w: %q", ErrInvalidSymbol, s)
+	}
+	return sym, nil
+}
+
+// String 返回规范的统一符号，永续合约不带 -SWAP 后缀
+func (s Symbol) String() string {
+	out := s.Base + "/" + s.Quote
+	if s.Kind == Spot || s.Kind == "" {
+		return out
+	}
+	out += ":" + s.Settle
+	switch s.Kind {
+	case Futures:
+		out += "-" + s.Expiry
+	case Option:
+		out += "-" + s.Expiry + "-" + s.Strike + "-" + s.OptionType
+	}
+	return out
+}
+
+// Canonical 规范化统一符号，例如 btc/usdt:usdt-swap -> BTC/USDT:USDT
+func Canonical(s string) (string, error) {
+	sym, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return sym.String(), nil
+}
+
+func isExpiry(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// knownQuotes 没有分隔符的原生符号（BTCUSDT）按这些计价币拆分，长的优先
+var knownQuotes = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "USDP", "DAI", "TRY", "EUR", "BRL", "BTC", "ETH", "BNB", "USD"}
+
+// SplitNative 拆分原生符号 BTC-USDT、BTC_USDT、BTCUSDT 为 [BTC USDT]，无法识别返回 nil
+func SplitNative(s string) []string {
+	s = strings.ToUpper(s)
+	if parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' || r == '/' }); len(parts) >= 2 {
+		return parts[:2]
+	}
+	for _, q := range knownQuotes {
+		if strings.HasSuffix(s, q) && len(s) > len(q) {
+			return []string{strings.TrimSuffix(s, q), q}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package instrument
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"fmt"
+)
+
+// ValidationError 下单前校验失败，在发出请求前返回。
+// errors.Is(err, models.ErrInvalidRequest) 为 true。
+type ValidationError struct {
+	Symbol string
+	Field  string // price / size / notional
+	Reason string
+	Value  models.Decimal
+	Limit  models.Decimal
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s %s %s (limit %s)", e.Symbol, e.Field, e.Value, e.Reason, e.Limit)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return models.ErrInvalidRequest
+}
+
+// isMarket 市价类订单使用 MaxMarketSize，且没有价格时不检查名义价值
+func isMarket(typ string) bool {
+	return typ == base.MARKET || typ == base.STOPMARKET || typ == base.TAKEPROFITMARKET
+}
+
+// Quantize 按交易规则对齐价格和数量：价格四舍五入到 TickSize，数量向下截断到 LotSize，
+// 然后检查最小/最大数量和最小名义价值。size 的单位与 LotSize 一致（现货为币，OKX 合约为张）。
+// price 为 0 时（市价单）不处理价格，也不检查名义价值。
+func (inst Instrument) Quantize(typ string, price, size models.Decimal) (models.Decimal, models.Decimal, error) {
+	if price.Sign() < 0 {
+		return price, size, inst.invalid("price", "must not be negative", price, models.Decimal{})
+	}
+	if price.Sign() > 0 && inst.TickSize.Sign() > 0 {
+		price = price.RoundToStep(inst.TickSize)
+		if price.IsZero() {
+			return price, size, inst.invalid("price", "is below tick size", price, inst.TickSize)
+		}
+	}
+	if inst.LotSize.Sign() > 0 {
+		size = size.FloorToStep(inst.LotSize)
+	}
+	if size.Sign() <= 0 {
+		return price, size, inst.invalid("size", "must be at least one lot", size, inst.LotSize)
+	}
+	if inst.MinSize.Sign() > 0 && size.LessThan(inst.MinSize) {
+		return price, size, inst.invalid("size", "is below minimum", size, inst.MinSize)
+	}
+	max := inst.MaxLimitSize
+	if isMarket(typ) {
+		max = inst.MaxMarketSize
+	}
+	if max.Sign() > 0 && size.GreaterThan(max) {
+		return price, size, inst.invalid("size", "exceeds maximum", size, max)
+	}
+	if price.Sign() > 0 && inst.MinNotional.Sign() > 0 {
+		notional := price.Mul(size)
+		if inst.ContractValue.Sign() > 0 {
+			notional = notional.Mul(inst.ContractValue)
+		}
+		if notional.LessThan(inst.MinNotional) {
+			return price, size, inst.invalid("notional", "is below minimum", notional, inst.MinNotional)
+		}
+	}
+	return price, size, nil
+}
+
+func (inst Instrument) invalid(field, reason string, value, limit models.Decimal) error {
+	return &ValidationError{Symbol: inst.Symbol, Field: field, Reason: reason, Value: value, Limit: limit}
+}
+
+// QuantizeOrder 按注册表中的交易规则对齐下单价格和数量，id 为交易所原生 id 或统一符号。
+// size 以币计，合约会按 ContractValue 换算成张数校验后再换算回币。
+// 注册表没有加载该交易所该类型的合约时原样返回，交给交易所校验；已经加载但查不到 id 时返回 ErrUnknownInstrument。
+func (r *Registry) QuantizeOrder(exchange string, kind Kind, id, typ, price, size string) (string, string, error) {
+	inst, err := r.Lookup(exchange, kind, id)
+	if err != nil {
+		if r.Loaded(exchange, kind) {
+			return price, size, err
+		}
+		return price, size, nil
+	}
+	p := models.Decimal{}
+	if price != "" {
+		if p, err = models.RequireDecimal("price", price); err != nil {
+			return price, size, err
+		}
+	}
+	s, err := models.RequireDecimal("size", size)
+	if err != nil {
+		return price, size, err
+	}
+	contract := kind != Spot && inst.ContractValue.Sign() > 0
+	if contract {
+		if s, err = s.Div(inst.ContractValue, 16); err != nil {
+			return price, size, err
+		}
+	}
+	p, s, err = inst.Quantize(typ, p, s)
+	if err != nil {
+		return price, size, err
+	}
+	if contract {
+		s = s.Mul(inst.ContractValue)
+	}
+	if p.Sign() > 0 {
+		price = p.String()
+	}
+	return price, s.String(), nil
+}
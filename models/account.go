@@ -0,0 +1,58 @@
+package models
+
+// AssetBalance 账户中一个币种的余额，数量以该币种计，USDValue 以美元计
+type AssetBalance struct {
+	Account       string  `json:"account"` // base.SPOT / base.FUTURES，OKX 现货和合约共用交易账户，为 base.SPOT
+	Asset         string  `json:"asset"`
+	Free          Decimal `json:"free"`
+	Locked        Decimal `json:"locked"`
+	Equity        Decimal `json:"equity"` // 余额加未实现盈亏
+	USDValue      Decimal `json:"usd_value"`
+	InitialMargin Decimal `json:"initial_margin"`
+	MaintMargin   Decimal `json:"maint_margin"`
+}
+
+// Account 账户快照：全部非零余额、总权益、保证金和账户配置
+type Account struct {
+	Mode          string         `json:"mode"`      // base.AccountSimple / AccountSingleCurrency / AccountMultiCurrency / AccountPortfolio
+	DualSide      bool           `json:"dual_side"` // 合约双向持仓
+	Balances      []AssetBalance `json:"balances"`
+	TotalEquity   Decimal        `json:"total_equity"`   // 美元
+	InitialMargin Decimal        `json:"initial_margin"` // 美元
+	MaintMargin   Decimal        `json:"maint_margin"`   // 美元
+	MarginRatio   Decimal        `json:"margin_ratio"`   // 见 MarginRatio
+	Time          int64          `json:"time"`
+}
+
+// Balance account 账户中 asset 的余额，没有时返回零值
+func (a Account) Balance(account, asset string) AssetBalance {
+	for _, b := range a.Balances {
+		if b.Account == account && b.Asset == asset {
+			return b
+		}
+	}
+	return AssetBalance{Account: account, Asset: asset}
+}
+
+// MarginRatio 维持保证金 / 保证金权益，达到 1 时强平。没有保证金占用时为 0，权益不为正时为 1
+func MarginRatio(maint, equity Decimal) Decimal {
+	if maint.Sign() <= 0 {
+		return Decimal{}
+	}
+	if equity.Sign() <= 0 {
+		return NewDecimalFromInt(1)
+	}
+	r, err := maint.Div(equity, 8)
+	if err != nil {
+		return Decimal{}
+	}
+	return r
+}
+
+// usdStables 估值时按 1 美元计的稳定币
+var usdStables = map[string]bool{"USD": true, "USDT": true, "USDC": true, "FDUSD": true, "BUSD": true}
+
+// IsUSDStable asset 是否按 1 美元估值
+func IsUSDStable(asset string) bool {
+	return usdStables[asset]
+}
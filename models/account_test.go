@@ -0,0 +1,33 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"testing"
+)
+
+func TestMarginRatio(t *testing.T) {
+	d := ParseDecimalOrZero
+	for _, tc := range []struct{ maint, equity, want string }{
+		{"20", "1960", "0.01020408"},
+		{"0", "1000", "0"},
+		{"10", "0", "1"},
+		{"10", "-5", "1"},
+	} {
+		if got := MarginRatio(d(tc.maint), d(tc.equity)); got.String() != tc.want {
+			t.Fatalf("MarginRatio(%s, %s) = %s", tc.maint, tc.equity, got)
+		}
+	}
+}
+
+func TestAccountBalance(t *testing.T) {
+	a := Account{Balances: []AssetBalance{
+		{Account: base.SPOT, Asset: "USDT", Free: NewDecimalFromInt(1)},
+		{Account: base.FUTURES, Asset: "USDT", Free: NewDecimalFromInt(2)},
+	}}
+	if b := a.Balance(base.FUTURES, "USDT"); b.Free.String() != "2" {
+		t.Fatalf("futures = %+v", b)
+	}
+	if b := a.Balance(base.SPOT, "BTC"); b.Asset != "BTC" || !b.Free.IsZero() {
+		t.Fatalf("missing = %+v", b)
+	}
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"fmt"
+	"time"
+)
+
+// AlgoOrderRequest 策略委托请求，Type 为 base.TRIGGER / CONDITIONAL / OCO / TRAILINGSTOP / ICEBERG / TWAP。
+// 价格字段为 0 表示不填；委托价为 0 时触发后按市价下单
+type AlgoOrderRequest struct {
+	Symbol       string  `json:"symbol"`
+	Side         string  `json:"side"`          // base.BID / base.ASK
+	PositionSide string  `json:"position_side"` // 合约双向持仓时 base.LONG / base.SHORT
+	MarginType   string  `json:"margin_type"`   // 合约 base.ISOLATED / base.CROSSED，默认全仓
+	Type         string  `json:"type"`
+	Size         Decimal `json:"size"`
+	ReduceOnly   bool    `json:"reduce_only"`
+
+	// TRIGGER：价格到达 TriggerPrice 后按 Price 下单
+	TriggerPrice Decimal `json:"trigger_price"`
+	Price        Decimal `json:"price"`
+
+	// CONDITIONAL：止盈、止损至少填一侧；OCO：两侧都要填
+	TakeProfitTrigger Decimal `json:"take_profit_trigger"`
+	TakeProfitPrice   Decimal `json:"take_profit_price"`
+	StopLossTrigger   Decimal `json:"stop_loss_trigger"`
+	StopLossPrice     Decimal `json:"stop_loss_price"`
+
+	// TRAILINGSTOP：回调比例（0.01 为 1%），ActivationPrice 为 0 时立即激活
+	CallbackRate    Decimal `json:"callback_rate"`
+	ActivationPrice Decimal `json:"activation_price"`
+
+	// ICEBERG / TWAP：单笔数量和限价，PriceSpread 为与盘口的价距；Interval 为 TWAP 的下单间隔
+	SliceSize   Decimal       `json:"slice_size"`
+	PriceLimit  Decimal       `json:"price_limit"`
+	PriceSpread Decimal       `json:"price_spread"`
+	Interval    time.Duration `json:"interval"`
+}
+
+// Validate 按类型校验必填字段
+func (r AlgoOrderRequest) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: empty symbol", ErrInvalidRequest)
+	}
+	if r.Side != base.BID && r.Side != base.ASK {
+		return fmt.Errorf("%w: unknown side %q", ErrInvalidRequest, r.Side)
+	}
+	if r.Size.Sign() <= 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidRequest)
+	}
+	tp, sl := r.TakeProfitTrigger.Sign() > 0, r.StopLossTrigger.Sign() > 0
+	switch r.Type {
+	case base.TRIGGER:
+		if r.TriggerPrice.Sign() <= 0 {
+			return fmt.Errorf("%w: trigger order without trigger price", ErrInvalidRequest)
+		}
+	case base.CONDITIONAL:
+		if !tp && !sl {
+			return fmt.Errorf("%w: conditional order without take-profit or stop-loss trigger", ErrInvalidRequest)
+		}
+	case base.OCO:
+		if !tp || !sl {
+			return fmt.Errorf("%w: oco order needs both take-profit and stop-loss triggers", ErrInvalidRequest)
+		}
+	case base.TRAILINGSTOP:
+		if r.CallbackRate.Sign() <= 0 {
+			return fmt.Errorf("%w: trailing stop without callback rate", ErrInvalidRequest)
+		}
+	case base.ICEBERG, base.TWAP:
+		if r.SliceSize.Sign() <= 0 || r.PriceLimit.Sign() <= 0 {
+			return fmt.Errorf("%w: %s order needs slice size and price limit", ErrInvalidRequest, r.Type)
+		}
+		if r.Type == base.TWAP && r.Interval < time.Second {
+			return fmt.Errorf("%w: twap interval must be at least 1s", ErrInvalidRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unknown algo order type %q", ErrInvalidRequest, r.Type)
+	}
+	return nil
+}
+
+// AlgoOrder 未触发或执行中的策略委托，没有的字段为 0
+type AlgoOrder struct {
+	AlgoID       string  `json:"algo_id"`
+	Symbol       string  `json:"symbol"`
+	Side         string  `json:"side"`
+	PositionSide string  `json:"position_side"`
+	Type         string  `json:"type"`
+	Size         Decimal `json:"size"` // 交易所返回的数量，OKX 合约为张数
+	Status       string  `json:"status"`
+
+	TriggerPrice      Decimal `json:"trigger_price"`
+	Price             Decimal `json:"price"`
+	TakeProfitTrigger Decimal `json:"take_profit_trigger"`
+	TakeProfitPrice   Decimal `json:"take_profit_price"`
+	StopLossTrigger   Decimal `json:"stop_loss_trigger"`
+	StopLossPrice     Decimal `json:"stop_loss_price"`
+	CallbackRate      Decimal `json:"callback_rate"`
+	ActivationPrice   Decimal `json:"activation_price"`
+	Time              int64   `json:"time"`
+}
@@ -0,0 +1,404 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero 除数为 0
+var ErrDivisionByZero = errors.New("decimal division by zero")
+
+var bigTen = big.NewInt(10)
+
+// Decimal 定点小数，值为 coef * 10^-scale。
+// 零值即为 0，可直接使用；所有运算都返回新值，不修改接收者。
+// JSON 编码为字符串（与交易所返回的格式一致），解码时兼容字符串和数字。
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// NewDecimal 返回 coef * 10^-scale
+func NewDecimal(coef int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(coef), scale)
+}
+
+func newDecimal(coef *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(coef, pow10(-scale))}
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// NewDecimalFromInt 整数转 Decimal
+func NewDecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// NewDecimalFromFloat float64 转 Decimal，取最短的十进制表示，NaN 和 Inf 返回 0
+func NewDecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// maxExponent 科学计数法指数的上限，交易所不会返回这么大的指数，更大的值会让 1e2000000000 这样的输入分配巨大的 big.Int
+const maxExponent = 1000
+
+// ParseDecimal 解析十进制字符串，支持 "-1.5"、"+2"、".5"、"1e-8"
+func ParseDecimal(s string) (Decimal, error) {
+	raw := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("parse decimal %q: empty string", raw)
+	}
+
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("parse decimal %q: bad exponent", raw)
+		}
+		if e > maxExponent || e < -maxExponent {
+			return Decimal{}, fmt.Errorf("parse decimal %q: exponent out of range", raw)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" {
+		return Decimal{}, fmt.Errorf("parse decimal %q: no digits", raw)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Decimal{}, fmt.Errorf("parse decimal %q: invalid character %q", raw, r)
+		}
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("parse decimal %q", raw)
+	}
+	if neg {
+		coef.Neg(coef)
+	}
+	// 小数位极多时 scale 也可能溢出
+	scale := int64(len(fracPart)) - exp
+	if scale < math.MinInt32 || scale > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("parse decimal %q: exponent out of range", raw)
+	}
+	return newDecimal(coef, int32(scale)), nil
+}
+
+// ParseDecimalOrZero 解析交易所返回的数值字段，空串或非法值返回 0
+func ParseDecimalOrZero(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// RequireDecimal 解析调用方传入的数值参数，非法时返回带字段名的错误
+func RequireDecimal(name, s string) (Decimal, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: %s: %v", ErrInvalidRequest, name, err)
+	}
+	return d, nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale 把系数放大到目标 scale，scale 只能变大
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return new(big.Int).Set(d.int())
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add 加法
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub 减法
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul 乘法，结果精确
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div 除法，结果四舍五入保留 places 位小数
+func (d Decimal) Div(o Decimal, places int32) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d/o = (dc * 10^(places+1+os-ds)) / oc * 10^-(places+1)，多算一位用于舍入
+	shift := places + 1 + o.scale - d.scale
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(o.int())
+	if shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	q := new(big.Int).Quo(num, den)
+	return Decimal{coef: q, scale: places + 1}.Round(places), nil
+}
+
+// Neg 取反
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs 绝对值
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Sign 返回 -1、0、1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero 是否为 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp 比较大小，d < o 返回 -1，相等返回 0，d > o 返回 1
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+// Equal 数值相等（忽略小数位数），"1.50" 等于 "1.5"
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// LessThan d < o
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+// GreaterThan d > o
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+const (
+	roundDown = iota // 向零舍入
+	roundFloor
+	roundCeil
+	roundHalfUp // 四舍五入，远离零
+)
+
+// quoRound 计算 num/den 并按 mode 取整
+func quoRound(num, den *big.Int, mode int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// r 与 num 同号，r 与 den 符号之积即真实商的符号
+	sign := r.Sign() * den.Sign()
+	switch mode {
+	case roundFloor:
+		if sign < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case roundCeil:
+		if sign > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case roundHalfUp:
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+			q.Add(q, big.NewInt(int64(sign)))
+		}
+	}
+	return q
+}
+
+func (d Decimal) round(places int32, mode int) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return newDecimal(quoRound(d.int(), pow10(d.scale-places), mode), places)
+}
+
+// Truncate 保留 places 位小数，多余部分直接舍弃（向零）
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.round(places, roundDown)
+}
+
+// Floor 保留 places 位小数，向负无穷舍入
+func (d Decimal) Floor(places int32) Decimal {
+	return d.round(places, roundFloor)
+}
+
+// Ceil 保留 places 位小数，舍弃的尾数不为 0 时强制进位
+func (d Decimal) Ceil(places int32) Decimal {
+	return d.round(places, roundCeil)
+}
+
+// Round 四舍五入保留 places 位小数
+func (d Decimal) Round(places int32) Decimal {
+	return d.round(places, roundHalfUp)
+}
+
+func (d Decimal) toStep(step Decimal, mode int) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b, scale := align(d, step)
+	n := quoRound(a, b, mode)
+	return Decimal{coef: n.Mul(n, b), scale: scale}
+}
+
+// FloorToStep 向下取整到 step 的整数倍，例如按 lotSz 截断下单数量
+func (d Decimal) FloorToStep(step Decimal) Decimal {
+	return d.toStep(step, roundFloor)
+}
+
+// CeilToStep 向上取整到 step 的整数倍
+func (d Decimal) CeilToStep(step Decimal) Decimal {
+	return d.toStep(step, roundCeil)
+}
+
+// RoundToStep 四舍五入到 step 的整数倍，例如按 tickSz 对齐价格
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	return d.toStep(step, roundHalfUp)
+}
+
+// normalize 去掉末尾的 0
+func (d Decimal) normalize() Decimal {
+	if d.IsZero() {
+		return Decimal{}
+	}
+	coef := new(big.Int).Set(d.int())
+	scale := d.scale
+	r := new(big.Int)
+	for scale > 0 {
+		q, m := new(big.Int).QuoRem(coef, bigTen, r)
+		if m.Sign() != 0 {
+			break
+		}
+		coef = q
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Places 有效小数位数，"0.0100" 返回 2，"5" 返回 0
+func (d Decimal) Places() int {
+	return int(d.normalize().scale)
+}
+
+// String 返回不带末尾 0 的十进制表示
+func (d Decimal) String() string {
+	return d.format(d.normalize())
+}
+
+// StringFixed 四舍五入后固定输出 places 位小数
+func (d Decimal) StringFixed(places int32) string {
+	r := d.Round(places)
+	return d.format(Decimal{coef: r.rescale(places), scale: places})
+}
+
+func (Decimal) format(d Decimal) string {
+	c := d.int()
+	neg := c.Sign() < 0
+	s := new(big.Int).Abs(c).String()
+	if d.scale > 0 {
+		if len(s) <= int(d.scale) {
+			s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 转 float64，可能丢失精度，仅用于展示或与旧代码交互
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON 编码为 JSON 字符串
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON 兼容 "1.5"、1.5、""、null
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		s, err = strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("unmarshal decimal %s: %w", data, err)
+		}
+		if strings.TrimSpace(s) == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
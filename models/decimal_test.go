@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func d(s string) Decimal {
+	v, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]string{
+		"0":           "0",
+		"1.50":        "1.5",
+		"-0.001":      "-0.001",
+		"+2":          "2",
+		".5":          "0.5",
+		"1e-8":        "0.00000001",
+		"1.2E3":       "1200",
+		"00012.3400":  "12.34",
+		"0.000000000": "0",
+	}
+	for in, want := range cases {
+		if got := d(in).String(); got != want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", in, got, want)
+		}
+	}
+
+	// 指数上限为 1000
+	if got := d("1e1000").String(); len(got) != 1001 {
+		t.Errorf("ParseDecimal(1e1000) has %d digits", len(got))
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3", "-", "1e", "1e-2147483648", "0.1e-2147483647", "1e2147483648", "1e2000000000", "1e-1001", "1e1001"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) expected error", in)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	// 0.1 + 0.2 在 float64 下不等于 0.3
+	if got := d("0.1").Add(d("0.2")); !got.Equal(d("0.3")) {
+		t.Errorf("0.1+0.2 = %s", got)
+	}
+	if got := d("1").Sub(d("0.00000001")).String(); got != "0.99999999" {
+		t.Errorf("sub = %s", got)
+	}
+	if got := d("0.00001234").Mul(d("3000")).String(); got != "0.03702" {
+		t.Errorf("mul = %s", got)
+	}
+	q, err := d("10").Div(d("3"), 4)
+	if err != nil || q.String() != "3.3333" {
+		t.Errorf("div = %s, %v", q, err)
+	}
+	q, _ = d("2").Div(d("3"), 2)
+	if q.String() != "0.67" {
+		t.Errorf("div round = %s", q)
+	}
+	if _, err := d("1").Div(Decimal{}, 2); err != ErrDivisionByZero {
+		t.Errorf("div by zero err = %v", err)
+	}
+	var zero Decimal
+	if !zero.Add(d("1")).Equal(d("1")) || zero.String() != "0" {
+		t.Errorf("zero value not usable")
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	cases := []struct {
+		in                         string
+		places                     int32
+		trunc, floor, ceil, halfUp string
+	}{
+		{"1.005", 2, "1", "1", "1.01", "1.01"},
+		{"1.1", 2, "1.1", "1.1", "1.1", "1.1"},
+		{"-1.235", 2, "-1.23", "-1.24", "-1.23", "-1.24"},
+		{"0.123456789", 4, "0.1234", "0.1234", "0.1235", "0.1235"},
+	}
+	for _, c := range cases {
+		v := d(c.in)
+		if got := v.Truncate(c.places).String(); got != c.trunc {
+			t.Errorf("Truncate(%s) = %s, want %s", c.in, got, c.trunc)
+		}
+		if got := v.Floor(c.places).String(); got != c.floor {
+			t.Errorf("Floor(%s) = %s, want %s", c.in, got, c.floor)
+		}
+		if got := v.Ceil(c.places).String(); got != c.ceil {
+			t.Errorf("Ceil(%s) = %s, want %s", c.in, got, c.ceil)
+		}
+		if got := v.Round(c.places).String(); got != c.halfUp {
+			t.Errorf("Round(%s) = %s, want %s", c.in, got, c.halfUp)
+		}
+	}
+
+	if got := d("1.23456").FloorToStep(d("0.005")).String(); got != "1.23" {
+		t.Errorf("FloorToStep = %s", got)
+	}
+	if got := d("1.23456").CeilToStep(d("0.005")).String(); got != "1.235" {
+		t.Errorf("CeilToStep = %s", got)
+	}
+	if got := d("1234").RoundToStep(d("5")).String(); got != "1235" {
+		t.Errorf("RoundToStep = %s", got)
+	}
+	if got := d("2.5").StringFixed(3); got != "2.500" {
+		t.Errorf("StringFixed = %s", got)
+	}
+	if got := d("0.0100").Places(); got != 2 {
+		t.Errorf("Places = %d", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var p PriceLevel
+	if err := json.Unmarshal([]byte(`{"price":"0.00001234","quantity":15}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Price.String() != "0.00001234" || p.Quantity.String() != "15" {
+		t.Fatalf("unmarshal = %+v", p)
+	}
+	b, _ := json.Marshal(p)
+	if string(b) != `{"price":"0.00001234","quantity":"15"}` {
+		t.Fatalf("marshal = %s", b)
+	}
+
+	var o OrderInfo
+	if err := json.Unmarshal([]byte(`{"price":"","filled":null}`), &o); err != nil {
+		t.Fatal(err)
+	}
+	if !o.Price.IsZero() || !o.Filled.IsZero() {
+		t.Fatalf("empty fields should decode to zero: %+v", o)
+	}
+}
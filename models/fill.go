@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultFillWindow GetFills 的 since 为零时查询 until 之前这么长时间的成交
+const DefaultFillWindow = 24 * time.Hour
+
+// FillRange 规范化 GetFills 的时间范围：until 为零时取 now，since 为零时取 until 前 DefaultFillWindow
+func FillRange(since, until, now time.Time) (time.Time, time.Time, error) {
+	return timeRange(since, until, now, DefaultFillWindow)
+}
+
+// timeRange until 为零时取 now，since 为零时取 until 前 window
+func timeRange(since, until, now time.Time, window time.Duration) (time.Time, time.Time, error) {
+	if until.IsZero() {
+		until = now
+	}
+	if since.IsZero() {
+		since = until.Add(-window)
+	}
+	if since.After(until) {
+		return since, until, fmt.Errorf("%w: since %s after until %s", ErrInvalidRequest, since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+	return since, until, nil
+}
+
+// SetRealFees 按实际成交计算 TakerFeeFromRealOrder / MakerFeeFromRealOrder（按成交额加权）。
+// 手续费以基础币收取时折算成计价币；以其它币抵扣（如 BNB）的成交无法折算，跳过并把 IfDiscount 置为 true。
+// contractValue 为合约面值，数量为张数时（OKX 合约）用来换算成币数，其它情况传 1。没有可用成交的一侧保持为 0
+func (f *TradingFee) SetRealFees(fills []Fill, baseAsset, quoteAsset string, contractValue Decimal) {
+	var fee, notional [2]Decimal // 0 taker，1 maker
+	for _, fl := range fills {
+		qty := fl.Quantity.Mul(contractValue)
+		n := fl.Price.Mul(qty)
+		var quoteFee Decimal
+		switch fl.FeeAsset {
+		case quoteAsset:
+			quoteFee = fl.Fee
+		case baseAsset:
+			quoteFee = fl.Fee.Mul(fl.Price)
+		default:
+			f.IfDiscount = true
+			continue
+		}
+		i := 0
+		if fl.Maker {
+			i = 1
+		}
+		fee[i], notional[i] = fee[i].Add(quoteFee), notional[i].Add(n)
+	}
+	if rate, err := fee[0].Div(notional[0], 8); err == nil {
+		f.TakerFeeFromRealOrder = rate
+	}
+	if rate, err := fee[1].Div(notional[1], 8); err == nil {
+		f.MakerFeeFromRealOrder = rate
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFillRange(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	since, until, err := FillRange(time.Time{}, time.Time{}, now)
+	if err != nil || !until.Equal(now) || !since.Equal(now.Add(-DefaultFillWindow)) {
+		t.Fatalf("range = %s %s %v", since, until, err)
+	}
+	if _, _, err := FillRange(now, now.Add(-time.Second), now); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestSetRealFees(t *testing.T) {
+	d := ParseDecimalOrZero
+	fills := []Fill{
+		// 买入手续费以 BTC 收取：0.00001 BTC * 40000 = 0.4 USDT，成交额 400
+		{Price: d("40000"), Quantity: d("0.01"), Fee: d("0.00001"), FeeAsset: "BTC"},
+		// 卖出手续费以 USDT 收取：0.6 / 600
+		{Price: d("30000"), Quantity: d("0.02"), Fee: d("0.6"), FeeAsset: "USDT"},
+		// maker 返佣
+		{Price: d("40000"), Quantity: d("0.01"), Fee: d("-0.02"), FeeAsset: "USDT", Maker: true},
+		{Price: d("40000"), Quantity: d("0.01"), Fee: d("0.001"), FeeAsset: "BNB"},
+	}
+	var fee TradingFee
+	fee.SetRealFees(fills, "BTC", "USDT", NewDecimalFromInt(1))
+	if fee.TakerFeeFromRealOrder.String() != "0.001" || fee.MakerFeeFromRealOrder.String() != "-0.00005" || !fee.IfDiscount {
+		t.Fatalf("fee = %+v", fee)
+	}
+
+	// 数量为张数，面值 0.01
+	fee = TradingFee{}
+	fee.SetRealFees([]Fill{{Price: d("2000"), Quantity: d("10"), Fee: d("0.1"), FeeAsset: "USDT"}}, "ETH", "USDT", d("0.01"))
+	if fee.TakerFeeFromRealOrder.String() != "0.0005" || !fee.MakerFeeFromRealOrder.IsZero() {
+		t.Fatalf("contract fee = %+v", fee)
+	}
+}
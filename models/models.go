@@ -13,59 +13,59 @@ type WsData struct {
 }
 
 type PositionInfo struct {
-	Symbol           string `json:"symbol"`
-	PositionAmt      string `json:"positionAmt"`
-	EntryPrice       string `json:"entryPrice"`
-	MarkPrice        string `json:"markPrice"`
-	UnRealizedProfit string `json:"unRealizedProfit"`
-	LiquidationPrice string `json:"liquidationPrice"`
-	Leverage         string `json:"leverage"`
-	MaxNotionalValue string `json:"maxNotionalValue"`
-	MarginType       string `json:"marginType"`
-	IsolatedMargin   string `json:"isolatedMargin"`
-	IsAutoAddMargin  string `json:"isAutoAddMargin"`
-	PositionSide     string `json:"positionSide"`
-	Notional         string `json:"notional"`
-	IsolatedWallet   string `json:"isolatedWallet"`
-	UpdateTime       int64  `json:"updateTime"`
+	Symbol           string  `json:"symbol"`
+	PositionAmt      Decimal `json:"positionAmt"`
+	EntryPrice       Decimal `json:"entryPrice"`
+	MarkPrice        Decimal `json:"markPrice"`
+	UnRealizedProfit Decimal `json:"unRealizedProfit"`
+	LiquidationPrice Decimal `json:"liquidationPrice"`
+	Leverage         Decimal `json:"leverage"`
+	MaxNotionalValue Decimal `json:"maxNotionalValue"`
+	MarginType       string  `json:"marginType"`
+	IsolatedMargin   Decimal `json:"isolatedMargin"`
+	IsAutoAddMargin  string  `json:"isAutoAddMargin"`
+	PositionSide     string  `json:"positionSide"`
+	Notional         Decimal `json:"notional"`
+	IsolatedWallet   Decimal `json:"isolatedWallet"`
+	UpdateTime       int64   `json:"updateTime"`
 }
 
 type FutureBalance struct {
-	Asset            string `json:"asset"`
-	TotalBalance     string `json:"totalBalance"`
-	CrossBalance     string `json:"crossBalance"`
-	AvailableBalance string `json:"availableBalance"`
+	Asset            string  `json:"asset"`
+	TotalBalance     Decimal `json:"totalBalance"`
+	CrossBalance     Decimal `json:"crossBalance"`
+	AvailableBalance Decimal `json:"availableBalance"`
 }
 type FutureOrderInfo struct {
-	AvgPrice      string `json:"avgPrice"`
-	CumQuote      string `json:"cumQuote"`
-	ExecutedQty   string `json:"executedQty"`
-	OrderId       int    `json:"orderId"`
-	OrigQty       string `json:"origQty"`
-	OrigType      string `json:"origType"`
-	Price         string `json:"price"`
-	ReduceOnly    bool   `json:"reduceOnly"`
-	Side          string `json:"side"`
-	PositionSide  string `json:"positionSide"`
-	Status        string `json:"status"`
-	StopPrice     string `json:"stopPrice"`
-	ClosePosition bool   `json:"closePosition"`
-	Symbol        string `json:"symbol"`
-	Time          int64  `json:"time"`
-	TimeInForce   string `json:"timeInForce"`
-	Type          string `json:"type"`
-	UpdateTime    int64  `json:"updateTime"`
-	PriceProtect  bool   `json:"priceProtect"`
+	AvgPrice      Decimal `json:"avgPrice"`
+	CumQuote      Decimal `json:"cumQuote"`
+	ExecutedQty   Decimal `json:"executedQty"`
+	OrderId       int     `json:"orderId"`
+	OrigQty       Decimal `json:"origQty"`
+	OrigType      string  `json:"origType"`
+	Price         Decimal `json:"price"`
+	ReduceOnly    bool    `json:"reduceOnly"`
+	Side          string  `json:"side"`
+	PositionSide  string  `json:"positionSide"`
+	Status        string  `json:"status"`
+	StopPrice     Decimal `json:"stopPrice"`
+	ClosePosition bool    `json:"closePosition"`
+	Symbol        string  `json:"symbol"`
+	Time          int64   `json:"time"`
+	TimeInForce   string  `json:"timeInForce"`
+	Type          string  `json:"type"`
+	UpdateTime    int64   `json:"updateTime"`
+	PriceProtect  bool    `json:"priceProtect"`
 }
 type FundingRate struct {
-	Symbol               string `json:"symbol"`
-	MarkPrice            string `json:"markPrice"`  // 标记价格
-	IndexPrice           string `json:"indexPrice"` // 指数价格
-	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
-	LastFundingRate      string `json:"lastFundingRate"` // 当前 Funding rate
-	NextFundingTime      int64  `json:"nextFundingTime"` // next 更新时间 时间cuo
-	InterestRate         string `json:"interestRate"`    // 0 0
-	Time                 int64  `json:"time"`
+	Symbol               string  `json:"symbol"`
+	MarkPrice            Decimal `json:"markPrice"`  // 标记价格
+	IndexPrice           Decimal `json:"indexPrice"` // 指数价格
+	EstimatedSettlePrice Decimal `json:"estimatedSettlePrice"`
+	LastFundingRate      Decimal `json:"lastFundingRate"` // 当前 Funding rate
+	NextFundingTime      int64   `json:"nextFundingTime"` // next 更新时间 时间cuo
+	InterestRate         Decimal `json:"interestRate"`    // 0 0
+	Time                 int64   `json:"time"`
 }
 type Candle struct {
 	Symbol  string   `json:"symbol"`
@@ -99,28 +99,28 @@ type PrvWsTradeOrderInfo struct {
 }
 
 type PriceLevel struct {
-	Price    string `json:"price"`
-	Quantity string `json:"quantity"`
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
 }
 
 type OrderInfo struct {
-	OrderID string `json:"order_id"`
-	Symbol  string `json:"symbol"`
-	Side    string `json:"side"`
-	Price   string `json:"price"`
+	OrderID string  `json:"order_id"`
+	Symbol  string  `json:"symbol"`
+	Side    string  `json:"side"`
+	Price   Decimal `json:"price"`
 	// Size     string `json:"size"`
-	Quantity string `json:"quantity"`
-	Type     string `json:"type"`
-	Filled   string `json:"filled"`
-	USDT     string `json:"usdt"`
-	Status   string `json:"status"`
-	Time     int64  `json:"time"`
+	Quantity Decimal `json:"quantity"`
+	Type     string  `json:"type"`
+	Filled   Decimal `json:"filled"`
+	USDT     Decimal `json:"usdt"`
+	Status   string  `json:"status"`
+	Time     int64   `json:"time"`
 }
 
 type Balance struct {
-	Asset  string `json:"asset"`
-	Free   string `json:"free"`
-	Locked string `json:"locked"`
+	Asset  string  `json:"asset"`
+	Free   Decimal `json:"free"`
+	Locked Decimal `json:"locked"`
 }
 
 type MexcOrderInfo struct {
@@ -158,18 +158,25 @@ type OrderList struct {
 }
 
 type TradingFee struct {
-	Symbol                string `json:"symbol"`
-	TakerFeeFromApi       string `json:"taker_fee_from_api"`
-	MakerFeeFromApi       string `json:"maker_fee_from_api"`
-	TakerFeeFromRealOrder string `json:"taker_fee_from_real_order"`
-	MakerFeeFromRealOrder string `json:"maker_fee_from_real_order"`
-	IfDiscount            bool   `json:"if_discount"`
+	Symbol          string  `json:"symbol"`
+	TakerFeeFromApi Decimal `json:"taker_fee_from_api"`
+	MakerFeeFromApi Decimal `json:"maker_fee_from_api"`
+	// 按最近 24 小时实际成交计算的费率（见 SetRealFees），正数为支出，没有成交时为 0
+	TakerFeeFromRealOrder Decimal `json:"taker_fee_from_real_order"`
+	MakerFeeFromRealOrder Decimal `json:"maker_fee_from_real_order"`
+	IfDiscount            bool    `json:"if_discount"`
 }
 type PairInfo struct {
-	MinBaseAmount   string `json:"min_base_amount"`
-	MinQuoteAmount  string `json:"min_quote_amount"`
-	AmountPrecision int    `json:"amount_precision"`
-	Precision       int    `json:"precision"`
+	MinBaseAmount   Decimal `json:"min_base_amount"`
+	MinQuoteAmount  Decimal `json:"min_quote_amount"`
+	AmountPrecision int     `json:"amount_precision"`
+	Precision       int     `json:"precision"`
+	TickSize        Decimal `json:"tick_size"`
+	LotSize         Decimal `json:"lot_size"`
+	MinSize         Decimal `json:"min_size"`
+	MaxLimitSize    Decimal `json:"max_limit_size"`
+	MaxMarketSize   Decimal `json:"max_market_size"`
+	MinNotional     Decimal `json:"min_notional"`
 }
 
 // SideAdaptor Uniformity Side 统一 side 适配器
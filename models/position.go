@@ -0,0 +1,81 @@
+package models
+
+import "AxonTrading/base"
+
+// 持仓的产品类型，与 instrument.Kind 的取值相同，MARGIN 为现货杠杆
+const (
+	PositionSwap    = "SWAP"
+	PositionFutures = "FUTURES"
+	PositionOption  = "OPTION"
+	PositionMargin  = "MARGIN"
+)
+
+// PositionFilter GetPositions 的过滤条件，字段为空表示不限
+type PositionFilter struct {
+	Kinds   []string `json:"kinds"`   // PositionSwap / PositionFutures / PositionOption / PositionMargin
+	Symbols []string `json:"symbols"` // 统一符号或交易所原生 id
+}
+
+// Match p 是否满足过滤条件
+func (f PositionFilter) Match(p Position) bool {
+	return f.HasKind(p.Kind) && (len(f.Symbols) == 0 || contains(f.Symbols, p.Symbol) || contains(f.Symbols, p.InstrumentID))
+}
+
+// HasKind 过滤条件是否包含 kind 类型的持仓
+func (f PositionFilter) HasKind(kind string) bool {
+	return len(f.Kinds) == 0 || contains(f.Kinds, kind)
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Greeks 期权持仓的希腊值（Black-Scholes，以美元计）
+type Greeks struct {
+	Delta Decimal `json:"delta"`
+	Gamma Decimal `json:"gamma"`
+	Theta Decimal `json:"theta"`
+	Vega  Decimal `json:"vega"`
+}
+
+// Position 一个持仓的完整信息，价格以计价币计，保证金和盈亏以保证金币种计
+type Position struct {
+	Symbol           string  `json:"symbol"`        // 统一符号，无法转换时为原生 id
+	InstrumentID     string  `json:"instrument_id"` // 交易所原生 id
+	Kind             string  `json:"kind"`          // PositionSwap / PositionFutures / PositionOption / PositionMargin
+	PositionID       string  `json:"position_id"`
+	Side             string  `json:"side"`          // base.LONG / base.SHORT，按持仓数量的方向
+	PositionSide     string  `json:"position_side"` // 双向持仓时为 base.LONG / base.SHORT，单向持仓为空
+	MarginType       string  `json:"margin_type"`   // base.ISOLATED / base.CROSSED
+	MarginAsset      string  `json:"margin_asset"`
+	Quantity         Decimal `json:"quantity"`  // 持仓数量，正数；OKX 合约为张数，Binance 为币数
+	Available        Decimal `json:"available"` // 可平数量
+	EntryPrice       Decimal `json:"entry_price"`
+	MarkPrice        Decimal `json:"mark_price"`
+	LiquidationPrice Decimal `json:"liquidation_price"`
+	Leverage         Decimal `json:"leverage"`
+	Notional         Decimal `json:"notional"` // 美元
+	Margin           Decimal `json:"margin"`   // 逐仓为仓位保证金（不含未实现盈亏），全仓为初始保证金
+	InitialMargin    Decimal `json:"initial_margin"`
+	MaintMargin      Decimal `json:"maint_margin"`
+	MarginRatio      Decimal `json:"margin_ratio"` // 逐仓为 MaintMargin / (Margin + UnrealizedPnl)，全仓看 Account.MarginRatio
+	UnrealizedPnl    Decimal `json:"unrealized_pnl"`
+	RealizedPnl      Decimal `json:"realized_pnl"` // 开仓以来的已实现盈亏，含手续费和资金费
+	ADLRank          int     `json:"adl_rank"`     // 自动减仓排名 1-5，越大越先减仓，0 表示未知
+	Greeks           Greeks  `json:"greeks"`
+	OpenTime         int64   `json:"open_time"` // 0 表示未知
+	UpdateTime       int64   `json:"update_time"`
+}
+
+// IsolatedMarginRatio 逐仓仓位的保证金率，全仓为 0
+func (p Position) IsolatedMarginRatio() Decimal {
+	if p.MarginType != base.ISOLATED {
+		return Decimal{}
+	}
+	return MarginRatio(p.MaintMargin, p.Margin.Add(p.UnrealizedPnl))
+}
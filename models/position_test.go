@@ -0,0 +1,37 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"testing"
+)
+
+func TestPositionFilter(t *testing.T) {
+	p := Position{Symbol: "BTC/USDT:USDT", InstrumentID: "BTC-USDT-SWAP", Kind: PositionSwap}
+	for _, tc := range []struct {
+		filter PositionFilter
+		want   bool
+	}{
+		{PositionFilter{}, true},
+		{PositionFilter{Kinds: []string{PositionFutures, PositionSwap}}, true},
+		{PositionFilter{Kinds: []string{PositionOption}}, false},
+		{PositionFilter{Symbols: []string{"BTC-USDT-SWAP"}}, true},
+		{PositionFilter{Symbols: []string{"BTC/USDT:USDT"}}, true},
+		{PositionFilter{Kinds: []string{PositionSwap}, Symbols: []string{"ETH/USDT:USDT"}}, false},
+	} {
+		if got := tc.filter.Match(p); got != tc.want {
+			t.Fatalf("%+v Match = %v", tc.filter, got)
+		}
+	}
+}
+
+func TestIsolatedMarginRatio(t *testing.T) {
+	d := ParseDecimalOrZero
+	p := Position{MarginType: base.ISOLATED, Margin: d("20"), UnrealizedPnl: d("10"), MaintMargin: d("0.84")}
+	if r := p.IsolatedMarginRatio(); r.String() != "0.028" {
+		t.Fatalf("isolated = %s", r)
+	}
+	p.MarginType = base.CROSSED
+	if r := p.IsolatedMarginRatio(); !r.IsZero() {
+		t.Fatalf("cross = %s", r)
+	}
+}
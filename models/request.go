@@ -0,0 +1,203 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRequest 请求参数不合法
+var ErrInvalidRequest = errors.New("invalid request")
+
+// OrderRequest 统一下单请求（现货）
+type OrderRequest struct {
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"` // base.BID / base.ASK
+	Type          string  `json:"type"` // base.LIMIT / base.MARKET / base.MAKER / base.TAKER / base.LIMITHIDDEN
+	Price         Decimal `json:"price"`
+	Size          Decimal `json:"size"`
+	ClientOrderID string  `json:"client_order_id"`
+}
+
+// Validate 校验必填字段，市价单可以不填价格
+func (r OrderRequest) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: empty symbol", ErrInvalidRequest)
+	}
+	if r.Side != base.BID && r.Side != base.ASK {
+		return fmt.Errorf("%w: unknown side %q", ErrInvalidRequest, r.Side)
+	}
+	switch r.Type {
+	case base.MARKET:
+	case base.LIMIT, base.MAKER, base.TAKER, base.LIMITHIDDEN:
+		if r.Price.Sign() <= 0 {
+			return fmt.Errorf("%w: %s order without price", ErrInvalidRequest, r.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown order type %q", ErrInvalidRequest, r.Type)
+	}
+	if r.Size.Sign() <= 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// OrderResult 下单结果
+type OrderResult struct {
+	Symbol        string `json:"symbol"`
+	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id"`
+}
+
+// CancelRequest 撤单请求
+type CancelRequest struct {
+	Symbol  string `json:"symbol"`
+	OrderID string `json:"order_id"`
+}
+
+// Validate 校验必填字段
+func (r CancelRequest) Validate() error {
+	if r.Symbol == "" || r.OrderID == "" {
+		return fmt.Errorf("%w: cancel needs symbol and order id", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// AmendRequest 改单请求，Price、Size 为 0 表示不变，Size 为含已成交部分的总数量
+type AmendRequest struct {
+	Symbol  string  `json:"symbol"`
+	OrderID string  `json:"order_id"`
+	Price   Decimal `json:"price"`
+	Size    Decimal `json:"size"`
+}
+
+// Validate 校验必填字段，价格和数量至少修改一个
+func (r AmendRequest) Validate() error {
+	if r.Symbol == "" || r.OrderID == "" {
+		return fmt.Errorf("%w: amend needs symbol and order id", ErrInvalidRequest)
+	}
+	if r.Price.Sign() < 0 || r.Size.Sign() < 0 {
+		return fmt.Errorf("%w: price and size must not be negative", ErrInvalidRequest)
+	}
+	if r.Price.IsZero() && r.Size.IsZero() {
+		return fmt.Errorf("%w: nothing to amend", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// CancelResult 撤单结果
+type CancelResult struct {
+	OrderID  string `json:"order_id"`
+	Canceled bool   `json:"canceled"`
+}
+
+// OrderQuery 查询单个订单
+type OrderQuery struct {
+	Symbol  string `json:"symbol"`
+	OrderID string `json:"order_id"`
+}
+
+// OpenOrdersRequest 查询挂单，Side 为空时返回全部方向
+type OpenOrdersRequest struct {
+	Symbol string `json:"symbol"`
+	Side   string `json:"side"`
+}
+
+// DepthRequest 深度请求，Limit 为深度档位
+type DepthRequest struct {
+	Symbol string `json:"symbol"`
+	Limit  int    `json:"limit"`
+}
+
+// Validate 校验必填字段
+func (r DepthRequest) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: empty symbol", ErrInvalidRequest)
+	}
+	if r.Limit <= 0 {
+		return fmt.Errorf("%w: depth limit must be positive", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// FutureOrderRequest 期货下单请求
+type FutureOrderRequest struct {
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`          // base.BID / base.ASK
+	PositionSide  string  `json:"position_side"` // base.LONG / base.SHORT
+	Type          string  `json:"type"`          // base.LIMIT / base.MARKET / base.STOP ...
+	Price         Decimal `json:"price"`
+	Size          Decimal `json:"size"`
+	StopPrice     Decimal `json:"stop_price"`
+	MarginType    string  `json:"margin_type"` // base.ISOLATED / base.CROSSED
+	ClosePosition bool    `json:"close_position"`
+	PriceProtect  bool    `json:"price_protect"`
+	ClientOrderID string  `json:"client_order_id"`
+}
+
+// Validate 校验必填字段
+func (r FutureOrderRequest) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: empty symbol", ErrInvalidRequest)
+	}
+	if r.Side != base.BID && r.Side != base.ASK {
+		return fmt.Errorf("%w: unknown side %q", ErrInvalidRequest, r.Side)
+	}
+	if r.Size.Sign() <= 0 && !r.ClosePosition {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidRequest)
+	}
+	switch r.Type {
+	case base.MARKET:
+	case base.LIMIT:
+		if r.Price.Sign() <= 0 {
+			return fmt.Errorf("%w: limit order without price", ErrInvalidRequest)
+		}
+	case base.STOP, base.TAKEPROFIT:
+		if r.Price.Sign() <= 0 || r.StopPrice.Sign() <= 0 {
+			return fmt.Errorf("%w: %s order needs price and stop price", ErrInvalidRequest, r.Type)
+		}
+	case base.STOPMARKET, base.TAKEPROFITMARKET:
+		if r.StopPrice.Sign() <= 0 {
+			return fmt.Errorf("%w: %s order without stop price", ErrInvalidRequest, r.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown order type %q", ErrInvalidRequest, r.Type)
+	}
+	return nil
+}
+
+// LeverageRequest 调整杠杆倍数
+type LeverageRequest struct {
+	Symbol   string `json:"symbol"`
+	Leverage int    `json:"leverage"`
+}
+
+// MarginRequest 调整逐仓保证金，Type 为 base.ADDMARGIN / base.REMOVEMARGIN
+type MarginRequest struct {
+	Symbol       string  `json:"symbol"`
+	PositionSide string  `json:"position_side"`
+	Amount       Decimal `json:"amount"`
+	Type         int     `json:"type"`
+}
+
+// WithdrawRequest 提币请求
+type WithdrawRequest struct {
+	Currency string  `json:"currency"`
+	Chain    string  `json:"chain"`
+	Address  string  `json:"address"`
+	Tag      string  `json:"tag"` // memo / tag，链上不需要时为空
+	Amount   Decimal `json:"amount"`
+}
+
+// Validate 校验必填字段
+func (r WithdrawRequest) Validate() error {
+	if r.Currency == "" || r.Chain == "" || r.Address == "" || r.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: withdraw needs currency, chain, address and amount", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// WithdrawResult 提币结果
+type WithdrawResult struct {
+	WithdrawID string `json:"withdraw_id"`
+}
@@ -0,0 +1,81 @@
+package models
+
+// 推送（websocket）的统一模型，各交易所的订阅接口都转换成这些类型。
+// 深度推送使用 WsData，标记价格使用 FundingRate，持仓推送使用 PositionInfo。
+
+// Trade 公开成交
+type Trade struct {
+	Symbol   string  `json:"symbol"`
+	TradeID  string  `json:"tradeId"`
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
+	Side     string  `json:"side"` // 主动成交方向 base.BID / base.ASK
+	Time     int64   `json:"time"`
+}
+
+// Ticker 最优买卖价和最新成交价，推送中没有的字段为 0
+type Ticker struct {
+	Symbol   string  `json:"symbol"`
+	Last     Decimal `json:"last"`
+	BidPrice Decimal `json:"bidPrice"`
+	BidQty   Decimal `json:"bidQty"`
+	AskPrice Decimal `json:"askPrice"`
+	AskQty   Decimal `json:"askQty"`
+	Time     int64   `json:"time"`
+}
+
+// Kline K 线，未收盘的 K 线会重复推送
+type Kline struct {
+	Symbol      string  `json:"symbol"`
+	Interval    string  `json:"interval"`
+	OpenTime    int64   `json:"openTime"`
+	CloseTime   int64   `json:"closeTime"`
+	Open        Decimal `json:"open"`
+	High        Decimal `json:"high"`
+	Low         Decimal `json:"low"`
+	Close       Decimal `json:"close"`
+	Volume      Decimal `json:"volume"`
+	QuoteVolume Decimal `json:"quoteVolume"`
+	Closed      bool    `json:"closed"`
+}
+
+// OrderUpdate 订单状态推送，现货和合约共用，Side/Type/Status 的取值与 OrderInfo 相同
+type OrderUpdate struct {
+	Symbol        string  `json:"symbol"`
+	OrderID       string  `json:"orderId"`
+	ClientOrderID string  `json:"clientOrderId"`
+	Side          string  `json:"side"`
+	PositionSide  string  `json:"positionSide"` // 仅合约
+	Type          string  `json:"type"`
+	Price         Decimal `json:"price"`
+	Quantity      Decimal `json:"quantity"`
+	Filled        Decimal `json:"filled"`
+	AvgPrice      Decimal `json:"avgPrice"`
+	Status        string  `json:"status"`
+	ReduceOnly    bool    `json:"reduceOnly"`
+	Time          int64   `json:"time"`
+}
+
+// Fill 自己订单的一笔成交
+type Fill struct {
+	Symbol        string  `json:"symbol"`
+	OrderID       string  `json:"orderId"`
+	ClientOrderID string  `json:"clientOrderId"`
+	TradeID       string  `json:"tradeId"`
+	Side          string  `json:"side"`
+	Price         Decimal `json:"price"`
+	Quantity      Decimal `json:"quantity"` // 交易所返回的数量，OKX 合约为张数
+	Fee           Decimal `json:"fee"`      // 正数为支出，返佣为负
+	FeeAsset      string  `json:"feeAsset"`
+	Maker         bool    `json:"maker"`
+	Time          int64   `json:"time"`
+}
+
+// BalanceUpdate 余额推送。现货 Free/Locked 为可用/冻结，Total 为两者之和；合约 Total 为钱包余额，Free 为全仓钱包余额
+type BalanceUpdate struct {
+	Asset  string  `json:"asset"`
+	Total  Decimal `json:"total"`
+	Free   Decimal `json:"free"`
+	Locked Decimal `json:"locked"`
+	Time   int64   `json:"time"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"fmt"
+)
+
+// SubAccount 子账户，Name 为 OKX 的子账户名或 Binance 的子账户邮箱
+type SubAccount struct {
+	Name    string `json:"name"`
+	Label   string `json:"label"`
+	Enabled bool   `json:"enabled"` // 未冻结
+	Time    int64  `json:"time"`    // 创建时间
+}
+
+// SubAccountTransferRequest 母子账户之间划转，FromSub / ToSub 为空表示母账户；
+// FromAccount / ToAccount 为 base.FUNDING / SPOT / FUTURES，为空时取 base.SPOT
+type SubAccountTransferRequest struct {
+	Currency    string  `json:"currency"`
+	Amount      Decimal `json:"amount"`
+	FromSub     string  `json:"from_sub"`
+	ToSub       string  `json:"to_sub"`
+	FromAccount string  `json:"from_account"`
+	ToAccount   string  `json:"to_account"`
+}
+
+// Validate 校验必填字段和账户类型
+func (r SubAccountTransferRequest) Validate() error {
+	if r.Currency == "" || r.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: transfer needs currency and positive amount", ErrInvalidRequest)
+	}
+	if r.FromSub == r.ToSub {
+		return fmt.Errorf("%w: transfer from %q to itself", ErrInvalidRequest, r.FromSub)
+	}
+	from, to := r.Accounts()
+	for _, a := range []string{from, to} {
+		switch a {
+		case base.FUNDING, base.SPOT, base.FUTURES:
+		default:
+			return fmt.Errorf("%w: unknown account %q", ErrInvalidRequest, a)
+		}
+	}
+	return nil
+}
+
+// Accounts 转出、转入的账户类型，为空时取 base.SPOT
+func (r SubAccountTransferRequest) Accounts() (from, to string) {
+	from, to = r.FromAccount, r.ToAccount
+	if from == "" {
+		from = base.SPOT
+	}
+	if to == "" {
+		to = base.SPOT
+	}
+	return from, to
+}
+
+// SubAccountAPIKeyRequest 为子账户创建 API key，Permissions 为 base.PermRead / PermTrade / PermWithdraw
+type SubAccountAPIKeyRequest struct {
+	SubAccount  string   `json:"sub_account"`
+	Label       string   `json:"label"`
+	Passphrase  string   `json:"passphrase"` // OKX 必填
+	Permissions []string `json:"permissions"`
+	IPs         []string `json:"ips"` // IP 白名单，为空时不绑定
+}
+
+// Validate 校验必填字段和权限
+func (r SubAccountAPIKeyRequest) Validate() error {
+	if r.SubAccount == "" || r.Label == "" {
+		return fmt.Errorf("%w: api key needs sub account and label", ErrInvalidRequest)
+	}
+	for _, p := range r.Permissions {
+		switch p {
+		case base.PermRead, base.PermTrade, base.PermWithdraw:
+		default:
+			return fmt.Errorf("%w: unknown permission %q", ErrInvalidRequest, p)
+		}
+	}
+	return nil
+}
+
+// SubAccountAPIKey 子账户的 API key，SecretKey 只在创建时返回
+type SubAccountAPIKey struct {
+	SubAccount  string   `json:"sub_account"`
+	Label       string   `json:"label"`
+	APIKey      string   `json:"api_key"`
+	SecretKey   string   `json:"secret_key"`
+	Passphrase  string   `json:"passphrase"`
+	Permissions []string `json:"permissions"`
+	IPs         []string `json:"ips"`
+	Time        int64    `json:"time"`
+}
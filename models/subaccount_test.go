@@ -0,0 +1,44 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"errors"
+	"testing"
+)
+
+func TestSubAccountTransferRequestValidate(t *testing.T) {
+	one := NewDecimalFromInt(1)
+	ok := SubAccountTransferRequest{Currency: "USDT", Amount: one, ToSub: "grid01"}
+	if err := ok.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if from, to := ok.Accounts(); from != base.SPOT || to != base.SPOT {
+		t.Fatalf("accounts = %s %s", from, to)
+	}
+	for _, r := range []SubAccountTransferRequest{
+		{Currency: "USDT", Amount: one},
+		{Currency: "USDT", Amount: one, FromSub: "grid01", ToSub: "grid01"},
+		{Currency: "USDT", Amount: one, ToSub: "grid01", FromAccount: "margin"},
+		{Currency: "USDT", ToSub: "grid01"},
+	} {
+		if err := r.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("%+v err = %v", r, err)
+		}
+	}
+}
+
+func TestSubAccountAPIKeyRequestValidate(t *testing.T) {
+	ok := SubAccountAPIKeyRequest{SubAccount: "grid01", Label: "bot", Permissions: []string{base.PermRead, base.PermTrade}}
+	if err := ok.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []SubAccountAPIKeyRequest{
+		{Label: "bot"},
+		{SubAccount: "grid01"},
+		{SubAccount: "grid01", Label: "bot", Permissions: []string{"admin"}},
+	} {
+		if err := r.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("%+v err = %v", r, err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"fmt"
+	"time"
+)
+
+// DefaultHistoryWindow GetDeposits / GetWithdrawals 的 since 为零时查询 until 之前这么长时间的记录
+const DefaultHistoryWindow = 30 * 24 * time.Hour
+
+// HistoryRange 规范化充提记录的时间范围：until 为零时取 now，since 为零时取 until 前 DefaultHistoryWindow
+func HistoryRange(since, until, now time.Time) (time.Time, time.Time, error) {
+	return timeRange(since, until, now, DefaultHistoryWindow)
+}
+
+// ChainInfo 币种在某条链上的充提信息。Chain 为交易所的链名，不带币种前缀（OKX 如 TRC20，Binance 为 network 如 TRX），
+// 与 GetDepositAddress、WithdrawRequest 的 chain 相同。没有限制的字段为 0
+type ChainInfo struct {
+	Currency       string  `json:"currency"`
+	Chain          string  `json:"chain"`
+	CanDeposit     bool    `json:"can_deposit"`
+	CanWithdraw    bool    `json:"can_withdraw"`
+	WithdrawFee    Decimal `json:"withdraw_fee"`     // 最低（默认）提币手续费
+	MaxWithdrawFee Decimal `json:"max_withdraw_fee"` // 最高提币手续费，固定手续费时与 WithdrawFee 相同
+	MinWithdraw    Decimal `json:"min_withdraw"`
+	MaxWithdraw    Decimal `json:"max_withdraw"`
+	WithdrawStep   Decimal `json:"withdraw_step"` // 提币数量精度，如 0.0001
+	MinDeposit     Decimal `json:"min_deposit"`
+	NeedTag        bool    `json:"need_tag"` // 需要 memo / tag
+}
+
+// Deposit 充值记录，Status 为 base.PROCESSING / SUCCEEDED / FAILED
+type Deposit struct {
+	DepositID string  `json:"deposit_id"`
+	Currency  string  `json:"currency"`
+	Chain     string  `json:"chain"`
+	Amount    Decimal `json:"amount"`
+	Address   string  `json:"address"`
+	Tag       string  `json:"tag"`
+	TxID      string  `json:"tx_id"`
+	Status    string  `json:"status"`
+	Time      int64   `json:"time"`
+}
+
+// Withdrawal 提币记录，Status 为 base.PROCESSING / SUCCEEDED / FAILED / CANCELED
+type Withdrawal struct {
+	WithdrawID string  `json:"withdraw_id"`
+	Currency   string  `json:"currency"`
+	Chain      string  `json:"chain"`
+	Amount     Decimal `json:"amount"` // 不含手续费
+	Fee        Decimal `json:"fee"`
+	Address    string  `json:"address"`
+	Tag        string  `json:"tag"`
+	TxID       string  `json:"tx_id"`
+	Status     string  `json:"status"`
+	Time       int64   `json:"time"`
+}
+
+// Done 提币是否已结束（成功、失败或取消）
+func (w Withdrawal) Done() bool {
+	return w.Status != "" && w.Status != base.PROCESSING
+}
+
+// TransferRequest 账户间划转，From / To 为 base.FUNDING / SPOT / FUTURES
+type TransferRequest struct {
+	Currency string  `json:"currency"`
+	Amount   Decimal `json:"amount"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+}
+
+// Validate 校验必填字段和账户类型
+func (r TransferRequest) Validate() error {
+	if r.Currency == "" || r.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: transfer needs currency and positive amount", ErrInvalidRequest)
+	}
+	for _, a := range []string{r.From, r.To} {
+		switch a {
+		case base.FUNDING, base.SPOT, base.FUTURES:
+		default:
+			return fmt.Errorf("%w: unknown account %q", ErrInvalidRequest, a)
+		}
+	}
+	if r.From == r.To {
+		return fmt.Errorf("%w: transfer from %s to itself", ErrInvalidRequest, r.From)
+	}
+	return nil
+}
+
+// Transfer 划转结果
+type Transfer struct {
+	TransferID string  `json:"transfer_id"`
+	Currency   string  `json:"currency"`
+	Amount     Decimal `json:"amount"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"AxonTrading/base"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHistoryRange(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	since, until, err := HistoryRange(time.Time{}, time.Time{}, now)
+	if err != nil || !until.Equal(now) || !since.Equal(now.Add(-DefaultHistoryWindow)) {
+		t.Fatalf("range = %s %s %v", since, until, err)
+	}
+}
+
+func TestTransferRequestValidate(t *testing.T) {
+	one := NewDecimalFromInt(1)
+	ok := TransferRequest{Currency: "USDT", Amount: one, From: base.FUNDING, To: base.FUTURES}
+	if err := ok.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []TransferRequest{
+		{Currency: "USDT", Amount: one, From: base.SPOT, To: base.SPOT},
+		{Currency: "USDT", Amount: one, From: "margin", To: base.SPOT},
+		{Currency: "USDT", From: base.SPOT, To: base.FUNDING},
+		{Amount: one, From: base.SPOT, To: base.FUNDING},
+	} {
+		if err := r.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("%+v err = %v", r, err)
+		}
+	}
+}
+
+func TestWithdrawalDone(t *testing.T) {
+	for status, done := range map[string]bool{
+		"":              false,
+		base.PROCESSING: false,
+		base.SUCCEEDED:  true,
+		base.FAILED:     true,
+		base.CANCELED:   true,
+	} {
+		if (Withdrawal{Status: status}).Done() != done {
+			t.Fatalf("%q done != %v", status, done)
+		}
+	}
+}
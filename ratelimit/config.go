@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config 限速配置，放在 New/NewFuture 的 params 中：
+//
+//	{"apiKey": "...", "rateLimit": {"mode": "fail", "rules": {"/api/v5/trade/order": {"limit": 30, "interval": "2s"}}}}
+//
+// 没有 rateLimit 字段时使用交易所默认规则和 Block 模式。
+type Config struct {
+	Disabled bool                  `json:"disabled"`
+	Mode     Mode                  `json:"mode"`
+	Rules    map[string]RuleConfig `json:"rules"` // 覆盖或新增默认规则，limit 为 0 表示关闭该规则
+}
+
+// RuleConfig 规则配置，interval 支持 time.ParseDuration 的格式以及 "1d"，为空时沿用默认规则的周期
+type RuleConfig struct {
+	Limit    int    `json:"limit"`
+	Interval string `json:"interval"`
+}
+
+// ParseConfig 从 params 中读取 rateLimit 字段
+func ParseConfig(params []byte) (Config, error) {
+	var p struct {
+		RateLimit Config `json:"rateLimit"`
+	}
+	if len(params) == 0 {
+		return p.RateLimit, nil
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return Config{}, fmt.Errorf("parse rateLimit: %w", err)
+	}
+	switch p.RateLimit.Mode {
+	case "", Block, Fail:
+	default:
+		return Config{}, fmt.Errorf("parse rateLimit: unknown mode %q", p.RateLimit.Mode)
+	}
+	return p.RateLimit, nil
+}
+
+// Build 在默认规则上应用配置，Disabled 时返回 nil（不限速）
+func (c Config) Build(defaults map[string]Rule) (*Limiter, error) {
+	if c.Disabled {
+		return nil, nil
+	}
+	l := New(c.Mode, defaults)
+	for name, rc := range c.Rules {
+		interval := defaults[name].Interval
+		if rc.Interval != "" {
+			var err error
+			if interval, err = parseInterval(rc.Interval); err != nil {
+				return nil, fmt.Errorf("rateLimit rule %s: %w", name, err)
+			}
+		}
+		l.SetRule(name, Rule{Limit: rc.Limit, Interval: interval})
+	}
+	return l, nil
+}
+
+func parseInterval(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("bad interval %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
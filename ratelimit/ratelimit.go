@@ -0,0 +1,233 @@
+// Package ratelimit 令牌桶限速。
+//
+// 每条规则（Rule）对应交易所的一个限速维度，例如 OKX 某个接口按 instId 限速、
+// Binance 账户每分钟的请求权重。同一规则下不同 scope（instId、账户）各自持有一个令牌桶。
+package ratelimit
+
+import (
+	"AxonTrading/base"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Mode 令牌不足时的行为
+type Mode string
+
+const (
+	Block Mode = "block" // 等待令牌，直到 ctx 结束
+	Fail  Mode = "fail"  // 立即返回 base.ErrRateLimited
+)
+
+// Rule 限速规则：每 Interval 最多 Limit 个令牌
+type Rule struct {
+	Limit    int
+	Interval time.Duration
+}
+
+// Cost 一次请求在某条规则上消耗的令牌
+type Cost struct {
+	Rule  string
+	Scope string // 为空表示整个账户共享
+	N     int
+}
+
+// Bucket 令牌桶，令牌按 Limit/Interval 的速率连续补充
+type Bucket struct {
+	mu     sync.Mutex
+	limit  float64
+	rate   float64 // 每纳秒补充的令牌数
+	tokens float64
+	last   time.Time
+	pause  time.Time // 交易所要求暂停（Retry-After）的截止时间
+}
+
+func newBucket(r Rule, now time.Time) *Bucket {
+	return &Bucket{
+		limit:  float64(r.Limit),
+		rate:   float64(r.Limit) / float64(r.Interval),
+		tokens: float64(r.Limit),
+		last:   now,
+	}
+}
+
+func (b *Bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += float64(now.Sub(b.last)) * b.rate
+		if b.tokens > b.limit {
+			b.tokens = b.limit
+		}
+		b.last = now
+	}
+}
+
+// delay 取 n 个令牌还需等待的时间，调用方需持有锁
+func (b *Bucket) delay(n int, now time.Time) time.Duration {
+	b.refill(now)
+	var d time.Duration
+	if now.Before(b.pause) {
+		d = b.pause.Sub(now)
+	}
+	need := float64(n)
+	if need > b.limit {
+		// 单次请求超过桶容量时按桶满计算，避免永远等待
+		need = b.limit
+	}
+	if missing := need - b.tokens; missing > 0 {
+		if w := time.Duration(missing / b.rate); w > d {
+			d = w
+		}
+	}
+	return d
+}
+
+// Sync 用交易所返回的已用额度校准，只会减少本地令牌
+func (b *Bucket) Sync(used int, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	if left := b.limit - float64(used); left < b.tokens {
+		b.tokens = left
+	}
+}
+
+// Pause 交易所返回 429/418 时暂停到 until
+func (b *Bucket) Pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.pause) {
+		b.pause = until
+	}
+}
+
+// Available 当前剩余令牌数
+func (b *Bucket) Available(now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	return b.tokens
+}
+
+type bucketKey struct {
+	rule  string
+	scope string
+}
+
+// Limiter 按规则和 scope 管理令牌桶，并发安全
+type Limiter struct {
+	mode    Mode
+	mu      sync.Mutex
+	take    sync.Mutex
+	rules   map[string]Rule
+	buckets map[bucketKey]*Bucket
+
+	// Now 返回当前时间，测试时可替换
+	Now func() time.Time
+}
+
+// New 创建限速器，rules 为规则名到规则的映射
+func New(mode Mode, rules map[string]Rule) *Limiter {
+	if mode == "" {
+		mode = Block
+	}
+	l := &Limiter{mode: mode, rules: make(map[string]Rule), buckets: make(map[bucketKey]*Bucket), Now: time.Now}
+	for name, r := range rules {
+		l.rules[name] = r
+	}
+	return l
+}
+
+// Mode 令牌不足时的行为
+func (l *Limiter) Mode() Mode {
+	return l.mode
+}
+
+// SetRule 新增或替换规则，已有的令牌桶会被重建
+func (l *Limiter) SetRule(name string, r Rule) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rules[name] = r
+	for k := range l.buckets {
+		if k.rule == name {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Bucket 返回规则在 scope 下的令牌桶，规则未配置时返回 nil
+func (l *Limiter) Bucket(rule, scope string) *Bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.rules[rule]
+	if !ok || r.Limit <= 0 || r.Interval <= 0 {
+		return nil
+	}
+	k := bucketKey{rule, scope}
+	b := l.buckets[k]
+	if b == nil {
+		b = newBucket(r, l.Now())
+		l.buckets[k] = b
+	}
+	return b
+}
+
+// Wait 同时从多条规则中取令牌，要么全部取到，要么一个都不取。
+// 未配置的规则不限速。Fail 模式下令牌不足立即返回 base.ErrRateLimited。
+// nil *Limiter 不限速。
+func (l *Limiter) Wait(ctx context.Context, costs ...Cost) error {
+	if l == nil {
+		return nil
+	}
+	buckets := make([]*Bucket, len(costs))
+	for i, c := range costs {
+		buckets[i] = l.Bucket(c.Rule, c.Scope)
+	}
+	for {
+		d, blocking := l.tryTake(buckets, costs)
+		if d <= 0 {
+			return nil
+		}
+		if l.mode == Fail {
+			return fmt.Errorf("%w: %s needs %v", base.ErrRateLimited, blocking, d)
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (l *Limiter) tryTake(buckets []*Bucket, costs []Cost) (time.Duration, string) {
+	// take 保证检查和扣减之间没有其它请求插入，桶锁每次只持有一个
+	l.take.Lock()
+	defer l.take.Unlock()
+	now := l.Now()
+	var wait time.Duration
+	var blocking string
+	for i, b := range buckets {
+		if b == nil {
+			continue
+		}
+		b.mu.Lock()
+		d := b.delay(costs[i].N, now)
+		b.mu.Unlock()
+		if d > wait {
+			wait, blocking = d, costs[i].Rule
+		}
+	}
+	if wait > 0 {
+		return wait, blocking
+	}
+	for i, b := range buckets {
+		if b != nil {
+			b.mu.Lock()
+			b.tokens -= float64(costs[i].N)
+			b.mu.Unlock()
+		}
+	}
+	return 0, ""
+}
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"AxonTrading/base"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestLimiter(mode Mode, rules map[string]Rule) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	l := New(mode, rules)
+	l.Now = clock.now
+	return l, clock
+}
+
+func TestFailMode(t *testing.T) {
+	l, clock := newTestLimiter(Fail, map[string]Rule{
+		"order":  {Limit: 2, Interval: 2 * time.Second},
+		"orders": {Limit: 3, Interval: 2 * time.Second},
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, Cost{Rule: "order", Scope: "BTC-USDT", N: 1}, Cost{Rule: "orders", N: 1}); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	// BTC-USDT 的额度用完
+	if err := l.Wait(ctx, Cost{Rule: "order", Scope: "BTC-USDT", N: 1}); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	// 其它 instId 不受影响，但账户总额度只剩 1，失败时不扣任何桶
+	if err := l.Wait(ctx, Cost{Rule: "order", Scope: "ETH-USDT", N: 2}, Cost{Rule: "orders", N: 2}); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if got := l.Bucket("order", "ETH-USDT").Available(clock.t); got != 2 {
+		t.Fatalf("ETH-USDT tokens = %v, want 2 (not consumed)", got)
+	}
+	if err := l.Wait(ctx, Cost{Rule: "order", Scope: "ETH-USDT", N: 1}, Cost{Rule: "orders", N: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 令牌按速率补充：1 秒补 1 个
+	clock.t = clock.t.Add(time.Second)
+	if err := l.Wait(ctx, Cost{Rule: "order", Scope: "BTC-USDT", N: 1}); err != nil {
+		t.Fatal(err)
+	}
+	// 未配置的规则不限速
+	if err := l.Wait(ctx, Cost{Rule: "unknown", N: 100}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncAndPause(t *testing.T) {
+	l, clock := newTestLimiter(Fail, map[string]Rule{"weight": {Limit: 100, Interval: time.Minute}})
+	b := l.Bucket("weight", "")
+
+	b.Sync(95, clock.t)
+	if err := l.Wait(context.Background(), Cost{Rule: "weight", N: 10}); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited after sync", err)
+	}
+	// Sync 不会增加令牌
+	b.Sync(0, clock.t)
+	if got := b.Available(clock.t); got != 5 {
+		t.Fatalf("tokens = %v, want 5", got)
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	b.Pause(clock.t.Add(30 * time.Second))
+	if err := l.Wait(context.Background(), Cost{Rule: "weight", N: 1}); !errors.Is(err, base.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited while paused", err)
+	}
+	clock.t = clock.t.Add(31 * time.Second)
+	if err := l.Wait(context.Background(), Cost{Rule: "weight", N: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlockMode(t *testing.T) {
+	l := New(Block, map[string]Rule{"r": {Limit: 1, Interval: 50 * time.Millisecond}})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, Cost{Rule: "r", N: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Fatalf("3 requests took %v, want >= 100ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(ctx, Cost{Rule: "r", N: 1}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	var nilLimiter *Limiter
+	if err := nilLimiter.Wait(ctx, Cost{Rule: "r", N: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{"apiKey":"k","rateLimit":{"mode":"fail","rules":{"order":{"limit":1,"interval":"1d"},"books":{"limit":0}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := cfg.Build(map[string]Rule{"books": {Limit: 1, Interval: time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Mode() != Fail {
+		t.Fatalf("mode = %s", l.Mode())
+	}
+	if l.Bucket("books", "") != nil {
+		t.Fatal("limit 0 should disable the rule")
+	}
+	if b := l.Bucket("order", ""); b == nil || b.rate != 1/float64(24*time.Hour) {
+		t.Fatalf("order bucket = %+v", b)
+	}
+
+	if cfg, _ := ParseConfig([]byte(`{"rateLimit":{"disabled":true}}`)); cfg.Disabled {
+		if l, _ := cfg.Build(nil); l != nil {
+			t.Fatal("disabled config should build a nil limiter")
+		}
+	} else {
+		t.Fatal("disabled not parsed")
+	}
+	if _, err := ParseConfig([]byte(`{"rateLimit":{"mode":"drop"}}`)); err == nil {
+		t.Fatal("unknown mode should fail")
+	}
+	if _, err := (Config{Rules: map[string]RuleConfig{"x": {Limit: 1, Interval: "soon"}}}).Build(nil); err == nil {
+		t.Fatal("bad interval should fail")
+	}
+}
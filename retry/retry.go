@@ -0,0 +1,166 @@
+// Package retry 下单重试。
+//
+// 每个订单在第一次提交前生成 clientOrderID，只有被交易所明确拒绝且可以重发（限速、时间戳过期）时才重新提交。
+// 请求结果不确定（超时、连接中断、5xx）时不再提交，而是按 clientOrderID 退避轮询订单直到 Policy.Reconcile：
+// 交易所已经接受的订单可能过一会儿才查得到，而 Binance 只在前一个订单未完结时拒绝重复的 clientOrderID，
+// 已成交的订单再提交一次就会重复下单。期限内查不到时返回 base.ErrUnknownStatus，由调用方处理。
+package retry
+
+import (
+	"AxonTrading/base"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Policy 重试策略，零值使用 DefaultPolicy
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Reconcile 结果不确定时按 clientOrderID 轮询订单的最长时间，零值使用 DefaultPolicy.Reconcile
+	Reconcile time.Duration
+}
+
+// DefaultPolicy 最多提交 3 次，间隔 200ms、400ms；结果不确定时轮询 10 秒
+var DefaultPolicy = Policy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second, Reconcile: 10 * time.Second}
+
+func (p Policy) orDefault() Policy {
+	if p.MaxAttempts <= 0 {
+		return DefaultPolicy
+	}
+	if p.Reconcile <= 0 {
+		p.Reconcile = DefaultPolicy.Reconcile
+	}
+	return p
+}
+
+// Delay 第 attempt 次失败后的等待时间，指数增长，不超过 MaxDelay
+func (p Policy) Delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// IsTransient 请求被交易所明确拒绝但稍后可以重发，例如限速、时间戳过期
+func IsTransient(err error) bool {
+	return errors.Is(err, base.ErrRateLimited) || errors.Is(err, base.ErrTimestamp)
+}
+
+// IsAmbiguous 请求可能已经被交易所执行：超时、连接中断、5xx 或交易所明确表示状态未知
+func IsAmbiguous(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, base.ErrUnknownStatus) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var exErr *base.ExchangeError
+	if errors.As(err, &exErr) {
+		return exErr.HTTPStatus >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Do 执行 fn，可重发或结果不确定时按 policy 退避重试，返回最后一次的错误。
+// 结果不确定时由 fn 自己负责对账，重试的 fn 必须幂等。
+func Do(ctx context.Context, p Policy, fn func() error) error {
+	p = p.orDefault()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !(IsTransient(err) || IsAmbiguous(err)) {
+			return err
+		}
+		t := time.NewTimer(p.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+// Order 用固定 clientOrderID 下单。place 提交订单并返回交易所订单号；
+// lookup 按 clientOrderID 查询，found 为 false 表示订单不存在。
+// 只有可以重发的错误才重新提交；提交结果不确定或交易所报告 id 重复时改为用 Reconcile 轮询，不再提交。
+func Order(ctx context.Context, p Policy, place func() (string, error), lookup func() (string, bool, error)) (string, error) {
+	p = p.orDefault()
+	var (
+		orderID string
+		unknown error
+	)
+	err := Do(ctx, p, func() error {
+		id, err := place()
+		if err == nil {
+			orderID = id
+			return nil
+		}
+		if IsAmbiguous(err) || errors.Is(err, base.ErrDuplicateOrder) {
+			// 订单可能已经在交易所，停止重发
+			unknown = err
+			return nil
+		}
+		return err
+	})
+	if err != nil || unknown == nil {
+		return orderID, err
+	}
+	return Reconcile(ctx, p, lookup, unknown)
+}
+
+// Reconcile 提交结果不确定（cause）后按 clientOrderID 退避轮询，直到查到订单或超过 p.Reconcile。
+// 查不到、查询一直失败或 ctx 结束时返回包装了 cause 的 base.ErrUnknownStatus，订单可能存在也可能不存在
+func Reconcile(ctx context.Context, p Policy, lookup func() (string, bool, error), cause error) (string, error) {
+	p = p.orDefault()
+	deadline := time.Now().Add(p.Reconcile)
+	for attempt := 1; ; attempt++ {
+		id, found, err := lookup()
+		if err == nil && found {
+			return id, nil
+		}
+		wait, left := p.Delay(attempt), time.Until(deadline)
+		if left <= 0 {
+			break
+		}
+		if wait <= 0 || wait > left {
+			wait = left
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return "", fmt.Errorf("%w: order status unknown: %w", base.ErrUnknownStatus, cause)
+		case <-t.C:
+		}
+	}
+	return "", fmt.Errorf("%w: order not found within %s: %w", base.ErrUnknownStatus, p.Reconcile, cause)
+}
+
+var seq uint64
+
+// ClientOrderID 生成 clientOrderID，以字母开头、只含字母数字、不超过 32 位，OKX 和 Binance 都能使用。
+// 传入 parts 时由 parts 的哈希决定（同样的输入得到同样的 id，便于调用方在进程重启后对账）；
+// 不传时由时间戳和进程内序号生成。
+func ClientOrderID(parts ...string) string {
+	if len(parts) > 0 {
+		sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+		return "ax" + hex.EncodeToString(sum[:15])
+	}
+	n := atomic.AddUint64(&seq, 1)
+	return "ax" + strconv.FormatInt(time.Now().UnixNano(), 36) + strconv.FormatUint(n, 36)
+}
@@ -0,0 +1,130 @@
+package retry
+
+import (
+	"AxonTrading/base"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var fast = Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, Reconcile: 20 * time.Millisecond}
+
+func TestClassify(t *testing.T) {
+	ambiguous := []error{
+		&url.Error{Op: "Post", URL: "https://www.okx.com", Err: io.ErrUnexpectedEOF},
+		context.DeadlineExceeded,
+		&base.ExchangeError{HTTPStatus: 502},
+		&base.ExchangeError{HTTPStatus: 200, Code: "-1007", Kind: base.ErrUnknownStatus},
+	}
+	for _, err := range ambiguous {
+		if !IsAmbiguous(err) {
+			t.Errorf("IsAmbiguous(%v) = false", err)
+		}
+	}
+	definite := []error{
+		context.Canceled,
+		&base.ExchangeError{HTTPStatus: 200, Code: "51008", Kind: base.ErrInsufficientFunds},
+		fmt.Errorf("local: %w", base.ErrRateLimited),
+	}
+	for _, err := range definite {
+		if IsAmbiguous(err) {
+			t.Errorf("IsAmbiguous(%v) = true", err)
+		}
+	}
+	if !IsTransient(&base.ExchangeError{Kind: base.ErrRateLimited}) || IsTransient(errors.New("x")) {
+		t.Error("IsTransient misclassified")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	p := Policy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	want := []time.Duration{100, 200, 300, 300}
+	for i, w := range want {
+		if got := p.Delay(i + 1); got != w*time.Millisecond {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+}
+
+func TestOrderReconcilesAmbiguousSubmit(t *testing.T) {
+	placed := 0
+	place := func() (string, error) {
+		placed++
+		return "", &url.Error{Op: "Post", URL: "x", Err: io.EOF}
+	}
+	lookup := func() (string, bool, error) { return "123", true, nil }
+	id, err := Order(context.Background(), fast, place, lookup)
+	if err != nil || id != "123" || placed != 1 {
+		t.Fatalf("Order = %q, %v after %d submits", id, err, placed)
+	}
+}
+
+func TestOrderNeverResubmitsAmbiguous(t *testing.T) {
+	placed, lookups := 0, 0
+	place := func() (string, error) {
+		placed++
+		return "", &base.ExchangeError{HTTPStatus: 503}
+	}
+	// 交易所已经接受的订单过一会儿才查得到
+	lookup := func() (string, bool, error) {
+		lookups++
+		if lookups < 3 {
+			return "", false, nil
+		}
+		return "456", true, nil
+	}
+	id, err := Order(context.Background(), fast, place, lookup)
+	if err != nil || id != "456" || placed != 1 {
+		t.Fatalf("Order = %q, %v after %d submits", id, err, placed)
+	}
+
+	// 期限内一直查不到时返回状态未知，不重新提交
+	placed, lookups = 0, 0
+	lookup = func() (string, bool, error) { lookups++; return "", false, errors.New("lookup failed") }
+	id, err = Order(context.Background(), fast, place, lookup)
+	if !errors.Is(err, base.ErrUnknownStatus) || id != "" || placed != 1 || lookups < 2 {
+		t.Fatalf("Order = %q, %v after %d submits, %d lookups", id, err, placed, lookups)
+	}
+	var exErr *base.ExchangeError
+	if !errors.As(err, &exErr) || exErr.HTTPStatus != 503 {
+		t.Fatalf("cause not wrapped: %v", err)
+	}
+}
+
+func TestOrderStopsOnDefiniteError(t *testing.T) {
+	placed := 0
+	reject := &base.ExchangeError{HTTPStatus: 200, Kind: base.ErrInsufficientFunds}
+	place := func() (string, error) { placed++; return "", reject }
+	lookup := func() (string, bool, error) { t.Fatal("lookup should not be called"); return "", false, nil }
+	if _, err := Order(context.Background(), fast, place, lookup); !errors.Is(err, base.ErrInsufficientFunds) || placed != 1 {
+		t.Fatalf("err = %v after %d submits", err, placed)
+	}
+
+	// 限速可以重发，但次数受 MaxAttempts 限制
+	placed = 0
+	limited := fmt.Errorf("local: %w", base.ErrRateLimited)
+	place = func() (string, error) { placed++; return "", limited }
+	if _, err := Order(context.Background(), fast, place, lookup); !errors.Is(err, base.ErrRateLimited) || placed != 3 {
+		t.Fatalf("err = %v after %d submits", err, placed)
+	}
+}
+
+func TestClientOrderID(t *testing.T) {
+	valid := regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]{0,31}$`)
+	a, b := ClientOrderID(), ClientOrderID()
+	if a == b || !valid.MatchString(a) || !valid.MatchString(b) {
+		t.Fatalf("ClientOrderID() = %q, %q", a, b)
+	}
+	x, y := ClientOrderID("grid", "BTC/USDT", "7"), ClientOrderID("grid", "BTC/USDT", "7")
+	if x != y || !valid.MatchString(x) {
+		t.Fatalf("deterministic ids = %q, %q", x, y)
+	}
+	if x == ClientOrderID("grid", "BTC/USDT", "8") {
+		t.Fatal("different inputs should give different ids")
+	}
+}
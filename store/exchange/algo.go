@@ -0,0 +1,40 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/models"
+	"context"
+)
+
+// AlgoExchange 在 Exchange 的基础上提供策略委托（触发单、止盈止损、OCO、移动止损、冰山、TWAP），
+// 由交易所托管，触发前不占用普通挂单。交易所不支持的类型返回 base.ErrNotSupported：
+// OKX 现货和永续支持全部类型；Binance 现货只支持 OCO，U本位合约支持 TRIGGER、单侧 CONDITIONAL 和 TRAILINGSTOP
+type AlgoExchange interface {
+	Exchange
+
+	// PlaceAlgoOrder 下策略委托，返回 algo id
+	PlaceAlgoOrder(ctx context.Context, req models.AlgoOrderRequest) (string, error)
+	// CancelAlgoOrder 撤销 symbol 上的策略委托
+	CancelAlgoOrder(ctx context.Context, symbol, algoID string) error
+	// GetOpenAlgoOrders 未触发或执行中的策略委托
+	GetOpenAlgoOrders(ctx context.Context, symbol string) ([]models.AlgoOrder, error)
+}
+
+var (
+	_ AlgoExchange = (*binance.Client)(nil)
+	_ AlgoExchange = (*okx.Client)(nil)
+)
+
+// CreateAlgoClient 创建支持策略委托的客户端，不支持的交易所返回 nil
+func (e ExchangeFactory) CreateAlgoClient(exchange string) AlgoExchange {
+	switch exchange {
+	case base.BINANCE:
+		return &binance.Client{}
+	case base.OKEX:
+		return &okx.Client{}
+	default:
+		return nil
+	}
+}
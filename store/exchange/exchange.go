@@ -4,12 +4,19 @@ import (
 	"AxonTrading/base"
 	"AxonTrading/exchanges/binance"
 	"AxonTrading/exchanges/okx"
+	"AxonTrading/exchanges/sim"
 	"AxonTrading/models"
+	"context"
+	"time"
 )
 
 type Exchange interface {
 	New(params []byte) error
+	// Has 是否支持 base.Cap* 中的可选功能，不支持的方法返回 base.ErrNotSupported
+	Has(capability string) bool
 	GetAccountBalance(currency string) ([]string, error)
+	// GetAccount 账户快照：现货和合约账户的全部非零余额、总权益、保证金和账户模式
+	GetAccount(ctx context.Context) (models.Account, error)
 	MarketOrder(symbol, side, size string) (string, error)
 	LimitOrder(symbol, side, price, size string) (string, error)
 	LimitHiddenOrder(symbol, side, price, size string) (string, error)
@@ -20,6 +27,9 @@ type Exchange interface {
 	TakerOrders(symbol string, ol []models.OrderList) ([]string, error)
 	CancelOrder(symbol, id string) (bool, error)
 	CancelOrders(symbol string) error
+	// AmendOrder 修改挂单价格和数量，newPrice、newSize 为空表示不变，newSize 为含已成交部分的总数量。
+	// 返回修改后的订单；Binance 现货为撤单重下，返回的是新订单
+	AmendOrder(ctx context.Context, symbol, id, newPrice, newSize string) (models.OrderInfo, error)
 	GetOrder(symbol, id string) (models.OrderInfo, error)
 	GetOpenOrders(symbol string) ([]models.OrderInfo, error)
 	GetOpenOrdersWithSide(symbol, side string) ([]models.OrderInfo, error)
@@ -29,6 +39,8 @@ type Exchange interface {
 	GetTradingFee(symbol string) (models.TradingFee, error)
 	GetPairInfo(symbol string) (models.PairInfo, error)
 	GetFeeFromFilled(symbol, id string) (string, string, error)
+	// GetFills 自己在 symbol 上 [since, until] 内的成交，自动翻页，按时间升序；since 为零时取 until 前 24 小时，until 为零时取当前时间
+	GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error)
 	IceBergOrder(symbol, side, typ, price, size, ice string) (string, error)
 	LimitHiddenOrders(symbol string, ol []models.OrderList) ([]string, error)
 	GetDepositAddress(token, chain string) (string, error)
@@ -66,6 +78,8 @@ type Exchange interface {
 	ChangePositionMargin(symbol, positionSide, amount string, typ int) (bool, error)
 	// GetPositionRisk 获取当前仓位
 	GetPositionRisk(symbol string) ([]models.PositionInfo, error)
+	// GetPositions 全部非零持仓（永续、交割、期权和杠杆），filter 为零值时不过滤
+	GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error)
 	// GetFutureTradingFee 获取手续费
 	GetFutureTradingFee(symbol string) (models.TradingFee, error)
 
@@ -82,6 +96,8 @@ func (e ExchangeFactory) CreateClient(exchange string) Exchange {
 		return &binance.Client{}
 	case base.OKEX:
 		return &okx.Client{}
+	case base.SIM:
+		return &sim.Client{}
 
 	default:
 		return nil
@@ -0,0 +1,366 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 提币审核错误，调用方使用 errors.Is 判断
+var (
+	// ErrWithdrawRejected 提币不符合 WithdrawPolicy，没有发出
+	ErrWithdrawRejected = errors.New("withdrawal rejected by policy")
+	// ErrWithdrawPending 提币已进入待审批队列，返回的 id 为审批单号，Approve 后才会发出
+	ErrWithdrawPending = errors.New("withdrawal pending approval")
+)
+
+// WithdrawPolicy 提币策略，默认拒绝：地址不在白名单、币种没有配置限额的提币都会被拒绝
+type WithdrawPolicy struct {
+	// Allowlist 每条链允许的提币地址，key 为链名（与 Withdraw 的 chain 相同，如 OKX 的 TRC20、Binance 的 TRX）
+	Allowlist map[string][]string `json:"allowlist"`
+	// Limits 每个币种的限额，key 为币种（如 USDT）
+	Limits map[string]WithdrawLimit `json:"limits"`
+	// MaxFeeRatio 提币手续费占数量的最大比例（0.01 为 1%），0 表示不检查。
+	// 手续费、最小提币数量和是否可提来自 TreasuryExchange.GetChains，交易所不支持时设置了该项的提币会被拒绝
+	MaxFeeRatio models.Decimal `json:"max_fee_ratio"`
+	// RequireApproval 为 true 时提币先进入待审批队列，人工 Approve 后才发出
+	RequireApproval bool `json:"require_approval"`
+}
+
+// WithdrawLimit 币种限额，0 表示不限
+type WithdrawLimit struct {
+	PerTx models.Decimal `json:"per_tx"` // 单笔上限
+	Daily models.Decimal `json:"daily"`  // 最近 24 小时发出的累计上限，待审批的提币一直计入，批准后按发出时间计算
+}
+
+// 审计事件
+const (
+	AuditRequested = "requested" // 收到提币请求
+	AuditRejected  = "rejected"  // 不符合策略
+	AuditPending   = "pending"   // 进入待审批队列
+	AuditApproved  = "approved"  // 人工批准
+	AuditDenied    = "denied"    // 人工拒绝
+	AuditSubmitted = "submitted" // 已提交到交易所
+	AuditFailed    = "failed"    // 交易所返回错误
+)
+
+// WithdrawAudit 一条审计记录，同一次提币的各个事件 RequestID 相同
+type WithdrawAudit struct {
+	Time       int64          `json:"time"`
+	Event      string         `json:"event"`
+	RequestID  string         `json:"request_id"`
+	Currency   string         `json:"currency"`
+	Chain      string         `json:"chain"`
+	Address    string         `json:"address"`
+	Amount     models.Decimal `json:"amount"`
+	WithdrawID string         `json:"withdraw_id,omitempty"`
+	Approver   string         `json:"approver,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+}
+
+// AuditLog 提币审计日志。写入失败时提币不会发出
+type AuditLog interface {
+	Record(a WithdrawAudit) error
+}
+
+// JSONAuditLog 每条记录写一行 JSON
+type JSONAuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONAuditLog 创建写入 w 的审计日志，w 通常是以追加方式打开的文件
+func NewJSONAuditLog(w io.Writer) *JSONAuditLog {
+	return &JSONAuditLog{w: w}
+}
+
+func (l *JSONAuditLog) Record(a WithdrawAudit) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(data, '\n'))
+	return err
+}
+
+// PendingWithdrawal 待审批的提币
+type PendingWithdrawal struct {
+	ID       string         `json:"id"`
+	Currency string         `json:"currency"`
+	Chain    string         `json:"chain"`
+	Address  string         `json:"address"`
+	Amount   models.Decimal `json:"amount"`
+	Time     int64          `json:"time"`
+
+	seq int
+}
+
+// WithdrawGuard 包装任意 Exchange，Withdraw 按 WithdrawPolicy 检查后才发出，每次尝试都写入审计日志；
+// 其它方法直接调用被包装的 Exchange。每日限额按进程内记录计算，重启后清零
+type WithdrawGuard struct {
+	Exchange
+
+	policy WithdrawPolicy
+	audit  AuditLog
+	now    func() time.Time
+
+	mu      sync.Mutex
+	seq     int
+	used    []withdrawUsage // 最近 24 小时已发出和全部待审批的提币，计入每日限额
+	pending map[string]PendingWithdrawal
+}
+
+var _ Exchange = (*WithdrawGuard)(nil)
+
+type withdrawUsage struct {
+	id       string
+	currency string
+	amount   models.Decimal
+	time     time.Time // 发出时间
+	pending  bool      // 待审批，不会过期
+}
+
+// NewWithdrawGuard 用 policy 包装 e，audit 不能为 nil
+func NewWithdrawGuard(e Exchange, policy WithdrawPolicy, audit AuditLog) *WithdrawGuard {
+	return &WithdrawGuard{
+		Exchange: e,
+		policy:   policy,
+		audit:    audit,
+		now:      time.Now,
+		pending:  make(map[string]PendingWithdrawal),
+	}
+}
+
+// Withdraw 检查策略后提币。RequireApproval 时返回审批单号和 ErrWithdrawPending；
+// 不符合策略时返回 ErrWithdrawRejected
+func (g *WithdrawGuard) Withdraw(token, chain, to, amount string) (string, error) {
+	g.mu.Lock()
+	g.seq++
+	p := PendingWithdrawal{ID: "W" + strconv.Itoa(g.seq), Currency: token, Chain: chain, Address: to, Time: g.now().UnixMilli(), seq: g.seq}
+	g.mu.Unlock()
+
+	d, parseErr := models.RequireDecimal("amount", amount)
+	p.Amount = d
+	if err := g.record(AuditRequested, p, "", "", ""); err != nil {
+		return "", err
+	}
+	if parseErr != nil {
+		return "", g.reject(p, parseErr.Error())
+	}
+	if reason := g.check(p); reason != "" {
+		return "", g.reject(p, reason)
+	}
+
+	// 限额检查和占用额度放在同一把锁里，并发的提币不会一起超过每日限额
+	g.mu.Lock()
+	if reason := g.checkLimit(p); reason != "" {
+		g.mu.Unlock()
+		return "", g.reject(p, reason)
+	}
+	g.used = append(g.used, withdrawUsage{id: p.ID, currency: p.Currency, amount: p.Amount, time: g.now(), pending: g.policy.RequireApproval})
+	if g.policy.RequireApproval {
+		g.pending[p.ID] = p
+	}
+	g.mu.Unlock()
+
+	if g.policy.RequireApproval {
+		if err := g.record(AuditPending, p, "", "", ""); err != nil {
+			_, _ = g.take(p.ID)
+			g.release(p.ID)
+			return "", err
+		}
+		return p.ID, fmt.Errorf("%w: %s", ErrWithdrawPending, p.ID)
+	}
+	return g.submit(p, "")
+}
+
+// check 白名单和链上信息检查，返回拒绝原因
+func (g *WithdrawGuard) check(p PendingWithdrawal) string {
+	if p.Amount.Sign() <= 0 {
+		return "amount must be positive"
+	}
+	allowed := false
+	for _, addr := range g.policy.Allowlist[p.Chain] {
+		if addr == p.Address {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Sprintf("address %s is not allowlisted on %s", p.Address, p.Chain)
+	}
+	if _, ok := g.policy.Limits[p.Currency]; !ok {
+		return fmt.Sprintf("no withdraw limit configured for %s", p.Currency)
+	}
+
+	t, ok := g.Exchange.(TreasuryExchange)
+	if !ok {
+		if g.policy.MaxFeeRatio.Sign() > 0 {
+			return "exchange does not provide chain info for fee check"
+		}
+		return ""
+	}
+	chains, err := t.GetChains(context.Background(), p.Currency)
+	if err != nil {
+		return "get chain info: " + err.Error()
+	}
+	for _, info := range chains {
+		if info.Chain != p.Chain {
+			continue
+		}
+		switch {
+		case !info.CanWithdraw:
+			return fmt.Sprintf("%s withdrawal on %s is suspended", p.Currency, p.Chain)
+		case p.Amount.LessThan(info.MinWithdraw):
+			return fmt.Sprintf("amount %s below minimum %s", p.Amount, info.MinWithdraw)
+		case !info.WithdrawFee.LessThan(p.Amount):
+			return fmt.Sprintf("fee %s not below amount %s", info.WithdrawFee, p.Amount)
+		}
+		if g.policy.MaxFeeRatio.Sign() > 0 && info.WithdrawFee.GreaterThan(p.Amount.Mul(g.policy.MaxFeeRatio)) {
+			return fmt.Sprintf("fee %s exceeds %s of amount %s", info.WithdrawFee, g.policy.MaxFeeRatio, p.Amount)
+		}
+		return ""
+	}
+	return fmt.Sprintf("unknown chain %s for %s", p.Chain, p.Currency)
+}
+
+// checkLimit 单笔和每日限额，调用方持有 g.mu
+func (g *WithdrawGuard) checkLimit(p PendingWithdrawal) string {
+	limit := g.policy.Limits[p.Currency]
+	if limit.PerTx.Sign() > 0 && p.Amount.GreaterThan(limit.PerTx) {
+		return fmt.Sprintf("amount %s exceeds per-tx limit %s", p.Amount, limit.PerTx)
+	}
+	since := g.now().Add(-24 * time.Hour)
+	kept := g.used[:0]
+	total := p.Amount
+	for _, u := range g.used {
+		if !u.pending && u.time.Before(since) {
+			continue
+		}
+		kept = append(kept, u)
+		if u.currency == p.Currency {
+			total = total.Add(u.amount)
+		}
+	}
+	g.used = kept
+	if limit.Daily.Sign() > 0 && total.GreaterThan(limit.Daily) {
+		return fmt.Sprintf("24h total %s exceeds daily limit %s", total, limit.Daily)
+	}
+	return ""
+}
+
+// submit 发出提币，失败时释放占用的额度。已发出但审计日志写入失败时同时返回提币 id 和错误，调用方不应重试
+func (g *WithdrawGuard) submit(p PendingWithdrawal, approver string) (string, error) {
+	id, err := g.Exchange.Withdraw(p.Currency, p.Chain, p.Address, p.Amount.String())
+	if err != nil {
+		g.release(p.ID)
+		_ = g.record(AuditFailed, p, "", approver, err.Error())
+		return "", err
+	}
+	if err := g.record(AuditSubmitted, p, id, approver, ""); err != nil {
+		return id, err
+	}
+	return id, nil
+}
+
+func (g *WithdrawGuard) reject(p PendingWithdrawal, reason string) error {
+	if err := g.record(AuditRejected, p, "", "", reason); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrWithdrawRejected, reason)
+}
+
+func (g *WithdrawGuard) release(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, u := range g.used {
+		if u.id == id {
+			g.used = append(g.used[:i], g.used[i+1:]...)
+			return
+		}
+	}
+}
+
+func (g *WithdrawGuard) record(event string, p PendingWithdrawal, withdrawID, approver, reason string) error {
+	return g.audit.Record(WithdrawAudit{
+		Time:       g.now().UnixMilli(),
+		Event:      event,
+		RequestID:  p.ID,
+		Currency:   p.Currency,
+		Chain:      p.Chain,
+		Address:    p.Address,
+		Amount:     p.Amount,
+		WithdrawID: withdrawID,
+		Approver:   approver,
+		Reason:     reason,
+	})
+}
+
+// Pending 待审批的提币，按提交顺序
+func (g *WithdrawGuard) Pending() []PendingWithdrawal {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	list := make([]PendingWithdrawal, 0, len(g.pending))
+	for _, p := range g.pending {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].seq < list[j].seq })
+	return list
+}
+
+// take 从待审批队列中取出 id，同一审批单只能处理一次
+func (g *WithdrawGuard) take(id string) (PendingWithdrawal, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.pending[id]
+	if !ok {
+		return p, fmt.Errorf("%w: pending %s", base.ErrWithdrawNotFound, id)
+	}
+	delete(g.pending, id)
+	return p, nil
+}
+
+// Approve 批准待审批的提币并发出，返回交易所的提币 id。发出前按当前的策略、链上信息和额度重新检查，
+// 不符合时返回 ErrWithdrawRejected；额度从发出时开始计算 24 小时
+func (g *WithdrawGuard) Approve(id, approver string) (string, error) {
+	p, err := g.take(id)
+	if err != nil {
+		return "", err
+	}
+	// 先释放审批单占用的额度，重新检查后按发出时间占用
+	g.release(p.ID)
+	if err := g.record(AuditApproved, p, "", approver, ""); err != nil {
+		return "", err
+	}
+	if reason := g.check(p); reason != "" {
+		return "", g.reject(p, reason)
+	}
+	g.mu.Lock()
+	if reason := g.checkLimit(p); reason != "" {
+		g.mu.Unlock()
+		return "", g.reject(p, reason)
+	}
+	g.used = append(g.used, withdrawUsage{id: p.ID, currency: p.Currency, amount: p.Amount, time: g.now()})
+	g.mu.Unlock()
+	return g.submit(p, approver)
+}
+
+// Reject 拒绝待审批的提币，释放占用的每日额度
+func (g *WithdrawGuard) Reject(id, approver, reason string) error {
+	p, err := g.take(id)
+	if err != nil {
+		return err
+	}
+	g.release(p.ID)
+	return g.record(AuditDenied, p, "", approver, reason)
+}
@@ -0,0 +1,267 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/exchangetest"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/exchanges/sim"
+	"AxonTrading/models"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryAudit 记录全部审计事件，fail 不为 nil 时写入失败
+type memoryAudit struct {
+	events []WithdrawAudit
+	fail   error
+}
+
+func (m *memoryAudit) Record(a WithdrawAudit) error {
+	if m.fail != nil {
+		return m.fail
+	}
+	m.events = append(m.events, a)
+	return nil
+}
+
+func (m *memoryAudit) kinds() string {
+	var s []string
+	for _, e := range m.events {
+		s = append(s, e.Event)
+	}
+	return strings.Join(s, ",")
+}
+
+func newSimGuard(t *testing.T, policy WithdrawPolicy) (*WithdrawGuard, *sim.Client, *memoryAudit) {
+	t.Helper()
+	c := &sim.Client{}
+	if err := c.New([]byte(`{"balances":{"USDT":"10000"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	audit := &memoryAudit{}
+	return NewWithdrawGuard(c, policy, audit), c, audit
+}
+
+func usdtPolicy() WithdrawPolicy {
+	d := models.ParseDecimalOrZero
+	return WithdrawPolicy{
+		Allowlist: map[string][]string{"TRC20": {"TAllowed"}},
+		Limits:    map[string]WithdrawLimit{"USDT": {PerTx: d("500"), Daily: d("800")}},
+	}
+}
+
+func TestWithdrawGuardPolicy(t *testing.T) {
+	g, c, audit := newSimGuard(t, usdtPolicy())
+	now := time.Unix(1700000000, 0)
+	g.now = func() time.Time { return now }
+
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "400"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ token, chain, to, amount string }{
+		{"USDT", "TRC20", "TOther", "10"},   // 不在白名单
+		{"USDT", "ERC20", "TAllowed", "10"}, // 白名单按链区分
+		{"BTC", "TRC20", "TAllowed", "1"},   // 没有配置限额
+		{"USDT", "TRC20", "TAllowed", "501"},
+		{"USDT", "TRC20", "TAllowed", "401"}, // 24 小时累计 801
+		{"USDT", "TRC20", "TAllowed", "-1"},
+		{"USDT", "TRC20", "TAllowed", "abc"},
+	} {
+		if _, err := g.Withdraw(tc.token, tc.chain, tc.to, tc.amount); !errors.Is(err, ErrWithdrawRejected) {
+			t.Fatalf("%+v err = %v", tc, err)
+		}
+	}
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "400"); err != nil {
+		t.Fatal(err)
+	}
+	// 24 小时后额度恢复
+	now = now.Add(24*time.Hour + time.Second)
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "500"); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := c.GetAccountBalance("USDT")
+	if b[0] != "8700" {
+		t.Fatalf("balance = %v", b)
+	}
+	if audit.events[0].Event != AuditRequested || audit.events[1].Event != AuditSubmitted || audit.events[1].WithdrawID == "" {
+		t.Fatalf("audit = %+v", audit.events[:2])
+	}
+	if got := strings.Count(audit.kinds(), AuditRejected); got != 7 {
+		t.Fatalf("rejected events = %d: %s", got, audit.kinds())
+	}
+	if r := audit.events[3]; r.Event != AuditRejected || !strings.Contains(r.Reason, "allowlist") || r.RequestID != audit.events[2].RequestID {
+		t.Fatalf("rejected = %+v", r)
+	}
+
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "301"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("over daily err = %v", err)
+	}
+
+	// 交易所失败时释放额度
+	g2, _, audit2 := newSimGuard(t, usdtPolicy())
+	if _, err := g2.Withdraw("USDT", "TRC20", "TAllowed", "500"); err != nil {
+		t.Fatal(err)
+	}
+	g2.Exchange = &sim.Client{} // 没有余额
+	if _, err := g2.Withdraw("USDT", "TRC20", "TAllowed", "300"); !errors.Is(err, base.ErrInsufficientFunds) {
+		t.Fatalf("insufficient err = %v", err)
+	}
+	if !strings.HasSuffix(audit2.kinds(), AuditFailed) || len(g2.used) != 1 {
+		t.Fatalf("audit = %s used = %+v", audit2.kinds(), g2.used)
+	}
+}
+
+func TestWithdrawGuardApproval(t *testing.T) {
+	policy := usdtPolicy()
+	policy.RequireApproval = true
+	g, c, audit := newSimGuard(t, policy)
+
+	id, err := g.Withdraw("USDT", "TRC20", "TAllowed", "300")
+	if !errors.Is(err, ErrWithdrawPending) || id == "" {
+		t.Fatalf("withdraw = %s err = %v", id, err)
+	}
+	id2, _ := g.Withdraw("USDT", "TRC20", "TAllowed", "400")
+	// 待审批的提币占用每日额度
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "200"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("over daily err = %v", err)
+	}
+	if p := g.Pending(); len(p) != 2 || p[0].ID != id || p[1].Amount.String() != "400" {
+		t.Fatalf("pending = %+v", p)
+	}
+	if b, _ := c.GetAccountBalance("USDT"); b[0] != "10000" {
+		t.Fatalf("balance before approval = %v", b)
+	}
+
+	wid, err := g.Approve(id, "alice")
+	if err != nil || wid == "" {
+		t.Fatalf("approve = %s err = %v", wid, err)
+	}
+	if _, err := g.Approve(id, "alice"); !errors.Is(err, base.ErrWithdrawNotFound) {
+		t.Fatalf("approve twice err = %v", err)
+	}
+	if err := g.Reject(id2, "bob", "unexpected"); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := c.GetAccountBalance("USDT"); b[0] != "9700" {
+		t.Fatalf("balance after approval = %v", b)
+	}
+	// 拒绝后释放额度
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "400"); !errors.Is(err, ErrWithdrawPending) {
+		t.Fatalf("after reject err = %v", err)
+	}
+	want := "requested,pending,requested,pending,requested,rejected,approved,submitted,denied,requested,pending"
+	if audit.kinds() != want {
+		t.Fatalf("audit = %s", audit.kinds())
+	}
+	if a := audit.events[7]; a.Approver != "alice" || a.WithdrawID != wid {
+		t.Fatalf("submitted = %+v", a)
+	}
+
+	// 审计日志写不进去时不提币
+	audit.fail = errors.New("disk full")
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "1"); err == nil || errors.Is(err, ErrWithdrawPending) {
+		t.Fatalf("audit failure err = %v", err)
+	}
+	if len(g.Pending()) != 1 {
+		t.Fatalf("pending = %+v", g.Pending())
+	}
+}
+
+func TestWithdrawGuardApprovalRechecks(t *testing.T) {
+	policy := usdtPolicy()
+	policy.RequireApproval = true
+	g, c, audit := newSimGuard(t, policy)
+	now := time.Unix(1700000000, 0)
+	g.now = func() time.Time { return now }
+
+	id, _ := g.Withdraw("USDT", "TRC20", "TAllowed", "300")
+	id2, _ := g.Withdraw("USDT", "TRC20", "TAllowed", "400")
+	// 待审批超过 24 小时仍然占用额度
+	now = now.Add(25 * time.Hour)
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "200"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("pending expired err = %v", err)
+	}
+
+	// 批准时按当前策略重新检查
+	g.policy.Allowlist = map[string][]string{"TRC20": {"TOther"}}
+	if _, err := g.Approve(id, "alice"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("approve removed address err = %v", err)
+	}
+	g.policy.Allowlist = usdtPolicy().Allowlist
+	g.policy.Limits = map[string]WithdrawLimit{"USDT": {Daily: models.ParseDecimalOrZero("300")}}
+	if _, err := g.Approve(id2, "alice"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("approve over lowered limit err = %v", err)
+	}
+	if b, _ := c.GetAccountBalance("USDT"); b[0] != "10000" || len(g.Pending()) != 0 || len(g.used) != 0 {
+		t.Fatalf("balance = %v pending = %+v used = %+v", b, g.Pending(), g.used)
+	}
+	if want := "requested,pending,requested,pending,requested,rejected,approved,rejected,approved,rejected"; audit.kinds() != want {
+		t.Fatalf("audit = %s", audit.kinds())
+	}
+
+	// 额度从发出时开始计算
+	g.policy = usdtPolicy()
+	g.policy.RequireApproval = true
+	id3, _ := g.Withdraw("USDT", "TRC20", "TAllowed", "500")
+	now = now.Add(23 * time.Hour)
+	if _, err := g.Approve(id3, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Hour)
+	if _, err := g.Withdraw("USDT", "TRC20", "TAllowed", "400"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("usage counted from request time: err = %v", err)
+	}
+}
+
+func TestWithdrawGuardChainInfo(t *testing.T) {
+	srv := exchangetest.NewOKX(exchangetest.Credentials{APIKey: "key", SecretKey: "secret", Passphrase: "pass"})
+	t.Cleanup(srv.Close)
+	c := &okx.Client{}
+	if err := c.New(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	policy := WithdrawPolicy{
+		Allowlist:   map[string][]string{"TRC20": {"TAllowed"}, "ERC20": {"0xAllowed"}},
+		Limits:      map[string]WithdrawLimit{"USDT": {}},
+		MaxFeeRatio: models.ParseDecimalOrZero("0.01"),
+	}
+	var buf bytes.Buffer
+	g := NewWithdrawGuard(c, policy, NewJSONAuditLog(&buf))
+
+	// fixture 中 TRC20 最低手续费 1、最小提币 2，ERC20 暂停提币
+	for _, tc := range []struct{ chain, to, amount string }{
+		{"TRC20", "TAllowed", "50"}, // 手续费 2%
+		{"ERC20", "0xAllowed", "1000"},
+	} {
+		if _, err := g.Withdraw("USDT", tc.chain, tc.to, tc.amount); !errors.Is(err, ErrWithdrawRejected) {
+			t.Fatalf("%+v err = %v", tc, err)
+		}
+	}
+	id, err := g.Withdraw("USDT", "TRC20", "TAllowed", "100")
+	if err != nil || id != "67485" {
+		t.Fatalf("withdraw = %s err = %v", id, err)
+	}
+	if n := len(srv.Requests(http.MethodPost, "/api/v5/asset/withdrawal")); n != 1 {
+		t.Fatalf("withdrawal requests = %d", n)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var last WithdrawAudit
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 6 || last.Event != AuditSubmitted || last.WithdrawID != "67485" || last.Amount.String() != "100" {
+		t.Fatalf("audit log = %s", buf.String())
+	}
+
+	// 交易所不提供链上信息时不能检查手续费
+	g2, _, _ := newSimGuard(t, policy)
+	if _, err := g2.Withdraw("USDT", "TRC20", "TAllowed", "100"); !errors.Is(err, ErrWithdrawRejected) {
+		t.Fatalf("sim fee check err = %v", err)
+	}
+}
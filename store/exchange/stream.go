@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/feed"
+	"AxonTrading/models"
+	"context"
+)
+
+// StreamingExchange 在 Exchange 的基础上提供推送订阅，New/NewFuture 之后使用。
+//
+// 统一符号（BTC/USDT、BTC/USDT:USDT）按类型选择现货或合约，推送中的 Symbol 与传入的一致；
+// 旧格式按交易所原生 id 处理（Binance 的 BTCUSDT 视为现货）。
+// 每个订阅返回只读 channel 和 feed.Subscription，Unsubscribe 或 ctx 取消后 channel 被关闭。
+// 断线后自动重连并恢复订阅，channel 不会因此关闭
+type StreamingExchange interface {
+	Exchange
+
+	// SubscribeOrderBook 本地维护的订单簿，每次更新推送买卖各前 depth 档
+	SubscribeOrderBook(ctx context.Context, symbol string, depth int) (<-chan models.WsData, *feed.Subscription, error)
+	// SubscribeTrades 公开成交
+	SubscribeTrades(ctx context.Context, symbol string) (<-chan models.Trade, *feed.Subscription, error)
+	// SubscribeTicker 最优买卖价，交易所推送中有时带最新成交价
+	SubscribeTicker(ctx context.Context, symbol string) (<-chan models.Ticker, *feed.Subscription, error)
+	// SubscribeOrders 自己在 symbol 上的订单状态
+	SubscribeOrders(ctx context.Context, symbol string) (<-chan models.OrderUpdate, *feed.Subscription, error)
+	// SubscribeFills 自己在 symbol 上的成交
+	SubscribeFills(ctx context.Context, symbol string) (<-chan models.Fill, *feed.Subscription, error)
+	// SubscribePositions 合约持仓，symbol 为空时推送全部持仓
+	SubscribePositions(ctx context.Context, symbol string) (<-chan models.PositionInfo, *feed.Subscription, error)
+	// SubscribeBalances 余额，future 为 true 时为合约账户（统一账户的交易所忽略）
+	SubscribeBalances(ctx context.Context, future bool) (<-chan models.BalanceUpdate, *feed.Subscription, error)
+}
+
+var (
+	_ StreamingExchange = (*binance.Client)(nil)
+	_ StreamingExchange = (*okx.Client)(nil)
+)
+
+// CreateStreamingClient 创建支持推送订阅的客户端，不支持的交易所返回 nil
+func (e ExchangeFactory) CreateStreamingClient(exchange string) StreamingExchange {
+	switch exchange {
+	case base.BINANCE:
+		return &binance.Client{}
+	case base.OKEX:
+		return &okx.Client{}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/models"
+	"context"
+	"fmt"
+)
+
+// SubAccountManager 母账户管理子账户：列表、余额、母子账户划转和子账户 API key。
+// 只包含子账户操作，不嵌入 Exchange，测试时可以直接用假实现替换。
+// OKX 的余额为子账户交易账户，Binance 为子账户现货账户；Binance 普通母账户不能管理子账户 API key，
+// CreateSubAccountAPIKey / DeleteSubAccountAPIKey 返回 base.ErrNotSupported
+type SubAccountManager interface {
+	// GetSubAccounts 全部子账户
+	GetSubAccounts(ctx context.Context) ([]models.SubAccount, error)
+	// GetSubAccountBalances 子账户余额，只返回非零币种
+	GetSubAccountBalances(ctx context.Context, sub string) ([]models.Balance, error)
+	// SubAccountTransfer 母子账户或子账户之间划转
+	SubAccountTransfer(ctx context.Context, req models.SubAccountTransferRequest) (models.Transfer, error)
+	// CreateSubAccountAPIKey 为子账户创建 API key，返回值包含 SecretKey
+	CreateSubAccountAPIKey(ctx context.Context, req models.SubAccountAPIKeyRequest) (models.SubAccountAPIKey, error)
+	// DeleteSubAccountAPIKey 删除子账户的 API key
+	DeleteSubAccountAPIKey(ctx context.Context, sub, apiKey string) error
+}
+
+var (
+	_ SubAccountManager = (*binance.Client)(nil)
+	_ SubAccountManager = (*okx.Client)(nil)
+)
+
+// CreateSubAccountClient 创建支持子账户管理的客户端，不支持的交易所返回 nil
+func (e ExchangeFactory) CreateSubAccountClient(exchange string) SubAccountManager {
+	switch exchange {
+	case base.BINANCE:
+		return &binance.Client{}
+	case base.OKEX:
+		return &okx.Client{}
+	default:
+		return nil
+	}
+}
+
+// RotateSubAccountAPIKey 轮换子账户 API key：先按 req 创建新 key，再删除 oldKey。
+// 删除失败时仍返回新 key 和错误，调用方需要保存新 key 后再处理旧 key
+func RotateSubAccountAPIKey(ctx context.Context, m SubAccountManager, req models.SubAccountAPIKeyRequest, oldKey string) (models.SubAccountAPIKey, error) {
+	key, err := m.CreateSubAccountAPIKey(ctx, req)
+	if err != nil {
+		return models.SubAccountAPIKey{}, err
+	}
+	if err := m.DeleteSubAccountAPIKey(ctx, req.SubAccount, oldKey); err != nil {
+		return key, fmt.Errorf("delete old api key %s: %w", oldKey, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,54 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeSubAccounts 记录 API key 的创建和删除，deleteErr 不为 nil 时删除失败
+type fakeSubAccounts struct {
+	SubAccountManager
+	created   []string
+	deleted   []string
+	deleteErr error
+}
+
+func (f *fakeSubAccounts) CreateSubAccountAPIKey(ctx context.Context, req models.SubAccountAPIKeyRequest) (models.SubAccountAPIKey, error) {
+	key := "key" + string(rune('0'+len(f.created)))
+	f.created = append(f.created, key)
+	return models.SubAccountAPIKey{SubAccount: req.SubAccount, Label: req.Label, APIKey: key, SecretKey: "secret"}, nil
+}
+
+func (f *fakeSubAccounts) DeleteSubAccountAPIKey(ctx context.Context, sub, apiKey string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, sub+"/"+apiKey)
+	return nil
+}
+
+func TestRotateSubAccountAPIKey(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeSubAccounts{}
+	req := models.SubAccountAPIKeyRequest{SubAccount: "grid01", Label: "bot"}
+	key, err := RotateSubAccountAPIKey(ctx, f, req, "old")
+	if err != nil || key.APIKey != "key0" || len(f.deleted) != 1 || f.deleted[0] != "grid01/old" {
+		t.Fatalf("key = %+v err = %v deleted = %v", key, err, f.deleted)
+	}
+
+	// 删除旧 key 失败时仍返回新 key
+	f.deleteErr = base.ErrRateLimited
+	key, err = RotateSubAccountAPIKey(ctx, f, req, "key0")
+	if !errors.Is(err, base.ErrRateLimited) || key.APIKey != "key1" || key.SecretKey == "" {
+		t.Fatalf("key = %+v err = %v", key, err)
+	}
+
+	// 创建失败时不删除旧 key
+	var e ExchangeFactory
+	if _, err := RotateSubAccountAPIKey(ctx, e.CreateSubAccountClient(base.BINANCE), req, "key1"); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("binance rotate err = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// TreasuryExchange 在 Exchange 的基础上提供资金操作：链上充提信息、充提记录、带状态的提币和账户间划转。
+// OKX 提币从资金账户发出，现货和永续共用交易账户，SPOT 与 FUTURES 之间的划转返回 base.ErrNotSupported；
+// Binance 提币从现货账户发出，三个账户之间都可以划转
+type TreasuryExchange interface {
+	Exchange
+
+	// GetChains 币种在各条链上的充提信息，currency 为空时返回全部币种
+	GetChains(ctx context.Context, currency string) ([]models.ChainInfo, error)
+	// SubmitWithdrawal 链上提币，返回状态为 base.PROCESSING 的提币记录
+	SubmitWithdrawal(ctx context.Context, req models.WithdrawRequest) (models.Withdrawal, error)
+	// GetWithdrawal 按提币 id 查询，查不到时返回 base.ErrWithdrawNotFound
+	GetWithdrawal(ctx context.Context, currency, id string) (models.Withdrawal, error)
+	// GetWithdrawals [since, until] 内的提币记录，按时间升序，时间范围见 models.HistoryRange
+	GetWithdrawals(ctx context.Context, currency string, since, until time.Time) ([]models.Withdrawal, error)
+	// GetDeposits [since, until] 内的充值记录，按时间升序，时间范围见 models.HistoryRange
+	GetDeposits(ctx context.Context, currency string, since, until time.Time) ([]models.Deposit, error)
+	// Transfer 账户间划转
+	Transfer(ctx context.Context, req models.TransferRequest) (models.Transfer, error)
+}
+
+var (
+	_ TreasuryExchange = (*binance.Client)(nil)
+	_ TreasuryExchange = (*okx.Client)(nil)
+)
+
+// CreateTreasuryClient 创建支持资金操作的客户端，不支持的交易所返回 nil
+func (e ExchangeFactory) CreateTreasuryClient(exchange string) TreasuryExchange {
+	switch exchange {
+	case base.BINANCE:
+		return &binance.Client{}
+	case base.OKEX:
+		return &okx.Client{}
+	default:
+		return nil
+	}
+}
+
+// WaitWithdrawal 每隔 interval 查询一次提币状态，直到成功、失败或取消，ctx 结束时返回 ctx.Err()。
+// 刚提交的提币可能还查不到，base.ErrWithdrawNotFound 视为处理中继续等待
+func WaitWithdrawal(ctx context.Context, t TreasuryExchange, currency, id string, interval time.Duration) (models.Withdrawal, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w, err := t.GetWithdrawal(ctx, currency, id)
+		switch {
+		case err == nil && w.Done():
+			return w, nil
+		case err != nil && !errors.Is(err, base.ErrWithdrawNotFound):
+			return w, err
+		}
+		select {
+		case <-ctx.Done():
+			return w, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
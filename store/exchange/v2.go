@@ -0,0 +1,558 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/okx"
+	"AxonTrading/exchanges/sim"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ExchangeV2 是 Exchange 的继任接口：所有调用都带 context.Context，
+// 参数和返回值使用 models 中的类型化结构体。
+type ExchangeV2 interface {
+	New(params []byte) error
+	NewFuture(params []byte) error
+	// Has 是否支持 base.Cap* 中的可选功能
+	Has(capability string) bool
+
+	// GetBalance 获取现货账户某个币种的余额
+	GetBalance(ctx context.Context, currency string) (models.Balance, error)
+	// GetAccount 账户快照：全部非零余额、总权益、保证金和账户模式
+	GetAccount(ctx context.Context) (models.Account, error)
+	// GetMarketPrice 获取最新成交价
+	GetMarketPrice(ctx context.Context, symbol string) (string, error)
+	// Depth 现货深度
+	Depth(ctx context.Context, req models.DepthRequest) (models.WsData, error)
+	GetTradingFee(ctx context.Context, symbol string) (models.TradingFee, error)
+	GetPairInfo(ctx context.Context, symbol string) (models.PairInfo, error)
+
+	// PlaceOrder 下单，按 req.Type 分派到限价/市价/maker/taker
+	PlaceOrder(ctx context.Context, req models.OrderRequest) (models.OrderResult, error)
+	// PlaceOrders 批量下单，同币对同类型的订单走交易所批量接口
+	PlaceOrders(ctx context.Context, reqs []models.OrderRequest) ([]models.OrderResult, error)
+	CancelOrder(ctx context.Context, req models.CancelRequest) (models.CancelResult, error)
+	// AmendOrder 修改挂单价格和数量，返回修改后的订单
+	AmendOrder(ctx context.Context, req models.AmendRequest) (models.OrderInfo, error)
+	// CancelOrders 取消币对全部挂单
+	CancelOrders(ctx context.Context, symbol string) error
+	GetOrder(ctx context.Context, req models.OrderQuery) (models.OrderInfo, error)
+	GetOpenOrders(ctx context.Context, req models.OpenOrdersRequest) ([]models.OrderInfo, error)
+	// GetFills 自己在 symbol 上 [since, until] 内的成交，按时间升序
+	GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error)
+
+	GetDepositAddress(ctx context.Context, currency, chain string) (string, error)
+	Withdraw(ctx context.Context, req models.WithdrawRequest) (models.WithdrawResult, error)
+
+	// GetFutureBalance 获取期货账户底仓（U本位）
+	GetFutureBalance(ctx context.Context) (models.FutureBalance, error)
+	FutureDepth(ctx context.Context, req models.DepthRequest) (models.WsData, error)
+	GetFutureMarketPrice(ctx context.Context, symbol string) (string, error)
+	GetMarkPriceAndFundingRate(ctx context.Context, symbol string) (models.FundingRate, error)
+	// SetDual 改变持仓方向 true 双向 false 单向
+	SetDual(ctx context.Context, dual bool) error
+	// CheckDual 检查当前是否为双向持仓（true）
+	CheckDual(ctx context.Context) (bool, error)
+	PlaceFutureOrder(ctx context.Context, req models.FutureOrderRequest) (models.OrderResult, error)
+	GetFutureOrder(ctx context.Context, req models.OrderQuery) (models.FutureOrderInfo, error)
+	CancelFutureOrder(ctx context.Context, req models.CancelRequest) (models.CancelResult, error)
+	CancelFutureOrders(ctx context.Context, symbol string) error
+	GetFutureOpenOrders(ctx context.Context, symbol string) ([]models.FutureOrderInfo, error)
+	ChangeLeverage(ctx context.Context, req models.LeverageRequest) error
+	ChangeMarginType(ctx context.Context, symbol, typ string) error
+	ChangePositionMargin(ctx context.Context, req models.MarginRequest) error
+	GetPositionRisk(ctx context.Context, symbol string) ([]models.PositionInfo, error)
+	// GetPositions 全部非零持仓（永续、交割、期权和杠杆），filter 为零值时不过滤
+	GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error)
+	GetFutureTradingFee(ctx context.Context, symbol string) (models.TradingFee, error)
+
+	// Legacy 返回底层的旧接口实现，迁移期间使用
+	Legacy() Exchange
+}
+
+// ClientOrderExchange 下单时使用调用方指定的 client order id（Binance 为 newClientOrderId，OKX 为 clOrdId），
+// ExchangeV2 的 OrderRequest / FutureOrderRequest 带 ClientOrderID 时需要
+type ClientOrderExchange interface {
+	// PlaceOrderWithClientID 现货下单，typ 为 base.MARKET / LIMIT / MAKER / TAKER
+	PlaceOrderWithClientID(symbol, side, typ, price, size, clientID string) (string, error)
+	// NewFutureOrderWithClientID 与 NewFutureOrder 相同
+	NewFutureOrderWithClientID(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool, clientID string) (string, error)
+}
+
+var (
+	_ Exchange = (*binance.Client)(nil)
+	_ Exchange = (*okx.Client)(nil)
+	_ Exchange = (*sim.Client)(nil)
+
+	_ ClientOrderExchange = (*binance.Client)(nil)
+	_ ClientOrderExchange = (*okx.Client)(nil)
+
+	_ ExchangeV2 = (*adapter)(nil)
+)
+
+// NewV2 把旧的 Exchange 实现包装成 ExchangeV2。
+// ctx 取消或超时后调用立即返回 ctx.Err()，底层的请求会在后台结束；
+// 下单已经发出时返回包装了 ctx.Err() 的 base.ErrUnknownStatus，需要按 ClientOrderID 查询确认。
+func NewV2(e Exchange) ExchangeV2 {
+	return &adapter{e: e}
+}
+
+// CreateClientV2 创建 ExchangeV2 客户端
+func (e ExchangeFactory) CreateClientV2(exchange string) ExchangeV2 {
+	client := e.CreateClient(exchange)
+	if client == nil {
+		return nil
+	}
+	return NewV2(client)
+}
+
+type adapter struct {
+	e Exchange
+}
+
+// call 在单独的 goroutine 中执行旧接口调用，并等待其完成或 ctx 结束
+func call[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// callOrder 与 call 相同，用于下单：请求发出后 ctx 结束时订单是否已经提交未知，返回 base.ErrUnknownStatus
+func callOrder[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := call(ctx, fn)
+	if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return v, fmt.Errorf("%w: order still in flight: %w", base.ErrUnknownStatus, err)
+	}
+	return v, err
+}
+
+// clientOrder 带 ClientOrderID 的请求要求底层实现 ClientOrderExchange
+func (a *adapter) clientOrder() (ClientOrderExchange, error) {
+	c, ok := a.e.(ClientOrderExchange)
+	if !ok {
+		return nil, fmt.Errorf("%w: client order id", base.ErrNotSupported)
+	}
+	return c, nil
+}
+
+// legacyNumber 旧接口用空串表示未填写的数值
+func legacyNumber(d models.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+func callErr(ctx context.Context, fn func() error) error {
+	_, err := call(ctx, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+func (a *adapter) Legacy() Exchange {
+	return a.e
+}
+
+func (a *adapter) Has(capability string) bool {
+	return a.e.Has(capability)
+}
+
+func (a *adapter) New(params []byte) error {
+	return a.e.New(params)
+}
+
+func (a *adapter) NewFuture(params []byte) error {
+	return a.e.NewFuture(params)
+}
+
+func (a *adapter) GetBalance(ctx context.Context, currency string) (models.Balance, error) {
+	return call(ctx, func() (models.Balance, error) {
+		bs, err := a.e.GetAccountBalance(currency)
+		if err != nil {
+			return models.Balance{}, err
+		}
+		b := models.Balance{Asset: currency}
+		if len(bs) >= 2 {
+			b.Free = models.ParseDecimalOrZero(bs[0])
+			b.Locked = models.ParseDecimalOrZero(bs[1])
+		}
+		return b, nil
+	})
+}
+
+// GetAccount 旧接口的 GetAccount 已支持 ctx，直接调用
+func (a *adapter) GetAccount(ctx context.Context) (models.Account, error) {
+	return a.e.GetAccount(ctx)
+}
+
+func (a *adapter) GetMarketPrice(ctx context.Context, symbol string) (string, error) {
+	return call(ctx, func() (string, error) {
+		return a.e.GetMarketPrice(symbol)
+	})
+}
+
+func (a *adapter) Depth(ctx context.Context, req models.DepthRequest) (models.WsData, error) {
+	if err := req.Validate(); err != nil {
+		return models.WsData{}, err
+	}
+	return call(ctx, func() (models.WsData, error) {
+		return a.e.Depth(req.Symbol, strconv.Itoa(req.Limit))
+	})
+}
+
+func (a *adapter) GetTradingFee(ctx context.Context, symbol string) (models.TradingFee, error) {
+	return call(ctx, func() (models.TradingFee, error) {
+		return a.e.GetTradingFee(symbol)
+	})
+}
+
+func (a *adapter) GetPairInfo(ctx context.Context, symbol string) (models.PairInfo, error) {
+	return call(ctx, func() (models.PairInfo, error) {
+		return a.e.GetPairInfo(symbol)
+	})
+}
+
+func (a *adapter) PlaceOrder(ctx context.Context, req models.OrderRequest) (models.OrderResult, error) {
+	if err := req.Validate(); err != nil {
+		return models.OrderResult{}, err
+	}
+	if req.ClientOrderID != "" {
+		c, err := a.clientOrder()
+		if err != nil {
+			return models.OrderResult{}, err
+		}
+		return callOrder(ctx, func() (models.OrderResult, error) {
+			id, err := c.PlaceOrderWithClientID(req.Symbol, req.Side, req.Type, legacyNumber(req.Price), legacyNumber(req.Size), req.ClientOrderID)
+			if err != nil {
+				return models.OrderResult{}, err
+			}
+			return models.OrderResult{Symbol: req.Symbol, OrderID: id, ClientOrderID: req.ClientOrderID}, nil
+		})
+	}
+	return callOrder(ctx, func() (models.OrderResult, error) {
+		var (
+			id  string
+			err error
+		)
+		switch req.Type {
+		case base.MARKET:
+			id, err = a.e.MarketOrder(req.Symbol, req.Side, legacyNumber(req.Size))
+		case base.LIMIT:
+			id, err = a.e.LimitOrder(req.Symbol, req.Side, legacyNumber(req.Price), legacyNumber(req.Size))
+		case base.MAKER:
+			id, err = a.e.MakerOrder(req.Symbol, req.Side, legacyNumber(req.Price), legacyNumber(req.Size))
+		case base.TAKER:
+			id, err = a.e.TakerOrder(req.Symbol, req.Side, legacyNumber(req.Price), legacyNumber(req.Size))
+		case base.LIMITHIDDEN:
+			id, err = a.e.LimitHiddenOrder(req.Symbol, req.Side, legacyNumber(req.Price), legacyNumber(req.Size))
+		}
+		if err != nil {
+			return models.OrderResult{}, err
+		}
+		return models.OrderResult{Symbol: req.Symbol, OrderID: id}, nil
+	})
+}
+
+func (a *adapter) PlaceOrders(ctx context.Context, reqs []models.OrderRequest) ([]models.OrderResult, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	for _, req := range reqs {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 同币对同类型才能走批量接口，否则逐个下单；旧的批量接口不能指定 client order id
+	symbol, typ := reqs[0].Symbol, reqs[0].Type
+	uniform := typ != base.MARKET
+	for _, req := range reqs {
+		if req.Symbol != symbol || req.Type != typ || req.ClientOrderID != "" {
+			uniform = false
+			break
+		}
+	}
+	if !uniform {
+		results := make([]models.OrderResult, 0, len(reqs))
+		for _, req := range reqs {
+			r, err := a.PlaceOrder(ctx, req)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, r)
+		}
+		return results, nil
+	}
+
+	ol := make([]models.OrderList, 0, len(reqs))
+	for _, req := range reqs {
+		ol = append(ol, models.OrderList{Side: req.Side, Price: legacyNumber(req.Price), Size: legacyNumber(req.Size), Type: req.Type})
+	}
+	return callOrder(ctx, func() ([]models.OrderResult, error) {
+		var (
+			ids []string
+			err error
+		)
+		switch typ {
+		case base.LIMIT:
+			ids, err = a.e.LimitOrders(symbol, ol)
+		case base.MAKER:
+			ids, err = a.e.MakerOrders(symbol, ol)
+		case base.TAKER:
+			ids, err = a.e.TakerOrders(symbol, ol)
+		case base.LIMITHIDDEN:
+			ids, err = a.e.LimitHiddenOrders(symbol, ol)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results := make([]models.OrderResult, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, models.OrderResult{Symbol: symbol, OrderID: id})
+		}
+		return results, nil
+	})
+}
+
+func (a *adapter) CancelOrder(ctx context.Context, req models.CancelRequest) (models.CancelResult, error) {
+	if err := req.Validate(); err != nil {
+		return models.CancelResult{}, err
+	}
+	return call(ctx, func() (models.CancelResult, error) {
+		ok, err := a.e.CancelOrder(req.Symbol, req.OrderID)
+		return models.CancelResult{OrderID: req.OrderID, Canceled: ok}, err
+	})
+}
+
+// AmendOrder 旧接口的 AmendOrder 已支持 ctx，直接调用
+func (a *adapter) AmendOrder(ctx context.Context, req models.AmendRequest) (models.OrderInfo, error) {
+	if err := req.Validate(); err != nil {
+		return models.OrderInfo{}, err
+	}
+	return a.e.AmendOrder(ctx, req.Symbol, req.OrderID, legacyNumber(req.Price), legacyNumber(req.Size))
+}
+
+// GetFills 旧接口的 GetFills 已支持 ctx，直接调用
+func (a *adapter) GetFills(ctx context.Context, symbol string, since, until time.Time) ([]models.Fill, error) {
+	return a.e.GetFills(ctx, symbol, since, until)
+}
+
+func (a *adapter) CancelOrders(ctx context.Context, symbol string) error {
+	return callErr(ctx, func() error {
+		return a.e.CancelOrders(symbol)
+	})
+}
+
+func (a *adapter) GetOrder(ctx context.Context, req models.OrderQuery) (models.OrderInfo, error) {
+	return call(ctx, func() (models.OrderInfo, error) {
+		return a.e.GetOrder(req.Symbol, req.OrderID)
+	})
+}
+
+func (a *adapter) GetOpenOrders(ctx context.Context, req models.OpenOrdersRequest) ([]models.OrderInfo, error) {
+	return call(ctx, func() ([]models.OrderInfo, error) {
+		if req.Side == "" {
+			return a.e.GetOpenOrders(req.Symbol)
+		}
+		return a.e.GetOpenOrdersWithSide(req.Symbol, req.Side)
+	})
+}
+
+func (a *adapter) GetDepositAddress(ctx context.Context, currency, chain string) (string, error) {
+	return call(ctx, func() (string, error) {
+		return a.e.GetDepositAddress(currency, chain)
+	})
+}
+
+func (a *adapter) Withdraw(ctx context.Context, req models.WithdrawRequest) (models.WithdrawResult, error) {
+	if err := req.Validate(); err != nil {
+		return models.WithdrawResult{}, err
+	}
+	// 旧接口没有 tag 参数，带 tag 的提币走 TreasuryExchange.SubmitWithdrawal
+	if req.Tag != "" {
+		t, ok := a.e.(TreasuryExchange)
+		if !ok {
+			return models.WithdrawResult{}, fmt.Errorf("%w: withdraw with tag", base.ErrNotSupported)
+		}
+		w, err := t.SubmitWithdrawal(ctx, req)
+		return models.WithdrawResult{WithdrawID: w.WithdrawID}, err
+	}
+	return call(ctx, func() (models.WithdrawResult, error) {
+		id, err := a.e.Withdraw(req.Currency, req.Chain, req.Address, legacyNumber(req.Amount))
+		return models.WithdrawResult{WithdrawID: id}, err
+	})
+}
+
+func (a *adapter) GetFutureBalance(ctx context.Context) (models.FutureBalance, error) {
+	return call(ctx, func() (models.FutureBalance, error) {
+		return a.e.GetFutureBalance()
+	})
+}
+
+func (a *adapter) FutureDepth(ctx context.Context, req models.DepthRequest) (models.WsData, error) {
+	if err := req.Validate(); err != nil {
+		return models.WsData{}, err
+	}
+	return call(ctx, func() (models.WsData, error) {
+		return a.e.FutureDepth(req.Symbol, strconv.Itoa(req.Limit))
+	})
+}
+
+func (a *adapter) GetFutureMarketPrice(ctx context.Context, symbol string) (string, error) {
+	return call(ctx, func() (string, error) {
+		return a.e.GetFutureMarketPrice(symbol)
+	})
+}
+
+func (a *adapter) GetMarkPriceAndFundingRate(ctx context.Context, symbol string) (models.FundingRate, error) {
+	return call(ctx, func() (models.FundingRate, error) {
+		return a.e.GetMarkPriceAndFundingRate(symbol)
+	})
+}
+
+func (a *adapter) SetDual(ctx context.Context, dual bool) error {
+	return callErr(ctx, func() error {
+		ok, err := a.e.Dual(dual)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("change position mode to dual=%v failed", dual)
+		}
+		return nil
+	})
+}
+
+func (a *adapter) CheckDual(ctx context.Context) (bool, error) {
+	return call(ctx, func() (bool, error) {
+		return a.e.CheckDual()
+	})
+}
+
+func (a *adapter) PlaceFutureOrder(ctx context.Context, req models.FutureOrderRequest) (models.OrderResult, error) {
+	if err := req.Validate(); err != nil {
+		return models.OrderResult{}, err
+	}
+	var place func() (string, error)
+	if req.ClientOrderID != "" {
+		c, err := a.clientOrder()
+		if err != nil {
+			return models.OrderResult{}, err
+		}
+		place = func() (string, error) {
+			return c.NewFutureOrderWithClientID(req.Symbol, req.Side, req.PositionSide, req.Type, legacyNumber(req.Size), legacyNumber(req.Price),
+				legacyNumber(req.StopPrice), req.MarginType, req.ClosePosition, req.PriceProtect, req.ClientOrderID)
+		}
+	} else {
+		place = func() (string, error) {
+			return a.e.NewFutureOrder(req.Symbol, req.Side, req.PositionSide, req.Type, legacyNumber(req.Size), legacyNumber(req.Price),
+				legacyNumber(req.StopPrice), req.MarginType, req.ClosePosition, req.PriceProtect)
+		}
+	}
+	return callOrder(ctx, func() (models.OrderResult, error) {
+		id, err := place()
+		if err != nil {
+			return models.OrderResult{}, err
+		}
+		return models.OrderResult{Symbol: req.Symbol, OrderID: id, ClientOrderID: req.ClientOrderID}, nil
+	})
+}
+
+func (a *adapter) GetFutureOrder(ctx context.Context, req models.OrderQuery) (models.FutureOrderInfo, error) {
+	return call(ctx, func() (models.FutureOrderInfo, error) {
+		return a.e.GetFutureOrder(req.Symbol, req.OrderID)
+	})
+}
+
+func (a *adapter) CancelFutureOrder(ctx context.Context, req models.CancelRequest) (models.CancelResult, error) {
+	if err := req.Validate(); err != nil {
+		return models.CancelResult{}, err
+	}
+	return call(ctx, func() (models.CancelResult, error) {
+		ok, err := a.e.CancelFutureOrder(req.Symbol, req.OrderID)
+		return models.CancelResult{OrderID: req.OrderID, Canceled: ok}, err
+	})
+}
+
+func (a *adapter) CancelFutureOrders(ctx context.Context, symbol string) error {
+	return callErr(ctx, func() error {
+		return a.e.CancelFutureOrders(symbol)
+	})
+}
+
+func (a *adapter) GetFutureOpenOrders(ctx context.Context, symbol string) ([]models.FutureOrderInfo, error) {
+	return call(ctx, func() ([]models.FutureOrderInfo, error) {
+		return a.e.GetFutureOpenOrders(symbol)
+	})
+}
+
+func (a *adapter) ChangeLeverage(ctx context.Context, req models.LeverageRequest) error {
+	return callErr(ctx, func() error {
+		_, err := a.e.ChangeLeverage(req.Symbol, req.Leverage)
+		return err
+	})
+}
+
+func (a *adapter) ChangeMarginType(ctx context.Context, symbol, typ string) error {
+	return callErr(ctx, func() error {
+		return a.e.ChangeMarginType(symbol, typ)
+	})
+}
+
+func (a *adapter) ChangePositionMargin(ctx context.Context, req models.MarginRequest) error {
+	return callErr(ctx, func() error {
+		ok, err := a.e.ChangePositionMargin(req.Symbol, req.PositionSide, legacyNumber(req.Amount), req.Type)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("change position margin of %s failed", req.Symbol)
+		}
+		return nil
+	})
+}
+
+func (a *adapter) GetPositionRisk(ctx context.Context, symbol string) ([]models.PositionInfo, error) {
+	return call(ctx, func() ([]models.PositionInfo, error) {
+		return a.e.GetPositionRisk(symbol)
+	})
+}
+
+// GetPositions 旧接口的 GetPositions 已支持 ctx，直接调用
+func (a *adapter) GetPositions(ctx context.Context, filter models.PositionFilter) ([]models.Position, error) {
+	return a.e.GetPositions(ctx, filter)
+}
+
+func (a *adapter) GetFutureTradingFee(ctx context.Context, symbol string) (models.TradingFee, error) {
+	return call(ctx, func() (models.TradingFee, error) {
+		return a.e.GetFutureTradingFee(symbol)
+	})
+}
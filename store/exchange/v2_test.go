@@ -0,0 +1,127 @@
+package exchange
+
+import (
+	"AxonTrading/base"
+	"AxonTrading/exchanges/binance"
+	"AxonTrading/exchanges/exchangetest"
+	"AxonTrading/exchanges/sim"
+	"AxonTrading/models"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// slowExchange 下单阻塞到 release 关闭，记录收到的 client order id
+type slowExchange struct {
+	Exchange
+	release   chan struct{}
+	clientIDs []string
+}
+
+func (s *slowExchange) LimitOrder(symbol, side, price, size string) (string, error) {
+	<-s.release
+	return "1", nil
+}
+
+func (s *slowExchange) PlaceOrderWithClientID(symbol, side, typ, price, size, clientID string) (string, error) {
+	<-s.release
+	s.clientIDs = append(s.clientIDs, clientID)
+	return "2", nil
+}
+
+func (s *slowExchange) NewFutureOrderWithClientID(symbol, side, positionSide, typ, size, price, stopPrice, positionType string, closePosition, priceProtect bool, clientID string) (string, error) {
+	<-s.release
+	s.clientIDs = append(s.clientIDs, clientID)
+	return "3", nil
+}
+
+func limitRequest(clientID string) models.OrderRequest {
+	return models.OrderRequest{Symbol: "BTC/USDT", Side: base.BID, Type: base.LIMIT,
+		Price: models.ParseDecimalOrZero("100"), Size: models.ParseDecimalOrZero("1"), ClientOrderID: clientID}
+}
+
+func TestAdapterOrderTimeout(t *testing.T) {
+	s := &slowExchange{release: make(chan struct{})}
+	defer close(s.release)
+	a := NewV2(s)
+
+	// 已经发出的下单在 ctx 超时后状态未知
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := a.PlaceOrder(ctx, limitRequest(""))
+	if !errors.Is(err, base.ErrUnknownStatus) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("timeout err = %v", err)
+	}
+
+	// ctx 已经结束时不下单，返回 ctx.Err()
+	done, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if _, err := a.PlaceOrder(done, limitRequest("")); err != context.Canceled {
+		t.Fatalf("canceled err = %v", err)
+	}
+}
+
+func TestAdapterClientOrderID(t *testing.T) {
+	s := &slowExchange{release: make(chan struct{})}
+	close(s.release)
+	a := NewV2(s)
+	ctx := context.Background()
+
+	r, err := a.PlaceOrder(ctx, limitRequest("my-1"))
+	if err != nil || r.OrderID != "2" || r.ClientOrderID != "my-1" {
+		t.Fatalf("result = %+v err = %v", r, err)
+	}
+	// 带 client order id 的批量下单逐个发出
+	rs, err := a.PlaceOrders(ctx, []models.OrderRequest{limitRequest("my-2"), limitRequest("my-3")})
+	if err != nil || len(rs) != 2 || rs[1].ClientOrderID != "my-3" {
+		t.Fatalf("results = %+v err = %v", rs, err)
+	}
+	fr, err := a.PlaceFutureOrder(ctx, models.FutureOrderRequest{Symbol: "BTC/USDT:USDT", Side: base.ASK, Type: base.MARKET,
+		Size: models.ParseDecimalOrZero("1"), ClientOrderID: "my-4"})
+	if err != nil || fr.OrderID != "3" {
+		t.Fatalf("future result = %+v err = %v", fr, err)
+	}
+	if got := s.clientIDs; len(got) != 4 || got[0] != "my-1" || got[3] != "my-4" {
+		t.Fatalf("client ids = %v", got)
+	}
+
+	if _, err := NewV2(&sim.Client{}).PlaceOrder(ctx, limitRequest("my-5")); !errors.Is(err, base.ErrNotSupported) {
+		t.Fatalf("sim err = %v", err)
+	}
+}
+
+func TestAdapterUniformBatch(t *testing.T) {
+	srv := exchangetest.NewBinance(exchangetest.Credentials{APIKey: "key", SecretKey: "secret"})
+	t.Cleanup(srv.Close)
+	c := &binance.Client{}
+	if err := c.New(srv.Params()); err != nil {
+		t.Fatal(err)
+	}
+	a := NewV2(c)
+
+	// 同类型的 MAKER/TAKER 批量走旧的 MakerOrders/TakerOrders
+	for _, typ := range []string{base.MAKER, base.TAKER} {
+		req := models.OrderRequest{Symbol: "BTCUSDT", Side: base.BID, Type: typ,
+			Price: models.ParseDecimalOrZero("100"), Size: models.ParseDecimalOrZero("1")}
+		rs, err := a.PlaceOrders(context.Background(), []models.OrderRequest{req, req})
+		if err != nil || len(rs) != 2 || rs[0].OrderID != "29" {
+			t.Fatalf("%s results = %+v err = %v", typ, rs, err)
+		}
+	}
+	posts := srv.Requests(http.MethodPost, "/api/v3/order")
+	if len(posts) != 4 {
+		t.Fatalf("posts = %d", len(posts))
+	}
+	for _, p := range posts {
+		q, err := url.ParseQuery(string(p.Body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q.Get("symbol") != "BTCUSDT" || q.Get("side") != "BUY" {
+			t.Fatalf("order = %v", q)
+		}
+	}
+}
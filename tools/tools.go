@@ -2,12 +2,13 @@ package tools
 
 import (
 	"AxonTrading/base"
+	"AxonTrading/instrument"
+	"AxonTrading/models"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/rand"
 	"sort"
 	"strconv"
@@ -15,8 +16,17 @@ import (
 	"time"
 )
 
+// UnifiedSymbol Token1_Token2 转交易所格式。
+//
+// Deprecated: 使用 instrument.Registry.Native，统一符号（BTC/USDT）在这里也会按注册表的命名规则转换。
 func UnifiedSymbol(exchange, symbol string) string {
 	//Default: Token1_Token2
+	if sym, err := instrument.Parse(symbol); err == nil {
+		if id, err := instrument.FormatNative(exchange, sym); err == nil {
+			return id
+		}
+		symbol = sym.Base + "_" + sym.Quote
+	}
 
 	tokens := strings.Split(symbol, "_")
 
@@ -81,31 +91,20 @@ func RandFloat64WithSeed(seed int, min, max float64) float64 {
 }
 
 // FormatFloatCeil 舍弃的尾数不为0，强制进位
+// 按十进制计算，避免 1.1*100 这类浮点误差导致多进一位
 func FormatFloatCeil(num float64, decimal int) (float64, error) {
-	// 默认乘1
-	d := float64(1)
-	if decimal > 0 {
-		// 10的N次方
-		d = math.Pow10(decimal)
+	if decimal < 0 {
+		decimal = 0
 	}
-	// math.trunc作用就是返回浮点数的整数部分
-	// 再除回去，小数点后无效的0也就不存在了
-	res := strconv.FormatFloat(math.Ceil(num*d)/d, 'f', -1, 64)
-	return strconv.ParseFloat(res, 64)
+	return models.NewDecimalFromFloat(num).Ceil(int32(decimal)).Float64(), nil
 }
 
 // FormatFloatFloor 强制舍弃尾数
 func FormatFloatFloor(num float64, decimal int) (float64, error) {
-	// 默认乘1
-	d := float64(1)
-	if decimal > 0 {
-		// 10的N次方
-		d = math.Pow10(decimal)
+	if decimal < 0 {
+		decimal = 0
 	}
-	// math.trunc作用就是返回浮点数的整数部分
-	// 再除回去，小数点后无效的0也就不存在了
-	res := strconv.FormatFloat(math.Floor(num*d)/d, 'f', -1, 64)
-	return strconv.ParseFloat(res, 64)
+	return models.NewDecimalFromFloat(num).Floor(int32(decimal)).Float64(), nil
 }
 
 // GetMaxFloat64 获取 Float64 列表中的最大值
@@ -211,15 +210,29 @@ func ReplaceStringChar(symbol string) string {
 	return strings.NewReplacer("_", "", "-", "").Replace(symbol)
 }
 func GetSizeWithU(price, total float64, apres int) string {
-	sizeF := total / price
-	return strconv.FormatFloat(sizeF, 'f', apres, 64)
+	return GetSizeWithUDecimal(models.NewDecimalFromFloat(price), models.NewDecimalFromFloat(total), apres).StringFixed(int32(apres))
 }
+
+// GetSizeWithUDecimal 用 U 的数量计算下单数量，四舍五入保留 apres 位小数
+func GetSizeWithUDecimal(price, total models.Decimal, apres int) models.Decimal {
+	size, err := total.Div(price, int32(apres))
+	if err != nil {
+		return models.Decimal{}
+	}
+	return size
+}
+
+// FormatSymbol 把 BTCUSDT 格式转为交易所格式，已带分隔符的原样返回。
+//
+// Deprecated: 只认识 USDT 计价的币对，使用 instrument.Registry.Native。
 func FormatSymbol(exchange, symbol string) string {
 	symbols := SplitStringChar(symbol)
 	if len(symbols) == 0 {
 		return ""
 	}
-	if len(symbols) == 1 && strings.Contains(symbol, "USDT") {
+	if sym, err := instrument.Parse(symbol); err == nil {
+		symbols = []string{sym.Base, sym.Quote}
+	} else if len(symbols) == 1 && strings.Contains(symbol, "USDT") {
 		tokens := strings.Split(symbol, "USDT")
 		symbols[0] = tokens[0]
 		symbols = append(symbols, "USDT")
@@ -243,7 +256,13 @@ func FormatSymbol(exchange, symbol string) string {
 	}
 }
 
+// SplitSymbol 拆分币对为 [base quote]，无法识别返回 nil。
+//
+// Deprecated: 使用 instrument.Parse 或 instrument.SplitNative。
 func SplitSymbol(symbol string) []string {
+	if sym, err := instrument.Parse(symbol); err == nil {
+		return []string{sym.Base, sym.Quote}
+	}
 	symbols := SplitStringChar(symbol)
 
 	if len(symbols) == 0 {
@@ -261,7 +280,7 @@ func SplitSymbol(symbol string) []string {
 		return symbols
 	}
 
-	return nil
+	return instrument.SplitNative(symbol)
 }
 
 func FormatSide(exchange, side string) string {